@@ -237,6 +237,15 @@ type Settings struct {
 	// By default, this is disabled.
 	XdsTLS bool `split_words:"true" default:"false"`
 
+	// EnableValidationWebhook starts a validating admission webhook server that rejects policies that
+	// would be reported as invalid, running the same validation as translation (including xDS validation
+	// when ValidationMode is "STRICT"). The serving certificate is read from the kgateway-webhook-cert Secret.
+	// By default, this is disabled.
+	EnableValidationWebhook bool `split_words:"true" default:"false"`
+
+	// ValidationWebhookPort is the port the validating admission webhook server listens on.
+	ValidationWebhookPort int `split_words:"true" default:"9443"`
+
 	// DefaultImageRegistry is the default image registry to use for the kgateway image.
 	DefaultImageRegistry string `split_words:"true" default:"cr.kgateway.dev"`
 	// DefaultImageTag is the default image tag to use for the kgateway image.
@@ -315,6 +324,29 @@ type Settings struct {
 	// for Backend resources when AWS EC2 discovery is enabled.
 	AwsEc2RefreshInterval time.Duration `split_words:"true" default:"30s"`
 
+	// EnableConsulDiscovery enables discovery of Consul service instances for Backend resources.
+	// This is disabled by default and must be explicitly enabled by the controller operator.
+	EnableConsulDiscovery bool `split_words:"true" default:"false"`
+
+	// ConsulRetryInterval caps how long the controller waits before reissuing a failed
+	// Consul query when Consul discovery is enabled. Failed queries are retried after a
+	// second, and the wait doubles with every consecutive failure up to this interval.
+	ConsulRetryInterval time.Duration `split_words:"true" default:"10s"`
+
+	// EnableDnsSrvDiscovery enables the resolution of DNS SRV records for Backend resources.
+	// This is disabled by default and must be explicitly enabled by the controller operator.
+	EnableDnsSrvDiscovery bool `split_words:"true" default:"false"`
+
+	// DnsSrvMinRefreshInterval is the minimum interval between two resolutions of the SRV
+	// records of a Backend, used for records with a lower TTL and as the initial backoff
+	// after a failed resolution.
+	DnsSrvMinRefreshInterval time.Duration `split_words:"true" default:"5s"`
+
+	// FileEndpointsDirectory is the directory, watched for changes, holding the endpoints
+	// documents of File Backends that reference a path. Such Backends are rejected when it
+	// is empty, which is the default.
+	FileEndpointsDirectory string `split_words:"true"`
+
 	PolicyMerge string `split_words:"true" default:"{}"`
 
 	// EnableWaypoint enables kgateway to translate istio waypoints
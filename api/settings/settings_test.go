@@ -46,11 +46,18 @@ func allEnvVarsSet() map[string]string {
 		"KGW_DISABLE_LEADER_ELECTION":                   "true",
 		"KGW_ENABLE_AWS_EC2_DISCOVERY":                  "true",
 		"KGW_AWS_EC2_REFRESH_INTERVAL":                  "45s",
+		"KGW_ENABLE_CONSUL_DISCOVERY":                   "true",
+		"KGW_CONSUL_RETRY_INTERVAL":                     "15s",
+		"KGW_ENABLE_DNS_SRV_DISCOVERY":                  "true",
+		"KGW_DNS_SRV_MIN_REFRESH_INTERVAL":              "2s",
+		"KGW_FILE_ENDPOINTS_DIRECTORY":                  "/etc/kgateway/endpoints",
 		"KGW_POLICY_MERGE":                              `{"TrafficPolicy":{"extProc":"DeepMerge"}}`,
 		"KGW_GATEWAY_CLASS_PARAMETERS_REFS":             `{"kgateway":{"name":"custom-gwp","namespace":"infra"}}`,
 		"KGW_ENABLE_WAYPOINT":                           "true",
 		"KGW_XDS_AUTH":                                  "false",
 		"KGW_XDS_TLS":                                   "true",
+		"KGW_ENABLE_VALIDATION_WEBHOOK":                 "true",
+		"KGW_VALIDATION_WEBHOOK_PORT":                   "8443",
 		"KGW_ENABLE_EXPERIMENTAL_GATEWAY_API_FEATURES":  "false",
 		"KGW_ENABLE_AUTH_METADATA":                      "true",
 		"KGW_WORKLOAD_ENTRIES_EXCLUSION_LABELS":         "example.io/managed-by,example.io/other-key",
@@ -107,10 +114,15 @@ func TestSettings(t *testing.T) {
 				DisableLeaderElection:                 false,
 				EnableAwsEc2Discovery:                 false,
 				AwsEc2RefreshInterval:                 30 * time.Second,
+				EnableConsulDiscovery:                 false,
+				ConsulRetryInterval:                   10 * time.Second,
+				EnableDnsSrvDiscovery:                 false,
+				DnsSrvMinRefreshInterval:              5 * time.Second,
 				PolicyMerge:                           "{}",
 				EnableWaypoint:                        false,
 				XdsAuth:                               true,
 				XdsTLS:                                false,
+				ValidationWebhookPort:                 9443,
 				EnableExperimentalGatewayAPIFeatures:  true,
 				GatewayClassParametersRefs:            GatewayClassParametersRefs{},
 				EnableAuthMetadata:                    false,
@@ -149,10 +161,17 @@ func TestSettings(t *testing.T) {
 				DisableLeaderElection:                 true,
 				EnableAwsEc2Discovery:                 true,
 				AwsEc2RefreshInterval:                 45 * time.Second,
+				EnableConsulDiscovery:                 true,
+				ConsulRetryInterval:                   15 * time.Second,
+				EnableDnsSrvDiscovery:                 true,
+				DnsSrvMinRefreshInterval:              2 * time.Second,
+				FileEndpointsDirectory:                "/etc/kgateway/endpoints",
 				PolicyMerge:                           `{"TrafficPolicy":{"extProc":"DeepMerge"}}`,
 				EnableWaypoint:                        true,
 				XdsAuth:                               false,
 				XdsTLS:                                true,
+				EnableValidationWebhook:               true,
+				ValidationWebhookPort:                 8443,
 				EnableExperimentalGatewayAPIFeatures:  false,
 				WorkloadEntriesExclusionLabels:        "example.io/managed-by,example.io/other-key",
 				ServiceEntriesExclusionLabelSelectors: `[{"matchLabels":{"example.io/managed-by":"some-controller"}}]`,
@@ -258,10 +277,15 @@ func TestSettings(t *testing.T) {
 				ValidatorCacheSize:                    0,
 				EnableAwsEc2Discovery:                 false,
 				AwsEc2RefreshInterval:                 30 * time.Second,
+				EnableConsulDiscovery:                 false,
+				ConsulRetryInterval:                   10 * time.Second,
+				EnableDnsSrvDiscovery:                 false,
+				DnsSrvMinRefreshInterval:              5 * time.Second,
 				ReferenceGrantMode:                    ReferenceGrantPermissive,
 				PolicyMerge:                           "{}",
 				XdsAuth:                               true,
 				XdsTLS:                                false,
+				ValidationWebhookPort:                 9443,
 				EnableExperimentalGatewayAPIFeatures:  true,
 				GatewayClassParametersRefs:            GatewayClassParametersRefs{},
 				ServiceEntriesExclusionLabelSelectors: "[]",
@@ -0,0 +1,85 @@
+package kgateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdaptiveConcurrencyPolicy configures Envoy's adaptive concurrency filter, which dynamically limits the
+// number of outstanding requests to the backends of the targeted routes. The limit is computed by a
+// gradient controller that compares the sampled request latency to the minimum round-trip time (min RTT)
+// of the backends, and requests exceeding the limit are rejected.
+// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/adaptive_concurrency_filter)
+// for more details.
+type AdaptiveConcurrencyPolicy struct {
+	// SampleAggregatePercentile is the latency percentile of the sampled requests that is compared to the
+	// min RTT. Defaults to 50.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SampleAggregatePercentile *int32 `json:"sampleAggregatePercentile,omitempty"`
+
+	// ConcurrencyUpdateInterval is the period of time samples are taken to recalculate the concurrency limit.
+	// Defaults to 100ms.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="must be at least 1ms"
+	ConcurrencyUpdateInterval *metav1.Duration `json:"concurrencyUpdateInterval,omitempty"`
+
+	// MaxConcurrencyLimit is the upper bound of the concurrency limit. Defaults to 1000.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrencyLimit *int32 `json:"maxConcurrencyLimit,omitempty"`
+
+	// MinRTT configures how the min RTT of the backends is measured.
+	// +optional
+	MinRTT *AdaptiveConcurrencyMinRTT `json:"minRTT,omitempty"`
+
+	// ConcurrencyLimitExceededStatus is the HTTP status code returned for requests rejected because
+	// the concurrency limit is exceeded. Defaults to 503.
+	// +optional
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=599
+	ConcurrencyLimitExceededStatus *int32 `json:"concurrencyLimitExceededStatus,omitempty"`
+}
+
+// AdaptiveConcurrencyMinRTT configures the measurement of the min RTT of the backends.
+// The min RTT is either measured periodically, during which the concurrency limit is lowered to
+// MinConcurrency, or set to a fixed value.
+// +kubebuilder:validation:AtMostOneOf=interval;fixedValue
+type AdaptiveConcurrencyMinRTT struct {
+	// Interval is the time between min RTT measurements. Defaults to 60s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="must be at least 1s"
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// FixedValue is used as the min RTT instead of measuring it.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="must be at least 1ms"
+	FixedValue *metav1.Duration `json:"fixedValue,omitempty"`
+
+	// RequestCount is the number of requests sampled to measure the min RTT. Defaults to 50.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestCount *int32 `json:"requestCount,omitempty"`
+
+	// JitterPercent randomly delays the start of each min RTT measurement by up to this percentage
+	// of the interval, so that multiple proxies do not measure at the same time. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	JitterPercent *int32 `json:"jitterPercent,omitempty"`
+
+	// MinConcurrency is the concurrency limit used while measuring the min RTT. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinConcurrency *int32 `json:"minConcurrency,omitempty"`
+
+	// BufferPercent is the amount the measured min RTT is increased by, so that normal latency
+	// variance does not lower the concurrency limit. Defaults to 25.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	BufferPercent *int32 `json:"bufferPercent,omitempty"`
+}
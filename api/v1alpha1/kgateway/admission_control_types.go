@@ -0,0 +1,84 @@
+package kgateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdmissionControlPolicy configures Envoy's admission control filter, which probabilistically rejects
+// requests to the targeted routes when the success rate of recent requests drops below a threshold.
+// This sheds load from struggling backends before clients retry them into failure.
+// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/admission_control_filter)
+// for more details.
+type AdmissionControlPolicy struct {
+	// SamplingWindow is the time window over which the success rate is calculated. Defaults to 30s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="must be at least 1s"
+	SamplingWindow *metav1.Duration `json:"samplingWindow,omitempty"`
+
+	// Aggression controls how quickly the rejection probability grows as the success rate drops.
+	// A value of 1.0 grows the rejection probability linearly, and larger values reject more requests
+	// at higher success rates. Defaults to 1.0.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="(self.matches('^-?(?:[0-9]+(?:\\\\.[0-9]*)?|\\\\.[0-9]+)$') && double(self) >= 1.0)",message="Aggression, if specified, must be a string representing a number of at least 1.0"
+	Aggression *string `json:"aggression,omitempty"`
+
+	// SuccessRateThreshold is the success rate percentage below which requests are rejected.
+	// Defaults to 95.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	SuccessRateThreshold *int32 `json:"successRateThreshold,omitempty"`
+
+	// RPSThreshold is the number of requests per second below which no requests are rejected,
+	// regardless of the success rate. Defaults to 0.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RPSThreshold *int32 `json:"rpsThreshold,omitempty"`
+
+	// MaxRejectionPercent is the upper bound of the rejection probability. Defaults to 80.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxRejectionPercent *int32 `json:"maxRejectionPercent,omitempty"`
+
+	// SuccessCriteria defines which responses count as successful.
+	// +optional
+	SuccessCriteria *AdmissionControlSuccessCriteria `json:"successCriteria,omitempty"`
+}
+
+// AdmissionControlSuccessCriteria defines which responses count as successful for admission control.
+type AdmissionControlSuccessCriteria struct {
+	// HTTPStatuses are the HTTP status codes considered successful.
+	// Defaults to all status codes below 500.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	HTTPStatuses []StatusCodeRange `json:"httpStatuses,omitempty"`
+
+	// GRPCStatuses are the gRPC status codes considered successful.
+	// Defaults to all status codes except Aborted, DataLoss, DeadlineExceeded, Internal,
+	// ResourceExhausted and Unavailable.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=17
+	// +kubebuilder:validation:items:Minimum=0
+	// +kubebuilder:validation:items:Maximum=16
+	GRPCStatuses []int32 `json:"grpcStatuses,omitempty"`
+}
+
+// StatusCodeRange is an inclusive range of HTTP status codes.
+// +kubebuilder:validation:XValidation:rule="self.start <= self.end",message="start must be less than or equal to end"
+type StatusCodeRange struct {
+	// Start is the first status code of the range.
+	// +required
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	Start int32 `json:"start"`
+
+	// End is the last status code of the range.
+	// +required
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	End int32 `json:"end"`
+}
@@ -116,11 +116,17 @@ type BackendConfigPolicySpec struct {
 	// See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/transport_sockets/proxy_protocol/v3/upstream_proxy_protocol.proto) for more details.
 	// +optional
 	UpstreamProxyProtocol *UpstreamProxyProtocol `json:"upstreamProxyProtocol,omitempty"`
+
+	// CredentialInjection injects a credential into the HTTP requests sent to the backend,
+	// for example an API key or an OAuth2 access token required by an external API.
+	// +optional
+	CredentialInjection *CredentialInjection `json:"credentialInjection,omitempty"`
 }
 
 // CircuitBreakers contains the options to configure circuit breaker thresholds for the default priority.
 // See [Envoy documentation](https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/cluster/v3/circuit_breaker.proto) for more details.
-// +kubebuilder:validation:AtLeastOneOf=maxConnections;maxPendingRequests;maxRequests;maxRetries
+// +kubebuilder:validation:AtLeastOneOf=maxConnections;maxPendingRequests;maxRequests;maxRetries;retryBudget
+// +kubebuilder:validation:XValidation:rule="!(has(self.maxRetries) && has(self.retryBudget))",message="maxRetries and retryBudget are mutually exclusive"
 type CircuitBreakers struct {
 	// MaxConnections is the maximum number of connections that will be made to
 	// the upstream cluster. If not specified, defaults to 1024.
@@ -146,6 +152,11 @@ type CircuitBreakers struct {
 	// +kubebuilder:validation:Minimum=0
 	MaxRetries *int32 `json:"maxRetries,omitempty"`
 
+	// RetryBudget limits the parallel retries to the upstream cluster to a share of
+	// its active requests. Mutually exclusive with MaxRetries.
+	// +optional
+	RetryBudget *RetryBudget `json:"retryBudget,omitempty"`
+
 	// TrackRemaining controls whether Envoy tracks the remaining resource
 	// gauges for this circuit breaker threshold group. When enabled, the
 	// remaining_cx, remaining_pending, remaining_rq, and remaining_retries
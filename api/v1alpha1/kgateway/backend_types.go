@@ -42,6 +42,12 @@ const (
 	BackendTypeGCP BackendType = "GCP"
 	// BackendTypePriorityGroups is the type for priority groups backends.
 	BackendTypePriorityGroups BackendType = "PriorityGroups"
+	// BackendTypeConsul is the type for Consul backends.
+	BackendTypeConsul BackendType = "Consul"
+	// BackendTypeDnsSrv is the type for DNS SRV backends.
+	BackendTypeDnsSrv BackendType = "DnsSrv"
+	// BackendTypeFile is the type for backends whose endpoints are listed in a document.
+	BackendTypeFile BackendType = "File"
 )
 
 // BackendSpec defines the desired state of Backend.
@@ -50,10 +56,13 @@ const (
 // +kubebuilder:validation:XValidation:message="dynamicForwardProxy backend must be specified when type is 'DynamicForwardProxy'",rule="!has(self.type) || (self.type == 'DynamicForwardProxy' ? has(self.dynamicForwardProxy) : true)"
 // +kubebuilder:validation:XValidation:message="gcp backend must be specified when type is 'GCP'",rule="!has(self.type) || (self.type == 'GCP' ? has(self.gcp) : true)"
 // +kubebuilder:validation:XValidation:message="priorityGroups backend must be specified when type is 'PriorityGroups'",rule="!has(self.type) || (self.type == 'PriorityGroups' ? has(self.priorityGroups) : true)"
-// +kubebuilder:validation:ExactlyOneOf=aws;static;dynamicForwardProxy;gcp;priorityGroups
+// +kubebuilder:validation:XValidation:message="consul backend must be specified when type is 'Consul'",rule="!has(self.type) || (self.type == 'Consul' ? has(self.consul) : true)"
+// +kubebuilder:validation:XValidation:message="dnsSrv backend must be specified when type is 'DnsSrv'",rule="!has(self.type) || (self.type == 'DnsSrv' ? has(self.dnsSrv) : true)"
+// +kubebuilder:validation:XValidation:message="file backend must be specified when type is 'File'",rule="!has(self.type) || (self.type == 'File' ? has(self.file) : true)"
+// +kubebuilder:validation:ExactlyOneOf=aws;static;dynamicForwardProxy;gcp;priorityGroups;consul;dnsSrv;file
 type BackendSpec struct {
 	// Type indicates the type of the backend to be used.
-	// +kubebuilder:validation:Enum=AWS;Static;DynamicForwardProxy;GCP;PriorityGroups
+	// +kubebuilder:validation:Enum=AWS;Static;DynamicForwardProxy;GCP;PriorityGroups;Consul;DnsSrv;File
 	// Deprecated: The Type field is deprecated and will be removed in a future release.
 	// The backend type is inferred from the configuration.
 	// +optional
@@ -81,6 +90,16 @@ type BackendSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
 	PriorityGroups []PriorityGroup `json:"priorityGroups,omitempty"`
+	// Consul discovers the healthy instances of a service registered in Consul.
+	// +optional
+	Consul *ConsulBackend `json:"consul,omitempty"`
+	// DnsSrv discovers the endpoints of the backend from DNS SRV records.
+	// +optional
+	DnsSrv *DnsSrvBackend `json:"dnsSrv,omitempty"`
+	// File reads the endpoints of the backend from a JSON or YAML document in a
+	// ConfigMap or in a file mounted into the controller.
+	// +optional
+	File *FileBackend `json:"file,omitempty"`
 }
 
 // PriorityGroup defines one failover priority level of a priority groups backend.
@@ -226,9 +245,12 @@ const (
 	AwsLambdaInvocationModeSynchronous = "Sync"
 	// AwsLambdaInvocationModeAsynchronous is the asynchronous invocation mode for the lambda function.
 	AwsLambdaInvocationModeAsynchronous = "Async"
+	// AwsLambdaInvocationModeStreaming is the response streaming invocation mode for the lambda function.
+	AwsLambdaInvocationModeStreaming = "Stream"
 )
 
 // AwsLambda configures the AWS Lambda service.
+// +kubebuilder:validation:XValidation:message="asyncResponse can only be set when invocationMode is Async",rule="!has(self.asyncResponse) || (has(self.invocationMode) && self.invocationMode == 'Async')"
 type AwsLambda struct {
 	// AccountId is the AWS account ID to use for the backend.
 	// This is the preferred location for Lambda backends.
@@ -251,8 +273,15 @@ type AwsLambda struct {
 	FunctionName string `json:"functionName"`
 	// InvocationMode defines how to invoke the Lambda function.
 	// Defaults to Sync.
+	//
+	// Sync waits for the function to complete and returns its response.
+	// Async queues the event and responds without waiting for the function, see AsyncResponse.
+	// Stream invokes the function with InvokeWithResponseStream and relays the response to the
+	// client as the function writes it, which is required for server-sent events. When the
+	// function streams an HTTP integration response, its status code and headers are used for
+	// the response; otherwise the response is a 200 with the content type of the stream.
 	// +optional
-	// +kubebuilder:validation:Enum=Sync;Async
+	// +kubebuilder:validation:Enum=Sync;Async;Stream
 	// +kubebuilder:default=Sync
 	InvocationMode string `json:"invocationMode,omitempty"`
 	// Qualifier is the alias or version for the Lambda function.
@@ -267,6 +296,53 @@ type AwsLambda struct {
 	// +optional
 	// +kubebuilder:default=Envoy
 	PayloadTransformMode AWSLambdaPayloadTransformMode `json:"payloadTransformMode,omitempty"`
+
+	// QualifierHeader selects the alias or version of the function to invoke from a request
+	// header, e.g. to send canary traffic to a new version. Requests without the header, or
+	// with a value that is not listed, invoke the function at Qualifier.
+	// +optional
+	QualifierHeader *AwsLambdaQualifierHeader `json:"qualifierHeader,omitempty"`
+
+	// AsyncResponse configures the response sent to the client once an Async invocation is
+	// queued. Defaults to the 202 response of Lambda, with an empty body.
+	// +optional
+	AsyncResponse *AwsLambdaAsyncResponse `json:"asyncResponse,omitempty"`
+}
+
+// AwsLambdaQualifierHeader selects the qualifier of a Lambda function from a request header.
+type AwsLambdaQualifierHeader struct {
+	// Name is the name of the request header. The header is removed before the function is
+	// invoked.
+	// +required
+	Name gwv1.HTTPHeaderName `json:"name"`
+
+	// Qualifiers are the aliases or versions the header may select. Only these can be
+	// invoked, so that clients cannot invoke arbitrary versions of the function.
+	// +required
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern="^(\\$LATEST|[0-9]+|[A-Za-z0-9-_]{1,128})$"
+	Qualifiers []string `json:"qualifiers"`
+}
+
+// AwsLambdaAsyncResponse configures the response to an asynchronous Lambda invocation.
+type AwsLambdaAsyncResponse struct {
+	// StatusCode is the status code of the response.
+	// Defaults to 202.
+	// +optional
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=299
+	// +kubebuilder:default=202
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// Headers are set on the response, replacing the headers of the same name returned by
+	// Lambda.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Headers []gwv1.HTTPHeader `json:"headers,omitempty"`
 }
 
 // AwsAddressType defines which EC2 IP address to route to.
@@ -380,6 +456,138 @@ type GcpBackend struct {
 	Audience *string `json:"audience,omitempty"`
 }
 
+// ConsulBackend discovers the healthy instances of a service from the Consul
+// HTTP health API. Blocking queries are used so that changes in the catalog are
+// picked up as soon as Consul reports them.
+type ConsulBackend struct {
+	// Address is the URL of the Consul HTTP API, e.g. "https://consul.example.com:8501".
+	// HTTPS endpoints are verified against the system CAs.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://[^\s/?#]+/?$`
+	Address string `json:"address"`
+
+	// Service is the name of the Consul service to discover.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Service string `json:"service"`
+
+	// Tags restricts discovery to the service instances that have all of the given tags.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	Tags []string `json:"tags,omitempty"`
+
+	// Datacenter is the Consul datacenter to query.
+	// Defaults to the datacenter of the agent serving the API.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Datacenter *string `json:"datacenter,omitempty"`
+
+	// Namespace is the Consul Enterprise namespace of the service.
+	// Defaults to the namespace of the ACL token.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace *string `json:"namespace,omitempty"`
+
+	// TokenSecretRef references a Secret in the namespace of the Backend whose "token"
+	// key holds the Consul ACL token used for discovery.
+	// +optional
+	TokenSecretRef *corev1.LocalObjectReference `json:"tokenSecretRef,omitempty"`
+
+	// WaitTime is the maximum duration a blocking query waits for a change in the
+	// catalog before it is reissued. Defaults to 5m.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s') && duration(self) <= duration('10m')",message="waitTime must be between 1s and 10m"
+	WaitTime *metav1.Duration `json:"waitTime,omitempty"`
+}
+
+// DnsSrvBackend discovers the endpoints of a backend from DNS SRV records, which the
+// controller resolves periodically, re-resolving them when their TTL expires.
+// The targets of the records are resolved to their IPv4 addresses, or to their
+// IPv6 addresses when they have none. The priority of a record becomes the
+// priority of its endpoints, so lower priority records only receive traffic when
+// the higher priority ones are unhealthy, and the weight of a record becomes the
+// load balancing weight of its endpoints.
+type DnsSrvBackend struct {
+	// Name is the name of the SRV record, e.g. "_postgres._tcp.db.example.com".
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_]([A-Za-z0-9_-]*[A-Za-z0-9_])?(\.[A-Za-z0-9_]([A-Za-z0-9_-]*[A-Za-z0-9_])?)*\.?$`
+	Name string `json:"name"`
+
+	// Nameservers are the DNS servers to query, as an IP address with an optional
+	// port, e.g. "10.0.0.10" or "[fd00::10]:5353". They are tried in order.
+	// Defaults to the nameservers in the resolv.conf of the controller.
+	// +optional
+	// +kubebuilder:validation:MaxItems=3
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=64
+	Nameservers []string `json:"nameservers,omitempty"`
+}
+
+// FileBackend reads the endpoints of a backend from a JSON or YAML document, which
+// is watched for changes. The document lists the endpoints of the backend:
+//
+//	endpoints:
+//	- address: 10.0.0.1
+//	  port: 8080
+//	  weight: 2
+//	  priority: 0
+//	  locality:
+//	    region: eu-west
+//	    zone: site-a
+//	  metadata:
+//	    rack: r12
+//
+// The address of an endpoint must be an IP address and its port is required. The
+// weight is the load balancing weight of the endpoint, from 1 to 65535, defaulting
+// to 1. Endpoints with a higher priority value only receive traffic when those with
+// a lower one are unhealthy. The metadata of an endpoint is set as its "envoy.lb"
+// metadata in Envoy, which access logs can reference with
+// %UPSTREAM_METADATA(envoy.lb:KEY)%; keys and values must be valid label keys and values.
+//
+// The EndpointsDiscovered condition of the Backend reports errors reading or
+// validating the document. An invalid document is rejected as a whole, and the
+// endpoints of the last valid document keep being served meanwhile.
+// +kubebuilder:validation:ExactlyOneOf=configMapRef;path
+type FileBackend struct {
+	// ConfigMapRef references the key of a ConfigMap in the namespace of the Backend
+	// that holds the document.
+	// +optional
+	ConfigMapRef *FileBackendConfigMapRef `json:"configMapRef,omitempty"`
+
+	// Path is the path of a file holding the document, relative to the endpoints
+	// directory of the controller, which is configured with the
+	// KGW_FILE_ENDPOINTS_DIRECTORY setting, e.g. "site-a/endpoints.yaml".
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-][A-Za-z0-9._-]*(/[A-Za-z0-9_-][A-Za-z0-9._-]*)*$`
+	Path *string `json:"path,omitempty"`
+}
+
+// FileBackendConfigMapRef references the key of a ConfigMap holding an endpoints document.
+type FileBackendConfigMapRef struct {
+	// Name is the name of the ConfigMap.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key of the document in the data of the ConfigMap.
+	// Defaults to "endpoints.yaml".
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Key *string `json:"key,omitempty"`
+}
+
 // Host defines a static backend host.
 type Host struct {
 	// Host is the host name to use for the backend.
@@ -410,7 +618,7 @@ const (
 	BackendReasonInvalid BackendConditionReason = "Invalid"
 
 	// BackendConditionEndpointsDiscovered indicates whether runtime endpoint discovery
-	// (e.g. AWS EC2 instance discovery) succeeded for backends that resolve their
+	// (e.g. AWS EC2 instance, Consul service, DNS SRV or endpoints document discovery) succeeded for backends that resolve their
 	// endpoints dynamically. It is only set on backends that perform such discovery.
 	BackendConditionEndpointsDiscovered BackendConditionType = "EndpointsDiscovered"
 
@@ -438,6 +646,11 @@ const (
 	// failed for a transient or otherwise unclassified reason.
 	BackendReasonDiscoveryError BackendConditionReason = "DiscoveryError"
 
+	// BackendReasonInvalidEndpoints is used with EndpointsDiscovered=False when the
+	// endpoints listed for the backend, e.g. in the endpoints document of a File
+	// backend, failed validation.
+	BackendReasonInvalidEndpoints BackendConditionReason = "InvalidEndpoints"
+
 	// BackendReasonDegraded is used with EndpointsDiscovered=False when the last discovery
 	// poll failed but the backend is still serving endpoints carried forward from a previous
 	// successful poll. It distinguishes a degraded-but-serving backend from one that is hard
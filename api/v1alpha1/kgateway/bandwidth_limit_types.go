@@ -0,0 +1,58 @@
+package kgateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+// BandwidthLimitPolicy configures Envoy's bandwidth limit filter, which caps the rate at which
+// request and/or response bodies are transferred on each stream of the targeted routes.
+// Unlike the responseRateLimit of FaultInjectionPolicy, it applies to every request.
+// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/bandwidth_limit_filter)
+// for more details.
+//
+// The filter statistics are emitted under `<prefix>.http_bandwidth_limit.*`. When the policy targets
+// a route that has a statPrefix, the prefix is the resolved statPrefix of the route, so each route
+// has its own statistics. Otherwise, the prefix is `http_bandwidth_limiter`.
+//
+// +kubebuilder:validation:ExactlyOneOf=kibPerSecond;disable
+// +kubebuilder:validation:XValidation:rule="!has(self.disable) || (!has(self.direction) && !has(self.fillInterval))",message="direction and fillInterval cannot be set when disable is set"
+type BandwidthLimitPolicy struct {
+	// Direction sets which bodies are limited. Defaults to RequestAndResponse.
+	// +optional
+	Direction *BandwidthLimitDirection `json:"direction,omitempty"`
+
+	// KiBPerSecond is the maximum rate in KiB (1024 bytes) per second, applied to each direction
+	// separately.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	KiBPerSecond *uint64 `json:"kibPerSecond,omitempty"`
+
+	// FillInterval is how often the token bucket that enforces the limit is refilled.
+	// Shorter intervals produce a smoother data rate at the cost of more timer wakeups.
+	// Defaults to 50ms.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('20ms')",message="must be at least 20ms"
+	// +kubebuilder:validation:XValidation:rule="duration(self) <= duration('1s')",message="must not exceed 1s"
+	FillInterval *metav1.Duration `json:"fillInterval,omitempty"`
+
+	// Disable the bandwidth limit filter.
+	// Can be used to disable bandwidth limit policies applied at a higher level in the config hierarchy.
+	// +optional
+	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+}
+
+// BandwidthLimitDirection selects the bodies a bandwidth limit applies to.
+// +kubebuilder:validation:Enum=Request;Response;RequestAndResponse
+type BandwidthLimitDirection string
+
+const (
+	// BandwidthLimitDirectionRequest limits request bodies sent to the backend.
+	BandwidthLimitDirectionRequest BandwidthLimitDirection = "Request"
+	// BandwidthLimitDirectionResponse limits response bodies sent to the client.
+	BandwidthLimitDirectionResponse BandwidthLimitDirection = "Response"
+	// BandwidthLimitDirectionRequestAndResponse limits both request and response bodies.
+	BandwidthLimitDirectionRequestAndResponse BandwidthLimitDirection = "RequestAndResponse"
+)
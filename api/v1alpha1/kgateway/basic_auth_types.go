@@ -8,16 +8,24 @@ import (
 
 // BasicAuthPolicy configures HTTP basic authentication using the Authorization header.
 // Basic authentication validates requests against username/password pairs provided either inline or via a Kubernetes secret.
-// The credentials must be in htpasswd SHA-1 format.
+// The credentials must be in htpasswd format, hashed with bcrypt, argon2 or SHA-1.
 //
 // +kubebuilder:validation:ExactlyOneOf=users;secretRef;disable
+// +kubebuilder:validation:XValidation:rule="!has(self.disable) || (!has(self.realms) && !has(self.forwardUsernameHeader) && !has(self.forwardRealmHeader))",message="realms, forwardUsernameHeader and forwardRealmHeader cannot be set when basic auth is disabled"
 type BasicAuthPolicy struct {
 	// Users provides an inline list of username/password pairs in htpasswd format.
 	// Each entry should be formatted as "username:hashed_password".
-	// The only supported hash format is SHA-1
+	// The supported hash formats are bcrypt ($2a$, $2b$, $2y$), argon2 ($argon2i$, $argon2d$, $argon2id$)
+	// and SHA-1 ({SHA}). SHA-1 is accepted for compatibility only: a policy with SHA-1 users is Accepted
+	// with the PartiallyValid reason and a message naming them. Entries in any other format are dropped.
+	//
+	// Passwords hashed with bcrypt or argon2 are verified by the kgateway dynamic module rather than the
+	// Envoy basic auth filter. Their verification cost is paid on every request, so pick work factors
+	// suited to the request rate of the routes.
 	//
 	// Example entries:
-	//   - "user1:{SHA}d95o2uzYI7q7tY7bHI4U1xBug7s="
+	//   - "user1:$2a$10$EALZ96i9yhdWvYEKOaOejOi6TiEO0MCyMBBWZEef0yGQXwbXFyWOm"
+	//   - "user2:{SHA}d95o2uzYI7q7tY7bHI4U1xBug7s="
 	//
 	// +optional
 	// +kubebuilder:validation:MinItems=1
@@ -33,6 +41,51 @@ type BasicAuthPolicy struct {
 	// Can be used to disable basic auth policies applied at a higher level in the config hierarchy.
 	// +optional
 	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+
+	// Realms assigns users to realms. The realm of an authenticated user is set as the "realm"
+	// dynamic metadata key of the dev.kgateway.http.basic_auth namespace, next to the "username" key,
+	// and forwarded upstream when ForwardRealmHeader is set.
+	// A user may be in at most one realm.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Realms []BasicAuthRealm `json:"realms,omitempty"`
+
+	// ForwardUsernameHeader is the name of the request header the username of the authenticated
+	// user is forwarded upstream in.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	ForwardUsernameHeader *string `json:"forwardUsernameHeader,omitempty"`
+
+	// ForwardRealmHeader is the name of the request header the realm of the authenticated
+	// user is forwarded upstream in. The header is not set for users that are not in a realm.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	ForwardRealmHeader *string `json:"forwardRealmHeader,omitempty"`
+}
+
+// BasicAuthRealm is a named group of basic auth users.
+type BasicAuthRealm struct {
+	// Name of the realm.
+	//
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Users in the realm.
+	//
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=256
+	Users []string `json:"users"`
 }
 
 // SecretReference identifies a Kubernetes secret containing authentication data.
@@ -0,0 +1,76 @@
+package kgateway
+
+import (
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+// CachePolicy configures Envoy's HTTP cache filter with the in-memory cache backend, which stores
+// cacheable responses according to their Cache-Control and Expires headers and serves later requests
+// from the cache. Envoy does not serve requests carrying an Authorization header from the cache, and
+// the policy status reports when the authentication of the policy requires that header.
+// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/cache_filter)
+// for more details.
+// +kubebuilder:validation:XValidation:rule="!has(self.disable) || (!has(self.maxBodyBytes) && !has(self.allowedVaryHeaders) && !has(self.key) && !has(self.ignoreRequestCacheControl))",message="disable is mutually exclusive with other cache fields"
+type CachePolicy struct {
+	// MaxBodyBytes is the largest response body that is stored in the cache.
+	// Defaults to no limit beyond the limit of the cache backend.
+	// NOTE: This limit is not yet enforced by Envoy.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxBodyBytes *int32 `json:"maxBodyBytes,omitempty"`
+
+	// AllowedVaryHeaders are the request headers that responses may vary on. Responses with a Vary
+	// header naming any other request header are not cached. Listing a header here caches a separate
+	// response for each of its values, which is how a header becomes part of the cache key.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	AllowedVaryHeaders []string `json:"allowedVaryHeaders,omitempty"`
+
+	// Key customizes which parts of the request URL make up the cache key.
+	// NOTE: Key customization is not yet enforced by Envoy, which always keys on the full URL.
+	// +optional
+	Key *CacheKey `json:"key,omitempty"`
+
+	// IgnoreRequestCacheControl serves cached responses even when the request has a
+	// `Cache-Control: no-cache` or `Pragma: no-cache` header, instead of revalidating them with the backend.
+	// +optional
+	IgnoreRequestCacheControl *bool `json:"ignoreRequestCacheControl,omitempty"`
+
+	// Disable the cache.
+	// Can be used to disable cache policies applied at a higher level in the config hierarchy.
+	// +optional
+	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+}
+
+// CacheKey customizes the cache key built from the request URL.
+type CacheKey struct {
+	// ExcludeScheme removes the scheme from the cache key, so that HTTP and HTTPS requests share
+	// cached responses.
+	// +optional
+	ExcludeScheme *bool `json:"excludeScheme,omitempty"`
+
+	// ExcludeHost removes the host from the cache key, so that all hosts share cached responses.
+	// +optional
+	ExcludeHost *bool `json:"excludeHost,omitempty"`
+
+	// IncludeQueryParameters are the only query parameters that are part of the cache key.
+	// Defaults to all query parameters.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	IncludeQueryParameters []string `json:"includeQueryParameters,omitempty"`
+
+	// ExcludeQueryParameters are query parameters that are not part of the cache key, even when they
+	// are listed in IncludeQueryParameters.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	ExcludeQueryParameters []string `json:"excludeQueryParameters,omitempty"`
+}
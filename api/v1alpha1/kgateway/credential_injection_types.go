@@ -0,0 +1,88 @@
+package kgateway
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CredentialInjection injects a credential into the requests sent to the backend.
+// The credential is delivered to Envoy as a secret over SDS and added by an upstream HTTP filter,
+// so it is not part of the route or listener configuration.
+// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/credential_injector_filter)
+// for more details.
+// +kubebuilder:validation:ExactlyOneOf=secretRef;oauth2
+type CredentialInjection struct {
+	// SecretRef injects a static credential, such as a bearer token or an API key, read from a Secret.
+	// +optional
+	SecretRef *CredentialSecretRef `json:"secretRef,omitempty"`
+
+	// OAuth2 injects an access token fetched with the OAuth2 client credentials grant. Envoy fetches the
+	// token and refreshes it before it expires.
+	// +optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+
+	// Overwrite replaces the credential header when it is already present in the request.
+	// Defaults to false, in which case the credential of the request is kept.
+	// +optional
+	Overwrite *bool `json:"overwrite,omitempty"`
+
+	// AllowRequestWithoutCredential forwards the requests without a credential when the credential is
+	// not available yet, for example while the OAuth2 token is being fetched.
+	// Defaults to false, in which case such requests are rejected with a 401 status code.
+	// +optional
+	AllowRequestWithoutCredential *bool `json:"allowRequestWithoutCredential,omitempty"`
+}
+
+// CredentialSecretRef references a Secret key holding a static credential.
+type CredentialSecretRef struct {
+	// Name is the name of the Secret, in the same namespace as the policy.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key of the Secret entry that contains the credential.
+	// +optional
+	// +kubebuilder:default=credential
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key,omitempty"`
+
+	// Header is the name of the header the credential is set in, for example `x-api-key`.
+	// Defaults to `Authorization`.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_|~]+$`
+	Header *string `json:"header,omitempty"`
+
+	// Prefix is prepended to the credential in the header value, for example `Bearer `.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	Prefix *string `json:"prefix,omitempty"`
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client credentials grant used to fetch the access token
+// injected into the requests. The token is set in the `Authorization` header as a bearer token.
+type OAuth2ClientCredentials struct {
+	// TokenEndpoint is the endpoint of the authorization server the access token is fetched from.
+	// Envoy connects to it over TLS and verifies its certificate against the system CA certificates.
+	// Refer to https://datatracker.ietf.org/doc/html/rfc6749#section-3.2 for more details.
+	// +required
+	TokenEndpoint HttpsUri `json:"tokenEndpoint"`
+
+	// Credentials specifies the client credentials used to authenticate with the authorization server.
+	// +required
+	Credentials OAuth2Credentials `json:"credentials"`
+
+	// Scopes are the scopes requested for the access token.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Scopes []string `json:"scopes,omitempty"`
+
+	// TokenFetchRetryInterval is the interval between retries when fetching the token fails.
+	// Defaults to 2s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	TokenFetchRetryInterval *metav1.Duration `json:"tokenFetchRetryInterval,omitempty"`
+}
@@ -0,0 +1,99 @@
+package kgateway
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+// CustomResponsePolicy replaces the responses of the targeted routes, whether returned by the upstream
+// or generated locally by Envoy, based on their status code. Unlike the local replies of a ListenerPolicy,
+// it only applies to the routes the policy is attached to, so each hostname or route can serve its own
+// error pages. Requests that do not match any route are not affected.
+// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/custom_response_filter)
+// for more details.
+// +kubebuilder:validation:ExactlyOneOf=rules;disable
+type CustomResponsePolicy struct {
+	// Rules map status codes to custom responses. The first rule matching the status code of a
+	// response is applied.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Rules []CustomResponseRule `json:"rules,omitempty"`
+
+	// Disable the custom responses.
+	// Can be used to disable custom response policies applied at a higher level in the config hierarchy.
+	// +optional
+	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+}
+
+// CustomResponseRule replaces the responses with the given status codes.
+// +kubebuilder:validation:ExactlyOneOf=directResponseRef;configMapRef;redirect
+type CustomResponseRule struct {
+	// StatusCodes are the status codes of the responses to replace.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	StatusCodes []StatusCodeRange `json:"statusCodes"`
+
+	// DirectResponseRef references a DirectResponse in the same namespace as the TrafficPolicy.
+	// The status code and body of the DirectResponse replace those of the response.
+	// +optional
+	DirectResponseRef *corev1.LocalObjectReference `json:"directResponseRef,omitempty"`
+
+	// ConfigMapRef references a ConfigMap key in the same namespace as the TrafficPolicy,
+	// whose content replaces the body of the response.
+	// +optional
+	ConfigMapRef *CustomResponseConfigMapRef `json:"configMapRef,omitempty"`
+
+	// Redirect internally redirects the request to another route and returns its response instead.
+	// +optional
+	Redirect *CustomResponseRedirect `json:"redirect,omitempty"`
+}
+
+// CustomResponseConfigMapRef references a key of a ConfigMap holding the body of a custom response.
+type CustomResponseConfigMapRef struct {
+	// Name is the name of the ConfigMap.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key of the ConfigMap entry that contains the body.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+
+	// StatusCode replaces the status code of the response. Defaults to the original status code.
+	// +optional
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// ContentType is the content type of the body, for example `text/html; charset=utf-8`.
+	// Defaults to `text/plain`.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	ContentType *string `json:"contentType,omitempty"`
+}
+
+// CustomResponseRedirect internally redirects a request to get a custom response.
+type CustomResponseRedirect struct {
+	// URI is the absolute URI the request is redirected to, for example `http://errors.example.com/404.html`.
+	// The redirected request is routed by the same listener, so its host and path must match a route
+	// of the Gateway.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://[^/]+(/.*)?$`
+	URI string `json:"uri"`
+
+	// StatusCode replaces the status code of the redirected response, unless that response is an error.
+	// Defaults to the status code of the redirected response.
+	// +optional
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	StatusCode *int32 `json:"statusCode,omitempty"`
+}
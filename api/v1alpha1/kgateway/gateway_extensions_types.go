@@ -40,16 +40,18 @@ type NamedJWTProvider struct {
 }
 
 // GatewayExtensionSpec defines the desired state of GatewayExtension.
-// +kubebuilder:validation:ExactlyOneOf=extAuth;extProc;rateLimit;jwt;oauth2
+// +kubebuilder:validation:ExactlyOneOf=extAuth;extProc;rateLimit;jwt;oauth2;wasm;geoIP
 // +kubebuilder:validation:XValidation:message="extAuth must be set when type is ExtAuth",rule="has(self.type) && self.type == 'ExtAuth' ? has(self.extAuth) : true"
 // +kubebuilder:validation:XValidation:message="extProc must be set when type is ExtProc",rule="has(self.type) && self.type == 'ExtProc' ? has(self.extProc) : true"
 // +kubebuilder:validation:XValidation:message="rateLimit must be set when type is RateLimit",rule="has(self.type) && self.type == 'RateLimit' ? has(self.rateLimit) : true"
 // +kubebuilder:validation:XValidation:message="JWT must be set when type is JWT",rule="has(self.type) && self.type == 'JWT' ? has(self.jwt) : true"
 // +kubebuilder:validation:XValidation:message="oauth2 must be set when type is OAuth2",rule="has(self.type) && self.type == 'OAuth2' ? has(self.oauth2) : true"
+// +kubebuilder:validation:XValidation:message="wasm must be set when type is Wasm",rule="has(self.type) && self.type == 'Wasm' ? has(self.wasm) : true"
+// +kubebuilder:validation:XValidation:message="geoIP must be set when type is GeoIP",rule="has(self.type) && self.type == 'GeoIP' ? has(self.geoIP) : true"
 type GatewayExtensionSpec struct {
 	// Deprecated: Setting this field has no effect.
 	// Type indicates the type of the GatewayExtension to be used.
-	// +kubebuilder:validation:Enum=ExtAuth;ExtProc;RateLimit;JWT;OAuth2
+	// +kubebuilder:validation:Enum=ExtAuth;ExtProc;RateLimit;JWT;OAuth2;Wasm;GeoIP
 	// +optional
 	Type *GatewayExtensionType `json:"type,omitempty"`
 
@@ -72,6 +74,14 @@ type GatewayExtensionSpec struct {
 	// OAuth2 configuration for OAuth2 extension type.
 	// +optional
 	OAuth2 *OAuth2Provider `json:"oauth2,omitempty"`
+
+	// Wasm configuration for Wasm extension type.
+	// +optional
+	Wasm *WasmProvider `json:"wasm,omitempty"`
+
+	// GeoIP configuration for GeoIP extension type.
+	// +optional
+	GeoIP *GeoIPProvider `json:"geoIP,omitempty"`
 }
 
 type JWT struct {
@@ -119,6 +129,10 @@ const (
 	GatewayExtensionTypeJWT GatewayExtensionType = "JWT"
 	// GatewayExtensionTypeOAuth2 is the type for OAuth2 extensions.
 	GatewayExtensionTypeOAuth2 GatewayExtensionType = "OAuth2"
+	// GatewayExtensionTypeWasm is the type for Wasm extensions.
+	GatewayExtensionTypeWasm GatewayExtensionType = "Wasm"
+	// GatewayExtensionTypeGeoIP is the type for Geo-IP extensions.
+	GatewayExtensionTypeGeoIP GatewayExtensionType = "GeoIP"
 )
 
 const HTTPDefaultTimeout = 2 * time.Second
@@ -274,6 +288,13 @@ type RateLimitProvider struct {
 	// +kubebuilder:default="Off"
 	// +optional
 	XRateLimitHeaders XRateLimitHeadersStandard `json:"xRateLimitHeaders,omitempty"`
+
+	// ShadowMode queries the rate limit service and records its decisions, including the X-RateLimit
+	// headers when enabled, without rejecting requests that exceed a limit. This is useful to
+	// evaluate new limits before enforcing them.
+	// Defaults to false.
+	// +optional
+	ShadowMode *bool `json:"shadowMode,omitempty"`
 }
 
 // XRateLimitHeadersStandard controls how XRateLimit headers will emitted.
@@ -0,0 +1,85 @@
+package kgateway
+
+import (
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+// GeoIPProvider defines the configuration for a Geo-IP extension provider.
+// Envoy looks up the client IP address in the configured MaxMind databases. The database files are
+// read from the local filesystem of the proxy, so they must be mounted into the proxy container,
+// for example with the extraVolumes and extraVolumeMounts fields of the GatewayParameters.
+//
+// The client IP address is the same one used by the ACL policy. See the UseRemoteAddress,
+// XffTrustedCIDRs and XffNumTrustedHops settings under ListenerPolicy -> HttpSettings for details.
+type GeoIPProvider struct {
+	// MaxMind configures the MaxMind databases used for the lookups.
+	// +required
+	MaxMind MaxMindDatabases `json:"maxMind"`
+}
+
+// MaxMindDatabases lists the MaxMind-format (.mmdb) database files to look client IP addresses up in.
+// +kubebuilder:validation:AtLeastOneOf=cityDBPath;countryDBPath;asnDBPath;ispDBPath
+type MaxMindDatabases struct {
+	// CityDBPath is the absolute path of a City database, e.g. "/etc/geoip/GeoLite2-City.mmdb".
+	// It provides the country, region and city of a client, and is used for the country when
+	// CountryDBPath is not set.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/.*\.mmdb$`
+	CityDBPath *string `json:"cityDBPath,omitempty"`
+
+	// CountryDBPath is the absolute path of a Country database, e.g. "/etc/geoip/GeoLite2-Country.mmdb".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/.*\.mmdb$`
+	CountryDBPath *string `json:"countryDBPath,omitempty"`
+
+	// ASNDBPath is the absolute path of an ASN database, e.g. "/etc/geoip/GeoLite2-ASN.mmdb".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/.*\.mmdb$`
+	ASNDBPath *string `json:"asnDBPath,omitempty"`
+
+	// ISPDBPath is the absolute path of an ISP database, e.g. "/etc/geoip/GeoIP2-ISP.mmdb".
+	// It is used for the ASN when ASNDBPath is not set.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/.*\.mmdb$`
+	ISPDBPath *string `json:"ispDBPath,omitempty"`
+}
+
+// GeoIPPolicy looks the client IP address up in a Geo-IP GatewayExtension and adds the result to
+// the request as headers, for the backend and for the ACL rules that match on country or ASN.
+// Headers with the same names sent by the client are always removed first, so they cannot be spoofed.
+type GeoIPPolicy struct {
+	// ExtensionRef references the GeoIP GatewayExtension that provides the databases.
+	// +required
+	ExtensionRef shared.NamespacedObjectReference `json:"extensionRef"`
+
+	// Headers sets the names of the request headers to add the lookup results to.
+	// +required
+	Headers GeoIPHeaders `json:"headers"`
+}
+
+// GeoIPHeaders sets the names of the request headers that carry the Geo-IP lookup results.
+// A header is only added when its value is known for the client.
+// +kubebuilder:validation:AtLeastOneOf=country;region;city;asn
+type GeoIPHeaders struct {
+	// Country is the header for the ISO 3166-1 alpha-2 country code of the client, e.g. "US".
+	// ACL rules that match on countries require this header.
+	// +optional
+	Country *gwv1.HTTPHeaderName `json:"country,omitempty"`
+
+	// Region is the header for the ISO 3166-2 code of the region of the client.
+	// Requires a City database.
+	// +optional
+	Region *gwv1.HTTPHeaderName `json:"region,omitempty"`
+
+	// City is the header for the city name of the client.
+	// Requires a City database.
+	// +optional
+	City *gwv1.HTTPHeaderName `json:"city,omitempty"`
+
+	// ASN is the header for the autonomous system number of the client, e.g. "15169".
+	// ACL rules that match on ASNs require this header.
+	// +optional
+	ASN *gwv1.HTTPHeaderName `json:"asn,omitempty"`
+}
@@ -0,0 +1,114 @@
+package kgateway
+
+import (
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GRPCJSONTranscoderPolicy configures Envoy's gRPC-JSON transcoder, which lets HTTP/JSON clients call
+// gRPC services. Requests are mapped to gRPC methods by the `google.api.http` annotations in the
+// protobuf descriptor set, and are routed again after transcoding, so the gRPC requests are matched
+// by the GRPCRoutes of the services. The policy therefore applies to the routes whose matches cover
+// the HTTP paths of the services, which is typically a Gateway or a catch-all route.
+// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/grpc_json_transcoder_filter)
+// for more details.
+type GRPCJSONTranscoderPolicy struct {
+	// DescriptorSet is the source of the binary protobuf descriptor set of the services,
+	// as generated by `protoc --include_imports --descriptor_set_out`.
+	// +required
+	DescriptorSet GRPCDescriptorSetSource `json:"descriptorSet"`
+
+	// Services are the fully qualified names of the gRPC services to transcode, for example
+	// `bookstore.Bookstore`. Every service must be defined in the descriptor set.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	Services []string `json:"services"`
+
+	// PrintOptions controls how gRPC responses are printed as JSON.
+	// +optional
+	PrintOptions *GRPCJSONPrintOptions `json:"printOptions,omitempty"`
+
+	// UnknownQueryParameters controls how requests with query parameters that do not map to a field
+	// of the gRPC request message are handled. By default, such requests are not transcoded and are
+	// passed through to the backend unchanged.
+	// +optional
+	UnknownQueryParameters *GRPCJSONUnknownQueryParameters `json:"unknownQueryParameters,omitempty"`
+
+	// IgnoredQueryParameters are query parameters that are never mapped to the gRPC request message,
+	// for example an API key read by an earlier filter.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	IgnoredQueryParameters []string `json:"ignoredQueryParameters,omitempty"`
+
+	// AutoMapping maps methods without a `google.api.http` annotation to `POST /<package>.<service>/<method>`.
+	// +optional
+	AutoMapping *bool `json:"autoMapping,omitempty"`
+
+	// ConvertGRPCStatus converts gRPC errors to JSON responses with a matching HTTP status code.
+	// +optional
+	ConvertGRPCStatus *bool `json:"convertGrpcStatus,omitempty"`
+}
+
+// GRPCDescriptorSetSource references the protobuf descriptor set of a GRPCJSONTranscoderPolicy.
+// +kubebuilder:validation:ExactlyOneOf=configMap;secret
+type GRPCDescriptorSetSource struct {
+	// ConfigMap references a key of a ConfigMap in the namespace of the policy. The descriptor set
+	// is read from the binaryData of the ConfigMap.
+	// +optional
+	ConfigMap *GRPCDescriptorSetRef `json:"configMap,omitempty"`
+
+	// Secret references a key of a Secret in the namespace of the policy.
+	// +optional
+	Secret *GRPCDescriptorSetRef `json:"secret,omitempty"`
+}
+
+// GRPCDescriptorSetRef references a key of a ConfigMap or Secret holding a protobuf descriptor set.
+type GRPCDescriptorSetRef struct {
+	// Name of the ConfigMap or Secret.
+	// +required
+	Name gwv1.ObjectName `json:"name"`
+
+	// Key holding the descriptor set.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+}
+
+// GRPCJSONPrintOptions controls how gRPC responses are printed as JSON.
+type GRPCJSONPrintOptions struct {
+	// AddWhitespace pretty-prints the JSON responses.
+	// +optional
+	AddWhitespace *bool `json:"addWhitespace,omitempty"`
+
+	// AlwaysPrintPrimitiveFields prints fields with default values, which are omitted otherwise.
+	// +optional
+	AlwaysPrintPrimitiveFields *bool `json:"alwaysPrintPrimitiveFields,omitempty"`
+
+	// AlwaysPrintEnumsAsInts prints enum values as numbers instead of names.
+	// +optional
+	AlwaysPrintEnumsAsInts *bool `json:"alwaysPrintEnumsAsInts,omitempty"`
+
+	// PreserveProtoFieldNames prints the field names of the proto definition instead of their
+	// lowerCamelCase JSON names.
+	// +optional
+	PreserveProtoFieldNames *bool `json:"preserveProtoFieldNames,omitempty"`
+}
+
+// GRPCJSONUnknownQueryParameters is how the gRPC-JSON transcoder handles query parameters that do
+// not map to a field of the gRPC request message.
+// +kubebuilder:validation:Enum=Ignore;Reject
+type GRPCJSONUnknownQueryParameters string
+
+const (
+	// GRPCJSONUnknownQueryParametersIgnore transcodes the request and drops the unknown query parameters.
+	GRPCJSONUnknownQueryParametersIgnore GRPCJSONUnknownQueryParameters = "Ignore"
+
+	// GRPCJSONUnknownQueryParametersReject rejects the request with a 400 response.
+	GRPCJSONUnknownQueryParametersReject GRPCJSONUnknownQueryParameters = "Reject"
+)
@@ -127,6 +127,20 @@ type ListenerConfig struct {
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
 	TransportSocketConnectTimeout *metav1.Duration `json:"transportSocketConnectTimeout,omitempty"`
+
+	// UDPSettings configures the Envoy UDP proxy of UDP listeners. It has no effect on other listeners.
+	// The UDP proxy forwards each session to a single backend and cannot split traffic by weight, so a
+	// UDPRoute must have exactly one rule with at most one backendRef of non-zero weight; other UDPRoutes
+	// are rejected.
+	// +optional
+	UDPSettings *UDPSettings `json:"udpSettings,omitempty"`
+
+	// HTTP3 enables HTTP/3 over QUIC on HTTPS listeners. When set, a UDP listener is added on the same port that
+	// serves the HTTPS listeners' hostnames with the same certificates and routes, and responses on the TCP
+	// listener advertise it to clients with an alt-svc header. The UDP port is also exposed on the proxy Service.
+	// It has no effect on other listeners.
+	// +optional
+	HTTP3 *HTTP3Settings `json:"http3,omitempty"`
 }
 
 type ListenerDefaultConfig struct {
@@ -169,6 +183,50 @@ type ProxyProtocolConfig struct {
 	AllowRequestsWithoutProxyProtocol *bool `json:"allowRequestsWithoutProxyProtocol,omitempty"`
 }
 
+// UDPSettings configures the Envoy UDP proxy that serves a UDP listener.
+type UDPSettings struct {
+	// SessionIdleTimeout is the time after which a UDP session with no datagrams in either direction is closed.
+	// If unspecified, Envoy's default of 60s is used.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/udp/udp_proxy/v3/udp_proxy.proto#envoy-v3-api-field-extensions-filters-udp-udp-proxy-v3-udpproxyconfig-idle-timeout
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	SessionIdleTimeout *metav1.Duration `json:"sessionIdleTimeout,omitempty"`
+}
+
+// HTTP3Settings configures the HTTP/3 (QUIC) listener added alongside HTTPS listeners.
+type HTTP3Settings struct {
+	// AltSvcMaxAge is how long clients may remember that HTTP/3 is available, as advertised in the
+	// max-age of the alt-svc response header. Defaults to 24h.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	AltSvcMaxAge *metav1.Duration `json:"altSvcMaxAge,omitempty"`
+
+	// IdleTimeout is the time after which an idle QUIC connection is closed.
+	// Envoy defaults to 300s.
+	// See here for more information: https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/listener/v3/quic_config.proto#envoy-v3-api-field-config-listener-v3-quicprotocoloptions-idle-timeout
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// MaxConcurrentStreams is the maximum number of concurrent streams of a QUIC connection.
+	// Envoy defaults to 100.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentStreams *int32 `json:"maxConcurrentStreams,omitempty"`
+
+	// InitialStreamWindowSize is the initial flow-control window of a QUIC stream.
+	// Valid values range from 1 to 16777216 (16Mi), which is also Envoy's default.
+	// Values can be specified with units like "64Ki".
+	// +optional
+	InitialStreamWindowSize *resource.Quantity `json:"initialStreamWindowSize,omitempty"`
+
+	// InitialConnectionWindowSize is the initial flow-control window of a QUIC connection.
+	// Valid values range from 1 to 25165824 (24Mi), which is also Envoy's default.
+	// Values can be specified with units like "64Ki".
+	// +optional
+	InitialConnectionWindowSize *resource.Quantity `json:"initialConnectionWindowSize,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:message="useRemoteAddress must be set to false if xffTrustedCIDRs is set",rule="!has(self.xffTrustedCIDRs) || (has(self.useRemoteAddress) && !self.useRemoteAddress)"
 // +kubebuilder:validation:XValidation:message="only one of xffNumTrustedHops and xffTrustedCIDRs may be set",rule="!has(self.xffNumTrustedHops) || !has(self.xffTrustedCIDRs)"
 // +kubebuilder:validation:XValidation:message="forwardClientCertDetails.details requires mode to be AppendForward or SanitizeSet (or unset)",rule="!has(self.forwardClientCertDetails) || !has(self.forwardClientCertDetails.details) || !has(self.forwardClientCertDetails.mode) || self.forwardClientCertDetails.mode == 'AppendForward' || self.forwardClientCertDetails.mode == 'SanitizeSet'"
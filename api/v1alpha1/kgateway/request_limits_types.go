@@ -0,0 +1,68 @@
+package kgateway
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+// RequestLimitsPolicy rejects requests to the targeted routes whose body, URL or header values
+// exceed the configured sizes. Unlike Buffer, the body size is enforced while the body is streamed
+// to the backend, so requests are never buffered in full.
+//
+// Rejections are counted per route and limit by the `dev.kgateway.http.request_limits.rejected`
+// counter. When the policy targets a route, the route label is the resolved statPrefix of the
+// route or, when the route has none, the name of the Envoy route. When the policy targets a
+// Gateway, it is the namespace and name of the policy. The exceeded limit is also written to the
+// `dev.kgateway.http.request_limits:rejected-by` dynamic metadata for access logs.
+//
+// +kubebuilder:validation:AtLeastOneOf=maxBodySize;maxURLLength;maxHeaderValueSize;disable
+// +kubebuilder:validation:XValidation:rule="!has(self.disable) || (!has(self.maxBodySize) && !has(self.maxURLLength) && !has(self.maxHeaderValueSize) && !has(self.rejectionResponse))",message="limits and rejectionResponse cannot be set when disable is set"
+type RequestLimitsPolicy struct {
+	// MaxBodySize is the maximum size of the request body. Requests with a larger Content-Length
+	// are rejected before their body is read, and requests without one are rejected as soon as
+	// more than this size has been received. Defaults to a 413 response.
+	// Example format: "1Mi", "512Ki", "1Gi"
+	// +optional
+	// +kubebuilder:validation:XValidation:message="maxBodySize must be greater than 0",rule="(type(self) == int && int(self) > 0) || (type(self) == string && quantity(self).isGreaterThan(quantity('0')))"
+	MaxBodySize *resource.Quantity `json:"maxBodySize,omitempty"`
+
+	// MaxURLLength is the maximum length of the request path, including the query string.
+	// Defaults to a 414 response.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxURLLength *int32 `json:"maxURLLength,omitempty"`
+
+	// MaxHeaderValueSize is the maximum size in bytes of each request header value. Unlike
+	// MaxRequestHeadersKb in the ListenerPolicy HTTPSettings, it applies to each header separately
+	// and can differ per route. Defaults to a 431 response.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxHeaderValueSize *int32 `json:"maxHeaderValueSize,omitempty"`
+
+	// RejectionResponse customizes the response sent when a request exceeds a limit.
+	// +optional
+	RejectionResponse *RequestLimitsRejection `json:"rejectionResponse,omitempty"`
+
+	// Disable the request limits.
+	// Can be used to disable request limits applied at a higher level in the config hierarchy.
+	// +optional
+	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+}
+
+// RequestLimitsRejection configures the response sent when a request exceeds a limit.
+// +kubebuilder:validation:AtLeastOneOf=statusCode;bodyFormat
+type RequestLimitsRejection struct {
+	// StatusCode overrides the status code of the response, which otherwise depends on the
+	// exceeded limit.
+	// +optional
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=599
+	StatusCode *int32 `json:"statusCode,omitempty"`
+
+	// BodyFormat defines the body of the response. Only the `%RESPONSE_CODE%` and
+	// `%LOCAL_REPLY_BODY%` operators are supported; the latter is replaced with a message
+	// describing the exceeded limit. Defaults to that message as plain text.
+	// +optional
+	BodyFormat *shared.BodyFormat `json:"bodyFormat,omitempty"`
+}
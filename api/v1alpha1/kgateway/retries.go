@@ -12,7 +12,8 @@ type RetryOnCondition string
 
 // Retry defines the retry policy
 //
-// +kubebuilder:validation:XValidation:rule="has(self.retryOn) || has(self.statusCodes)",message="retryOn or statusCodes must be set."
+// +kubebuilder:validation:XValidation:rule="has(self.retryOn) || has(self.statusCodes) || has(self.retriableHeaders)",message="retryOn, statusCodes or retriableHeaders must be set."
+// +kubebuilder:validation:XValidation:rule="!has(self.hedgeOnPerTryTimeout) || !self.hedgeOnPerTryTimeout || has(self.perTryTimeout)",message="hedgeOnPerTryTimeout requires perTryTimeout to be set."
 type Retry struct {
 	// RetryOn specifies the conditions under which a retry should be attempted.
 	// +optional
@@ -31,7 +32,10 @@ type Retry struct {
 
 	// PerTryTimeout specifies the timeout per retry attempt (including the initial attempt).
 	// If a global timeout is configured on a route, this timeout must be less than the global
-	// route timeout.
+	// route timeout. The per-try timeouts of the initial attempt and all retries together must
+	// not exceed the timeouts.request that applies to the same route, whether both are set in a
+	// single TrafficPolicy or in TrafficPolicies attached at different levels, e.g. a retry on
+	// an HTTPRoute and a request timeout on its Gateway.
 	// It is specified as a sequence of decimal numbers, each with optional fraction and a unit suffix, such as "1s" or "500ms".
 	// +optional
 	//
@@ -58,4 +62,65 @@ type Retry struct {
 	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid duration value"
 	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="retry.backoffBaseInterval must be at least 1ms."
 	BackoffBaseInterval *metav1.Duration `json:"backoffBaseInterval,omitempty"`
+
+	// RetriableHeaders specifies the response headers that should be retried in addition to the
+	// conditions specified in RetryOn. A response matching any of the headers is retried.
+	// +optional
+	//
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	RetriableHeaders []gwv1.HTTPHeaderMatch `json:"retriableHeaders,omitempty"`
+
+	// HostSelection configures how the backend host of a retry is selected.
+	// +optional
+	HostSelection *RetryHostSelection `json:"hostSelection,omitempty"`
+
+	// HedgeOnPerTryTimeout sends a retry to another host when the per-try timeout of an attempt
+	// elapses, without cancelling the timed out attempt. The first response to arrive is used and
+	// the outstanding attempts are cancelled. Requires PerTryTimeout to be set.
+	// Defaults to false.
+	// +optional
+	HedgeOnPerTryTimeout *bool `json:"hedgeOnPerTryTimeout,omitempty"`
+}
+
+// RetryHostSelection configures how the backend host of a retry is selected.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.avoidPreviousHosts) || has(self.avoidPreviousPriorities)",message="avoidPreviousHosts or avoidPreviousPriorities must be set."
+type RetryHostSelection struct {
+	// AvoidPreviousHosts rejects the hosts already attempted for a request when selecting the host of a retry.
+	// +optional
+	AvoidPreviousHosts *bool `json:"avoidPreviousHosts,omitempty"`
+
+	// AvoidPreviousPriorities selects the host of a retry from a priority level other than the ones already
+	// attempted for a request, while there are untried priority levels with healthy hosts.
+	// +optional
+	AvoidPreviousPriorities *bool `json:"avoidPreviousPriorities,omitempty"`
+
+	// MaxAttempts is the maximum number of times a host is selected for a retry before
+	// settling on a previously attempted one. Defaults to 1 if not set.
+	// +optional
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+}
+
+// RetryBudget limits the concurrent retries to a backend to a share of its active requests,
+// so that retries cannot overload a backend that is failing.
+type RetryBudget struct {
+	// Percent is the maximum percentage of the active requests to the backend that may be retries.
+	// Defaults to 20 if not set.
+	// +optional
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent *int32 `json:"percent,omitempty"`
+
+	// MinRetryConcurrency is the number of concurrent retries that are always allowed,
+	// regardless of Percent. Defaults to 3 if not set.
+	// +optional
+	//
+	// +kubebuilder:validation:Minimum=0
+	MinRetryConcurrency *int32 `json:"minRetryConcurrency,omitempty"`
 }
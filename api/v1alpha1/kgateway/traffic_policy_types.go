@@ -68,6 +68,10 @@ type TrafficPolicySpec struct {
 	// +optional
 	ExtProc *ExtProcPolicy `json:"extProc,omitempty"`
 
+	// Wasm enables a Wasm extension for the policy, with a plugin configuration specific to this policy.
+	// +optional
+	Wasm *WasmPolicy `json:"wasm,omitempty"`
+
 	// ExtAuth specifies the external authentication configuration for the policy.
 	// This controls what external server to send requests to for authentication.
 	// +optional
@@ -97,6 +101,12 @@ type TrafficPolicySpec struct {
 	// +optional
 	AutoHostRewrite *bool `json:"autoHostRewrite,omitempty"`
 
+	// Lua runs a Lua script on the requests and responses of the targeted routes.
+	// It can be used for small request and response changes that cannot be expressed with
+	// Transformation or HeaderModifiers.
+	// +optional
+	Lua *LuaPolicy `json:"lua,omitempty"`
+
 	// Buffer can be used to set the maximum request size that will be buffered.
 	// Requests exceeding this size will return a 413 response.
 	// +optional
@@ -117,6 +127,8 @@ type TrafficPolicySpec struct {
 	// When attached above the route level, the retry policy applies to all routes it
 	// covers; a route-level retry policy (from a more specific TrafficPolicy or the
 	// built-in HTTPRoute retry) takes precedence.
+	// Retry budgets are configured per backend with BackendConfigPolicy
+	// circuitBreakers.retryBudget rather than here.
 	// +optional
 	Retry *Retry `json:"retry,omitempty"`
 
@@ -181,6 +193,42 @@ type TrafficPolicySpec struct {
 	// +optional
 	FaultInjection *FaultInjectionPolicy `json:"faultInjection,omitempty"`
 
+	// AdaptiveConcurrency dynamically limits the number of concurrent requests to the backends of the
+	// targeted routes, based on their measured latency. Unlike the static circuit breakers of a
+	// BackendConfigPolicy, the limit adapts to changes in backend latency.
+	// +optional
+	AdaptiveConcurrency *AdaptiveConcurrencyPolicy `json:"adaptiveConcurrency,omitempty"`
+
+	// AdmissionControl rejects a share of the requests to the targeted routes when their recent
+	// success rate drops, to protect backends from retry storms.
+	// +optional
+	AdmissionControl *AdmissionControlPolicy `json:"admissionControl,omitempty"`
+
+	// BandwidthLimit caps the data rate of the request and/or response bodies of the targeted
+	// routes, so that large transfers cannot saturate the proxy for everyone else.
+	// +optional
+	BandwidthLimit *BandwidthLimitPolicy `json:"bandwidthLimit,omitempty"`
+
+	// RequestLimits rejects requests to the targeted routes whose body, URL or header values
+	// are too large, with a configurable response.
+	// +optional
+	RequestLimits *RequestLimitsPolicy `json:"requestLimits,omitempty"`
+
+	// Cache stores cacheable responses of the targeted routes in memory and serves later requests
+	// for them without contacting the backend.
+	// +optional
+	Cache *CachePolicy `json:"cache,omitempty"`
+
+	// GRPCJSONTranscoder translates HTTP/JSON requests to the targeted routes into gRPC requests,
+	// and their gRPC responses back into JSON.
+	// +optional
+	GRPCJSONTranscoder *GRPCJSONTranscoderPolicy `json:"grpcJsonTranscoder,omitempty"`
+
+	// CustomResponse replaces the responses of the targeted routes with custom error pages,
+	// based on their status code.
+	// +optional
+	CustomResponse *CustomResponsePolicy `json:"customResponse,omitempty"`
+
 	// ACL configures IP-based access control for HTTP requests.
 	// Rules are evaluated using longest-prefix matching on the effictive client IP
 	// from envoy base on settings. See the UseRemoteAddress, XffTrustedCIDRs,
@@ -196,6 +244,12 @@ type TrafficPolicySpec struct {
 	// +optional
 	ACL *shared.ACLPolicy `json:"acl,omitempty"`
 
+	// GeoIP adds the location and autonomous system of the client to the requests of the targeted
+	// routes as headers. ACL rules that match on countries or ASNs use these headers, so they
+	// require GeoIP to be set on the same policy.
+	// +optional
+	GeoIP *GeoIPPolicy `json:"geoIP,omitempty"`
+
 	// StatPrefix sets a custom prefix on the Envoy route so that per-route
 	// statistics are emitted for the targeted routes. When set, Envoy emits stats under
 	// `vhost.<vhost>.route.<statPrefix>.*`.
@@ -454,6 +508,7 @@ type RateLimitPolicy struct {
 	// Each descriptor represents a single rate limit rule with one or more entries.
 	// +required
 	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
 	Descriptors []RateLimitDescriptor `json:"descriptors"`
 
 	// ExtensionRef references a GatewayExtension that provides the global rate limit service.
@@ -469,10 +524,34 @@ type RateLimitDescriptor struct {
 	// +required
 	// +kubebuilder:validation:MinItems=1
 	Entries []RateLimitDescriptorEntry `json:"entries"`
+
+	// HitsAddend specifies how much a request matching this descriptor counts towards the limit.
+	// Defaults to 1 per request.
+	// +optional
+	HitsAddend *RateLimitHitsAddend `json:"hitsAddend,omitempty"`
+}
+
+// RateLimitHitsAddend defines how much a request counts towards a rate limit.
+// +kubebuilder:validation:ExactlyOneOf=number;format
+type RateLimitHitsAddend struct {
+	// Number is a fixed amount added for each request.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000000
+	Number *int64 `json:"number,omitempty"`
+
+	// Format is an Envoy substitution format string with a single command operator that evaluates
+	// to the amount added for each request, for example "%REQ(x-request-cost)%". The value must be
+	// a non-negative number no larger than 1000000000, otherwise the descriptor is ignored.
+	// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#config-access-log-format)
+	// for the supported command operators.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Format *string `json:"format,omitempty"`
 }
 
 // RateLimitDescriptorEntryType defines the type of a rate limit descriptor entry.
-// +kubebuilder:validation:Enum=Generic;Header;RemoteAddress;Path
+// +kubebuilder:validation:Enum=Generic;Header;RemoteAddress;Path;QueryParameter;MaskedRemoteAddress;Metadata;JWTClaim;Method;Expression
 type RateLimitDescriptorEntryType string
 
 const (
@@ -487,11 +566,36 @@ const (
 
 	// RateLimitDescriptorEntryTypePath represents a descriptor entry that uses the request path as its value.
 	RateLimitDescriptorEntryTypePath RateLimitDescriptorEntryType = "Path"
+
+	// RateLimitDescriptorEntryTypeQueryParameter represents a descriptor entry that extracts its value from a query parameter.
+	RateLimitDescriptorEntryTypeQueryParameter RateLimitDescriptorEntryType = "QueryParameter"
+
+	// RateLimitDescriptorEntryTypeMaskedRemoteAddress represents a descriptor entry that uses the client's IP address,
+	// masked to a CIDR prefix, as its value.
+	RateLimitDescriptorEntryTypeMaskedRemoteAddress RateLimitDescriptorEntryType = "MaskedRemoteAddress"
+
+	// RateLimitDescriptorEntryTypeMetadata represents a descriptor entry that extracts its value from the request's dynamic metadata.
+	RateLimitDescriptorEntryTypeMetadata RateLimitDescriptorEntryType = "Metadata"
+
+	// RateLimitDescriptorEntryTypeJWTClaim represents a descriptor entry that extracts its value from a claim of a verified JWT.
+	RateLimitDescriptorEntryTypeJWTClaim RateLimitDescriptorEntryType = "JWTClaim"
+
+	// RateLimitDescriptorEntryTypeMethod represents a descriptor entry that uses the request method as its value.
+	RateLimitDescriptorEntryTypeMethod RateLimitDescriptorEntryType = "Method"
+
+	// RateLimitDescriptorEntryTypeExpression represents a descriptor entry whose value is computed by a CEL expression.
+	RateLimitDescriptorEntryTypeExpression RateLimitDescriptorEntryType = "Expression"
 )
 
 // RateLimitDescriptorEntry defines a single entry in a rate limit descriptor.
-// Only one entry type may be specified.
-// +kubebuilder:validation:XValidation:message="exactly one entry type must be specified",rule="(has(self.type) && (self.type == 'Generic' && has(self.generic) && !has(self.header)) || (self.type == 'Header' && has(self.header) && !has(self.generic)) || (self.type == 'RemoteAddress' && !has(self.generic) && !has(self.header)) || (self.type == 'Path' && !has(self.generic) && !has(self.header)))"
+// Only the field matching the entry type may be specified.
+// +kubebuilder:validation:XValidation:message="generic must be specified if and only if type is Generic",rule="has(self.generic) == (self.type == 'Generic')"
+// +kubebuilder:validation:XValidation:message="header must be specified if and only if type is Header",rule="has(self.header) == (self.type == 'Header')"
+// +kubebuilder:validation:XValidation:message="queryParameter must be specified if and only if type is QueryParameter",rule="has(self.queryParameter) == (self.type == 'QueryParameter')"
+// +kubebuilder:validation:XValidation:message="maskedRemoteAddress may only be specified when type is MaskedRemoteAddress",rule="!has(self.maskedRemoteAddress) || self.type == 'MaskedRemoteAddress'"
+// +kubebuilder:validation:XValidation:message="metadata must be specified if and only if type is Metadata",rule="has(self.metadata) == (self.type == 'Metadata')"
+// +kubebuilder:validation:XValidation:message="jwtClaim must be specified if and only if type is JWTClaim",rule="has(self.jwtClaim) == (self.type == 'JWTClaim')"
+// +kubebuilder:validation:XValidation:message="expression must be specified if and only if type is Expression",rule="has(self.expression) == (self.type == 'Expression')"
 type RateLimitDescriptorEntry struct {
 	// Type specifies what kind of rate limit descriptor entry this is.
 	// +required
@@ -507,6 +611,32 @@ type RateLimitDescriptorEntry struct {
 	// +optional
 	// +kubebuilder:validation:MinLength=1
 	Header *string `json:"header,omitempty"`
+
+	// QueryParameter specifies a query parameter to extract the descriptor value from.
+	// This field must be specified when Type is QueryParameter.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	QueryParameter *string `json:"queryParameter,omitempty"`
+
+	// MaskedRemoteAddress configures the CIDR prefix lengths the client's IP address is masked to.
+	// This field may be specified when Type is MaskedRemoteAddress.
+	// +optional
+	MaskedRemoteAddress *RateLimitDescriptorEntryMaskedRemoteAddress `json:"maskedRemoteAddress,omitempty"`
+
+	// Metadata specifies the dynamic metadata to extract the descriptor value from.
+	// This field must be specified when Type is Metadata.
+	// +optional
+	Metadata *RateLimitDescriptorEntryMetadata `json:"metadata,omitempty"`
+
+	// JWTClaim specifies the claim of a JWT, verified by a JWT policy, to extract the descriptor value from.
+	// This field must be specified when Type is JWTClaim.
+	// +optional
+	JWTClaim *RateLimitDescriptorEntryJWTClaim `json:"jwtClaim,omitempty"`
+
+	// Expression specifies a CEL expression that computes the descriptor value.
+	// This field must be specified when Type is Expression.
+	// +optional
+	Expression *RateLimitDescriptorEntryExpression `json:"expression,omitempty"`
 }
 
 // RateLimitDescriptorEntryGeneric defines a generic key-value descriptor entry.
@@ -522,6 +652,90 @@ type RateLimitDescriptorEntryGeneric struct {
 	Value string `json:"value"`
 }
 
+// RateLimitDescriptorEntryMaskedRemoteAddress defines a descriptor entry that uses the client's IP
+// address masked to a CIDR prefix, so that all clients within the same subnet share a limit.
+type RateLimitDescriptorEntryMaskedRemoteAddress struct {
+	// V4PrefixLength is the prefix length IPv4 addresses are masked to.
+	// Defaults to 32, meaning the full address is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=32
+	V4PrefixLength *int32 `json:"v4PrefixLength,omitempty"`
+
+	// V6PrefixLength is the prefix length IPv6 addresses are masked to.
+	// Defaults to 128, meaning the full address is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	V6PrefixLength *int32 `json:"v6PrefixLength,omitempty"`
+}
+
+// RateLimitDescriptorEntryMetadata defines a descriptor entry that extracts its value from the
+// dynamic metadata of the request, such as values written by the ext_authz filter.
+type RateLimitDescriptorEntryMetadata struct {
+	// Key is the name of this descriptor entry.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Namespace is the metadata namespace the value is read from, usually the name of the
+	// filter that wrote it, e.g. "envoy.filters.http.ext_authz".
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Path is the path of keys to the value within the namespace.
+	// +required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	Path []string `json:"path"`
+
+	// DefaultValue is used when the metadata is not present.
+	// If not specified, the descriptor is not sent when the metadata is not present.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+// RateLimitDescriptorEntryJWTClaim defines a descriptor entry that extracts its value from a claim of
+// a JWT verified by a JWT policy on the same route. This allows rate limiting per tenant or user
+// based on a verified identity rather than a client-supplied header.
+type RateLimitDescriptorEntryJWTClaim struct {
+	// Name is the name of the claim. It is also used as the name of this descriptor entry.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Path selects a nested claim within the value of the claim, for claims that are JSON objects.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	Path []string `json:"path,omitempty"`
+
+	// DefaultValue is used when the request has no verified JWT or the claim is not present.
+	// If not specified, the descriptor is not sent in that case.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	DefaultValue *string `json:"defaultValue,omitempty"`
+}
+
+// RateLimitDescriptorEntryExpression defines a descriptor entry whose value is computed by a CEL expression.
+type RateLimitDescriptorEntryExpression struct {
+	// Key is the name of this descriptor entry.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Text is the CEL expression, evaluated against the request attributes.
+	// See [envoy docs](https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/advanced/attributes)
+	// for the available attributes.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Text string `json:"text"`
+}
+
 type CorsPolicy struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	*gwv1.HTTPCORSFilter `json:",inline"`
@@ -660,12 +874,52 @@ type APIKeyAuth struct {
 	// +optional
 	SecretSelector *LabelSelector `json:"secretSelector,omitempty"`
 
+	// secretFormat specifies how the API keys are stored in the selected secrets.
+	// Defaults to Plain.
+	//
+	// With the Hashed format, each entry of the Secret is a JSON object holding the hex encoded
+	// SHA-256 digest of the salt followed by the API key, so that the secret does not contain the key
+	// itself. The entry can also hold an expiry timestamp, after which the key is rejected, and
+	// metadata, which is emitted as dynamic metadata in the "dev.kgateway.http.api_key_auth"
+	// namespace, along with the client identifier under the "client" key, for use by rate limiting
+	// and access logs. Expired keys are excluded from the configuration and counted in the policy status.
+	//
+	// Example:
+	//
+	// apiVersion: v1
+	// kind: Secret
+	// metadata:
+	//   name: api-key
+	// stringData:
+	//   client1: |
+	//     {
+	//       "sha256": "42403fc45854b1038cfd7743f83188ed54b9348686303984268bb68b026f6f15",
+	//       "salt": "s1",
+	//       "expiresAt": "2027-01-01T00:00:00Z",
+	//       "metadata": {"tenant": "acme", "plan": "gold"}
+	//     }
+	//
+	// +optional
+	SecretFormat *APIKeySecretFormat `json:"secretFormat,omitempty"`
+
 	// Disable the API key authentication filter.
 	// Can be used to disable API key authentication policies applied at a higher level in the config hierarchy.
 	// +optional
 	Disable *shared.PolicyDisable `json:"disable,omitempty"`
 }
 
+// APIKeySecretFormat is the format of the API keys stored in a Secret.
+// +kubebuilder:validation:Enum=Plain;Hashed
+type APIKeySecretFormat string
+
+const (
+	// APIKeySecretFormatPlain stores each API key as the value of a Secret entry.
+	APIKeySecretFormatPlain APIKeySecretFormat = "Plain"
+	// APIKeySecretFormatHashed stores a salted SHA-256 digest of each API key, with optional
+	// expiry and metadata, as a JSON object in a Secret entry.
+	APIKeySecretFormatHashed APIKeySecretFormat = "Hashed"
+)
+
 // LabelSelector selects resources using label selectors.
 type LabelSelector struct {
 	// Label selector to select the target resource.
@@ -688,6 +942,45 @@ type Buffer struct {
 	Disable *shared.PolicyDisable `json:"disable,omitempty"`
 }
 
+// LuaPolicy configures the Lua script to run on a route.
+// The script must define an `envoy_on_request` function, an `envoy_on_response` function, or both.
+// See the [Envoy Lua filter docs](https://www.envoyproxy.io/docs/envoy/latest/configuration/http/http_filters/lua_filter)
+// for the API available to the script.
+// +kubebuilder:validation:ExactlyOneOf=inline;configMapRef;disable
+type LuaPolicy struct {
+	// Inline is the source code of the script.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=65536
+	Inline *string `json:"inline,omitempty"`
+
+	// ConfigMapRef references the ConfigMap key that contains the source code of the script.
+	// The ConfigMap must be in the same namespace as the TrafficPolicy, and the script must
+	// not be larger than 64KiB.
+	// +optional
+	ConfigMapRef *LuaConfigMapRef `json:"configMapRef,omitempty"`
+
+	// Disable the Lua filter.
+	// Can be used to disable Lua policies applied at a higher level in the config hierarchy.
+	// +optional
+	Disable *shared.PolicyDisable `json:"disable,omitempty"`
+}
+
+// LuaConfigMapRef references a key of a ConfigMap.
+type LuaConfigMapRef struct {
+	// Name is the name of the ConfigMap.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key of the ConfigMap entry that contains the script.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+}
+
 // Compression configures HTTP response compression and request decompression behavior.
 // +kubebuilder:validation:AtLeastOneOf=responseCompression;requestDecompression
 type Compression struct {
@@ -0,0 +1,117 @@
+package kgateway
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+)
+
+// WasmProvider defines the configuration for a Wasm extension provider.
+// The referenced module is loaded into a V8 VM by Envoy, and runs as an HTTP filter on the routes that
+// enable it with a TrafficPolicy.
+type WasmProvider struct {
+	// Module specifies where Envoy loads the Wasm module from.
+	// +required
+	Module WasmModuleSource `json:"module"`
+
+	// RootID is the root context ID of the plugin within the module. It must be set when the module
+	// contains more than one plugin.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	RootID *string `json:"rootID,omitempty"`
+
+	// FailOpen determines if requests are allowed when the Wasm plugin fails, for example because the
+	// VM crashed or the module could not be loaded.
+	// Defaults to false, meaning requests fail with a 503 when the plugin is unavailable.
+	// +optional
+	// +kubebuilder:default=false
+	FailOpen bool `json:"failOpen,omitempty"`
+}
+
+// WasmModuleSource specifies where a Wasm module is loaded from. Exactly one source must be set.
+// +kubebuilder:validation:ExactlyOneOf=configMap;file;http
+type WasmModuleSource struct {
+	// ConfigMap loads the module from a key of a ConfigMap in the namespace of the GatewayExtension.
+	// The module may be stored in either the binaryData or data field of the ConfigMap.
+	// +optional
+	ConfigMap *WasmConfigMapSource `json:"configMap,omitempty"`
+
+	// File loads the module from a file on the local filesystem of the proxy, such as a module baked
+	// into the proxy image.
+	// +optional
+	File *WasmFileSource `json:"file,omitempty"`
+
+	// HTTP fetches the module from a remote HTTP server when the filter is first configured.
+	// +optional
+	HTTP *WasmHTTPSource `json:"http,omitempty"`
+}
+
+// WasmConfigMapSource references a Wasm module stored in a ConfigMap.
+type WasmConfigMapSource struct {
+	// Name is the name of the ConfigMap.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key of the ConfigMap entry that contains the module.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+}
+
+// WasmFileSource references a Wasm module on the local filesystem of the proxy.
+type WasmFileSource struct {
+	// Path is the absolute path of the module.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+}
+
+// WasmHTTPSource references a Wasm module served over HTTP.
+type WasmHTTPSource struct {
+	// URL is the URL of the module.
+	// +required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// SHA256 is the hex-encoded SHA-256 checksum of the module. Envoy rejects a module that does
+	// not match this checksum.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-fA-F0-9]{64}$`
+	SHA256 string `json:"sha256"`
+
+	// BackendRef references the backend serving the URL. Envoy fetches the module through the
+	// cluster of this backend.
+	// +required
+	BackendRef gwv1.BackendRef `json:"backendRef"`
+
+	// Timeout is the timeout for fetching the module. Defaults to 2s.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="matches(self, '^([0-9]{1,5}(h|m|s|ms)){1,4}$')",message="invalid timeout value"
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1ms')",message="timeout must be at least 1ms."
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// WasmPolicy enables a Wasm extension on the targeted routes.
+type WasmPolicy struct {
+	// ExtensionRef references the Wasm GatewayExtension that provides the module.
+	// +required
+	ExtensionRef shared.NamespacedObjectReference `json:"extensionRef"`
+
+	// Config is the configuration passed to the plugin. It is serialized to JSON and delivered to
+	// the plugin when it is configured.
+	// +optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// FilterStage specifies where in the HTTP filter chain the Wasm filter should be placed.
+	// If not specified, the Wasm filter defaults to running after the AuthZ stage.
+	// +optional
+	FilterStage *FilterStageSpec `json:"filterStage,omitempty"`
+}
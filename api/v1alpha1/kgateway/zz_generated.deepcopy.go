@@ -8,6 +8,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -43,6 +44,11 @@ func (in *APIKeyAuth) DeepCopyInto(out *APIKeyAuth) {
 		*out = new(LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecretFormat != nil {
+		in, out := &in.SecretFormat, &out.SecretFormat
+		*out = new(APIKeySecretFormat)
+		**out = **in
+	}
 	if in.Disable != nil {
 		in, out := &in.Disable, &out.Disable
 		*out = new(shared.PolicyDisable)
@@ -190,6 +196,161 @@ func (in *AccessLogGrpcService) DeepCopy() *AccessLogGrpcService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveConcurrencyMinRTT) DeepCopyInto(out *AdaptiveConcurrencyMinRTT) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FixedValue != nil {
+		in, out := &in.FixedValue, &out.FixedValue
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RequestCount != nil {
+		in, out := &in.RequestCount, &out.RequestCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JitterPercent != nil {
+		in, out := &in.JitterPercent, &out.JitterPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinConcurrency != nil {
+		in, out := &in.MinConcurrency, &out.MinConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BufferPercent != nil {
+		in, out := &in.BufferPercent, &out.BufferPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveConcurrencyMinRTT.
+func (in *AdaptiveConcurrencyMinRTT) DeepCopy() *AdaptiveConcurrencyMinRTT {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveConcurrencyMinRTT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdaptiveConcurrencyPolicy) DeepCopyInto(out *AdaptiveConcurrencyPolicy) {
+	*out = *in
+	if in.SampleAggregatePercentile != nil {
+		in, out := &in.SampleAggregatePercentile, &out.SampleAggregatePercentile
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConcurrencyUpdateInterval != nil {
+		in, out := &in.ConcurrencyUpdateInterval, &out.ConcurrencyUpdateInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrencyLimit != nil {
+		in, out := &in.MaxConcurrencyLimit, &out.MaxConcurrencyLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinRTT != nil {
+		in, out := &in.MinRTT, &out.MinRTT
+		*out = new(AdaptiveConcurrencyMinRTT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConcurrencyLimitExceededStatus != nil {
+		in, out := &in.ConcurrencyLimitExceededStatus, &out.ConcurrencyLimitExceededStatus
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdaptiveConcurrencyPolicy.
+func (in *AdaptiveConcurrencyPolicy) DeepCopy() *AdaptiveConcurrencyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AdaptiveConcurrencyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionControlPolicy) DeepCopyInto(out *AdmissionControlPolicy) {
+	*out = *in
+	if in.SamplingWindow != nil {
+		in, out := &in.SamplingWindow, &out.SamplingWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Aggression != nil {
+		in, out := &in.Aggression, &out.Aggression
+		*out = new(string)
+		**out = **in
+	}
+	if in.SuccessRateThreshold != nil {
+		in, out := &in.SuccessRateThreshold, &out.SuccessRateThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RPSThreshold != nil {
+		in, out := &in.RPSThreshold, &out.RPSThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRejectionPercent != nil {
+		in, out := &in.MaxRejectionPercent, &out.MaxRejectionPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SuccessCriteria != nil {
+		in, out := &in.SuccessCriteria, &out.SuccessCriteria
+		*out = new(AdmissionControlSuccessCriteria)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControlPolicy.
+func (in *AdmissionControlPolicy) DeepCopy() *AdmissionControlPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionControlPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionControlSuccessCriteria) DeepCopyInto(out *AdmissionControlSuccessCriteria) {
+	*out = *in
+	if in.HTTPStatuses != nil {
+		in, out := &in.HTTPStatuses, &out.HTTPStatuses
+		*out = make([]StatusCodeRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.GRPCStatuses != nil {
+		in, out := &in.GRPCStatuses, &out.GRPCStatuses
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControlSuccessCriteria.
+func (in *AdmissionControlSuccessCriteria) DeepCopy() *AdmissionControlSuccessCriteria {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionControlSuccessCriteria)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlwaysOnConfig) DeepCopyInto(out *AlwaysOnConfig) {
 	*out = *in
@@ -389,6 +550,16 @@ func (in *AwsLambda) DeepCopyInto(out *AwsLambda) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.QualifierHeader != nil {
+		in, out := &in.QualifierHeader, &out.QualifierHeader
+		*out = new(AwsLambdaQualifierHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AsyncResponse != nil {
+		in, out := &in.AsyncResponse, &out.AsyncResponse
+		*out = new(AwsLambdaAsyncResponse)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsLambda.
@@ -401,6 +572,51 @@ func (in *AwsLambda) DeepCopy() *AwsLambda {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsLambdaAsyncResponse) DeepCopyInto(out *AwsLambdaAsyncResponse) {
+	*out = *in
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]apisv1.HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsLambdaAsyncResponse.
+func (in *AwsLambdaAsyncResponse) DeepCopy() *AwsLambdaAsyncResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsLambdaAsyncResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AwsLambdaQualifierHeader) DeepCopyInto(out *AwsLambdaQualifierHeader) {
+	*out = *in
+	if in.Qualifiers != nil {
+		in, out := &in.Qualifiers, &out.Qualifiers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AwsLambdaQualifierHeader.
+func (in *AwsLambdaQualifierHeader) DeepCopy() *AwsLambdaQualifierHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(AwsLambdaQualifierHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AwsTagFilter) DeepCopyInto(out *AwsTagFilter) {
 	*out = *in
@@ -607,6 +823,11 @@ func (in *BackendConfigPolicySpec) DeepCopyInto(out *BackendConfigPolicySpec) {
 		*out = new(UpstreamProxyProtocol)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CredentialInjection != nil {
+		in, out := &in.CredentialInjection, &out.CredentialInjection
+		*out = new(CredentialInjection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConfigPolicySpec.
@@ -686,6 +907,21 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Consul != nil {
+		in, out := &in.Consul, &out.Consul
+		*out = new(ConsulBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DnsSrv != nil {
+		in, out := &in.DnsSrv, &out.DnsSrv
+		*out = new(DnsSrvBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileBackend)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
@@ -741,6 +977,41 @@ func (in *BackoffStrategy) DeepCopy() *BackoffStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthLimitPolicy) DeepCopyInto(out *BandwidthLimitPolicy) {
+	*out = *in
+	if in.Direction != nil {
+		in, out := &in.Direction, &out.Direction
+		*out = new(BandwidthLimitDirection)
+		**out = **in
+	}
+	if in.KiBPerSecond != nil {
+		in, out := &in.KiBPerSecond, &out.KiBPerSecond
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.FillInterval != nil {
+		in, out := &in.FillInterval, &out.FillInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(shared.PolicyDisable)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimitPolicy.
+func (in *BandwidthLimitPolicy) DeepCopy() *BandwidthLimitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthLimitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicAuthPolicy) DeepCopyInto(out *BasicAuthPolicy) {
 	*out = *in
@@ -759,6 +1030,23 @@ func (in *BasicAuthPolicy) DeepCopyInto(out *BasicAuthPolicy) {
 		*out = new(shared.PolicyDisable)
 		**out = **in
 	}
+	if in.Realms != nil {
+		in, out := &in.Realms, &out.Realms
+		*out = make([]BasicAuthRealm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ForwardUsernameHeader != nil {
+		in, out := &in.ForwardUsernameHeader, &out.ForwardUsernameHeader
+		*out = new(string)
+		**out = **in
+	}
+	if in.ForwardRealmHeader != nil {
+		in, out := &in.ForwardRealmHeader, &out.ForwardRealmHeader
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuthPolicy.
@@ -771,6 +1059,26 @@ func (in *BasicAuthPolicy) DeepCopy() *BasicAuthPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuthRealm) DeepCopyInto(out *BasicAuthRealm) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuthRealm.
+func (in *BasicAuthRealm) DeepCopy() *BasicAuthRealm {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuthRealm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BodyTransformation) DeepCopyInto(out *BodyTransformation) {
 	*out = *in
@@ -863,6 +1171,81 @@ func (in *CSRFPolicy) DeepCopy() *CSRFPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheKey) DeepCopyInto(out *CacheKey) {
+	*out = *in
+	if in.ExcludeScheme != nil {
+		in, out := &in.ExcludeScheme, &out.ExcludeScheme
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExcludeHost != nil {
+		in, out := &in.ExcludeHost, &out.ExcludeHost
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludeQueryParameters != nil {
+		in, out := &in.IncludeQueryParameters, &out.IncludeQueryParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeQueryParameters != nil {
+		in, out := &in.ExcludeQueryParameters, &out.ExcludeQueryParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheKey.
+func (in *CacheKey) DeepCopy() *CacheKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CachePolicy) DeepCopyInto(out *CachePolicy) {
+	*out = *in
+	if in.MaxBodyBytes != nil {
+		in, out := &in.MaxBodyBytes, &out.MaxBodyBytes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedVaryHeaders != nil {
+		in, out := &in.AllowedVaryHeaders, &out.AllowedVaryHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(CacheKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IgnoreRequestCacheControl != nil {
+		in, out := &in.IgnoreRequestCacheControl, &out.IgnoreRequestCacheControl
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(shared.PolicyDisable)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CachePolicy.
+func (in *CachePolicy) DeepCopy() *CachePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CachePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CircuitBreakers) DeepCopyInto(out *CircuitBreakers) {
 	*out = *in
@@ -886,6 +1269,11 @@ func (in *CircuitBreakers) DeepCopyInto(out *CircuitBreakers) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RetryBudget != nil {
+		in, out := &in.RetryBudget, &out.RetryBudget
+		*out = new(RetryBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TrackRemaining != nil {
 		in, out := &in.TrackRemaining, &out.TrackRemaining
 		*out = new(bool)
@@ -1090,6 +1478,46 @@ func (in *ConnectionKeepalive) DeepCopy() *ConnectionKeepalive {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulBackend) DeepCopyInto(out *ConsulBackend) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datacenter != nil {
+		in, out := &in.Datacenter, &out.Datacenter
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.WaitTime != nil {
+		in, out := &in.WaitTime, &out.WaitTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulBackend.
+func (in *ConsulBackend) DeepCopy() *ConsulBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cookie) DeepCopyInto(out *Cookie) {
 	*out = *in
@@ -1155,6 +1583,66 @@ func (in *CorsPolicy) DeepCopy() *CorsPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialInjection) DeepCopyInto(out *CredentialInjection) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(CredentialSecretRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowRequestWithoutCredential != nil {
+		in, out := &in.AllowRequestWithoutCredential, &out.AllowRequestWithoutCredential
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialInjection.
+func (in *CredentialInjection) DeepCopy() *CredentialInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialSecretRef) DeepCopyInto(out *CredentialSecretRef) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialSecretRef.
+func (in *CredentialSecretRef) DeepCopy() *CredentialSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomAttribute) DeepCopyInto(out *CustomAttribute) {
 	*out = *in
@@ -1185,14 +1673,70 @@ func (in *CustomAttribute) DeepCopy() *CustomAttribute {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomAttribute)
+	out := new(CustomAttribute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomAttributeEnvironment) DeepCopyInto(out *CustomAttributeEnvironment) {
+	*out = *in
+	if in.DefaultValue != nil {
+		in, out := &in.DefaultValue, &out.DefaultValue
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeEnvironment.
+func (in *CustomAttributeEnvironment) DeepCopy() *CustomAttributeEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomAttributeEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomAttributeHeader) DeepCopyInto(out *CustomAttributeHeader) {
+	*out = *in
+	if in.DefaultValue != nil {
+		in, out := &in.DefaultValue, &out.DefaultValue
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeHeader.
+func (in *CustomAttributeHeader) DeepCopy() *CustomAttributeHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomAttributeHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomAttributeLiteral) DeepCopyInto(out *CustomAttributeLiteral) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeLiteral.
+func (in *CustomAttributeLiteral) DeepCopy() *CustomAttributeLiteral {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomAttributeLiteral)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomAttributeEnvironment) DeepCopyInto(out *CustomAttributeEnvironment) {
+func (in *CustomAttributeMetadata) DeepCopyInto(out *CustomAttributeMetadata) {
 	*out = *in
+	in.MetadataKey.DeepCopyInto(&out.MetadataKey)
 	if in.DefaultValue != nil {
 		in, out := &in.DefaultValue, &out.DefaultValue
 		*out = new(string)
@@ -1200,68 +1744,119 @@ func (in *CustomAttributeEnvironment) DeepCopyInto(out *CustomAttributeEnvironme
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeEnvironment.
-func (in *CustomAttributeEnvironment) DeepCopy() *CustomAttributeEnvironment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeMetadata.
+func (in *CustomAttributeMetadata) DeepCopy() *CustomAttributeMetadata {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomAttributeEnvironment)
+	out := new(CustomAttributeMetadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomAttributeHeader) DeepCopyInto(out *CustomAttributeHeader) {
+func (in *CustomResponseConfigMapRef) DeepCopyInto(out *CustomResponseConfigMapRef) {
 	*out = *in
-	if in.DefaultValue != nil {
-		in, out := &in.DefaultValue, &out.DefaultValue
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeHeader.
-func (in *CustomAttributeHeader) DeepCopy() *CustomAttributeHeader {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponseConfigMapRef.
+func (in *CustomResponseConfigMapRef) DeepCopy() *CustomResponseConfigMapRef {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomAttributeHeader)
+	out := new(CustomResponseConfigMapRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomAttributeLiteral) DeepCopyInto(out *CustomAttributeLiteral) {
+func (in *CustomResponsePolicy) DeepCopyInto(out *CustomResponsePolicy) {
 	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]CustomResponseRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(shared.PolicyDisable)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeLiteral.
-func (in *CustomAttributeLiteral) DeepCopy() *CustomAttributeLiteral {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponsePolicy.
+func (in *CustomResponsePolicy) DeepCopy() *CustomResponsePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomAttributeLiteral)
+	out := new(CustomResponsePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomAttributeMetadata) DeepCopyInto(out *CustomAttributeMetadata) {
+func (in *CustomResponseRedirect) DeepCopyInto(out *CustomResponseRedirect) {
 	*out = *in
-	in.MetadataKey.DeepCopyInto(&out.MetadataKey)
-	if in.DefaultValue != nil {
-		in, out := &in.DefaultValue, &out.DefaultValue
-		*out = new(string)
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomAttributeMetadata.
-func (in *CustomAttributeMetadata) DeepCopy() *CustomAttributeMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponseRedirect.
+func (in *CustomResponseRedirect) DeepCopy() *CustomResponseRedirect {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomAttributeMetadata)
+	out := new(CustomResponseRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResponseRule) DeepCopyInto(out *CustomResponseRule) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]StatusCodeRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.DirectResponseRef != nil {
+		in, out := &in.DirectResponseRef, &out.DirectResponseRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(CustomResponseConfigMapRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(CustomResponseRedirect)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponseRule.
+func (in *CustomResponseRule) DeepCopy() *CustomResponseRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomResponseRule)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1400,6 +1995,26 @@ func (in *DnsResolver) DeepCopy() *DnsResolver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DnsSrvBackend) DeepCopyInto(out *DnsSrvBackend) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DnsSrvBackend.
+func (in *DnsSrvBackend) DeepCopy() *DnsSrvBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(DnsSrvBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DurationFilter) DeepCopyInto(out *DurationFilter) {
 	*out = *in
@@ -1984,6 +2599,51 @@ func (in *FaultResponseRateLimit) DeepCopy() *FaultResponseRateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileBackend) DeepCopyInto(out *FileBackend) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(FileBackendConfigMapRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileBackend.
+func (in *FileBackend) DeepCopy() *FileBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(FileBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileBackendConfigMapRef) DeepCopyInto(out *FileBackendConfigMapRef) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileBackendConfigMapRef.
+func (in *FileBackendConfigMapRef) DeepCopy() *FileBackendConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(FileBackendConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileSink) DeepCopyInto(out *FileSink) {
 	*out = *in
@@ -2129,6 +2789,127 @@ func (in *FractionalPercent) DeepCopy() *FractionalPercent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCDescriptorSetRef) DeepCopyInto(out *GRPCDescriptorSetRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCDescriptorSetRef.
+func (in *GRPCDescriptorSetRef) DeepCopy() *GRPCDescriptorSetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCDescriptorSetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCDescriptorSetSource) DeepCopyInto(out *GRPCDescriptorSetSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(GRPCDescriptorSetRef)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(GRPCDescriptorSetRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCDescriptorSetSource.
+func (in *GRPCDescriptorSetSource) DeepCopy() *GRPCDescriptorSetSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCDescriptorSetSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCJSONPrintOptions) DeepCopyInto(out *GRPCJSONPrintOptions) {
+	*out = *in
+	if in.AddWhitespace != nil {
+		in, out := &in.AddWhitespace, &out.AddWhitespace
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlwaysPrintPrimitiveFields != nil {
+		in, out := &in.AlwaysPrintPrimitiveFields, &out.AlwaysPrintPrimitiveFields
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlwaysPrintEnumsAsInts != nil {
+		in, out := &in.AlwaysPrintEnumsAsInts, &out.AlwaysPrintEnumsAsInts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PreserveProtoFieldNames != nil {
+		in, out := &in.PreserveProtoFieldNames, &out.PreserveProtoFieldNames
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCJSONPrintOptions.
+func (in *GRPCJSONPrintOptions) DeepCopy() *GRPCJSONPrintOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCJSONPrintOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCJSONTranscoderPolicy) DeepCopyInto(out *GRPCJSONTranscoderPolicy) {
+	*out = *in
+	in.DescriptorSet.DeepCopyInto(&out.DescriptorSet)
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrintOptions != nil {
+		in, out := &in.PrintOptions, &out.PrintOptions
+		*out = new(GRPCJSONPrintOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnknownQueryParameters != nil {
+		in, out := &in.UnknownQueryParameters, &out.UnknownQueryParameters
+		*out = new(GRPCJSONUnknownQueryParameters)
+		**out = **in
+	}
+	if in.IgnoredQueryParameters != nil {
+		in, out := &in.IgnoredQueryParameters, &out.IgnoredQueryParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoMapping != nil {
+		in, out := &in.AutoMapping, &out.AutoMapping
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConvertGRPCStatus != nil {
+		in, out := &in.ConvertGRPCStatus, &out.ConvertGRPCStatus
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCJSONTranscoderPolicy.
+func (in *GRPCJSONTranscoderPolicy) DeepCopy() *GRPCJSONTranscoderPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCJSONTranscoderPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayExtension) DeepCopyInto(out *GatewayExtension) {
 	*out = *in
@@ -2221,6 +3002,16 @@ func (in *GatewayExtensionSpec) DeepCopyInto(out *GatewayExtensionSpec) {
 		*out = new(OAuth2Provider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Wasm != nil {
+		in, out := &in.Wasm, &out.Wasm
+		*out = new(WasmProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GeoIP != nil {
+		in, out := &in.GeoIP, &out.GeoIP
+		*out = new(GeoIPProvider)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayExtensionSpec.
@@ -2349,72 +3140,140 @@ func (in *GatewayParametersOverlays) DeepCopyInto(out *GatewayParametersOverlays
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersOverlays.
-func (in *GatewayParametersOverlays) DeepCopy() *GatewayParametersOverlays {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersOverlays.
+func (in *GatewayParametersOverlays) DeepCopy() *GatewayParametersOverlays {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParametersOverlays)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParametersSpec) DeepCopyInto(out *GatewayParametersSpec) {
+	*out = *in
+	if in.Kube != nil {
+		in, out := &in.Kube, &out.Kube
+		*out = new(KubernetesProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelfManaged != nil {
+		in, out := &in.SelfManaged, &out.SelfManaged
+		*out = new(SelfManagedGateway)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersSpec.
+func (in *GatewayParametersSpec) DeepCopy() *GatewayParametersSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParametersSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParametersStatus) DeepCopyInto(out *GatewayParametersStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersStatus.
+func (in *GatewayParametersStatus) DeepCopy() *GatewayParametersStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParametersStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GcpBackend) DeepCopyInto(out *GcpBackend) {
+	*out = *in
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpBackend.
+func (in *GcpBackend) DeepCopy() *GcpBackend {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayParametersOverlays)
+	out := new(GcpBackend)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayParametersSpec) DeepCopyInto(out *GatewayParametersSpec) {
+func (in *GeoIPHeaders) DeepCopyInto(out *GeoIPHeaders) {
 	*out = *in
-	if in.Kube != nil {
-		in, out := &in.Kube, &out.Kube
-		*out = new(KubernetesProxyConfig)
-		(*in).DeepCopyInto(*out)
+	if in.Country != nil {
+		in, out := &in.Country, &out.Country
+		*out = new(apisv1.HTTPHeaderName)
+		**out = **in
 	}
-	if in.SelfManaged != nil {
-		in, out := &in.SelfManaged, &out.SelfManaged
-		*out = new(SelfManagedGateway)
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(apisv1.HTTPHeaderName)
+		**out = **in
+	}
+	if in.City != nil {
+		in, out := &in.City, &out.City
+		*out = new(apisv1.HTTPHeaderName)
+		**out = **in
+	}
+	if in.ASN != nil {
+		in, out := &in.ASN, &out.ASN
+		*out = new(apisv1.HTTPHeaderName)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersSpec.
-func (in *GatewayParametersSpec) DeepCopy() *GatewayParametersSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoIPHeaders.
+func (in *GeoIPHeaders) DeepCopy() *GeoIPHeaders {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayParametersSpec)
+	out := new(GeoIPHeaders)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayParametersStatus) DeepCopyInto(out *GatewayParametersStatus) {
+func (in *GeoIPPolicy) DeepCopyInto(out *GeoIPPolicy) {
 	*out = *in
+	in.ExtensionRef.DeepCopyInto(&out.ExtensionRef)
+	in.Headers.DeepCopyInto(&out.Headers)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParametersStatus.
-func (in *GatewayParametersStatus) DeepCopy() *GatewayParametersStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoIPPolicy.
+func (in *GeoIPPolicy) DeepCopy() *GeoIPPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayParametersStatus)
+	out := new(GeoIPPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GcpBackend) DeepCopyInto(out *GcpBackend) {
+func (in *GeoIPProvider) DeepCopyInto(out *GeoIPProvider) {
 	*out = *in
-	if in.Audience != nil {
-		in, out := &in.Audience, &out.Audience
-		*out = new(string)
-		**out = **in
-	}
+	in.MaxMind.DeepCopyInto(&out.MaxMind)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcpBackend.
-func (in *GcpBackend) DeepCopy() *GcpBackend {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoIPProvider.
+func (in *GeoIPProvider) DeepCopy() *GeoIPProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(GcpBackend)
+	out := new(GeoIPProvider)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2469,6 +3328,46 @@ func (in *GrpcStatusFilter) DeepCopy() *GrpcStatusFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP3Settings) DeepCopyInto(out *HTTP3Settings) {
+	*out = *in
+	if in.AltSvcMaxAge != nil {
+		in, out := &in.AltSvcMaxAge, &out.AltSvcMaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrentStreams != nil {
+		in, out := &in.MaxConcurrentStreams, &out.MaxConcurrentStreams
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialStreamWindowSize != nil {
+		in, out := &in.InitialStreamWindowSize, &out.InitialStreamWindowSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.InitialConnectionWindowSize != nil {
+		in, out := &in.InitialConnectionWindowSize, &out.InitialConnectionWindowSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP3Settings.
+func (in *HTTP3Settings) DeepCopy() *HTTP3Settings {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP3Settings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPListenerPolicy) DeepCopyInto(out *HTTPListenerPolicy) {
 	*out = *in
@@ -3511,6 +4410,16 @@ func (in *ListenerConfig) DeepCopyInto(out *ListenerConfig) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.UDPSettings != nil {
+		in, out := &in.UDPSettings, &out.UDPSettings
+		*out = new(UDPSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP3 != nil {
+		in, out := &in.HTTP3, &out.HTTP3
+		*out = new(HTTP3Settings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerConfig.
@@ -4022,6 +4931,86 @@ func (in *LogFormat) DeepCopy() *LogFormat {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LuaConfigMapRef) DeepCopyInto(out *LuaConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaConfigMapRef.
+func (in *LuaConfigMapRef) DeepCopy() *LuaConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LuaConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LuaPolicy) DeepCopyInto(out *LuaPolicy) {
+	*out = *in
+	if in.Inline != nil {
+		in, out := &in.Inline, &out.Inline
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(LuaConfigMapRef)
+		**out = **in
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(shared.PolicyDisable)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LuaPolicy.
+func (in *LuaPolicy) DeepCopy() *LuaPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LuaPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaxMindDatabases) DeepCopyInto(out *MaxMindDatabases) {
+	*out = *in
+	if in.CityDBPath != nil {
+		in, out := &in.CityDBPath, &out.CityDBPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CountryDBPath != nil {
+		in, out := &in.CountryDBPath, &out.CountryDBPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.ASNDBPath != nil {
+		in, out := &in.ASNDBPath, &out.ASNDBPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.ISPDBPath != nil {
+		in, out := &in.ISPDBPath, &out.ISPDBPath
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaxMindDatabases.
+func (in *MaxMindDatabases) DeepCopy() *MaxMindDatabases {
+	if in == nil {
+		return nil
+	}
+	out := new(MaxMindDatabases)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetadataKey) DeepCopyInto(out *MetadataKey) {
 	*out = *in
@@ -4118,6 +5107,32 @@ func (in *NamedJWTProvider) DeepCopy() *NamedJWTProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	out.Credentials = in.Credentials
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenFetchRetryInterval != nil {
+		in, out := &in.TokenFetchRetryInterval, &out.TokenFetchRetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OAuth2CookieConfig) DeepCopyInto(out *OAuth2CookieConfig) {
 	*out = *in
@@ -4725,6 +5740,11 @@ func (in *RateLimitDescriptor) DeepCopyInto(out *RateLimitDescriptor) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HitsAddend != nil {
+		in, out := &in.HitsAddend, &out.HitsAddend
+		*out = new(RateLimitHitsAddend)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptor.
@@ -4745,34 +5765,174 @@ func (in *RateLimitDescriptorEntry) DeepCopyInto(out *RateLimitDescriptorEntry)
 		*out = new(RateLimitDescriptorEntryGeneric)
 		**out = **in
 	}
-	if in.Header != nil {
-		in, out := &in.Header, &out.Header
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+	if in.QueryParameter != nil {
+		in, out := &in.QueryParameter, &out.QueryParameter
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaskedRemoteAddress != nil {
+		in, out := &in.MaskedRemoteAddress, &out.MaskedRemoteAddress
+		*out = new(RateLimitDescriptorEntryMaskedRemoteAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(RateLimitDescriptorEntryMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWTClaim != nil {
+		in, out := &in.JWTClaim, &out.JWTClaim
+		*out = new(RateLimitDescriptorEntryJWTClaim)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(RateLimitDescriptorEntryExpression)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntry.
+func (in *RateLimitDescriptorEntry) DeepCopy() *RateLimitDescriptorEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitDescriptorEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitDescriptorEntryExpression) DeepCopyInto(out *RateLimitDescriptorEntryExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntryExpression.
+func (in *RateLimitDescriptorEntryExpression) DeepCopy() *RateLimitDescriptorEntryExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitDescriptorEntryExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitDescriptorEntryGeneric) DeepCopyInto(out *RateLimitDescriptorEntryGeneric) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntryGeneric.
+func (in *RateLimitDescriptorEntryGeneric) DeepCopy() *RateLimitDescriptorEntryGeneric {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitDescriptorEntryGeneric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitDescriptorEntryJWTClaim) DeepCopyInto(out *RateLimitDescriptorEntryJWTClaim) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultValue != nil {
+		in, out := &in.DefaultValue, &out.DefaultValue
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntryJWTClaim.
+func (in *RateLimitDescriptorEntryJWTClaim) DeepCopy() *RateLimitDescriptorEntryJWTClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitDescriptorEntryJWTClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitDescriptorEntryMaskedRemoteAddress) DeepCopyInto(out *RateLimitDescriptorEntryMaskedRemoteAddress) {
+	*out = *in
+	if in.V4PrefixLength != nil {
+		in, out := &in.V4PrefixLength, &out.V4PrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.V6PrefixLength != nil {
+		in, out := &in.V6PrefixLength, &out.V6PrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntryMaskedRemoteAddress.
+func (in *RateLimitDescriptorEntryMaskedRemoteAddress) DeepCopy() *RateLimitDescriptorEntryMaskedRemoteAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitDescriptorEntryMaskedRemoteAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitDescriptorEntryMetadata) DeepCopyInto(out *RateLimitDescriptorEntryMetadata) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultValue != nil {
+		in, out := &in.DefaultValue, &out.DefaultValue
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntry.
-func (in *RateLimitDescriptorEntry) DeepCopy() *RateLimitDescriptorEntry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntryMetadata.
+func (in *RateLimitDescriptorEntryMetadata) DeepCopy() *RateLimitDescriptorEntryMetadata {
 	if in == nil {
 		return nil
 	}
-	out := new(RateLimitDescriptorEntry)
+	out := new(RateLimitDescriptorEntryMetadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RateLimitDescriptorEntryGeneric) DeepCopyInto(out *RateLimitDescriptorEntryGeneric) {
+func (in *RateLimitHitsAddend) DeepCopyInto(out *RateLimitHitsAddend) {
 	*out = *in
+	if in.Number != nil {
+		in, out := &in.Number, &out.Number
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitDescriptorEntryGeneric.
-func (in *RateLimitDescriptorEntryGeneric) DeepCopy() *RateLimitDescriptorEntryGeneric {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitHitsAddend.
+func (in *RateLimitHitsAddend) DeepCopy() *RateLimitHitsAddend {
 	if in == nil {
 		return nil
 	}
-	out := new(RateLimitDescriptorEntryGeneric)
+	out := new(RateLimitHitsAddend)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4805,6 +5965,11 @@ func (in *RateLimitProvider) DeepCopyInto(out *RateLimitProvider) {
 	*out = *in
 	in.GrpcService.DeepCopyInto(&out.GrpcService)
 	out.Timeout = in.Timeout
+	if in.ShadowMode != nil {
+		in, out := &in.ShadowMode, &out.ShadowMode
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitProvider.
@@ -4873,6 +6038,71 @@ func (in *RequestDecompression) DeepCopy() *RequestDecompression {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestLimitsPolicy) DeepCopyInto(out *RequestLimitsPolicy) {
+	*out = *in
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxURLLength != nil {
+		in, out := &in.MaxURLLength, &out.MaxURLLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHeaderValueSize != nil {
+		in, out := &in.MaxHeaderValueSize, &out.MaxHeaderValueSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RejectionResponse != nil {
+		in, out := &in.RejectionResponse, &out.RejectionResponse
+		*out = new(RequestLimitsRejection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Disable != nil {
+		in, out := &in.Disable, &out.Disable
+		*out = new(shared.PolicyDisable)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestLimitsPolicy.
+func (in *RequestLimitsPolicy) DeepCopy() *RequestLimitsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestLimitsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestLimitsRejection) DeepCopyInto(out *RequestLimitsRejection) {
+	*out = *in
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BodyFormat != nil {
+		in, out := &in.BodyFormat, &out.BodyFormat
+		*out = new(shared.BodyFormat)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestLimitsRejection.
+func (in *RequestLimitsRejection) DeepCopy() *RequestLimitsRejection {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestLimitsRejection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceDetector) DeepCopyInto(out *ResourceDetector) {
 	*out = *in
@@ -4961,6 +6191,23 @@ func (in *Retry) DeepCopyInto(out *Retry) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.RetriableHeaders != nil {
+		in, out := &in.RetriableHeaders, &out.RetriableHeaders
+		*out = make([]apisv1.HTTPHeaderMatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostSelection != nil {
+		in, out := &in.HostSelection, &out.HostSelection
+		*out = new(RetryHostSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HedgeOnPerTryTimeout != nil {
+		in, out := &in.HedgeOnPerTryTimeout, &out.HedgeOnPerTryTimeout
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Retry.
@@ -4994,6 +6241,61 @@ func (in *RetryBackoff) DeepCopy() *RetryBackoff {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudget) DeepCopyInto(out *RetryBudget) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinRetryConcurrency != nil {
+		in, out := &in.MinRetryConcurrency, &out.MinRetryConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudget.
+func (in *RetryBudget) DeepCopy() *RetryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryHostSelection) DeepCopyInto(out *RetryHostSelection) {
+	*out = *in
+	if in.AvoidPreviousHosts != nil {
+		in, out := &in.AvoidPreviousHosts, &out.AvoidPreviousHosts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AvoidPreviousPriorities != nil {
+		in, out := &in.AvoidPreviousPriorities, &out.AvoidPreviousPriorities
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryHostSelection.
+func (in *RetryHostSelection) DeepCopy() *RetryHostSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryHostSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
 	*out = *in
@@ -5487,6 +6789,21 @@ func (in *StatusCodeFilter) DeepCopy() *StatusCodeFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCodeRange) DeepCopyInto(out *StatusCodeRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCodeRange.
+func (in *StatusCodeRange) DeepCopy() *StatusCodeRange {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCodeRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPKeepalive) DeepCopyInto(out *TCPKeepalive) {
 	*out = *in
@@ -5832,6 +7149,11 @@ func (in *TrafficPolicySpec) DeepCopyInto(out *TrafficPolicySpec) {
 		*out = new(ExtProcPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Wasm != nil {
+		in, out := &in.Wasm, &out.Wasm
+		*out = new(WasmPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtAuth != nil {
 		in, out := &in.ExtAuth, &out.ExtAuth
 		*out = new(ExtAuthPolicy)
@@ -5862,6 +7184,11 @@ func (in *TrafficPolicySpec) DeepCopyInto(out *TrafficPolicySpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Lua != nil {
+		in, out := &in.Lua, &out.Lua
+		*out = new(LuaPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Buffer != nil {
 		in, out := &in.Buffer, &out.Buffer
 		*out = new(Buffer)
@@ -5927,11 +7254,51 @@ func (in *TrafficPolicySpec) DeepCopyInto(out *TrafficPolicySpec) {
 		*out = new(FaultInjectionPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdaptiveConcurrency != nil {
+		in, out := &in.AdaptiveConcurrency, &out.AdaptiveConcurrency
+		*out = new(AdaptiveConcurrencyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionControl != nil {
+		in, out := &in.AdmissionControl, &out.AdmissionControl
+		*out = new(AdmissionControlPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(BandwidthLimitPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestLimits != nil {
+		in, out := &in.RequestLimits, &out.RequestLimits
+		*out = new(RequestLimitsPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(CachePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPCJSONTranscoder != nil {
+		in, out := &in.GRPCJSONTranscoder, &out.GRPCJSONTranscoder
+		*out = new(GRPCJSONTranscoderPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomResponse != nil {
+		in, out := &in.CustomResponse, &out.CustomResponse
+		*out = new(CustomResponsePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ACL != nil {
 		in, out := &in.ACL, &out.ACL
 		*out = new(shared.ACLPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GeoIP != nil {
+		in, out := &in.GeoIP, &out.GeoIP
+		*out = new(GeoIPPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StatPrefix != nil {
 		in, out := &in.StatPrefix, &out.StatPrefix
 		*out = new(string)
@@ -6016,6 +7383,26 @@ func (in *TransformationPolicy) DeepCopy() *TransformationPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UDPSettings) DeepCopyInto(out *UDPSettings) {
+	*out = *in
+	if in.SessionIdleTimeout != nil {
+		in, out := &in.SessionIdleTimeout, &out.SessionIdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPSettings.
+func (in *UDPSettings) DeepCopy() *UDPSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(UDPSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *URLRewrite) DeepCopyInto(out *URLRewrite) {
 	*out = *in
@@ -6101,6 +7488,134 @@ func (in *UuidRequestIdConfig) DeepCopy() *UuidRequestIdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmConfigMapSource) DeepCopyInto(out *WasmConfigMapSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmConfigMapSource.
+func (in *WasmConfigMapSource) DeepCopy() *WasmConfigMapSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmConfigMapSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmFileSource) DeepCopyInto(out *WasmFileSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmFileSource.
+func (in *WasmFileSource) DeepCopy() *WasmFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmHTTPSource) DeepCopyInto(out *WasmHTTPSource) {
+	*out = *in
+	in.BackendRef.DeepCopyInto(&out.BackendRef)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmHTTPSource.
+func (in *WasmHTTPSource) DeepCopy() *WasmHTTPSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmHTTPSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmModuleSource) DeepCopyInto(out *WasmModuleSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(WasmConfigMapSource)
+		**out = **in
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(WasmFileSource)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(WasmHTTPSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmModuleSource.
+func (in *WasmModuleSource) DeepCopy() *WasmModuleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmModuleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmPolicy) DeepCopyInto(out *WasmPolicy) {
+	*out = *in
+	in.ExtensionRef.DeepCopyInto(&out.ExtensionRef)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FilterStage != nil {
+		in, out := &in.FilterStage, &out.FilterStage
+		*out = new(FilterStageSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmPolicy.
+func (in *WasmPolicy) DeepCopy() *WasmPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmProvider) DeepCopyInto(out *WasmProvider) {
+	*out = *in
+	in.Module.DeepCopyInto(&out.Module)
+	if in.RootID != nil {
+		in, out := &in.RootID, &out.RootID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmProvider.
+func (in *WasmProvider) DeepCopy() *WasmProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneAwareForce) DeepCopyInto(out *ZoneAwareForce) {
 	*out = *in
@@ -9,7 +9,10 @@ const (
 	ACLActionDeny  ACLAction = "deny"
 )
 
-// ACLRule defines an IP/CIDR-based ACL rule.
+// ACLRule defines an ACL rule matching the client IP address, country or autonomous system.
+// A rule matching on countries or ASNs requires the geoIP field of the TrafficPolicy to add the
+// corresponding header.
+// +kubebuilder:validation:AtLeastOneOf=cidrs;countries;asns
 type ACLRule struct {
 	// Name is an optional rule identifier emitted as blocked-by dynamic metadata on deny.
 	// +optional
@@ -19,12 +22,27 @@ type ACLRule struct {
 	// CIDRs is a list of IP addresses or CIDR ranges (e.g. "10.0.0.0/8", "2001:db8::/32", "192.168.1.1", "::1").
 	// Bare IPs without a prefix are treated as /32 for IPv4 and /128 for IPv6.
 	// All entries share the same name and action.
-	// +required
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=256
+	CIDRs []IPOrCIDR `json:"cidrs,omitempty"`
+
+	// Countries is a list of ISO 3166-1 alpha-2 country codes (e.g. "US", "DE") of the client.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=256
+	// +kubebuilder:validation:items:Pattern=`^[A-Z]{2}$`
+	Countries []string `json:"countries,omitempty"`
+
+	// ASNs is a list of autonomous system numbers of the client.
+	// +optional
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=256
-	CIDRs []IPOrCIDR `json:"cidrs"`
+	// +kubebuilder:validation:items:Minimum=0
+	// +kubebuilder:validation:items:Maximum=4294967295
+	ASNs []int64 `json:"asns,omitempty"`
 
-	// Action determines what to do when a client IP matches this rule.
+	// Action determines what to do when a client matches this rule.
 	// +required
 	Action ACLAction `json:"action"`
 }
@@ -66,14 +84,15 @@ type ACLDenyResponse struct {
 	BlockedByHeaderName *string `json:"blockedByHeaderName,omitempty"`
 }
 
-// ACLPolicy defines IP-based access control rules evaluated on every HTTP request.
-// The filter uses longest-prefix matching so rule order does not matter.
+// ACLPolicy defines access control rules evaluated on every HTTP request.
+// The filter uses longest-prefix matching for CIDRs so rule order does not matter. A matching
+// CIDR takes precedence over a matching ASN, which takes precedence over a matching country.
 type ACLPolicy struct {
 	// DefaultAction is the action to take when no rule matches the client IP.
 	// +required
 	DefaultAction ACLAction `json:"defaultAction"`
 
-	// Rules is a list of IP/CIDR-based rules. Longest-prefix match wins regardless of rule order.
+	// Rules is a list of CIDR, country and ASN based rules. Longest-prefix match wins regardless of rule order.
 	// +optional
 	// +kubebuilder:validation:MaxItems=256
 	Rules []ACLRule `json:"rules,omitempty"`
@@ -56,7 +56,7 @@ const (
 	PolicyReasonPending PolicyConditionReason = "Pending"
 
 	// PolicyReasonPartiallyValid is used with the "Accepted" condition when the policy has been accepted by the system,
-	// but some of the referenced resources are not valid.
+	// but some of the referenced resources are not valid or part of its configuration does not take effect.
 	PolicyReasonPartiallyValid PolicyConditionReason = "PartiallyValid"
 )
 
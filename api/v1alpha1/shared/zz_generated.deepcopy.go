@@ -95,6 +95,16 @@ func (in *ACLRule) DeepCopyInto(out *ACLRule) {
 		*out = make([]IPOrCIDR, len(*in))
 		copy(*out, *in)
 	}
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ASNs != nil {
+		in, out := &in.ASNs, &out.ASNs
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLRule.
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/offline"
+)
+
+// errDiffFound is returned by the diff command with --exit-code when the outputs differ, and
+// makes main exit with status 1.
+var errDiffFound = errors.New("differences found")
+
+func diffCmd() *cobra.Command {
+	var (
+		before   []string
+		after    []string
+		crdDirs  []string
+		output   string
+		exitCode bool
+	)
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compares the xDS configuration of two manifest sets or controller snapshots",
+		Long: `Reports the listeners, route configurations, virtual hosts and clusters that were
+added, removed or changed between two translations, down to the individual proto fields.
+Resources are matched by name, so reordering alone is not reported.
+
+Each side is either a set of YAML files or directories translated offline, or the URL of
+a running controller's admin server (e.g. http://localhost:9095), whose live xDS snapshot
+is used.`,
+		Example: `  # Review the effect of a policy change
+  kgateway diff --before manifests/ --after manifests/ --after policy.yaml
+
+  # Compare a live controller with the manifests it should be serving
+  kgateway diff --before http://localhost:9095 --after manifests/`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := configureOfflineLogging(); err != nil {
+				return err
+			}
+			gvkToStructuralSchema, err := loadStructuralSchemas(crdDirs)
+			if err != nil {
+				return err
+			}
+			opts := offline.Options{GVKToStructuralSchema: gvkToStructuralSchema}
+
+			beforeOut, err := loadDiffSource(cmd.Context(), before, opts)
+			if err != nil {
+				return fmt.Errorf("error loading --before: %w", err)
+			}
+			afterOut, err := loadDiffSource(cmd.Context(), after, opts)
+			if err != nil {
+				return fmt.Errorf("error loading --after: %w", err)
+			}
+
+			diff := offline.Compare(beforeOut, afterOut)
+			w := cmd.OutOrStdout()
+			switch output {
+			case "text":
+				err = diff.Write(w)
+			case "json", "yaml":
+				var b []byte
+				b, err = json.MarshalIndent(diff, "", "  ")
+				if err == nil && output == "yaml" {
+					b, err = yaml.JSONToYAML(b)
+				}
+				if err == nil {
+					_, err = w.Write(b)
+				}
+			default:
+				return fmt.Errorf("unsupported output format %q, must be one of: text, json, yaml", output)
+			}
+			if err != nil {
+				return fmt.Errorf("error writing diff: %w", err)
+			}
+
+			if exitCode && !diff.Empty() {
+				// The diff was already written, so only the exit status is left to report.
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return errDiffFound
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&before, "before", nil, "YAML files or directories, or the URL of a controller admin server, to diff from")
+	cmd.Flags().StringSliceVar(&after, "after", nil, "YAML files or directories, or the URL of a controller admin server, to diff to")
+	cmd.Flags().StringSliceVar(&crdDirs, "crd-dir", nil, "Directories of CRDs used to default and validate the manifests before translation")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "Output format, one of: text, json, yaml")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with status 1 when there are differences")
+	_ = cmd.MarkFlagRequired("before")
+	_ = cmd.MarkFlagRequired("after")
+	return cmd
+}
+
+// loadDiffSource translates the given manifests, or fetches the live snapshot when the
+// source is an admin server URL.
+func loadDiffSource(ctx context.Context, source []string, opts offline.Options) (*offline.Output, error) {
+	if len(source) == 1 && (strings.HasPrefix(source[0], "http://") || strings.HasPrefix(source[0], "https://")) {
+		return fetchSnapshot(ctx, source[0])
+	}
+	opts.InputFiles = source
+	return offline.Run(ctx, opts)
+}
+
+func fetchSnapshot(ctx context.Context, adminURL string) (*offline.Output, error) {
+	u, err := url.Parse(adminURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/snapshots/xds"
+	}
+	q := u.Query()
+	q.Set("format", "protojson")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s: %s", resp.Status, u, body)
+	}
+	return offline.ParseSnapshot(body)
+}
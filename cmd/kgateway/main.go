@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -39,8 +40,13 @@ func main() {
 		},
 	}
 	cmd.Flags().BoolVarP(&kgatewayVersion, "version", "v", false, "Print the version of kgateway")
+	cmd.AddCommand(translateCmd())
+	cmd.AddCommand(diffCmd())
 
 	if err := cmd.ExecuteContext(ctx); err != nil {
+		if errors.Is(err, errDiffFound) {
+			os.Exit(1)
+		}
 		log.Fatal(err)
 	}
 }
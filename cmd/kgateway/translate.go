@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/spf13/cobra"
+	istiolog "istio.io/istio/pkg/log"
+	apiserverschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/offline"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/manifestutils"
+)
+
+func translateCmd() *cobra.Command {
+	var (
+		files    []string
+		gateways []string
+		crdDirs  []string
+		output   string
+	)
+	cmd := &cobra.Command{
+		Use:   "translate",
+		Short: "Renders the xDS configuration produced for a set of manifests",
+		Long: `Translates Gateway API and kgateway resources read from YAML files into the Envoy
+Listeners, Routes and Clusters each Gateway would be served, along with the statuses
+the controller would report. No cluster is needed; controller settings are read from
+the same KGW_* environment variables as the controller.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := configureOfflineLogging(); err != nil {
+				return err
+			}
+
+			opts := offline.Options{InputFiles: files}
+			for _, gw := range gateways {
+				opts.Gateways = append(opts.Gateways, parseNamespacedName(gw))
+			}
+			var err error
+			if opts.GVKToStructuralSchema, err = loadStructuralSchemas(crdDirs); err != nil {
+				return err
+			}
+
+			out, err := offline.Run(cmd.Context(), opts)
+			if err != nil {
+				return fmt.Errorf("error translating manifests: %w", err)
+			}
+
+			var b []byte
+			switch output {
+			case "json":
+				b, err = out.JSON()
+			case "yaml":
+				b, err = out.YAML()
+			default:
+				return fmt.Errorf("unsupported output format %q, must be one of: json, yaml", output)
+			}
+			if err != nil {
+				return fmt.Errorf("error formatting output: %w", err)
+			}
+			_, err = cmd.OutOrStdout().Write(b)
+			return err
+		},
+	}
+	cmd.Flags().StringSliceVarP(&files, "filename", "f", nil, "YAML files or directories containing the manifests to translate")
+	cmd.Flags().StringSliceVar(&gateways, "gateway", nil, "Only translate the given Gateways, as namespace/name")
+	cmd.Flags().StringSliceVar(&crdDirs, "crd-dir", nil, "Directories of CRDs used to default and validate the manifests before translation")
+	cmd.Flags().StringVarP(&output, "output", "o", "yaml", "Output format, one of: json, yaml")
+	_ = cmd.MarkFlagRequired("filename")
+	return cmd
+}
+
+// configureOfflineLogging keeps stdout for the rendered output: only warnings from the
+// informers and collections are surfaced, on stderr.
+func configureOfflineLogging() error {
+	loggingOptions := istiolog.DefaultOptions()
+	loggingOptions.OutputPaths = []string{"stderr"}
+	loggingOptions.SetDefaultOutputLevel(istiolog.OverrideScopeName, istiolog.WarnLevel)
+	if err := istiolog.Configure(loggingOptions); err != nil {
+		return fmt.Errorf("error configuring logging: %w", err)
+	}
+	return nil
+}
+
+// loadStructuralSchemas loads the structural schemas of the CRDs in the given directories.
+func loadStructuralSchemas(crdDirs []string) (map[schema.GroupVersionKind]*apiserverschema.Structural, error) {
+	if len(crdDirs) == 0 {
+		return nil, nil
+	}
+	gvkToStructuralSchema := map[schema.GroupVersionKind]*apiserverschema.Structural{}
+	for _, dir := range crdDirs {
+		schemas, err := manifestutils.GetStructuralSchemas(dir)
+		if err != nil {
+			return nil, fmt.Errorf("error loading CRDs from %s: %w", dir, err)
+		}
+		maps.Copy(gvkToStructuralSchema, schemas)
+	}
+	return gvkToStructuralSchema, nil
+}
+
+// parseNamespacedName parses a namespace/name reference, defaulting to the default
+// namespace as the manifest loader does for objects without one.
+func parseNamespacedName(ref string) types.NamespacedName {
+	ns, name, found := strings.Cut(ref, "/")
+	if !found {
+		return types.NamespacedName{Namespace: manifestutils.DefaultNamespace(), Name: ref}
+	}
+	return types.NamespacedName{Namespace: ns, Name: name}
+}
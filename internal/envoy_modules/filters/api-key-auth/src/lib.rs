@@ -0,0 +1,228 @@
+#![deny(clippy::unwrap_used, clippy::expect_used)]
+
+use apikey::{ApiKeyConfig, KeySource, Verdict};
+use envoy_proxy_dynamic_modules_rust_sdk::*;
+use std::sync::Arc;
+use std::time::{SystemTime, UNIX_EPOCH};
+
+const METADATA_NAMESPACE: &str = "dev.kgateway.http.api_key_auth";
+const METADATA_CLIENT_KEY: &str = "client";
+const METADATA_DENIED_BY_KEY: &str = "denied-by";
+const DENIED_BY_MISSING: &str = "missing";
+const DENIED_BY_INVALID: &str = "invalid";
+const DENIED_BY_EXPIRED: &str = "expired";
+const DENIED_COUNTER_NAME: &str = "dev.kgateway.http.api_key_auth.denied";
+
+/// The filter-level config carries no keys: requests are only authenticated
+/// on routes that have a per-route config.
+pub struct FilterConfig {
+    denied_counter: EnvoyCounterId,
+}
+
+impl FilterConfig {
+    pub fn new<EC: EnvoyHttpFilterConfig>(
+        envoy_filter_config: &mut EC,
+        _cfg: &str,
+    ) -> Option<Self> {
+        let denied_counter = match envoy_filter_config.define_counter(DENIED_COUNTER_NAME) {
+            Ok(id) => id,
+            Err(e) => {
+                envoy_log_error!(
+                    "api-key-auth: failed to define counter {DENIED_COUNTER_NAME}: {e:?}"
+                );
+                return None;
+            }
+        };
+        Some(Self { denied_counter })
+    }
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilterConfig<EHF> for FilterConfig {
+    fn new_http_filter(&self, _envoy: &mut EHF) -> Box<dyn HttpFilter<EHF>> {
+        Box::new(Filter {
+            per_route_config: None,
+            denied_counter: self.denied_counter,
+        })
+    }
+}
+
+pub struct PerRouteConfig {
+    pub config: Arc<ApiKeyConfig>,
+}
+
+impl PerRouteConfig {
+    pub fn new(cfg: &str) -> Option<Self> {
+        match ApiKeyConfig::from_json(cfg) {
+            Ok(c) => Some(Self {
+                config: Arc::new(c),
+            }),
+            Err(e) => {
+                envoy_log_error!("api-key-auth: bad per-route config: {e}");
+                None
+            }
+        }
+    }
+}
+
+struct Filter {
+    per_route_config: Option<Arc<ApiKeyConfig>>,
+    denied_counter: EnvoyCounterId,
+}
+
+impl Filter {
+    fn set_per_route_config<EHF: EnvoyHttpFilter>(&mut self, envoy_filter: &mut EHF) {
+        if self.per_route_config.is_some() {
+            return;
+        }
+        let Some(cfg) = envoy_filter.get_most_specific_route_config() else {
+            return;
+        };
+        match cfg.downcast_ref::<PerRouteConfig>() {
+            Some(prc) => self.per_route_config = Some(Arc::clone(&prc.config)),
+            None => envoy_log_error!("api-key-auth: per-route config has unexpected type"),
+        }
+    }
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilter<EHF> for Filter {
+    fn on_request_headers(
+        &mut self,
+        envoy_filter: &mut EHF,
+        _end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_request_headers_status {
+        self.set_per_route_config(envoy_filter);
+        let Some(config) = self.per_route_config.clone() else {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue;
+        };
+        let Some(credential) = extract_credential(envoy_filter, &config.key_sources) else {
+            return deny(envoy_filter, DENIED_BY_MISSING, self.denied_counter);
+        };
+        let key = match config.verify(credential.value.as_bytes(), now()) {
+            Verdict::Valid(key) => key,
+            Verdict::Expired(key) => {
+                envoy_log_trace!("api-key-auth: key of client {} is expired", key.client);
+                return deny(envoy_filter, DENIED_BY_EXPIRED, self.denied_counter);
+            }
+            Verdict::Invalid => {
+                return deny(envoy_filter, DENIED_BY_INVALID, self.denied_counter);
+            }
+        };
+        if config.hide_credentials {
+            hide_credential(envoy_filter, &credential.location);
+        }
+        if let Some(header) = config.client_id_header.as_deref() {
+            envoy_filter.set_request_header(header, key.client.as_bytes());
+        }
+        for (k, v) in &key.metadata {
+            envoy_filter.set_dynamic_metadata_string(METADATA_NAMESPACE, k, v);
+        }
+        envoy_filter.set_dynamic_metadata_string(
+            METADATA_NAMESPACE,
+            METADATA_CLIENT_KEY,
+            &key.client,
+        );
+        abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue
+    }
+}
+
+enum Location {
+    Header(String),
+    Query(String),
+    Cookie(String),
+}
+
+struct Credential {
+    value: String,
+    location: Location,
+}
+
+/// Returns the key of the first key source present in the request.
+fn extract_credential<EHF: EnvoyHttpFilter>(
+    envoy_filter: &mut EHF,
+    key_sources: &[KeySource],
+) -> Option<Credential> {
+    for source in key_sources {
+        if let Some(name) = source.header.as_deref() {
+            if let Some(value) = request_header(envoy_filter, name) {
+                return Some(Credential {
+                    value,
+                    location: Location::Header(name.to_string()),
+                });
+            }
+        }
+        if let Some(name) = source.query.as_deref() {
+            let path = request_header(envoy_filter, ":path").unwrap_or_default();
+            if let Some(value) = apikey::query_param(&path, name) {
+                return Some(Credential {
+                    value: value.to_string(),
+                    location: Location::Query(name.to_string()),
+                });
+            }
+        }
+        if let Some(name) = source.cookie.as_deref() {
+            let cookies = request_header(envoy_filter, "cookie").unwrap_or_default();
+            if let Some(value) = apikey::cookie(&cookies, name) {
+                return Some(Credential {
+                    value: value.to_string(),
+                    location: Location::Cookie(name.to_string()),
+                });
+            }
+        }
+    }
+    None
+}
+
+fn hide_credential<EHF: EnvoyHttpFilter>(envoy_filter: &mut EHF, location: &Location) {
+    match location {
+        Location::Header(name) => {
+            envoy_filter.remove_request_header(name);
+        }
+        Location::Query(name) => {
+            let path = request_header(envoy_filter, ":path").unwrap_or_default();
+            envoy_filter
+                .set_request_header(":path", apikey::strip_query_param(&path, name).as_bytes());
+        }
+        Location::Cookie(name) => {
+            let cookies = request_header(envoy_filter, "cookie").unwrap_or_default();
+            let stripped = apikey::strip_cookie(&cookies, name);
+            if stripped.is_empty() {
+                envoy_filter.remove_request_header("cookie");
+            } else {
+                envoy_filter.set_request_header("cookie", stripped.as_bytes());
+            }
+        }
+    }
+}
+
+fn request_header<EHF: EnvoyHttpFilter>(envoy_filter: &mut EHF, name: &str) -> Option<String> {
+    let value = envoy_filter.get_request_header_value(name)?;
+    String::from_utf8(value.as_slice().to_vec()).ok()
+}
+
+fn now() -> i64 {
+    SystemTime::now()
+        .duration_since(UNIX_EPOCH)
+        .map(|d| d.as_secs() as i64)
+        .unwrap_or_default()
+}
+
+fn deny<EHF: EnvoyHttpFilter>(
+    envoy_filter: &mut EHF,
+    denied_by: &str,
+    denied_counter: EnvoyCounterId,
+) -> abi::envoy_dynamic_module_type_on_http_filter_request_headers_status {
+    if let Err(e) = envoy_filter.increment_counter(denied_counter, 1) {
+        envoy_log_warn!("api-key-auth: failed to increment denied counter: {e:?}");
+    }
+    envoy_filter.set_dynamic_metadata_string(METADATA_NAMESPACE, METADATA_DENIED_BY_KEY, denied_by);
+    envoy_filter.send_response(
+        401,
+        &[],
+        Some(b"Client authentication failed.".as_slice()),
+        Some("api-key-auth: client authentication failed"),
+    );
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::StopIteration
+}
+
+#[cfg(test)]
+mod test;
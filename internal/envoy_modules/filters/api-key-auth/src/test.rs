@@ -0,0 +1,169 @@
+#![allow(clippy::unwrap_used, clippy::expect_used)]
+
+use super::*;
+use envoy_proxy_dynamic_modules_rust_sdk::{EnvoyBuffer, MockEnvoyHttpFilter};
+use std::any::Any;
+use std::sync::Arc;
+
+// sha256("s1" + "k-123").
+const K123_DIGEST: &str = "42403fc45854b1038cfd7743f83188ed54b9348686303984268bb68b026f6f15";
+
+// Test-only helper: build a FilterConfig without going through an
+// EnvoyHttpFilterConfig, see the http-acl filter tests.
+//
+// SAFETY: EnvoyCounterId is a public tuple struct wrapping a single `usize`,
+// and the id never reaches Envoy as every `increment_counter` call is
+// intercepted by the mock.
+fn make_filter_config() -> FilterConfig {
+    FilterConfig {
+        denied_counter: unsafe { std::mem::zeroed() },
+    }
+}
+
+fn per_route_config(extra: &str, expires_at: i64) -> Arc<dyn Any> {
+    let cfg = format!(
+        r#"{{{extra}"keys":[{{"client":"client1","sha256":"{K123_DIGEST}","salt":"s1","expiresAt":{expires_at},"metadata":{{"tenant":"acme"}}}}]}}"#
+    );
+    Arc::new(PerRouteConfig::new(&cfg).expect("valid per-route config"))
+}
+
+fn mock_with_headers(
+    prc: Option<Arc<dyn Any>>,
+    headers: &'static [(&'static str, &'static str)],
+) -> MockEnvoyHttpFilter {
+    let mut mock = MockEnvoyHttpFilter::default();
+    mock.expect_get_most_specific_route_config()
+        .returning_st(move || prc.clone());
+    mock.expect_get_request_header_value()
+        .returning(move |name| {
+            headers
+                .iter()
+                .find(|(k, _)| *k == name)
+                .map(|(_, v)| EnvoyBuffer::new(v.as_bytes()))
+        });
+    mock
+}
+
+fn expect_metadata(mock: &mut MockEnvoyHttpFilter, key: &'static str, value: &'static str) {
+    mock.expect_set_dynamic_metadata_string()
+        .withf(move |ns, k, v| ns == "dev.kgateway.http.api_key_auth" && k == key && v == value)
+        .times(1)
+        .returning(|_, _, _| ());
+}
+
+fn expect_denied(mock: &mut MockEnvoyHttpFilter, denied_by: &'static str) {
+    expect_metadata(mock, "denied-by", denied_by);
+    mock.expect_increment_counter()
+        .withf(|_, value| *value == 1)
+        .times(1)
+        .returning(|_, _| Ok(()));
+    mock.expect_send_response()
+        .times(1)
+        .returning(|status, headers, _, _| {
+            assert_eq!(status, 401);
+            assert!(headers.is_empty());
+        });
+}
+
+fn run(mock: &mut MockEnvoyHttpFilter) -> u32 {
+    let mut filter = make_filter_config().new_http_filter(mock);
+    filter.on_request_headers(mock, true) as u32
+}
+
+fn continue_status() -> u32 {
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue as u32
+}
+
+fn stop_status() -> u32 {
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::StopIteration as u32
+}
+
+#[test]
+fn no_per_route_config_continues() {
+    let mut mock = mock_with_headers(None, &[]);
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn valid_key_emits_metadata() {
+    let prc = per_route_config("", i64::MAX);
+    let mut mock = mock_with_headers(Some(prc), &[("api-key", "k-123")]);
+    expect_metadata(&mut mock, "tenant", "acme");
+    expect_metadata(&mut mock, "client", "client1");
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn valid_key_is_hidden_and_client_forwarded() {
+    let prc = per_route_config(
+        r#""keySources":[{"header":"x-api-key"}],"hideCredentials":true,"clientIdHeader":"x-client-id","#,
+        i64::MAX,
+    );
+    let mut mock = mock_with_headers(Some(prc), &[("x-api-key", "k-123")]);
+    mock.expect_remove_request_header()
+        .withf(|name| name == "x-api-key")
+        .times(1)
+        .returning(|_| true);
+    mock.expect_set_request_header()
+        .withf(|name, value| name == "x-client-id" && value == b"client1")
+        .times(1)
+        .returning(|_, _| true);
+    mock.expect_set_dynamic_metadata_string()
+        .returning(|_, _, _| ());
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn query_key_is_stripped_from_path() {
+    let prc = per_route_config(
+        r#""keySources":[{"header":"x-api-key","query":"key"}],"hideCredentials":true,"#,
+        i64::MAX,
+    );
+    let mut mock = mock_with_headers(Some(prc), &[(":path", "/get?key=k-123&a=1")]);
+    mock.expect_set_request_header()
+        .withf(|name, value| name == ":path" && value == b"/get?a=1")
+        .times(1)
+        .returning(|_, _| true);
+    mock.expect_set_dynamic_metadata_string()
+        .returning(|_, _, _| ());
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn cookie_key_is_accepted() {
+    let prc = per_route_config(r#""keySources":[{"cookie":"key"}],"#, i64::MAX);
+    let mut mock = mock_with_headers(Some(prc), &[("cookie", "a=1; key=k-123")]);
+    mock.expect_set_dynamic_metadata_string()
+        .returning(|_, _, _| ());
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn missing_key_is_denied() {
+    let prc = per_route_config("", i64::MAX);
+    let mut mock = mock_with_headers(Some(prc), &[]);
+    expect_denied(&mut mock, "missing");
+    assert_eq!(run(&mut mock), stop_status());
+}
+
+#[test]
+fn invalid_key_is_denied() {
+    let prc = per_route_config("", i64::MAX);
+    let mut mock = mock_with_headers(Some(prc), &[("api-key", "k-456")]);
+    expect_denied(&mut mock, "invalid");
+    assert_eq!(run(&mut mock), stop_status());
+}
+
+#[test]
+fn expired_key_is_denied() {
+    let prc = per_route_config("", 1);
+    let mut mock = mock_with_headers(Some(prc), &[("api-key", "k-123")]);
+    expect_denied(&mut mock, "expired");
+    assert_eq!(run(&mut mock), stop_status());
+}
+
+#[test]
+fn bad_per_route_config_is_rejected() {
+    assert!(PerRouteConfig::new("{").is_none());
+    assert!(PerRouteConfig::new(r#"{"keys":[{"client":"c","sha256":"zz"}]}"#).is_none());
+}
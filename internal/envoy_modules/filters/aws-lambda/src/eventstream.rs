@@ -0,0 +1,197 @@
+//! A decoder for the `application/vnd.amazon.eventstream` framing of the InvokeWithResponseStream
+//! response. Each message is laid out as:
+//!
+//! ```text
+//! total length (u32) | headers length (u32) | prelude crc (u32) | headers | payload | message crc (u32)
+//! ```
+//!
+//! with big-endian integers and CRC32 (IEEE) checksums.
+
+const PRELUDE_LEN: usize = 12;
+const CRC_LEN: usize = 4;
+const MIN_MESSAGE_LEN: usize = PRELUDE_LEN + CRC_LEN;
+/// The largest message accepted. Lambda sends payload chunks far smaller than this, so a larger
+/// length means the stream is corrupt.
+const MAX_MESSAGE_LEN: usize = 16 * 1024 * 1024;
+
+#[derive(Debug, PartialEq, Eq)]
+pub enum DecodeError {
+    PreludeChecksum,
+    MessageChecksum,
+    InvalidLength(usize),
+    InvalidHeaders,
+}
+
+impl std::fmt::Display for DecodeError {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        match self {
+            DecodeError::PreludeChecksum => write!(f, "event stream prelude checksum mismatch"),
+            DecodeError::MessageChecksum => write!(f, "event stream message checksum mismatch"),
+            DecodeError::InvalidLength(len) => {
+                write!(f, "invalid event stream message length {len}")
+            }
+            DecodeError::InvalidHeaders => write!(f, "invalid event stream message headers"),
+        }
+    }
+}
+
+#[derive(Debug, PartialEq, Eq)]
+pub struct Message {
+    /// The headers with a string value. Headers of the other types are not used by Lambda and are
+    /// skipped.
+    pub headers: Vec<(String, String)>,
+    pub payload: Vec<u8>,
+}
+
+impl Message {
+    pub fn header(&self, name: &str) -> Option<&str> {
+        self.headers
+            .iter()
+            .find(|(k, _)| k == name)
+            .map(|(_, v)| v.as_str())
+    }
+}
+
+/// Decodes messages from the chunks of the response body as they are received.
+#[derive(Debug, Default)]
+pub struct Decoder {
+    buf: Vec<u8>,
+}
+
+impl Decoder {
+    pub fn push(&mut self, data: &[u8]) {
+        self.buf.extend_from_slice(data);
+    }
+
+    /// Returns whether a partial message is buffered.
+    pub fn has_partial_message(&self) -> bool {
+        !self.buf.is_empty()
+    }
+
+    /// Returns the next complete message, or None when more data is needed.
+    pub fn next_message(&mut self) -> Result<Option<Message>, DecodeError> {
+        if self.buf.len() < PRELUDE_LEN {
+            return Ok(None);
+        }
+        let total_len = read_u32(&self.buf[0..4]) as usize;
+        let headers_len = read_u32(&self.buf[4..8]) as usize;
+        if read_u32(&self.buf[8..12]) != crc32(&self.buf[0..8]) {
+            return Err(DecodeError::PreludeChecksum);
+        }
+        if !(MIN_MESSAGE_LEN..=MAX_MESSAGE_LEN).contains(&total_len)
+            || headers_len > total_len - MIN_MESSAGE_LEN
+        {
+            return Err(DecodeError::InvalidLength(total_len));
+        }
+        if self.buf.len() < total_len {
+            return Ok(None);
+        }
+        let body_end = total_len - CRC_LEN;
+        if read_u32(&self.buf[body_end..total_len]) != crc32(&self.buf[..body_end]) {
+            return Err(DecodeError::MessageChecksum);
+        }
+        let headers_end = PRELUDE_LEN + headers_len;
+        let headers = decode_headers(&self.buf[PRELUDE_LEN..headers_end])?;
+        let payload = self.buf[headers_end..body_end].to_vec();
+        self.buf.drain(..total_len);
+        Ok(Some(Message { headers, payload }))
+    }
+}
+
+fn decode_headers(mut data: &[u8]) -> Result<Vec<(String, String)>, DecodeError> {
+    let mut headers = Vec::new();
+    while !data.is_empty() {
+        let name_len = data[0] as usize;
+        let name = take(&mut data, 1, name_len)?;
+        let name = String::from_utf8(name.to_vec()).map_err(|_| DecodeError::InvalidHeaders)?;
+        let value_type = *take(&mut data, 0, 1)?
+            .first()
+            .ok_or(DecodeError::InvalidHeaders)?;
+        let value_len = match value_type {
+            // Boolean true and false carry no value.
+            0 | 1 => 0,
+            2 => 1,
+            3 => 2,
+            4 => 4,
+            5 | 8 => 8,
+            9 => 16,
+            // Byte arrays and strings are prefixed with their u16 length.
+            6 | 7 => {
+                let len = take(&mut data, 0, 2)?;
+                u16::from_be_bytes([len[0], len[1]]) as usize
+            }
+            _ => return Err(DecodeError::InvalidHeaders),
+        };
+        let value = take(&mut data, 0, value_len)?;
+        if value_type == 7 {
+            let value =
+                String::from_utf8(value.to_vec()).map_err(|_| DecodeError::InvalidHeaders)?;
+            headers.push((name, value));
+        }
+    }
+    Ok(headers)
+}
+
+/// Skips `skip` bytes of `data` and returns the following `len` bytes.
+fn take<'a>(data: &mut &'a [u8], skip: usize, len: usize) -> Result<&'a [u8], DecodeError> {
+    if data.len() < skip + len {
+        return Err(DecodeError::InvalidHeaders);
+    }
+    let out = &data[skip..skip + len];
+    *data = &data[skip + len..];
+    Ok(out)
+}
+
+fn read_u32(b: &[u8]) -> u32 {
+    u32::from_be_bytes([b[0], b[1], b[2], b[3]])
+}
+
+const CRC32_TABLE: [u32; 256] = crc32_table();
+
+const fn crc32_table() -> [u32; 256] {
+    let mut table = [0u32; 256];
+    let mut i = 0;
+    while i < 256 {
+        let mut c = i as u32;
+        let mut k = 0;
+        while k < 8 {
+            c = if c & 1 != 0 {
+                0xedb8_8320 ^ (c >> 1)
+            } else {
+                c >> 1
+            };
+            k += 1;
+        }
+        table[i] = c;
+        i += 1;
+    }
+    table
+}
+
+pub fn crc32(data: &[u8]) -> u32 {
+    !data.iter().fold(!0u32, |c, b| {
+        CRC32_TABLE[((c ^ *b as u32) & 0xff) as usize] ^ (c >> 8)
+    })
+}
+
+/// Encodes a message with string headers, the way Lambda does.
+#[cfg(test)]
+pub fn encode(headers: &[(&str, &str)], payload: &[u8]) -> Vec<u8> {
+    let mut encoded_headers = Vec::new();
+    for (name, value) in headers {
+        encoded_headers.push(name.len() as u8);
+        encoded_headers.extend_from_slice(name.as_bytes());
+        encoded_headers.push(7);
+        encoded_headers.extend_from_slice(&(value.len() as u16).to_be_bytes());
+        encoded_headers.extend_from_slice(value.as_bytes());
+    }
+    let total_len = MIN_MESSAGE_LEN + encoded_headers.len() + payload.len();
+    let mut out = Vec::with_capacity(total_len);
+    out.extend_from_slice(&(total_len as u32).to_be_bytes());
+    out.extend_from_slice(&(encoded_headers.len() as u32).to_be_bytes());
+    out.extend_from_slice(&crc32(&out).to_be_bytes());
+    out.extend_from_slice(&encoded_headers);
+    out.extend_from_slice(payload);
+    out.extend_from_slice(&crc32(&out).to_be_bytes());
+    out
+}
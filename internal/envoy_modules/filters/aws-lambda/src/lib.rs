@@ -0,0 +1,544 @@
+#![deny(clippy::unwrap_used, clippy::expect_used)]
+
+pub mod eventstream;
+
+use base64::Engine;
+use envoy_proxy_dynamic_modules_rust_sdk::*;
+use eventstream::Decoder;
+use serde::Deserialize;
+use serde_json::{json, Map, Value};
+use std::collections::HashMap;
+use std::sync::Arc;
+
+/// The content type of a streamed response whose payload starts with a JSON prelude carrying the
+/// status code and headers, as written by the `awslambda.HttpResponseStream` helper.
+const HTTP_INTEGRATION_CONTENT_TYPE: &str = "application/vnd.awslambda.http-integration-response";
+/// The prelude ends with 8 NUL bytes.
+const PRELUDE_DELIMITER: [u8; 8] = [0; 8];
+/// The largest prelude accepted, to bound what is held before the response headers are sent.
+const MAX_PRELUDE_BYTES: usize = 64 * 1024;
+
+#[derive(Debug, Deserialize)]
+#[serde(rename_all = "camelCase", deny_unknown_fields)]
+pub struct LambdaConfig {
+    /// The ARN of the function, without the qualifier.
+    pub function_arn: String,
+    pub qualifier: String,
+    /// Invoke the function with InvokeWithResponseStream. The filter then builds the invocation
+    /// request itself, as the Envoy aws_lambda filter only supports Invoke.
+    #[serde(default)]
+    pub stream: bool,
+    /// Send the request body to the function as is rather than wrapped in an Envoy-format JSON
+    /// payload.
+    #[serde(default)]
+    pub payload_passthrough: bool,
+    pub qualifier_header: Option<QualifierHeader>,
+    pub async_response: Option<AsyncResponse>,
+}
+
+#[derive(Debug, Deserialize)]
+#[serde(rename_all = "camelCase", deny_unknown_fields)]
+pub struct QualifierHeader {
+    pub name: String,
+    pub qualifiers: Vec<String>,
+}
+
+#[derive(Debug, Deserialize)]
+#[serde(rename_all = "camelCase", deny_unknown_fields)]
+pub struct AsyncResponse {
+    pub status_code: u16,
+    #[serde(default)]
+    pub headers: Vec<Header>,
+}
+
+#[derive(Debug, Deserialize)]
+#[serde(deny_unknown_fields)]
+pub struct Header {
+    pub name: String,
+    pub value: String,
+}
+
+impl LambdaConfig {
+    pub fn from_json(cfg: &str) -> Result<Self, serde_json::Error> {
+        serde_json::from_str(cfg)
+    }
+
+    /// Returns the qualifier to invoke for the value of the qualifier header. Values that are not
+    /// allowed fall back to the configured qualifier.
+    pub fn select_qualifier(&self, header_value: Option<&[u8]>) -> &str {
+        let (Some(qh), Some(value)) = (&self.qualifier_header, header_value) else {
+            return &self.qualifier;
+        };
+        qh.qualifiers
+            .iter()
+            .find(|q| q.as_bytes() == value)
+            .map(String::as_str)
+            .unwrap_or(&self.qualifier)
+    }
+
+    pub fn invocation_path(&self, qualifier: &str) -> String {
+        if self.stream {
+            format!(
+                "/2021-11-15/functions/{}:{qualifier}/response-streaming-invocations",
+                self.function_arn
+            )
+        } else {
+            format!(
+                "/2015-03-31/functions/{}:{qualifier}/invocations",
+                self.function_arn
+            )
+        }
+    }
+}
+
+/// Builds the JSON payload the Envoy aws_lambda filter sends when the payload is transformed, so
+/// that functions see the same event whether or not they are invoked with response streaming.
+pub fn build_request_payload<'a>(
+    headers: impl IntoIterator<Item = (&'a [u8], &'a [u8])>,
+    body: &[u8],
+) -> Vec<u8> {
+    let mut raw_path = "";
+    let mut method = "";
+    let mut content_type = "";
+    let mut out_headers: Map<String, Value> = Map::new();
+    for (name, value) in headers {
+        let value = std::str::from_utf8(value).unwrap_or_default();
+        match name {
+            b":path" => raw_path = value,
+            b":method" => method = value,
+            _ if name.starts_with(b":") => {}
+            _ => {
+                let name = String::from_utf8_lossy(name).to_ascii_lowercase();
+                if name == "content-type" {
+                    content_type = value;
+                }
+                // Repeated headers are joined the way Envoy does.
+                match out_headers.get_mut(&name) {
+                    Some(Value::String(existing)) => {
+                        existing.push(',');
+                        existing.push_str(value);
+                    }
+                    _ => {
+                        out_headers.insert(name, Value::String(value.to_string()));
+                    }
+                }
+            }
+        }
+    }
+    let mut query_string_parameters: Map<String, Value> = Map::new();
+    if let Some((_, query)) = raw_path.split_once('?') {
+        for pair in query.split('&').filter(|p| !p.is_empty()) {
+            let (k, v) = pair.split_once('=').unwrap_or((pair, ""));
+            query_string_parameters.insert(k.to_string(), Value::String(v.to_string()));
+        }
+    }
+    let mut payload = json!({
+        "raw_path": raw_path,
+        "method": method,
+        "headers": out_headers,
+        "query_string_parameters": query_string_parameters,
+    });
+    if !body.is_empty() {
+        let is_base64_encoded = !is_text_content_type(content_type);
+        payload["body"] = Value::String(if is_base64_encoded {
+            base64::engine::general_purpose::STANDARD.encode(body)
+        } else {
+            String::from_utf8_lossy(body).into_owned()
+        });
+        payload["is_base64_encoded"] = Value::Bool(is_base64_encoded);
+    }
+    payload.to_string().into_bytes()
+}
+
+fn is_text_content_type(content_type: &str) -> bool {
+    let media_type = content_type
+        .split(';')
+        .next()
+        .unwrap_or_default()
+        .trim()
+        .to_ascii_lowercase();
+    media_type.starts_with("text/")
+        || matches!(
+            media_type.as_str(),
+            "application/json" | "application/javascript" | "application/xml"
+        )
+}
+
+/// The status code, headers, and cookies of an HTTP integration response.
+#[derive(Debug, Default, PartialEq, Deserialize)]
+#[serde(rename_all = "camelCase")]
+pub struct HttpPrelude {
+    pub status_code: Option<u16>,
+    #[serde(default)]
+    pub headers: HashMap<String, String>,
+    #[serde(default)]
+    pub cookies: Vec<String>,
+}
+
+/// What a chunk of the InvokeWithResponseStream response decodes to.
+#[derive(Debug, Default, PartialEq)]
+pub struct Decoded {
+    /// The prelude of an HTTP integration response, once it is complete.
+    pub prelude: Option<HttpPrelude>,
+    /// The bytes of the function response to send to the client.
+    pub body: Vec<u8>,
+    /// Set when the function failed or the stream is corrupt. Nothing is decoded after an error.
+    pub error: Option<String>,
+}
+
+/// Decodes the event stream of an InvokeWithResponseStream response into the function response.
+#[derive(Debug)]
+pub struct ResponseStream {
+    decoder: Decoder,
+    /// The prelude bytes received so far, while waiting for the delimiter. None when the response
+    /// has no prelude or once it has been parsed.
+    prelude: Option<Vec<u8>>,
+    failed: bool,
+}
+
+impl ResponseStream {
+    pub fn new(http_integration: bool) -> Self {
+        Self {
+            decoder: Decoder::default(),
+            prelude: http_integration.then(Vec::new),
+            failed: false,
+        }
+    }
+
+    /// Returns whether the response headers must be held until the prelude is received.
+    pub fn awaiting_prelude(&self) -> bool {
+        self.prelude.is_some() && !self.failed
+    }
+
+    pub fn push(&mut self, data: &[u8]) -> Decoded {
+        let mut out = Decoded::default();
+        if self.failed {
+            return out;
+        }
+        self.decoder.push(data);
+        loop {
+            let message = match self.decoder.next_message() {
+                Ok(Some(message)) => message,
+                Ok(None) => break,
+                Err(e) => {
+                    self.fail(&mut out, e.to_string());
+                    break;
+                }
+            };
+            if message.header(":message-type") == Some("exception") {
+                let kind = message.header(":exception-type").unwrap_or("exception");
+                let detail = String::from_utf8_lossy(&message.payload).into_owned();
+                self.fail(&mut out, format!("{kind}: {detail}"));
+                break;
+            }
+            match message.header(":event-type") {
+                Some("PayloadChunk") => self.payload_chunk(&mut out, &message.payload),
+                Some("InvokeComplete") => {
+                    if let Some(error) = invoke_complete_error(&message.payload) {
+                        self.fail(&mut out, error);
+                    }
+                }
+                _ => {}
+            }
+            if self.failed {
+                break;
+            }
+        }
+        out
+    }
+
+    /// Called at the end of the response, to report a stream that ended early.
+    pub fn finish(&mut self) -> Option<String> {
+        if self.failed {
+            return None;
+        }
+        let error = if self.prelude.is_some() {
+            "response stream ended before the HTTP integration prelude"
+        } else if self.decoder.has_partial_message() {
+            "response stream ended in the middle of a message"
+        } else {
+            return None;
+        };
+        self.failed = true;
+        Some(error.to_string())
+    }
+
+    fn payload_chunk(&mut self, out: &mut Decoded, payload: &[u8]) {
+        let Some(buf) = &mut self.prelude else {
+            out.body.extend_from_slice(payload);
+            return;
+        };
+        buf.extend_from_slice(payload);
+        let Some(end) = buf
+            .windows(PRELUDE_DELIMITER.len())
+            .position(|w| w == PRELUDE_DELIMITER)
+        else {
+            if buf.len() > MAX_PRELUDE_BYTES {
+                self.fail(out, "HTTP integration prelude is too large".to_string());
+            }
+            return;
+        };
+        match serde_json::from_slice::<HttpPrelude>(&buf[..end]) {
+            Ok(prelude) => {
+                out.body
+                    .extend_from_slice(&buf[end + PRELUDE_DELIMITER.len()..]);
+                out.prelude = Some(prelude);
+                self.prelude = None;
+            }
+            Err(e) => self.fail(out, format!("invalid HTTP integration prelude: {e}")),
+        }
+    }
+
+    fn fail(&mut self, out: &mut Decoded, error: String) {
+        self.failed = true;
+        out.error = Some(error);
+    }
+}
+
+fn invoke_complete_error(payload: &[u8]) -> Option<String> {
+    let v: Value = serde_json::from_slice(payload).ok()?;
+    let code = v.get("ErrorCode")?.as_str()?;
+    let details = v.get("ErrorDetails").and_then(Value::as_str).unwrap_or("");
+    Some(format!("{code}: {details}"))
+}
+
+pub struct FilterConfig {
+    config: Arc<LambdaConfig>,
+}
+
+impl FilterConfig {
+    pub fn new(cfg: &str) -> Option<Self> {
+        match LambdaConfig::from_json(cfg) {
+            Ok(c) => Some(Self {
+                config: Arc::new(c),
+            }),
+            Err(e) => {
+                envoy_log_error!("aws-lambda: bad config: {e}");
+                None
+            }
+        }
+    }
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilterConfig<EHF> for FilterConfig {
+    fn new_http_filter(&self, _envoy: &mut EHF) -> Box<dyn HttpFilter<EHF>> {
+        Box::new(Filter {
+            config: Arc::clone(&self.config),
+            request_headers: Vec::new(),
+            response: None,
+            response_started: false,
+        })
+    }
+}
+
+struct Filter {
+    config: Arc<LambdaConfig>,
+    /// The client request headers, kept to build the invocation payload once the body is received.
+    request_headers: Vec<(Vec<u8>, Vec<u8>)>,
+    /// Set while relaying a streamed response.
+    response: Option<ResponseStream>,
+    /// Whether response headers have been sent to the client for the streamed response.
+    response_started: bool,
+}
+
+impl Filter {
+    fn set_request_payload<EHF: EnvoyHttpFilter>(&self, envoy_filter: &mut EHF, body: &[u8]) {
+        let payload = build_request_payload(
+            self.request_headers
+                .iter()
+                .map(|(k, v)| (k.as_slice(), v.as_slice())),
+            body,
+        );
+        envoy_filter.set_request_header("content-type", b"application/json");
+        envoy_filter.remove_request_header("content-length");
+        envoy_filter.append_buffered_request_body(&payload);
+    }
+
+    /// Reads the request body the way rustformation does: the buffered body when the body was
+    /// buffered over several chunks, else the received chunk. The body is drained as it is read.
+    fn take_request_body<EHF: EnvoyHttpFilter>(envoy_filter: &mut EHF) -> Vec<u8> {
+        if let Some(buffers) = envoy_filter.get_buffered_request_body() {
+            let body = concat(&buffers);
+            envoy_filter.drain_buffered_request_body(body.len());
+            return body;
+        }
+        let body = envoy_filter
+            .get_received_request_body()
+            .map(|buffers| concat(&buffers))
+            .unwrap_or_default();
+        envoy_filter.drain_received_request_body(body.len());
+        body
+    }
+
+    /// Sends the error as a 502 when nothing of the response has reached the client yet. Once the
+    /// headers are sent the status cannot change, so the response is cut short.
+    fn fail_response<EHF: EnvoyHttpFilter>(&mut self, envoy_filter: &mut EHF, error: &str) {
+        envoy_log_warn!("aws-lambda: function response stream failed: {error}");
+        if self.response_started {
+            return;
+        }
+        envoy_filter.set_response_header(":status", b"502");
+        envoy_filter.set_response_header("content-type", b"text/plain");
+        envoy_filter.append_received_response_body(b"lambda function response stream failed");
+        self.response_started = true;
+    }
+
+    fn apply_prelude<EHF: EnvoyHttpFilter>(envoy_filter: &mut EHF, prelude: &HttpPrelude) {
+        envoy_filter.remove_response_header("content-type");
+        let status = prelude.status_code.unwrap_or(200).to_string();
+        envoy_filter.set_response_header(":status", status.as_bytes());
+        for (name, value) in &prelude.headers {
+            envoy_filter.set_response_header(name, value.as_bytes());
+        }
+        for cookie in &prelude.cookies {
+            envoy_filter.add_response_header("set-cookie", cookie.as_bytes());
+        }
+    }
+}
+
+fn concat(buffers: &[EnvoyMutBuffer]) -> Vec<u8> {
+    buffers.iter().flat_map(|b| b.as_slice()).copied().collect()
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilter<EHF> for Filter {
+    fn on_request_headers(
+        &mut self,
+        envoy_filter: &mut EHF,
+        end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_request_headers_status {
+        let mut qualifier = self.config.qualifier.as_str();
+        if let Some(qh) = &self.config.qualifier_header {
+            let value = envoy_filter
+                .get_request_header_value(&qh.name)
+                .map(|v| v.as_slice().to_vec());
+            // The header only selects the qualifier, so it is never sent to the function.
+            envoy_filter.remove_request_header(&qh.name);
+            qualifier = self.config.select_qualifier(value.as_deref());
+        }
+        let path = self.config.invocation_path(qualifier);
+
+        if !self.config.stream {
+            // The Envoy aws_lambda filter already set the path for the configured qualifier.
+            if qualifier != self.config.qualifier {
+                envoy_filter.set_request_header(":path", path.as_bytes());
+            }
+            return abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue;
+        }
+
+        if !self.config.payload_passthrough {
+            self.request_headers = envoy_filter
+                .get_request_headers()
+                .iter()
+                .map(|(k, v)| (k.as_slice().to_vec(), v.as_slice().to_vec()))
+                .collect();
+        }
+        envoy_filter.set_request_header(":method", b"POST");
+        envoy_filter.set_request_header(":path", path.as_bytes());
+        envoy_filter.set_request_header("x-amz-invocation-type", b"RequestResponse");
+        if self.config.payload_passthrough {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue;
+        }
+        if end_of_stream {
+            self.set_request_payload(envoy_filter, &[]);
+            return abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue;
+        }
+        abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::StopIteration
+    }
+
+    fn on_request_body(
+        &mut self,
+        envoy_filter: &mut EHF,
+        end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_request_body_status {
+        if !self.config.stream || self.config.payload_passthrough {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_body_status::Continue;
+        }
+        if !end_of_stream {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_body_status::StopIterationAndBuffer;
+        }
+        let body = Self::take_request_body(envoy_filter);
+        self.set_request_payload(envoy_filter, &body);
+        abi::envoy_dynamic_module_type_on_http_filter_request_body_status::Continue
+    }
+
+    fn on_response_headers(
+        &mut self,
+        envoy_filter: &mut EHF,
+        end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_response_headers_status {
+        let status = envoy_filter
+            .get_response_header_value(":status")
+            .map(|v| v.as_slice().to_vec())
+            .unwrap_or_default();
+
+        if let Some(async_response) = &self.config.async_response {
+            if status == b"202" {
+                let status = async_response.status_code.to_string();
+                envoy_filter.set_response_header(":status", status.as_bytes());
+                for h in &async_response.headers {
+                    envoy_filter.set_response_header(&h.name, h.value.as_bytes());
+                }
+            }
+            return abi::envoy_dynamic_module_type_on_http_filter_response_headers_status::Continue;
+        }
+
+        // Errors of the invocation itself, e.g. throttling, are not event streams and are passed
+        // through as is.
+        if !self.config.stream || status != b"200" || end_of_stream {
+            return abi::envoy_dynamic_module_type_on_http_filter_response_headers_status::Continue;
+        }
+        let http_integration = envoy_filter
+            .get_response_header_value("content-type")
+            .is_some_and(|v| v.as_slice() == HTTP_INTEGRATION_CONTENT_TYPE.as_bytes());
+        // The length of the event stream is not the length of the function response.
+        envoy_filter.remove_response_header("content-length");
+        let response = ResponseStream::new(http_integration);
+        let hold_headers = response.awaiting_prelude();
+        self.response = Some(response);
+        if hold_headers {
+            return abi::envoy_dynamic_module_type_on_http_filter_response_headers_status::StopIteration;
+        }
+        self.response_started = true;
+        abi::envoy_dynamic_module_type_on_http_filter_response_headers_status::Continue
+    }
+
+    fn on_response_body(
+        &mut self,
+        envoy_filter: &mut EHF,
+        end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_response_body_status {
+        let Some(response) = &mut self.response else {
+            return abi::envoy_dynamic_module_type_on_http_filter_response_body_status::Continue;
+        };
+        let chunk = envoy_filter
+            .get_received_response_body()
+            .map(|buffers| concat(&buffers))
+            .unwrap_or_default();
+        envoy_filter.drain_received_response_body(chunk.len());
+
+        let mut decoded = response.push(&chunk);
+        if end_of_stream && decoded.error.is_none() {
+            decoded.error = response.finish();
+        }
+        let awaiting_prelude = response.awaiting_prelude();
+        if let Some(prelude) = &decoded.prelude {
+            Self::apply_prelude(envoy_filter, prelude);
+            self.response_started = true;
+        }
+        if !decoded.body.is_empty() {
+            envoy_filter.append_received_response_body(&decoded.body);
+        }
+        if let Some(error) = decoded.error {
+            self.fail_response(envoy_filter, &error);
+            return abi::envoy_dynamic_module_type_on_http_filter_response_body_status::Continue;
+        }
+        if awaiting_prelude {
+            // Everything received was drained into the prelude, so there is nothing to buffer.
+            return abi::envoy_dynamic_module_type_on_http_filter_response_body_status::StopIterationNoBuffer;
+        }
+        abi::envoy_dynamic_module_type_on_http_filter_response_body_status::Continue
+    }
+}
+
+#[cfg(test)]
+mod test;
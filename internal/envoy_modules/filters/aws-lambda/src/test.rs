@@ -0,0 +1,390 @@
+#![allow(clippy::unwrap_used, clippy::expect_used)]
+
+use super::eventstream::{encode, DecodeError, Decoder};
+use super::*;
+use envoy_proxy_dynamic_modules_rust_sdk::{EnvoyBuffer, EnvoyMutBuffer, MockEnvoyHttpFilter};
+
+const FUNCTION_ARN: &str = "arn:aws:lambda:us-east-1:111111111111:function:hello";
+
+fn config(extra: &str) -> LambdaConfig {
+    LambdaConfig::from_json(&format!(
+        r#"{{"functionArn":"{FUNCTION_ARN}","qualifier":"live"{extra}}}"#
+    ))
+    .unwrap()
+}
+
+fn new_filter(
+    extra: &str,
+    mock: &mut MockEnvoyHttpFilter,
+) -> Box<dyn HttpFilter<MockEnvoyHttpFilter>> {
+    FilterConfig {
+        config: Arc::new(config(extra)),
+    }
+    .new_http_filter(mock)
+}
+
+fn payload_chunk(payload: &[u8]) -> Vec<u8> {
+    encode(
+        &[
+            (":message-type", "event"),
+            (":event-type", "PayloadChunk"),
+            (":content-type", "application/octet-stream"),
+        ],
+        payload,
+    )
+}
+
+fn invoke_complete(payload: &str) -> Vec<u8> {
+    encode(
+        &[
+            (":message-type", "event"),
+            (":event-type", "InvokeComplete"),
+            (":content-type", "application/json"),
+        ],
+        payload.as_bytes(),
+    )
+}
+
+#[test]
+fn decoder_decodes_messages_split_across_chunks() {
+    let mut stream = payload_chunk(b"hello");
+    stream.extend(payload_chunk(b"world"));
+
+    let mut decoder = Decoder::default();
+    let mut payloads = Vec::new();
+    for chunk in stream.chunks(7) {
+        decoder.push(chunk);
+        while let Some(message) = decoder.next_message().unwrap() {
+            assert_eq!(message.header(":event-type"), Some("PayloadChunk"));
+            payloads.push(message.payload);
+        }
+    }
+    assert_eq!(payloads, [b"hello".to_vec(), b"world".to_vec()]);
+    assert!(!decoder.has_partial_message());
+}
+
+#[test]
+fn decoder_rejects_corrupt_messages() {
+    let mut message = payload_chunk(b"hello");
+    let last = message.len() - 5;
+    message[last] ^= 0xff;
+    let mut decoder = Decoder::default();
+    decoder.push(&message);
+    assert_eq!(decoder.next_message(), Err(DecodeError::MessageChecksum));
+
+    let mut decoder = Decoder::default();
+    decoder.push(&[0, 0, 0, 16, 0, 0, 0, 0, 0, 0, 0, 0]);
+    assert_eq!(decoder.next_message(), Err(DecodeError::PreludeChecksum));
+}
+
+#[test]
+fn crc32_matches_ieee() {
+    assert_eq!(eventstream::crc32(b"123456789"), 0xcbf4_3926);
+}
+
+#[test]
+fn select_qualifier_only_allows_listed_qualifiers() {
+    let cfg =
+        config(r#","qualifierHeader":{"name":"x-lambda-qualifier","qualifiers":["canary","7"]}"#);
+    assert_eq!(cfg.select_qualifier(Some(b"canary")), "canary");
+    assert_eq!(cfg.select_qualifier(Some(b"$LATEST")), "live");
+    assert_eq!(cfg.select_qualifier(None), "live");
+}
+
+#[test]
+fn invocation_path_depends_on_the_api() {
+    assert_eq!(
+        config("").invocation_path("7"),
+        format!("/2015-03-31/functions/{FUNCTION_ARN}:7/invocations")
+    );
+    assert_eq!(
+        config(r#","stream":true"#).invocation_path("live"),
+        format!("/2021-11-15/functions/{FUNCTION_ARN}:live/response-streaming-invocations")
+    );
+}
+
+#[test]
+fn request_payload_matches_the_envoy_format() {
+    let headers: [(&[u8], &[u8]); 6] = [
+        (b":method", b"POST"),
+        (b":path", b"/chat?stream=true&model=a%20b"),
+        (b":authority", b"api.example.com"),
+        (b"content-type", b"application/json"),
+        (b"x-tag", b"a"),
+        (b"x-tag", b"b"),
+    ];
+    let payload = build_request_payload(headers, br#"{"prompt":"hi"}"#);
+    let got: Value = serde_json::from_slice(&payload).unwrap();
+    assert_eq!(
+        got,
+        json!({
+            "raw_path": "/chat?stream=true&model=a%20b",
+            "method": "POST",
+            "headers": {"content-type": "application/json", "x-tag": "a,b"},
+            "query_string_parameters": {"stream": "true", "model": "a%20b"},
+            "body": "{\"prompt\":\"hi\"}",
+            "is_base64_encoded": false,
+        })
+    );
+
+    let headers: [(&[u8], &[u8]); 3] = [
+        (b":method", b"PUT"),
+        (b":path", b"/upload"),
+        (b"content-type", b"application/octet-stream"),
+    ];
+    let got: Value = serde_json::from_slice(&build_request_payload(headers, &[0, 1, 2])).unwrap();
+    assert_eq!(got["body"], "AAEC");
+    assert_eq!(got["is_base64_encoded"], true);
+}
+
+#[test]
+fn response_stream_relays_payload_chunks() {
+    let mut stream = ResponseStream::new(false);
+    assert!(!stream.awaiting_prelude());
+    let mut data = payload_chunk(b"data: 1\n\n");
+    data.extend(payload_chunk(b"data: 2\n\n"));
+    let decoded = stream.push(&data);
+    assert_eq!(decoded.body, b"data: 1\n\ndata: 2\n\n");
+    assert_eq!(decoded.error, None);
+    assert_eq!(stream.push(&invoke_complete("{}")), Decoded::default());
+    assert_eq!(stream.finish(), None);
+}
+
+#[test]
+fn response_stream_parses_the_http_integration_prelude() {
+    let mut stream = ResponseStream::new(true);
+    assert!(stream.awaiting_prelude());
+
+    // The prelude may be split across payload chunks.
+    let decoded = stream.push(&payload_chunk(
+        br#"{"statusCode":201,"headers":{"content-type":"text/event-stream"},"#,
+    ));
+    assert_eq!(decoded, Decoded::default());
+    assert!(stream.awaiting_prelude());
+
+    let mut rest = br#""cookies":["a=1"]}"#.to_vec();
+    rest.extend_from_slice(&[0; 8]);
+    rest.extend_from_slice(b"data: 1\n\n");
+    let decoded = stream.push(&payload_chunk(&rest));
+    assert_eq!(
+        decoded.prelude,
+        Some(HttpPrelude {
+            status_code: Some(201),
+            headers: HashMap::from([("content-type".to_string(), "text/event-stream".to_string())]),
+            cookies: vec!["a=1".to_string()],
+        })
+    );
+    assert_eq!(decoded.body, b"data: 1\n\n");
+    assert!(!stream.awaiting_prelude());
+}
+
+#[test]
+fn response_stream_reports_errors() {
+    let mut stream = ResponseStream::new(false);
+    let decoded = stream.push(&invoke_complete(
+        r#"{"ErrorCode":"Runtime.ExitError","ErrorDetails":"exited"}"#,
+    ));
+    assert_eq!(decoded.error.as_deref(), Some("Runtime.ExitError: exited"));
+    // Nothing is decoded after an error.
+    assert_eq!(stream.push(&payload_chunk(b"more")), Decoded::default());
+
+    let mut stream = ResponseStream::new(false);
+    let decoded = stream.push(&encode(
+        &[
+            (":message-type", "exception"),
+            (":exception-type", "ServiceException"),
+        ],
+        b"boom",
+    ));
+    assert_eq!(decoded.error.as_deref(), Some("ServiceException: boom"));
+
+    let mut stream = ResponseStream::new(true);
+    assert_eq!(stream.push(&payload_chunk(b"{")), Decoded::default());
+    assert!(stream.finish().is_some());
+
+    let mut stream = ResponseStream::new(true);
+    let decoded = stream.push(&payload_chunk(&vec![b' '; MAX_PRELUDE_BYTES + 1]));
+    assert_eq!(
+        decoded.error.as_deref(),
+        Some("HTTP integration prelude is too large")
+    );
+}
+
+#[test]
+fn sync_invocation_rewrites_the_path_for_the_selected_qualifier() {
+    let mut mock = MockEnvoyHttpFilter::default();
+    mock.expect_get_request_header_value()
+        .withf(|name| *name == "x-lambda-qualifier")
+        .returning(|_| Some(EnvoyBuffer::new("canary".as_bytes())));
+    mock.expect_remove_request_header()
+        .withf(|name| *name == "x-lambda-qualifier")
+        .times(1)
+        .returning(|_| true);
+    mock.expect_set_request_header()
+        .withf(|name, value| {
+            *name == ":path"
+                && *value
+                    == format!("/2015-03-31/functions/{FUNCTION_ARN}:canary/invocations").as_bytes()
+        })
+        .times(1)
+        .returning(|_, _| true);
+    let mut filter = new_filter(
+        r#","qualifierHeader":{"name":"x-lambda-qualifier","qualifiers":["canary"]}"#,
+        &mut mock,
+    );
+    assert_eq!(
+        filter.on_request_headers(&mut mock, true),
+        abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue
+    );
+}
+
+#[test]
+fn stream_invocation_builds_the_request() {
+    let mut mock = MockEnvoyHttpFilter::default();
+    mock.expect_get_request_headers().returning(|| {
+        vec![
+            (
+                EnvoyBuffer::new(":method".as_bytes()),
+                EnvoyBuffer::new("GET".as_bytes()),
+            ),
+            (
+                EnvoyBuffer::new(":path".as_bytes()),
+                EnvoyBuffer::new("/chat".as_bytes()),
+            ),
+        ]
+    });
+    let mut set_headers = Vec::new();
+    mock.expect_set_request_header()
+        .times(4)
+        .returning(move |name, value| {
+            set_headers.push((name.to_string(), String::from_utf8_lossy(value).into_owned()));
+            if set_headers.len() == 4 {
+                assert_eq!(
+                    set_headers,
+                    [
+                        (":method".to_string(), "POST".to_string()),
+                        (
+                            ":path".to_string(),
+                            format!("/2021-11-15/functions/{FUNCTION_ARN}:live/response-streaming-invocations")
+                        ),
+                        ("x-amz-invocation-type".to_string(), "RequestResponse".to_string()),
+                        ("content-type".to_string(), "application/json".to_string()),
+                    ]
+                );
+            }
+            true
+        });
+    mock.expect_remove_request_header()
+        .withf(|name| *name == "content-length")
+        .returning(|_| true);
+    mock.expect_append_buffered_request_body()
+        .times(1)
+        .returning(|body| {
+            let got: Value = serde_json::from_slice(body).unwrap();
+            assert_eq!(got["raw_path"], "/chat");
+            assert_eq!(got["method"], "GET");
+            true
+        });
+    let mut filter = new_filter(r#","stream":true"#, &mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, true),
+        abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue
+    );
+}
+
+#[test]
+fn stream_response_holds_headers_until_the_prelude() {
+    static mut FIRST: Vec<u8> = Vec::new();
+    static mut SECOND: Vec<u8> = Vec::new();
+    unsafe {
+        FIRST = payload_chunk(br#"{"statusCode":418,"headers":{"x-teapot":"yes"}}"#);
+        let mut rest = vec![0; 8];
+        rest.extend_from_slice(b"short and stout");
+        SECOND = payload_chunk(&rest);
+    }
+
+    let mut mock = MockEnvoyHttpFilter::default();
+    mock.expect_get_response_header_value()
+        .withf(|name| *name == ":status")
+        .returning(|_| Some(EnvoyBuffer::new("200".as_bytes())));
+    mock.expect_get_response_header_value()
+        .withf(|name| *name == "content-type")
+        .returning(|_| Some(EnvoyBuffer::new(HTTP_INTEGRATION_CONTENT_TYPE.as_bytes())));
+    mock.expect_remove_response_header().returning(|_| true);
+    let mut filter = new_filter(r#","stream":true"#, &mut mock);
+    assert_eq!(
+        filter.on_response_headers(&mut mock, false),
+        abi::envoy_dynamic_module_type_on_http_filter_response_headers_status::StopIteration
+    );
+
+    mock.expect_get_received_response_body()
+        .times(1)
+        .returning(|| {
+            Some(vec![EnvoyMutBuffer::new(unsafe {
+                (*std::ptr::addr_of_mut!(FIRST)).as_mut_slice()
+            })])
+        });
+    mock.expect_drain_received_response_body()
+        .returning(|_| true);
+    assert_eq!(
+        filter.on_response_body(&mut mock, false),
+        abi::envoy_dynamic_module_type_on_http_filter_response_body_status::StopIterationNoBuffer
+    );
+
+    mock.expect_get_received_response_body()
+        .times(1)
+        .returning(|| {
+            Some(vec![EnvoyMutBuffer::new(unsafe {
+                (*std::ptr::addr_of_mut!(SECOND)).as_mut_slice()
+            })])
+        });
+    mock.expect_set_response_header()
+        .withf(|name, value| *name == ":status" && *value == b"418")
+        .times(1)
+        .returning(|_, _| true);
+    mock.expect_set_response_header()
+        .withf(|name, value| *name == "x-teapot" && *value == b"yes")
+        .times(1)
+        .returning(|_, _| true);
+    mock.expect_append_received_response_body()
+        .withf(|body| *body == b"short and stout")
+        .times(1)
+        .returning(|_| true);
+    assert_eq!(
+        filter.on_response_body(&mut mock, false),
+        abi::envoy_dynamic_module_type_on_http_filter_response_body_status::Continue
+    );
+}
+
+#[test]
+fn async_response_is_mapped() {
+    let mut mock = MockEnvoyHttpFilter::default();
+    mock.expect_get_response_header_value()
+        .withf(|name| *name == ":status")
+        .returning(|_| Some(EnvoyBuffer::new("202".as_bytes())));
+    mock.expect_set_response_header()
+        .withf(|name, value| *name == ":status" && *value == b"200")
+        .times(1)
+        .returning(|_, _| true);
+    mock.expect_set_response_header()
+        .withf(|name, value| *name == "x-queued" && *value == b"true")
+        .times(1)
+        .returning(|_, _| true);
+    let mut filter = new_filter(
+        r#","asyncResponse":{"statusCode":200,"headers":[{"name":"x-queued","value":"true"}]}"#,
+        &mut mock,
+    );
+    assert_eq!(
+        filter.on_response_headers(&mut mock, true),
+        abi::envoy_dynamic_module_type_on_http_filter_response_headers_status::Continue
+    );
+}
+
+#[test]
+fn bad_config_is_rejected() {
+    assert!(FilterConfig::new(r#"{"functionArn":"arn"}"#).is_none());
+    assert!(FilterConfig::new(&format!(
+        r#"{{"functionArn":"{FUNCTION_ARN}","qualifier":"live","unknown":true}}"#
+    ))
+    .is_none());
+}
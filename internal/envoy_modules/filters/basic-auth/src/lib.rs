@@ -0,0 +1,161 @@
+#![deny(clippy::unwrap_used, clippy::expect_used)]
+
+use envoy_proxy_dynamic_modules_rust_sdk::*;
+use htpasswd::BasicAuthConfig;
+use std::sync::Arc;
+
+const METADATA_NAMESPACE: &str = "dev.kgateway.http.basic_auth";
+const METADATA_USERNAME_KEY: &str = "username";
+const METADATA_REALM_KEY: &str = "realm";
+const METADATA_DENIED_BY_KEY: &str = "denied-by";
+const DENIED_BY_MISSING: &str = "missing";
+const DENIED_BY_INVALID: &str = "invalid";
+const DENIED_COUNTER_NAME: &str = "dev.kgateway.http.basic_auth.denied";
+
+// The response bodies of the Envoy basic auth filter, so that the response does not
+// depend on the filter that verified the credentials.
+const MISSING_CREDENTIALS_BODY: &[u8] =
+    b"User authentication failed. Missing username and password.";
+const INVALID_CREDENTIALS_BODY: &[u8] =
+    b"User authentication failed. Invalid username/password combination.";
+
+/// The filter-level config carries no users: requests are only authenticated
+/// on routes that have a per-route config.
+pub struct FilterConfig {
+    denied_counter: EnvoyCounterId,
+}
+
+impl FilterConfig {
+    pub fn new<EC: EnvoyHttpFilterConfig>(
+        envoy_filter_config: &mut EC,
+        _cfg: &str,
+    ) -> Option<Self> {
+        let denied_counter = match envoy_filter_config.define_counter(DENIED_COUNTER_NAME) {
+            Ok(id) => id,
+            Err(e) => {
+                envoy_log_error!(
+                    "basic-auth: failed to define counter {DENIED_COUNTER_NAME}: {e:?}"
+                );
+                return None;
+            }
+        };
+        Some(Self { denied_counter })
+    }
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilterConfig<EHF> for FilterConfig {
+    fn new_http_filter(&self, _envoy: &mut EHF) -> Box<dyn HttpFilter<EHF>> {
+        Box::new(Filter {
+            per_route_config: None,
+            denied_counter: self.denied_counter,
+        })
+    }
+}
+
+pub struct PerRouteConfig {
+    pub config: Arc<BasicAuthConfig>,
+}
+
+impl PerRouteConfig {
+    pub fn new(cfg: &str) -> Option<Self> {
+        match BasicAuthConfig::from_json(cfg) {
+            Ok(c) => Some(Self {
+                config: Arc::new(c),
+            }),
+            Err(e) => {
+                envoy_log_error!("basic-auth: bad per-route config: {e}");
+                None
+            }
+        }
+    }
+}
+
+struct Filter {
+    per_route_config: Option<Arc<BasicAuthConfig>>,
+    denied_counter: EnvoyCounterId,
+}
+
+impl Filter {
+    fn set_per_route_config<EHF: EnvoyHttpFilter>(&mut self, envoy_filter: &mut EHF) {
+        if self.per_route_config.is_some() {
+            return;
+        }
+        let Some(cfg) = envoy_filter.get_most_specific_route_config() else {
+            return;
+        };
+        match cfg.downcast_ref::<PerRouteConfig>() {
+            Some(prc) => self.per_route_config = Some(Arc::clone(&prc.config)),
+            None => envoy_log_error!("basic-auth: per-route config has unexpected type"),
+        }
+    }
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilter<EHF> for Filter {
+    fn on_request_headers(
+        &mut self,
+        envoy_filter: &mut EHF,
+        _end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_request_headers_status {
+        self.set_per_route_config(envoy_filter);
+        let Some(config) = self.per_route_config.clone() else {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue;
+        };
+        let Some((username, password)) = envoy_filter
+            .get_request_header_value("authorization")
+            .and_then(|v| String::from_utf8(v.as_slice().to_vec()).ok())
+            .and_then(|v| htpasswd::decode_authorization(&v))
+        else {
+            return deny(
+                envoy_filter,
+                DENIED_BY_MISSING,
+                MISSING_CREDENTIALS_BODY,
+                self.denied_counter,
+            );
+        };
+        let Some(user) = config.verify(&username, &password) else {
+            return deny(
+                envoy_filter,
+                DENIED_BY_INVALID,
+                INVALID_CREDENTIALS_BODY,
+                self.denied_counter,
+            );
+        };
+        if let Some(header) = config.forward_username_header.as_deref() {
+            envoy_filter.set_request_header(header, user.username.as_bytes());
+        }
+        envoy_filter.set_dynamic_metadata_string(
+            METADATA_NAMESPACE,
+            METADATA_USERNAME_KEY,
+            &user.username,
+        );
+        if let Some(realm) = user.realm.as_deref() {
+            if let Some(header) = config.forward_realm_header.as_deref() {
+                envoy_filter.set_request_header(header, realm.as_bytes());
+            }
+            envoy_filter.set_dynamic_metadata_string(METADATA_NAMESPACE, METADATA_REALM_KEY, realm);
+        }
+        abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue
+    }
+}
+
+fn deny<EHF: EnvoyHttpFilter>(
+    envoy_filter: &mut EHF,
+    denied_by: &str,
+    body: &[u8],
+    denied_counter: EnvoyCounterId,
+) -> abi::envoy_dynamic_module_type_on_http_filter_request_headers_status {
+    if let Err(e) = envoy_filter.increment_counter(denied_counter, 1) {
+        envoy_log_warn!("basic-auth: failed to increment denied counter: {e:?}");
+    }
+    envoy_filter.set_dynamic_metadata_string(METADATA_NAMESPACE, METADATA_DENIED_BY_KEY, denied_by);
+    envoy_filter.send_response(
+        401,
+        &[],
+        Some(body),
+        Some("basic-auth: user authentication failed"),
+    );
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::StopIteration
+}
+
+#[cfg(test)]
+mod test;
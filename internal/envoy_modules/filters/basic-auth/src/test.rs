@@ -0,0 +1,148 @@
+#![allow(clippy::unwrap_used, clippy::expect_used)]
+
+use super::*;
+use envoy_proxy_dynamic_modules_rust_sdk::{EnvoyBuffer, MockEnvoyHttpFilter};
+use std::any::Any;
+use std::sync::Arc;
+
+// bcrypt hash of "alicepass" and {SHA} hash of "davepass".
+const ALICE_BCRYPT: &str = "$2a$04$URH0IYxVk4WiQOCGD4oqj.YfDJBYWG249ysWHrY.zRTWd8oRLmMUS";
+const DAVE_SHA: &str = "{SHA}5LbiinPZLaG3OODOB1LgUzKSGfo=";
+
+// base64("alice:alicepass"), base64("alice:wrong") and base64("dave:davepass").
+const ALICE_AUTHORIZATION: &str = "Basic YWxpY2U6YWxpY2VwYXNz";
+const ALICE_WRONG_AUTHORIZATION: &str = "Basic YWxpY2U6d3Jvbmc=";
+const DAVE_AUTHORIZATION: &str = "Basic ZGF2ZTpkYXZlcGFzcw==";
+
+// Test-only helper: build a FilterConfig without going through an
+// EnvoyHttpFilterConfig, see the http-acl filter tests.
+//
+// SAFETY: EnvoyCounterId is a public tuple struct wrapping a single `usize`,
+// and the id never reaches Envoy as every `increment_counter` call is
+// intercepted by the mock.
+fn make_filter_config() -> FilterConfig {
+    FilterConfig {
+        denied_counter: unsafe { std::mem::zeroed() },
+    }
+}
+
+fn per_route_config(extra: &str) -> Arc<dyn Any> {
+    let cfg = format!(
+        r#"{{{extra}"users":[{{"username":"alice","hash":"{ALICE_BCRYPT}","realm":"eng"}},{{"username":"dave","hash":"{DAVE_SHA}"}}]}}"#
+    );
+    Arc::new(PerRouteConfig::new(&cfg).expect("valid per-route config"))
+}
+
+fn mock_with_authorization(
+    prc: Option<Arc<dyn Any>>,
+    authorization: Option<&'static str>,
+) -> MockEnvoyHttpFilter {
+    let mut mock = MockEnvoyHttpFilter::default();
+    mock.expect_get_most_specific_route_config()
+        .returning_st(move || prc.clone());
+    mock.expect_get_request_header_value()
+        .returning(move |name| match (name, authorization) {
+            ("authorization", Some(v)) => Some(EnvoyBuffer::new(v.as_bytes())),
+            _ => None,
+        });
+    mock
+}
+
+fn expect_metadata(mock: &mut MockEnvoyHttpFilter, key: &'static str, value: &'static str) {
+    mock.expect_set_dynamic_metadata_string()
+        .withf(move |ns, k, v| ns == "dev.kgateway.http.basic_auth" && k == key && v == value)
+        .times(1)
+        .returning(|_, _, _| ());
+}
+
+fn expect_denied(mock: &mut MockEnvoyHttpFilter, denied_by: &'static str, body: &'static [u8]) {
+    expect_metadata(mock, "denied-by", denied_by);
+    mock.expect_increment_counter()
+        .withf(|_, value| *value == 1)
+        .times(1)
+        .returning(|_, _| Ok(()));
+    mock.expect_send_response()
+        .times(1)
+        .returning(move |status, headers, b, _| {
+            assert_eq!(status, 401);
+            assert!(headers.is_empty());
+            assert_eq!(b, Some(body));
+        });
+}
+
+fn run(mock: &mut MockEnvoyHttpFilter) -> u32 {
+    let mut filter = make_filter_config().new_http_filter(mock);
+    filter.on_request_headers(mock, true) as u32
+}
+
+fn continue_status() -> u32 {
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue as u32
+}
+
+fn stop_status() -> u32 {
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::StopIteration as u32
+}
+
+#[test]
+fn no_per_route_config_continues() {
+    let mut mock = mock_with_authorization(None, None);
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn valid_bcrypt_password_emits_metadata() {
+    let prc = per_route_config("");
+    let mut mock = mock_with_authorization(Some(prc), Some(ALICE_AUTHORIZATION));
+    expect_metadata(&mut mock, "username", "alice");
+    expect_metadata(&mut mock, "realm", "eng");
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn username_and_realm_are_forwarded() {
+    let prc =
+        per_route_config(r#""forwardUsernameHeader":"x-user","forwardRealmHeader":"x-realm","#);
+    let mut mock = mock_with_authorization(Some(prc), Some(ALICE_AUTHORIZATION));
+    mock.expect_set_request_header()
+        .withf(|name, value| name == "x-user" && value == b"alice")
+        .times(1)
+        .returning(|_, _| true);
+    mock.expect_set_request_header()
+        .withf(|name, value| name == "x-realm" && value == b"eng")
+        .times(1)
+        .returning(|_, _| true);
+    mock.expect_set_dynamic_metadata_string()
+        .returning(|_, _, _| ());
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn user_without_realm_is_not_forwarded_a_realm() {
+    let prc = per_route_config(r#""forwardRealmHeader":"x-realm","#);
+    let mut mock = mock_with_authorization(Some(prc), Some(DAVE_AUTHORIZATION));
+    mock.expect_set_request_header().times(0);
+    expect_metadata(&mut mock, "username", "dave");
+    assert_eq!(run(&mut mock), continue_status());
+}
+
+#[test]
+fn missing_credentials_are_denied() {
+    let prc = per_route_config("");
+    let mut mock = mock_with_authorization(Some(prc), None);
+    expect_denied(&mut mock, "missing", MISSING_CREDENTIALS_BODY);
+    assert_eq!(run(&mut mock), stop_status());
+}
+
+#[test]
+fn wrong_password_is_denied() {
+    let prc = per_route_config("");
+    let mut mock = mock_with_authorization(Some(prc), Some(ALICE_WRONG_AUTHORIZATION));
+    expect_denied(&mut mock, "invalid", INVALID_CREDENTIALS_BODY);
+    assert_eq!(run(&mut mock), stop_status());
+}
+
+#[test]
+fn bad_per_route_config_is_rejected() {
+    assert!(PerRouteConfig::new("{").is_none());
+    assert!(PerRouteConfig::new(r#"{"users":[{"username":"u","hash":"plain"}]}"#).is_none());
+}
@@ -1,6 +1,6 @@
 #![deny(clippy::unwrap_used, clippy::expect_used)]
 
-use acl::{Acl, Action, DenyResponse};
+use acl::{Acl, Action, DenyResponse, Geo};
 use envoy_proxy_dynamic_modules_rust_sdk::*;
 use std::net::{IpAddr, SocketAddr};
 use std::str::FromStr;
@@ -126,8 +126,20 @@ impl<EHF: EnvoyHttpFilter> HttpFilter<EHF> for Filter {
             }
         };
         envoy_log_trace!("http-acl: ip: {ip}");
+        let country = acl
+            .country_header()
+            .and_then(|h| request_header(envoy_filter, h));
+        let asn = acl
+            .asn_header()
+            .and_then(|h| request_header(envoy_filter, h))
+            .and_then(|v| v.trim().parse::<u32>().ok());
+        let geo = Geo {
+            country: country.as_deref(),
+            asn,
+        };
+        envoy_log_trace!("http-acl: geo: {geo:?}");
         let start = std::time::Instant::now();
-        let decision = acl.evaluate(ip);
+        let decision = acl.evaluate_with_geo(ip, geo);
         envoy_log_trace!("http-acl: acl.evaluate() took {:?}", start.elapsed());
         match decision.action {
             Action::Allow => {
@@ -164,6 +176,11 @@ fn extract_source_ip<EHF: EnvoyHttpFilter>(envoy_filter: &mut EHF) -> Option<IpA
     IpAddr::from_str(s).ok()
 }
 
+fn request_header<EHF: EnvoyHttpFilter>(envoy_filter: &mut EHF, name: &str) -> Option<String> {
+    let value = envoy_filter.get_request_header_value(name)?;
+    String::from_utf8(value.as_slice().to_vec()).ok()
+}
+
 fn deny<EHF: EnvoyHttpFilter>(
     envoy_filter: &mut EHF,
     resp: &DenyResponse,
@@ -307,3 +307,64 @@ fn per_route_config_wrong_type_logs_and_falls_back() {
 
     assert_eq!(run(cfg, &mut mock), continue_status());
 }
+
+// ---- geo ----
+
+fn mock_with_geo_headers(
+    addr: &'static str,
+    headers: &'static [(&'static str, &'static str)],
+) -> MockEnvoyHttpFilter {
+    let mut mock = mock_with_source(Some(addr));
+    mock.expect_get_request_header_value()
+        .returning(move |name| {
+            headers
+                .iter()
+                .find(|(k, _)| *k == name)
+                .map(|(_, v)| EnvoyBuffer::new(v.as_bytes()))
+        });
+    mock
+}
+
+const GEO_CONFIG: &str = r#"{"defaultAction":"allow",
+    "geoHeaders":{"country":"x-geo-country","asn":"x-geo-asn"},
+    "rules":[
+        {"name":"block-countries","countries":["KP"],"action":"deny"},
+        {"name":"block-asn","asns":[64512],"action":"deny"}
+    ]}"#;
+
+#[test]
+fn geo_country_header_denies() {
+    let cfg = make_filter_config(GEO_CONFIG);
+    let mut mock = mock_with_geo_headers("1.2.3.4", &[("x-geo-country", "KP")]);
+    expect_metadata(&mut mock, "block-countries");
+    expect_counter_incremented_once(&mut mock);
+    mock.expect_send_response()
+        .times(1)
+        .returning(|_, _, _, _| ());
+
+    assert_eq!(run(cfg, &mut mock), stop_status());
+}
+
+#[test]
+fn geo_asn_header_denies() {
+    let cfg = make_filter_config(GEO_CONFIG);
+    let mut mock = mock_with_geo_headers(
+        "1.2.3.4",
+        &[("x-geo-country", "US"), ("x-geo-asn", "64512")],
+    );
+    expect_metadata(&mut mock, "block-asn");
+    expect_counter_incremented_once(&mut mock);
+    mock.expect_send_response()
+        .times(1)
+        .returning(|_, _, _, _| ());
+
+    assert_eq!(run(cfg, &mut mock), stop_status());
+}
+
+#[test]
+fn geo_missing_or_invalid_headers_fall_back_to_default() {
+    let cfg = make_filter_config(GEO_CONFIG);
+    let mut mock = mock_with_geo_headers("1.2.3.4", &[("x-geo-asn", "not-a-number")]);
+
+    assert_eq!(run(cfg, &mut mock), continue_status());
+}
@@ -0,0 +1,338 @@
+#![deny(clippy::unwrap_used, clippy::expect_used)]
+
+use envoy_proxy_dynamic_modules_rust_sdk::*;
+use serde::Deserialize;
+use serde_json::Value;
+use std::sync::Arc;
+
+const METADATA_NAMESPACE: &str = "dev.kgateway.http.request_limits";
+const METADATA_REJECTED_BY_KEY: &str = "rejected-by";
+const REJECTED_COUNTER_NAME: &str = "dev.kgateway.http.request_limits.rejected";
+const REJECTED_COUNTER_LABELS: &[&str] = &["route", "limit"];
+
+// The format string operators the rejection body supports, see `Rejection::render`.
+const RESPONSE_CODE_OPERATOR: &str = "%RESPONSE_CODE%";
+const LOCAL_REPLY_BODY_OPERATOR: &str = "%LOCAL_REPLY_BODY%";
+
+/// The limit a request exceeded.
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+pub enum Limit {
+    BodySize,
+    UrlLength,
+    HeaderValueSize,
+}
+
+impl Limit {
+    /// The value of the `limit` counter label and of the `rejected-by` dynamic metadata.
+    pub fn name(self) -> &'static str {
+        match self {
+            Limit::BodySize => "body_size",
+            Limit::UrlLength => "url_length",
+            Limit::HeaderValueSize => "header_value_size",
+        }
+    }
+
+    /// The status code sent when the rejection does not set one.
+    pub fn default_status_code(self) -> u32 {
+        match self {
+            Limit::BodySize => 413,
+            Limit::UrlLength => 414,
+            Limit::HeaderValueSize => 431,
+        }
+    }
+
+    /// The message sent as the response body when the rejection does not set a body, and
+    /// substituted for `%LOCAL_REPLY_BODY%` when it does.
+    pub fn message(self) -> &'static str {
+        match self {
+            Limit::BodySize => "request body too large",
+            Limit::UrlLength => "request URL too long",
+            Limit::HeaderValueSize => "request header value too large",
+        }
+    }
+}
+
+#[derive(Debug, Deserialize)]
+#[serde(rename_all = "camelCase", deny_unknown_fields)]
+pub struct LimitsConfig {
+    /// The label of the route in the counters.
+    #[serde(default)]
+    pub route: String,
+    pub max_body_bytes: Option<u64>,
+    pub max_url_length: Option<usize>,
+    pub max_header_value_bytes: Option<usize>,
+    #[serde(default)]
+    pub rejection: Rejection,
+}
+
+impl LimitsConfig {
+    pub fn from_json(cfg: &str) -> Result<Self, serde_json::Error> {
+        serde_json::from_str(cfg)
+    }
+
+    /// Checks the limits that only depend on the request headers. The body size is checked against
+    /// the content-length header here, and against the received body chunks in `on_request_body`
+    /// for requests without one.
+    pub fn check_headers<'a>(
+        &self,
+        headers: impl IntoIterator<Item = (&'a [u8], &'a [u8])>,
+    ) -> Option<Limit> {
+        for (name, value) in headers {
+            if name == b":path" {
+                if self.max_url_length.is_some_and(|max| value.len() > max) {
+                    return Some(Limit::UrlLength);
+                }
+                continue;
+            }
+            if name.starts_with(b":") {
+                continue;
+            }
+            if self
+                .max_header_value_bytes
+                .is_some_and(|max| value.len() > max)
+            {
+                return Some(Limit::HeaderValueSize);
+            }
+            if name.eq_ignore_ascii_case(b"content-length")
+                && self.exceeds_body_size(content_length(value))
+            {
+                return Some(Limit::BodySize);
+            }
+        }
+        None
+    }
+
+    pub fn exceeds_body_size(&self, size: u64) -> bool {
+        self.max_body_bytes.is_some_and(|max| size > max)
+    }
+}
+
+fn content_length(value: &[u8]) -> u64 {
+    std::str::from_utf8(value)
+        .ok()
+        .and_then(|v| v.trim().parse().ok())
+        .unwrap_or(0)
+}
+
+/// The response sent when a request exceeds a limit.
+#[derive(Debug, Default, Deserialize)]
+#[serde(rename_all = "camelCase", deny_unknown_fields)]
+pub struct Rejection {
+    pub status_code: Option<u16>,
+    pub content_type: Option<String>,
+    /// A text body, mutually exclusive with `json`.
+    pub text: Option<String>,
+    /// A JSON body, mutually exclusive with `text`.
+    pub json: Option<Value>,
+}
+
+impl Rejection {
+    pub fn status_code(&self, limit: Limit) -> u32 {
+        self.status_code
+            .map(u32::from)
+            .unwrap_or_else(|| limit.default_status_code())
+    }
+
+    /// Renders the response body and its content type. `%RESPONSE_CODE%` and `%LOCAL_REPLY_BODY%`
+    /// are substituted like in Envoy format strings: in a JSON body, a string value that is exactly
+    /// `%RESPONSE_CODE%` is rendered as a number.
+    pub fn render(&self, limit: Limit) -> (Vec<u8>, &str) {
+        let status = self.status_code(limit).to_string();
+        let substitute = |s: &str| {
+            s.replace(RESPONSE_CODE_OPERATOR, &status)
+                .replace(LOCAL_REPLY_BODY_OPERATOR, limit.message())
+        };
+        if let Some(json) = &self.json {
+            let body = render_json(json, &status, &substitute)
+                .to_string()
+                .into_bytes();
+            return (
+                body,
+                self.content_type.as_deref().unwrap_or("application/json"),
+            );
+        }
+        let body = match &self.text {
+            Some(text) => substitute(text),
+            None => limit.message().to_string(),
+        };
+        (
+            body.into_bytes(),
+            self.content_type.as_deref().unwrap_or("text/plain"),
+        )
+    }
+}
+
+fn render_json(value: &Value, status: &str, substitute: &impl Fn(&str) -> String) -> Value {
+    match value {
+        Value::String(s) if s == RESPONSE_CODE_OPERATOR => status
+            .parse::<u64>()
+            .map(Value::from)
+            .unwrap_or_else(|_| Value::String(status.to_string())),
+        Value::String(s) => Value::String(substitute(s)),
+        Value::Array(items) => Value::Array(
+            items
+                .iter()
+                .map(|v| render_json(v, status, substitute))
+                .collect(),
+        ),
+        Value::Object(fields) => Value::Object(
+            fields
+                .iter()
+                .map(|(k, v)| (k.clone(), render_json(v, status, substitute)))
+                .collect(),
+        ),
+        other => other.clone(),
+    }
+}
+
+/// The filter-level config carries no limits: requests are only checked on routes that have a
+/// per-route config.
+pub struct FilterConfig {
+    rejected_counter: EnvoyCounterVecId,
+}
+
+impl FilterConfig {
+    pub fn new<EC: EnvoyHttpFilterConfig>(
+        envoy_filter_config: &mut EC,
+        _cfg: &str,
+    ) -> Option<Self> {
+        let rejected_counter = match envoy_filter_config
+            .define_counter_vec(REJECTED_COUNTER_NAME, REJECTED_COUNTER_LABELS)
+        {
+            Ok(id) => id,
+            Err(e) => {
+                envoy_log_error!(
+                    "request-limits: failed to define counter {REJECTED_COUNTER_NAME}: {e:?}"
+                );
+                return None;
+            }
+        };
+        Some(Self { rejected_counter })
+    }
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilterConfig<EHF> for FilterConfig {
+    fn new_http_filter(&self, _envoy: &mut EHF) -> Box<dyn HttpFilter<EHF>> {
+        Box::new(Filter {
+            per_route_config: None,
+            rejected_counter: self.rejected_counter,
+            body_bytes: 0,
+        })
+    }
+}
+
+pub struct PerRouteConfig {
+    pub config: Arc<LimitsConfig>,
+}
+
+impl PerRouteConfig {
+    pub fn new(cfg: &str) -> Option<Self> {
+        match LimitsConfig::from_json(cfg) {
+            Ok(c) => Some(Self {
+                config: Arc::new(c),
+            }),
+            Err(e) => {
+                envoy_log_error!("request-limits: bad per-route config: {e}");
+                None
+            }
+        }
+    }
+}
+
+struct Filter {
+    per_route_config: Option<Arc<LimitsConfig>>,
+    rejected_counter: EnvoyCounterVecId,
+    /// The number of request body bytes received so far.
+    body_bytes: u64,
+}
+
+impl Filter {
+    fn set_per_route_config<EHF: EnvoyHttpFilter>(&mut self, envoy_filter: &mut EHF) {
+        if self.per_route_config.is_some() {
+            return;
+        }
+        let Some(cfg) = envoy_filter.get_most_specific_route_config() else {
+            return;
+        };
+        match cfg.downcast_ref::<PerRouteConfig>() {
+            Some(prc) => self.per_route_config = Some(Arc::clone(&prc.config)),
+            None => envoy_log_error!("request-limits: per-route config has unexpected type"),
+        }
+    }
+
+    fn reject<EHF: EnvoyHttpFilter>(
+        &self,
+        envoy_filter: &mut EHF,
+        config: &LimitsConfig,
+        limit: Limit,
+    ) {
+        if let Err(e) = envoy_filter.increment_counter_vec(
+            self.rejected_counter,
+            &[config.route.as_str(), limit.name()],
+            1,
+        ) {
+            envoy_log_warn!("request-limits: failed to increment rejected counter: {e:?}");
+        }
+        envoy_filter.set_dynamic_metadata_string(
+            METADATA_NAMESPACE,
+            METADATA_REJECTED_BY_KEY,
+            limit.name(),
+        );
+        let (body, content_type) = config.rejection.render(limit);
+        envoy_filter.send_response(
+            config.rejection.status_code(limit),
+            &[("content-type", content_type.as_bytes())],
+            Some(&body),
+            Some("request-limits: request exceeds limit"),
+        );
+    }
+}
+
+impl<EHF: EnvoyHttpFilter> HttpFilter<EHF> for Filter {
+    fn on_request_headers(
+        &mut self,
+        envoy_filter: &mut EHF,
+        _end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_request_headers_status {
+        self.set_per_route_config(envoy_filter);
+        let Some(config) = self.per_route_config.clone() else {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue;
+        };
+        let headers = envoy_filter.get_request_headers();
+        let exceeded =
+            config.check_headers(headers.iter().map(|(k, v)| (k.as_slice(), v.as_slice())));
+        if let Some(limit) = exceeded {
+            self.reject(envoy_filter, &config, limit);
+            return abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::StopIteration;
+        }
+        abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue
+    }
+
+    fn on_request_body(
+        &mut self,
+        envoy_filter: &mut EHF,
+        _end_of_stream: bool,
+    ) -> abi::envoy_dynamic_module_type_on_http_filter_request_body_status {
+        let Some(config) = self.per_route_config.clone() else {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_body_status::Continue;
+        };
+        if config.max_body_bytes.is_none() {
+            return abi::envoy_dynamic_module_type_on_http_filter_request_body_status::Continue;
+        }
+        // The body is streamed: only the size of each chunk is counted, and the chunk is passed
+        // on without being buffered.
+        let chunk: u64 = envoy_filter
+            .get_received_request_body()
+            .map(|buffers| buffers.iter().map(|b| b.as_slice().len() as u64).sum())
+            .unwrap_or(0);
+        self.body_bytes = self.body_bytes.saturating_add(chunk);
+        if config.exceeds_body_size(self.body_bytes) {
+            self.reject(envoy_filter, &config, Limit::BodySize);
+            return abi::envoy_dynamic_module_type_on_http_filter_request_body_status::StopIterationNoBuffer;
+        }
+        abi::envoy_dynamic_module_type_on_http_filter_request_body_status::Continue
+    }
+}
+
+#[cfg(test)]
+mod test;
@@ -0,0 +1,245 @@
+#![allow(clippy::unwrap_used, clippy::expect_used)]
+
+use super::*;
+use envoy_proxy_dynamic_modules_rust_sdk::{EnvoyBuffer, EnvoyMutBuffer, MockEnvoyHttpFilter};
+use std::any::Any;
+use std::sync::Arc;
+
+// Test-only helper: build a FilterConfig without going through an
+// EnvoyHttpFilterConfig, see the http-acl filter tests.
+//
+// SAFETY: EnvoyCounterVecId is a public tuple struct wrapping a single `usize`,
+// and the id never reaches Envoy as every `increment_counter_vec` call is
+// intercepted by the mock.
+fn make_filter_config() -> FilterConfig {
+    FilterConfig {
+        rejected_counter: unsafe { std::mem::zeroed() },
+    }
+}
+
+fn per_route_config(cfg: &str) -> Arc<dyn Any> {
+    Arc::new(PerRouteConfig::new(cfg).expect("valid per-route config"))
+}
+
+fn mock_with_headers(
+    prc: Option<Arc<dyn Any>>,
+    headers: &'static [(&'static str, &'static str)],
+) -> MockEnvoyHttpFilter {
+    let mut mock = MockEnvoyHttpFilter::default();
+    mock.expect_get_most_specific_route_config()
+        .returning_st(move || prc.clone());
+    mock.expect_get_request_headers().returning(move || {
+        headers
+            .iter()
+            .map(|(k, v)| {
+                (
+                    EnvoyBuffer::new(k.as_bytes()),
+                    EnvoyBuffer::new(v.as_bytes()),
+                )
+            })
+            .collect()
+    });
+    mock
+}
+
+fn expect_rejected(
+    mock: &mut MockEnvoyHttpFilter,
+    limit: &'static str,
+    status: u32,
+    content_type: &'static str,
+    body: &'static str,
+) {
+    mock.expect_set_dynamic_metadata_string()
+        .withf(move |ns, k, v| {
+            ns == "dev.kgateway.http.request_limits" && k == "rejected-by" && v == limit
+        })
+        .times(1)
+        .returning(|_, _, _| ());
+    mock.expect_increment_counter_vec()
+        .withf(move |_, labels, value| labels == ["default.api", limit] && *value == 1)
+        .times(1)
+        .returning(|_, _, _| Ok(()));
+    mock.expect_send_response()
+        .times(1)
+        .returning(move |s, headers, b, _| {
+            assert_eq!(s, status);
+            assert_eq!(headers, [("content-type", content_type.as_bytes())]);
+            assert_eq!(b, Some(body.as_bytes()));
+        });
+}
+
+fn continue_status() -> u32 {
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::Continue as u32
+}
+
+fn stop_status() -> u32 {
+    abi::envoy_dynamic_module_type_on_http_filter_request_headers_status::StopIteration as u32
+}
+
+const LIMITS: &str =
+    r#"{"route":"default.api","maxBodyBytes":10,"maxUrlLength":16,"maxHeaderValueBytes":8}"#;
+
+#[test]
+fn no_per_route_config_continues() {
+    let mut mock = mock_with_headers(None, &[(":path", "/a/very/long/path/indeed")]);
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, true) as u32,
+        continue_status()
+    );
+}
+
+#[test]
+fn request_within_limits_continues() {
+    let mut mock = mock_with_headers(
+        Some(per_route_config(LIMITS)),
+        &[
+            (":path", "/api"),
+            ("x-short", "12345678"),
+            ("content-length", "10"),
+        ],
+    );
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, false) as u32,
+        continue_status()
+    );
+}
+
+#[test]
+fn long_url_is_rejected() {
+    let mut mock = mock_with_headers(
+        Some(per_route_config(LIMITS)),
+        &[(":path", "/api?query=too-long")],
+    );
+    expect_rejected(
+        &mut mock,
+        "url_length",
+        414,
+        "text/plain",
+        "request URL too long",
+    );
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, true) as u32,
+        stop_status()
+    );
+}
+
+#[test]
+fn large_header_value_is_rejected() {
+    let mut mock = mock_with_headers(
+        Some(per_route_config(LIMITS)),
+        &[(":path", "/api"), ("x-long", "123456789")],
+    );
+    expect_rejected(
+        &mut mock,
+        "header_value_size",
+        431,
+        "text/plain",
+        "request header value too large",
+    );
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, true) as u32,
+        stop_status()
+    );
+}
+
+#[test]
+fn large_content_length_is_rejected() {
+    let mut mock = mock_with_headers(
+        Some(per_route_config(LIMITS)),
+        &[(":path", "/api"), ("Content-Length", "11")],
+    );
+    expect_rejected(
+        &mut mock,
+        "body_size",
+        413,
+        "text/plain",
+        "request body too large",
+    );
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, false) as u32,
+        stop_status()
+    );
+}
+
+#[test]
+fn streamed_body_is_rejected_once_the_limit_is_exceeded() {
+    static mut CHUNK: [u8; 6] = *b"abcdef";
+    let mut mock = mock_with_headers(Some(per_route_config(LIMITS)), &[(":path", "/api")]);
+    mock.expect_get_received_request_body()
+        .returning(|| Some(unsafe { vec![EnvoyMutBuffer::new(&mut CHUNK[..])] }));
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, false) as u32,
+        continue_status()
+    );
+
+    // The first chunk is within the limit and is not buffered.
+    assert_eq!(
+        filter.on_request_body(&mut mock, false),
+        abi::envoy_dynamic_module_type_on_http_filter_request_body_status::Continue
+    );
+
+    expect_rejected(
+        &mut mock,
+        "body_size",
+        413,
+        "text/plain",
+        "request body too large",
+    );
+    assert_eq!(
+        filter.on_request_body(&mut mock, false),
+        abi::envoy_dynamic_module_type_on_http_filter_request_body_status::StopIterationNoBuffer
+    );
+}
+
+#[test]
+fn text_rejection_is_rendered() {
+    let prc = per_route_config(
+        r#"{"route":"default.api","maxUrlLength":4,"rejection":{"statusCode":400,"text":"%RESPONSE_CODE%: %LOCAL_REPLY_BODY%"}}"#,
+    );
+    let mut mock = mock_with_headers(Some(prc), &[(":path", "/api/v1")]);
+    expect_rejected(
+        &mut mock,
+        "url_length",
+        400,
+        "text/plain",
+        "400: request URL too long",
+    );
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, true) as u32,
+        stop_status()
+    );
+}
+
+#[test]
+fn json_rejection_is_rendered() {
+    let prc = per_route_config(
+        r#"{"route":"default.api","maxUrlLength":4,"rejection":{"contentType":"application/problem+json","json":{"status":"%RESPONSE_CODE%","detail":"%LOCAL_REPLY_BODY%","tags":["limits"]}}}"#,
+    );
+    let mut mock = mock_with_headers(Some(prc), &[(":path", "/api/v1")]);
+    expect_rejected(
+        &mut mock,
+        "url_length",
+        414,
+        "application/problem+json",
+        r#"{"detail":"request URL too long","status":414,"tags":["limits"]}"#,
+    );
+    let mut filter = make_filter_config().new_http_filter(&mut mock);
+    assert_eq!(
+        filter.on_request_headers(&mut mock, true) as u32,
+        stop_status()
+    );
+}
+
+#[test]
+fn bad_per_route_config_is_rejected() {
+    assert!(PerRouteConfig::new("{").is_none());
+    assert!(PerRouteConfig::new(r#"{"maxBodyBytes":-1}"#).is_none());
+    assert!(PerRouteConfig::new(r#"{"unknown":true}"#).is_none());
+}
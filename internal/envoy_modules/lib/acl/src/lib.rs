@@ -3,6 +3,7 @@
 use ip_network::IpNetwork;
 use ip_network_table_deps_treebitmap::IpLookupTable;
 use serde::Deserialize;
+use std::collections::HashMap;
 use std::net::{IpAddr, Ipv4Addr, Ipv6Addr};
 use std::str::FromStr;
 
@@ -17,10 +18,27 @@ pub enum Action {
 pub struct Rule {
     #[serde(default)]
     pub name: Option<String>,
+    #[serde(default)]
     pub cidrs: Vec<String>,
+    /// ISO 3166-1 alpha-2 country codes, matched against the country header.
+    #[serde(default)]
+    pub countries: Vec<String>,
+    /// Autonomous system numbers, matched against the ASN header.
+    #[serde(default)]
+    pub asns: Vec<u32>,
     pub action: Action,
 }
 
+/// Names of the request headers carrying the Geo-IP lookup results for the
+/// client, as added by Envoy's geoip filter earlier in the chain.
+#[derive(Debug, Clone, Default, Deserialize)]
+pub struct GeoHeaders {
+    #[serde(default)]
+    pub country: Option<String>,
+    #[serde(default)]
+    pub asn: Option<String>,
+}
+
 #[derive(Debug, Clone, Deserialize)]
 pub struct Header {
     pub name: String,
@@ -65,6 +83,8 @@ pub struct AclConfig {
     pub rules: Vec<Rule>,
     #[serde(default)]
     pub deny_response: DenyResponse,
+    #[serde(default)]
+    pub geo_headers: GeoHeaders,
 }
 
 #[derive(thiserror::Error, Debug)]
@@ -73,6 +93,8 @@ pub enum AclError {
     Json(#[from] serde_json::Error),
     #[error("invalid CIDR `{0}`")]
     InvalidCidr(String),
+    #[error("rules match on {0} but no {0} header is configured")]
+    MissingGeoHeader(&'static str),
 }
 
 #[derive(Debug, Clone)]
@@ -84,8 +106,18 @@ struct RuleEntry {
 pub struct Acl {
     default_action: Action,
     deny_response: DenyResponse,
+    geo_headers: GeoHeaders,
     v4: IpLookupTable<Ipv4Addr, RuleEntry>,
     v6: IpLookupTable<Ipv6Addr, RuleEntry>,
+    countries: HashMap<String, RuleEntry>,
+    asns: HashMap<u32, RuleEntry>,
+}
+
+/// Geo-IP attributes of the client, read from the configured geo headers.
+#[derive(Debug, Clone, Copy, Default, PartialEq, Eq)]
+pub struct Geo<'a> {
+    pub country: Option<&'a str>,
+    pub asn: Option<u32>,
 }
 
 /// Result of evaluating a single request against the ACL.
@@ -109,6 +141,8 @@ impl Acl {
     pub fn from_config(cfg: AclConfig) -> Result<Self, AclError> {
         let mut v4: IpLookupTable<Ipv4Addr, RuleEntry> = IpLookupTable::new();
         let mut v6: IpLookupTable<Ipv6Addr, RuleEntry> = IpLookupTable::new();
+        let mut countries = HashMap::new();
+        let mut asns = HashMap::new();
         for rule in cfg.rules {
             let entry = RuleEntry {
                 action: rule.action,
@@ -124,22 +158,67 @@ impl Acl {
                     }
                 }
             }
+            for country in &rule.countries {
+                countries.insert(country.to_ascii_uppercase(), entry.clone());
+            }
+            for asn in &rule.asns {
+                asns.insert(*asn, entry.clone());
+            }
+        }
+        if !countries.is_empty() && cfg.geo_headers.country.is_none() {
+            return Err(AclError::MissingGeoHeader("country"));
+        }
+        if !asns.is_empty() && cfg.geo_headers.asn.is_none() {
+            return Err(AclError::MissingGeoHeader("asn"));
         }
         Ok(Self {
             default_action: cfg.default_action,
             deny_response: cfg.deny_response,
+            geo_headers: cfg.geo_headers,
             v4,
             v6,
+            countries,
+            asns,
         })
     }
 
+    /// Returns the name of the header to read the client country from, when
+    /// any rule matches on countries.
+    pub fn country_header(&self) -> Option<&str> {
+        if self.countries.is_empty() {
+            return None;
+        }
+        self.geo_headers.country.as_deref()
+    }
+
+    /// Returns the name of the header to read the client ASN from, when any
+    /// rule matches on ASNs.
+    pub fn asn_header(&self) -> Option<&str> {
+        if self.asns.is_empty() {
+            return None;
+        }
+        self.geo_headers.asn.as_deref()
+    }
+
     /// Returns the decision for the given address using longest-prefix match.
     /// IPv4-mapped IPv6 addresses (::ffff:a.b.c.d) are evaluated against the IPv4 trie.
     pub fn evaluate(&self, addr: IpAddr) -> Decision<'_> {
+        self.evaluate_with_geo(addr, Geo::default())
+    }
+
+    /// Returns the decision for the given address and Geo-IP attributes. A
+    /// matching CIDR takes precedence over a matching ASN, which takes
+    /// precedence over a matching country.
+    pub fn evaluate_with_geo(&self, addr: IpAddr, geo: Geo<'_>) -> Decision<'_> {
         let entry = match normalize(addr) {
             IpAddr::V4(v4) => self.v4.longest_match(v4).map(|(_, _, e)| e),
             IpAddr::V6(v6) => self.v6.longest_match(v6).map(|(_, _, e)| e),
-        };
+        }
+        .or_else(|| geo.asn.and_then(|asn| self.asns.get(&asn)))
+        .or_else(|| {
+            geo.country
+                .and_then(|c| self.countries.get(c.to_ascii_uppercase().as_str()))
+        });
         match entry {
             Some(e) => Decision {
                 action: e.action,
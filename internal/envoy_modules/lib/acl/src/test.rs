@@ -356,3 +356,107 @@ fn multiple_cidrs_in_one_rule_share_name_and_action() {
     let d = acl.evaluate(ip("10.5.5.5"));
     assert_eq!(d.matched_rule_name, Some("block-ranges"));
 }
+
+// ---- geo ----
+
+const GEO_CONFIG: &str = r#"{"defaultAction":"allow",
+    "geoHeaders":{"country":"x-geo-country","asn":"x-geo-asn"},
+    "rules":[
+        {"name":"block-countries","countries":["KP","IR"],"action":"deny"},
+        {"name":"block-asn","asns":[64512],"action":"deny"},
+        {"name":"allow-partner-asn","asns":[64513],"action":"allow"},
+        {"name":"allow-office","cidrs":["203.0.113.0/24"],"action":"allow"}
+    ]}"#;
+
+fn geo_decision<'a>(
+    acl: &'a Acl,
+    s: &str,
+    country: Option<&str>,
+    asn: Option<u32>,
+) -> Decision<'a> {
+    acl.evaluate_with_geo(ip(s), Geo { country, asn })
+}
+
+#[test]
+fn geo_country_rule_denies() {
+    let acl = build(GEO_CONFIG);
+    let d = geo_decision(&acl, "1.2.3.4", Some("KP"), None);
+    assert_eq!(d.action, Action::Deny);
+    assert_eq!(d.matched_rule_name, Some("block-countries"));
+    assert_eq!(
+        geo_decision(&acl, "1.2.3.4", Some("US"), None).action,
+        Action::Allow
+    );
+}
+
+#[test]
+fn geo_country_match_is_case_insensitive() {
+    let acl = build(GEO_CONFIG);
+    assert_eq!(
+        geo_decision(&acl, "1.2.3.4", Some("ir"), None).action,
+        Action::Deny
+    );
+}
+
+#[test]
+fn geo_asn_rule_denies() {
+    let acl = build(GEO_CONFIG);
+    let d = geo_decision(&acl, "1.2.3.4", Some("US"), Some(64512));
+    assert_eq!(d.action, Action::Deny);
+    assert_eq!(d.matched_rule_name, Some("block-asn"));
+}
+
+#[test]
+fn geo_asn_takes_precedence_over_country() {
+    let acl = build(GEO_CONFIG);
+    let d = geo_decision(&acl, "1.2.3.4", Some("KP"), Some(64513));
+    assert_eq!(d.action, Action::Allow);
+    assert_eq!(d.matched_rule_name, Some("allow-partner-asn"));
+}
+
+#[test]
+fn geo_cidr_takes_precedence_over_asn_and_country() {
+    let acl = build(GEO_CONFIG);
+    let d = geo_decision(&acl, "203.0.113.7", Some("KP"), Some(64512));
+    assert_eq!(d.action, Action::Allow);
+    assert_eq!(d.matched_rule_name, Some("allow-office"));
+}
+
+#[test]
+fn geo_unknown_location_falls_back_to_default() {
+    let acl = build(GEO_CONFIG);
+    let d = geo_decision(&acl, "1.2.3.4", None, None);
+    assert_eq!(d.action, Action::Allow);
+    assert!(d.default_applied);
+}
+
+#[test]
+fn geo_headers_only_reported_when_rules_use_them() {
+    let acl = build(GEO_CONFIG);
+    assert_eq!(acl.country_header(), Some("x-geo-country"));
+    assert_eq!(acl.asn_header(), Some("x-geo-asn"));
+
+    let acl = build(
+        r#"{"defaultAction":"allow","geoHeaders":{"country":"x-geo-country"},
+            "rules":[{"cidrs":["10.0.0.0/8"],"action":"deny"}]}"#,
+    );
+    assert_eq!(acl.country_header(), None);
+    assert_eq!(acl.asn_header(), None);
+}
+
+#[test]
+fn geo_rules_without_header_are_rejected() {
+    let err = Acl::from_json(
+        r#"{"defaultAction":"allow","rules":[{"countries":["KP"],"action":"deny"}]}"#,
+    )
+    .err()
+    .expect("missing country header must be rejected");
+    assert!(matches!(err, AclError::MissingGeoHeader("country")));
+
+    let err = Acl::from_json(
+        r#"{"defaultAction":"allow","geoHeaders":{"country":"x-geo-country"},"rules":[{"asns":[64512],"action":"deny"}]}"#,
+    )
+    .err()
+    .expect("missing asn header must be rejected");
+    assert!(matches!(err, AclError::MissingGeoHeader("asn")));
+}
@@ -0,0 +1,186 @@
+#![deny(clippy::unwrap_used, clippy::expect_used)]
+
+use serde::Deserialize;
+use sha2::{Digest, Sha256};
+use std::collections::BTreeMap;
+use subtle::ConstantTimeEq;
+
+/// A location the API key is read from. Within a key source, the header takes
+/// precedence over the query parameter, which takes precedence over the cookie.
+#[derive(Debug, Clone, Default, Deserialize)]
+pub struct KeySource {
+    #[serde(default)]
+    pub header: Option<String>,
+    #[serde(default)]
+    pub query: Option<String>,
+    #[serde(default)]
+    pub cookie: Option<String>,
+}
+
+#[derive(Debug, Clone, Deserialize)]
+#[serde(rename_all = "camelCase")]
+struct RawKey {
+    client: String,
+    sha256: String,
+    #[serde(default)]
+    salt: String,
+    #[serde(default)]
+    expires_at: Option<i64>,
+    #[serde(default)]
+    metadata: BTreeMap<String, String>,
+}
+
+#[derive(Debug, Clone, Deserialize)]
+#[serde(rename_all = "camelCase")]
+struct RawConfig {
+    #[serde(default)]
+    key_sources: Vec<KeySource>,
+    #[serde(default)]
+    hide_credentials: bool,
+    #[serde(default)]
+    client_id_header: Option<String>,
+    #[serde(default)]
+    keys: Vec<RawKey>,
+}
+
+/// A hashed API key. Only the SHA-256 digest of the salt followed by the key
+/// is known, so the key itself never has to be stored.
+#[derive(Debug, Clone)]
+pub struct Key {
+    pub client: String,
+    /// Unix timestamp, in seconds, after which the key is rejected.
+    pub expires_at: Option<i64>,
+    /// Emitted as dynamic metadata when a request is authenticated with the key.
+    pub metadata: BTreeMap<String, String>,
+    salt: Vec<u8>,
+    digest: [u8; 32],
+}
+
+impl Key {
+    fn matches(&self, candidate: &[u8]) -> bool {
+        let mut hasher = Sha256::new();
+        hasher.update(&self.salt);
+        hasher.update(candidate);
+        let digest = hasher.finalize();
+        digest.as_slice().ct_eq(&self.digest).into()
+    }
+
+    fn expired(&self, now: i64) -> bool {
+        self.expires_at.is_some_and(|expires_at| now >= expires_at)
+    }
+}
+
+#[derive(Debug, Clone)]
+pub struct ApiKeyConfig {
+    pub key_sources: Vec<KeySource>,
+    pub hide_credentials: bool,
+    pub client_id_header: Option<String>,
+    keys: Vec<Key>,
+}
+
+#[derive(thiserror::Error, Debug)]
+pub enum ApiKeyError {
+    #[error("invalid JSON: {0}")]
+    Json(#[from] serde_json::Error),
+    #[error("invalid sha256 digest for client `{0}`")]
+    InvalidDigest(String),
+}
+
+/// The outcome of checking a key presented by a client.
+#[derive(Debug)]
+pub enum Verdict<'a> {
+    Valid(&'a Key),
+    Expired(&'a Key),
+    Invalid,
+}
+
+impl ApiKeyConfig {
+    pub fn from_json(s: &str) -> Result<Self, ApiKeyError> {
+        let raw: RawConfig = serde_json::from_str(s)?;
+        let mut keys = Vec::with_capacity(raw.keys.len());
+        for k in raw.keys {
+            let mut digest = [0u8; 32];
+            hex::decode_to_slice(&k.sha256, &mut digest)
+                .map_err(|_| ApiKeyError::InvalidDigest(k.client.clone()))?;
+            keys.push(Key {
+                client: k.client,
+                expires_at: k.expires_at,
+                metadata: k.metadata,
+                salt: k.salt.into_bytes(),
+                digest,
+            });
+        }
+        let key_sources = if raw.key_sources.is_empty() {
+            vec![KeySource {
+                header: Some("api-key".to_string()),
+                ..Default::default()
+            }]
+        } else {
+            raw.key_sources
+        };
+        Ok(Self {
+            key_sources,
+            hide_credentials: raw.hide_credentials,
+            client_id_header: raw.client_id_header,
+            keys,
+        })
+    }
+
+    /// Checks `candidate` against every configured key. `now` is the current
+    /// Unix timestamp in seconds. Every digest comparison is constant time.
+    pub fn verify(&self, candidate: &[u8], now: i64) -> Verdict<'_> {
+        match self.keys.iter().find(|k| k.matches(candidate)) {
+            Some(k) if k.expired(now) => Verdict::Expired(k),
+            Some(k) => Verdict::Valid(k),
+            None => Verdict::Invalid,
+        }
+    }
+}
+
+/// Returns the value of the first query parameter called `name` in `path`.
+pub fn query_param<'a>(path: &'a str, name: &str) -> Option<&'a str> {
+    let (_, query) = path.split_once('?')?;
+    query
+        .split('&')
+        .filter_map(|pair| pair.split_once('=').or(Some((pair, ""))))
+        .find(|(k, _)| *k == name)
+        .map(|(_, v)| v)
+}
+
+/// Returns `path` without the query parameters called `name`.
+pub fn strip_query_param(path: &str, name: &str) -> String {
+    let Some((base, query)) = path.split_once('?') else {
+        return path.to_string();
+    };
+    let kept: Vec<&str> = query
+        .split('&')
+        .filter(|pair| pair.split('=').next() != Some(name))
+        .collect();
+    if kept.is_empty() {
+        base.to_string()
+    } else {
+        format!("{base}?{}", kept.join("&"))
+    }
+}
+
+/// Returns the value of the first cookie called `name` in a `cookie` header value.
+pub fn cookie<'a>(header: &'a str, name: &str) -> Option<&'a str> {
+    header
+        .split(';')
+        .filter_map(|c| c.trim().split_once('='))
+        .find(|(k, _)| *k == name)
+        .map(|(_, v)| v)
+}
+
+/// Returns the `cookie` header value without the cookies called `name`.
+pub fn strip_cookie(header: &str, name: &str) -> String {
+    header
+        .split(';')
+        .map(str::trim)
+        .filter(|c| !c.is_empty() && c.split('=').next() != Some(name))
+        .collect::<Vec<_>>()
+        .join("; ")
+}
+
+#[cfg(test)]
+mod test;
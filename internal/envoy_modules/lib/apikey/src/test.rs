@@ -0,0 +1,114 @@
+#![allow(clippy::unwrap_used, clippy::expect_used)]
+
+use super::*;
+
+// sha256("s1" + "k-123") and sha256("k-456").
+const K123_DIGEST: &str = "42403fc45854b1038cfd7743f83188ed54b9348686303984268bb68b026f6f15";
+const K456_DIGEST: &str = "efe96124b410574ffd343d0c9f342ce51d5aee47046ca355f85a50e23db3c37c";
+
+fn build() -> ApiKeyConfig {
+    ApiKeyConfig::from_json(&format!(
+        r#"{{
+            "keys": [
+                {{"client":"client1","sha256":"{K123_DIGEST}","salt":"s1","metadata":{{"tenant":"acme","plan":"gold"}}}},
+                {{"client":"client2","sha256":"{K456_DIGEST}","expiresAt":1000}}
+            ]
+        }}"#
+    ))
+    .unwrap()
+}
+
+#[test]
+fn salted_key_is_valid() {
+    let cfg = build();
+    match cfg.verify(b"k-123", 0) {
+        Verdict::Valid(k) => {
+            assert_eq!(k.client, "client1");
+            assert_eq!(k.metadata.get("tenant").map(String::as_str), Some("acme"));
+            assert_eq!(k.metadata.get("plan").map(String::as_str), Some("gold"));
+        }
+        v => panic!("unexpected verdict {v:?}"),
+    }
+}
+
+#[test]
+fn unknown_key_is_invalid() {
+    let cfg = build();
+    assert!(matches!(cfg.verify(b"k-999", 0), Verdict::Invalid));
+    // The digest is of the salted key, so the key alone does not match.
+    assert!(matches!(cfg.verify(b"s1", 0), Verdict::Invalid));
+    assert!(matches!(cfg.verify(b"", 0), Verdict::Invalid));
+}
+
+#[test]
+fn key_expires_at_timestamp() {
+    let cfg = build();
+    assert!(matches!(cfg.verify(b"k-456", 999), Verdict::Valid(k) if k.client == "client2"));
+    assert!(matches!(cfg.verify(b"k-456", 1000), Verdict::Expired(k) if k.client == "client2"));
+    assert!(matches!(cfg.verify(b"k-456", 2000), Verdict::Expired(_)));
+}
+
+#[test]
+fn defaults_to_api_key_header() {
+    let cfg = ApiKeyConfig::from_json("{}").unwrap();
+    assert_eq!(cfg.key_sources.len(), 1);
+    assert_eq!(cfg.key_sources[0].header.as_deref(), Some("api-key"));
+    assert!(!cfg.hide_credentials);
+    assert!(cfg.client_id_header.is_none());
+    assert!(matches!(cfg.verify(b"anything", 0), Verdict::Invalid));
+}
+
+#[test]
+fn parses_key_sources_and_forwarding() {
+    let cfg = ApiKeyConfig::from_json(
+        r#"{"keySources":[{"header":"x-api-key"},{"query":"key","cookie":"key"}],"hideCredentials":true,"clientIdHeader":"x-client-id"}"#,
+    )
+    .unwrap();
+    assert_eq!(cfg.key_sources.len(), 2);
+    assert_eq!(cfg.key_sources[1].query.as_deref(), Some("key"));
+    assert_eq!(cfg.key_sources[1].cookie.as_deref(), Some("key"));
+    assert!(cfg.hide_credentials);
+    assert_eq!(cfg.client_id_header.as_deref(), Some("x-client-id"));
+}
+
+#[test]
+fn rejects_invalid_digest() {
+    let err = ApiKeyConfig::from_json(r#"{"keys":[{"client":"c","sha256":"abcd"}]}"#).unwrap_err();
+    assert!(matches!(err, ApiKeyError::InvalidDigest(c) if c == "c"));
+    assert!(matches!(
+        ApiKeyConfig::from_json("{").unwrap_err(),
+        ApiKeyError::Json(_)
+    ));
+}
+
+#[test]
+fn query_param_lookup() {
+    assert_eq!(query_param("/a?key=k-123&b=2", "key"), Some("k-123"));
+    assert_eq!(query_param("/a?b=2&key=k-123", "key"), Some("k-123"));
+    assert_eq!(query_param("/a?key", "key"), Some(""));
+    assert_eq!(query_param("/a?keys=1", "key"), None);
+    assert_eq!(query_param("/a", "key"), None);
+}
+
+#[test]
+fn strips_query_param() {
+    assert_eq!(strip_query_param("/a?key=k-123&b=2", "key"), "/a?b=2");
+    assert_eq!(strip_query_param("/a?b=2&key=k-123", "key"), "/a?b=2");
+    assert_eq!(strip_query_param("/a?key=k-123", "key"), "/a");
+    assert_eq!(strip_query_param("/a?keys=1", "key"), "/a?keys=1");
+    assert_eq!(strip_query_param("/a", "key"), "/a");
+}
+
+#[test]
+fn cookie_lookup() {
+    assert_eq!(cookie("a=1; key=k-123", "key"), Some("k-123"));
+    assert_eq!(cookie("key=k-123;a=1", "key"), Some("k-123"));
+    assert_eq!(cookie("keys=1", "key"), None);
+}
+
+#[test]
+fn strips_cookie() {
+    assert_eq!(strip_cookie("a=1; key=k-123; b=2", "key"), "a=1; b=2");
+    assert_eq!(strip_cookie("key=k-123", "key"), "");
+    assert_eq!(strip_cookie("keys=1", "key"), "keys=1");
+}
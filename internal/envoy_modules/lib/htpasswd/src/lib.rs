@@ -0,0 +1,146 @@
+#![deny(clippy::unwrap_used, clippy::expect_used)]
+
+use argon2::{Argon2, PasswordHash, PasswordVerifier};
+use base64::engine::general_purpose::STANDARD;
+use base64::Engine;
+use serde::Deserialize;
+use sha1::{Digest, Sha1};
+use std::collections::HashMap;
+use subtle::ConstantTimeEq;
+
+const SHA_PREFIX: &str = "{SHA}";
+
+#[derive(Debug, Clone, Deserialize)]
+struct RawUser {
+    username: String,
+    hash: String,
+    #[serde(default)]
+    realm: Option<String>,
+}
+
+#[derive(Debug, Clone, Deserialize)]
+#[serde(rename_all = "camelCase")]
+struct RawConfig {
+    #[serde(default)]
+    forward_username_header: Option<String>,
+    #[serde(default)]
+    forward_realm_header: Option<String>,
+    #[serde(default)]
+    users: Vec<RawUser>,
+}
+
+/// A password hash in one of the supported htpasswd formats.
+#[derive(Debug, Clone)]
+enum Hash {
+    /// `{SHA}` followed by the base64 encoded SHA-1 digest of the password.
+    Sha([u8; 20]),
+    /// A `$2a$`, `$2b$` or `$2y$` bcrypt hash.
+    Bcrypt(String),
+    /// An argon2 hash in the PHC string format.
+    Argon2(String),
+}
+
+impl Hash {
+    fn parse(hash: &str) -> Option<Self> {
+        if let Some(encoded) = hash.strip_prefix(SHA_PREFIX) {
+            let decoded = STANDARD.decode(encoded).ok()?;
+            return decoded.try_into().ok().map(Hash::Sha);
+        }
+        if ["$2a$", "$2b$", "$2y$"].iter().any(|p| hash.starts_with(p)) {
+            return hash
+                .parse::<bcrypt::HashParts>()
+                .ok()
+                .map(|_| Hash::Bcrypt(hash.to_string()));
+        }
+        if hash.starts_with("$argon2") {
+            return PasswordHash::new(hash)
+                .ok()
+                .map(|_| Hash::Argon2(hash.to_string()));
+        }
+        None
+    }
+
+    fn matches(&self, password: &[u8]) -> bool {
+        match self {
+            Hash::Sha(digest) => Sha1::digest(password).as_slice().ct_eq(digest).into(),
+            Hash::Bcrypt(hash) => bcrypt::verify(password, hash).unwrap_or(false),
+            Hash::Argon2(hash) => PasswordHash::new(hash)
+                .map(|parsed| Argon2::default().verify_password(password, &parsed).is_ok())
+                .unwrap_or(false),
+        }
+    }
+}
+
+#[derive(Debug, Clone)]
+pub struct User {
+    pub username: String,
+    pub realm: Option<String>,
+    hash: Hash,
+}
+
+#[derive(Debug, Clone)]
+pub struct BasicAuthConfig {
+    pub forward_username_header: Option<String>,
+    pub forward_realm_header: Option<String>,
+    users: HashMap<String, User>,
+}
+
+#[derive(thiserror::Error, Debug)]
+pub enum BasicAuthError {
+    #[error("invalid JSON: {0}")]
+    Json(#[from] serde_json::Error),
+    #[error("unsupported password hash for user `{0}`")]
+    InvalidHash(String),
+    #[error("duplicate user `{0}`")]
+    DuplicateUser(String),
+}
+
+impl BasicAuthConfig {
+    pub fn from_json(s: &str) -> Result<Self, BasicAuthError> {
+        let raw: RawConfig = serde_json::from_str(s)?;
+        let mut users = HashMap::with_capacity(raw.users.len());
+        for u in raw.users {
+            let Some(hash) = Hash::parse(&u.hash) else {
+                return Err(BasicAuthError::InvalidHash(u.username));
+            };
+            if users.contains_key(&u.username) {
+                return Err(BasicAuthError::DuplicateUser(u.username));
+            }
+            users.insert(
+                u.username.clone(),
+                User {
+                    username: u.username,
+                    realm: u.realm,
+                    hash,
+                },
+            );
+        }
+        Ok(Self {
+            forward_username_header: raw.forward_username_header,
+            forward_realm_header: raw.forward_realm_header,
+            users,
+        })
+    }
+
+    /// Returns the user when `password` matches the password hash of `username`.
+    pub fn verify(&self, username: &str, password: &[u8]) -> Option<&User> {
+        self.users
+            .get(username)
+            .filter(|user| user.hash.matches(password))
+    }
+}
+
+/// Decodes the username and password of a `Basic` authorization header value.
+pub fn decode_authorization(header: &str) -> Option<(String, Vec<u8>)> {
+    let (scheme, credentials) = header.trim().split_once(' ')?;
+    if !scheme.eq_ignore_ascii_case("basic") {
+        return None;
+    }
+    let decoded = STANDARD.decode(credentials.trim()).ok()?;
+    let colon = decoded.iter().position(|b| *b == b':')?;
+    let username = String::from_utf8(decoded[..colon].to_vec()).ok()?;
+    Some((username, decoded[colon + 1..].to_vec()))
+}
+
+#[cfg(test)]
+mod test;
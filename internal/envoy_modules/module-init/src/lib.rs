@@ -56,8 +56,20 @@ fn new_http_filter_config_fn<EC: EnvoyHttpFilterConfig, EHF: EnvoyHttpFilter>(
             .map(|config| Box::new(config) as Box<dyn HttpFilterConfig<EHF>>),
         "http-acl" => http_acl_filter::FilterConfig::new(envoy_filter_config, filter_config)
             .map(|config| Box::new(config) as Box<dyn HttpFilterConfig<EHF>>),
+        "api-key-auth" => {
+            api_key_auth_filter::FilterConfig::new(envoy_filter_config, filter_config)
+                .map(|config| Box::new(config) as Box<dyn HttpFilterConfig<EHF>>)
+        }
+        "basic-auth" => basic_auth_filter::FilterConfig::new(envoy_filter_config, filter_config)
+            .map(|config| Box::new(config) as Box<dyn HttpFilterConfig<EHF>>),
+        "request-limits" => {
+            request_limits_filter::FilterConfig::new(envoy_filter_config, filter_config)
+                .map(|config| Box::new(config) as Box<dyn HttpFilterConfig<EHF>>)
+        }
+        "aws-lambda" => aws_lambda_filter::FilterConfig::new(filter_config)
+            .map(|config| Box::new(config) as Box<dyn HttpFilterConfig<EHF>>),
         _ => panic!(
-            "Unknown filter name: {}, known filters are: rustformation, http-acl",
+            "Unknown filter name: {}, known filters are: rustformation, http-acl, api-key-auth, basic-auth, request-limits, aws-lambda",
             filter_name
         ),
     }
@@ -83,8 +95,14 @@ fn new_http_filter_per_route_config_fn(name: &str, config: &[u8]) -> Option<Box<
             .map(|config| Box::new(config) as Box<dyn Any>),
         "http-acl" => http_acl_filter::PerRouteConfig::new(per_route_config)
             .map(|config| Box::new(config) as Box<dyn Any>),
+        "api-key-auth" => api_key_auth_filter::PerRouteConfig::new(per_route_config)
+            .map(|config| Box::new(config) as Box<dyn Any>),
+        "basic-auth" => basic_auth_filter::PerRouteConfig::new(per_route_config)
+            .map(|config| Box::new(config) as Box<dyn Any>),
+        "request-limits" => request_limits_filter::PerRouteConfig::new(per_route_config)
+            .map(|config| Box::new(config) as Box<dyn Any>),
         _ => panic!(
-            "Unknown filter name: {}, known filters are: rustformation, http-acl",
+            "Unknown filter name: {}, known filters are: rustformation, http-acl, api-key-auth, basic-auth, request-limits",
             name
         ),
     }
@@ -0,0 +1,356 @@
+// Package inmemory provides an apiclient.Client backed by in-memory object trackers
+// rather than an API server. It backs the fake client used by tests and the offline
+// translator, which must not depend on test helpers.
+package inmemory
+
+import (
+	"context"
+	"fmt"
+
+	"istio.io/istio/pkg/config/schema/gvr"
+	"istio.io/istio/pkg/kube"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	extfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/pkg/consts"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
+	"github.com/kgateway-dev/kgateway/v2/pkg/client/clientset/versioned"
+	"github.com/kgateway-dev/kgateway/v2/pkg/client/clientset/versioned/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+)
+
+var _ apiclient.Client = (*Client)(nil)
+
+// CRDs are the resources registered as installed CRDs on every client, so that the
+// informers gated on CRD presence start.
+var CRDs = []schema.GroupVersionResource{
+	// Gateway API
+	gvr.KubernetesGateway,
+	gvr.GatewayClass,
+	gvr.HTTPRoute,
+	gvr.GRPCRoute,
+	gvr.TCPRoute,
+	wellknown.TCPRouteV1GVR,
+	gvr.TLSRoute,
+	wellknown.TLSRouteV1Alpha3GVR,
+	gvr.UDPRoute,
+	gvr.ReferenceGrant,
+	gvr.BackendTLSPolicy,
+	wellknown.XListenerSetGVR,
+	wellknown.ListenerSetGVR,
+	wellknown.BackendTLSPolicyGVR,
+	wellknown.XBackendTrafficPolicyGVR,
+	// K8s API
+	gvr.Service,
+	gvr.Pod,
+	// Istio API
+	gvr.ServiceEntry,
+	gvr.WorkloadEntry,
+	gvr.AuthorizationPolicy,
+	// kgateway API
+	wellknown.BackendGVR,
+	wellknown.BackendConfigPolicyGVR,
+	wellknown.TrafficPolicyGVR,
+	wellknown.HTTPListenerPolicyGVR,
+	wellknown.ListenerPolicyGVR,
+	wellknown.DirectResponseGVR,
+	wellknown.GatewayExtensionGVR,
+	wellknown.GatewayParametersGVR,
+}
+
+func init() {
+	_ = apiextensionsv1.AddToScheme(kube.FakeIstioScheme)
+
+	// Register the legacy XListenerSet list kind so the fake dynamic client can
+	// back the delayed legacy informer used by older Gateway API versions.
+	kube.FakeIstioScheme.AddKnownTypeWithName(
+		wellknown.XListenerSetGVK.GroupVersion().WithKind("XListenerSetList"),
+		&unstructured.UnstructuredList{},
+	)
+}
+
+type Client struct {
+	kube.Client
+	kgateway versioned.Interface
+}
+
+// NewClient returns a client serving the given objects, with CRDs and extraGVRs
+// registered as installed.
+func NewClient(extraGVRs []schema.GroupVersionResource, objects ...client.Object) (*Client, error) {
+	known, kgw := filterObjects(objects...)
+	istioClient, err := newIstioClient(known...)
+	if err != nil {
+		return nil, err
+	}
+	kgwClient, err := newKgwClient(kgw...)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		Client:   istioClient,
+		kgateway: kgwClient,
+	}
+
+	allCRDs := append(append([]schema.GroupVersionResource{}, CRDs...), extraGVRs...)
+	for _, crd := range allCRDs {
+		if err := registerCRDMetadata(c.Client, crd); err != nil {
+			return nil, err
+		}
+	}
+	if err := seedCRDs(c.Client, allCRDs); err != nil {
+		return nil, err
+	}
+
+	apiclient.RegisterTypes()
+
+	return c, nil
+}
+
+func (c *Client) Kgateway() versioned.Interface {
+	return c.kgateway
+}
+
+func (c *Client) Core() kube.Client {
+	return c.Client
+}
+
+func newIstioClient(objects ...client.Object) (kube.Client, error) {
+	runtimeObjects := make([]runtime.Object, len(objects))
+	for i, obj := range objects {
+		runtimeObjects[i] = obj
+	}
+	c := kube.NewFakeClient(runtimeObjects...)
+	// Also add to the Dynamic store
+	for _, obj := range objects {
+		nn := kubeutils.NamespacedNameFrom(obj)
+		gvr, err := getGVR(obj, kube.IstioScheme)
+		if err != nil {
+			return nil, err
+		}
+		d := c.Dynamic().Resource(gvr).Namespace(obj.GetNamespace())
+		us, err := kubeutils.ToUnstructured(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert to unstructured for object %T %s: %w", obj, nn, err)
+		}
+		_, err = d.Create(context.Background(), us, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create in dynamic client for object %T %s: %w", obj, nn, err)
+		}
+	}
+
+	return c, nil
+}
+
+func newKgwClient(objects ...client.Object) (*fake.Clientset, error) {
+	// The generated clientset in this repo does not include the newer NewClientset helper
+	// because we do not generate applyconfigs for these APIs yet.
+	//nolint:staticcheck // SA1019: use the generated fake until applyconfig generation is enabled
+	f := fake.NewSimpleClientset()
+	for _, obj := range objects {
+		gvr, err := getGVR(obj, schemes.DefaultScheme())
+		if err != nil {
+			return nil, err
+		}
+		// Run Create() instead of Add(), so we can pass the GVR. Otherwise, Kubernetes guesses, and it guesses wrong for 'GatewayParameters'.
+		// DeepCopy since it will mutate the managed fields/etc
+		if err := f.Tracker().Create(gvr, obj.DeepCopyObject(), obj.(metav1.ObjectMetaAccessor).GetObjectMeta().GetNamespace()); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", kubeutils.NamespacedNameFrom(obj), err)
+		}
+	}
+	return f, nil
+}
+
+func filterObjects(objects ...client.Object) (istio []client.Object, kgw []client.Object) {
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *kgateway.Backend,
+			*kgateway.BackendConfigPolicy,
+			*kgateway.DirectResponse,
+			*kgateway.GatewayExtension,
+			*kgateway.GatewayParameters,
+			*kgateway.HTTPListenerPolicy,
+			*kgateway.ListenerPolicy,
+			*kgateway.TrafficPolicy:
+			kgw = append(kgw, obj)
+		default:
+			istio = append(istio, obj)
+		}
+	}
+	return istio, kgw
+}
+
+func getGVR(obj client.Object, scheme *runtime.Scheme) (schema.GroupVersionResource, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Group == "" {
+		gvks, _, _ := scheme.ObjectKinds(obj)
+		if len(gvks) == 0 {
+			return schema.GroupVersionResource{}, fmt.Errorf("unknown kind for object %T %s", obj, kubeutils.NamespacedNameFrom(obj))
+		}
+		gvk = gvks[0]
+	}
+	gvr, err := wellknown.GVKToGVR(gvk)
+	if err != nil {
+		// try unsafe guess
+		gvr, _ = meta.UnsafeGuessKindToResource(gvk)
+		if gvr == (schema.GroupVersionResource{}) {
+			return schema.GroupVersionResource{}, fmt.Errorf("failed to get GVR for object %s: %v", kubeutils.NamespacedNameFrom(obj), err)
+		}
+	}
+	if gvr.Group == "core" {
+		gvr.Group = ""
+	}
+	return gvr, nil
+}
+
+// registerCRDMetadata records the CRD in the fake metadata client, which is not kept in
+// sync with the apiextensions client and is what the CRD watcher reads.
+func registerCRDMetadata(c kube.Client, g schema.GroupVersionResource) error {
+	fmc, ok := c.Metadata().(*metadatafake.FakeMetadataClient)
+	if !ok {
+		return nil
+	}
+	fmd, ok := fmc.Resource(gvr.CustomResourceDefinition).(metadatafake.MetadataClient)
+	if !ok {
+		return nil
+	}
+	obj := &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s.%s", g.Resource, g.Group),
+			Annotations: map[string]string{
+				consts.BundleVersionAnnotation: consts.BundleVersion,
+			},
+		},
+	}
+	_, err := fmd.CreateFake(obj, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		_, err = fmd.UpdateFake(obj, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+func seedCRDs(c kube.Client, gvrs []schema.GroupVersionResource) error {
+	crds := map[string]*apiextensionsv1.CustomResourceDefinition{}
+	for _, gvr := range gvrs {
+		name := fmt.Sprintf("%s.%s", gvr.Resource, gvr.Group)
+		crd := crds[name]
+		if crd == nil {
+			crd = &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Annotations: map[string]string{
+						consts.BundleVersionAnnotation: consts.BundleVersion,
+					},
+				},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Group: gvr.Group,
+					Names: apiextensionsv1.CustomResourceDefinitionNames{
+						Plural: gvr.Resource,
+						Kind:   kindForSeededCRD(gvr),
+					},
+					Scope: apiextensionsv1.NamespaceScoped,
+				},
+			}
+			crds[name] = crd
+		}
+
+		foundVersion := false
+		for _, version := range crd.Spec.Versions {
+			if version.Name == gvr.Version {
+				foundVersion = true
+				break
+			}
+		}
+		if foundVersion {
+			continue
+		}
+
+		crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:    gvr.Version,
+			Served:  true,
+			Storage: len(crd.Spec.Versions) == 0,
+		})
+	}
+
+	extClient, ok := c.Ext().(*extfake.Clientset)
+	if !ok {
+		return fmt.Errorf("unexpected apiextensions fake client type %T", c.Ext())
+	}
+	for _, crd := range crds {
+		err := extClient.Tracker().Add(crd)
+		if apierrors.IsAlreadyExists(err) {
+			err = extClient.Tracker().Update(gvr.CustomResourceDefinition, crd, "")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func kindForSeededCRD(resource schema.GroupVersionResource) string {
+	switch resource {
+	case gvr.KubernetesGateway:
+		return wellknown.GatewayKind
+	case gvr.GatewayClass:
+		return wellknown.GatewayClassKind
+	case gvr.HTTPRoute:
+		return wellknown.HTTPRouteKind
+	case gvr.GRPCRoute:
+		return wellknown.GRPCRouteKind
+	case gvr.TCPRoute, wellknown.TCPRouteV1GVR:
+		return wellknown.TCPRouteKind
+	case gvr.TLSRoute, wellknown.TLSRouteV1Alpha3GVR:
+		return wellknown.TLSRouteKind
+	case gvr.UDPRoute:
+		return wellknown.UDPRouteKind
+	case gvr.ReferenceGrant:
+		return wellknown.ReferenceGrantKind
+	case gvr.BackendTLSPolicy, wellknown.BackendTLSPolicyGVR:
+		return wellknown.BackendTLSPolicyKind
+	case wellknown.XListenerSetGVR:
+		return wellknown.XListenerSetKind
+	case wellknown.XBackendTrafficPolicyGVR:
+		return wellknown.XBackendTrafficPolicyKind
+	case wellknown.ListenerSetGVR:
+		return wellknown.ListenerSetKind
+	case gvr.Service:
+		return wellknown.ServiceKind
+	case gvr.Pod:
+		return "Pod"
+	case gvr.ServiceEntry:
+		return "ServiceEntry"
+	case gvr.WorkloadEntry:
+		return "WorkloadEntry"
+	case gvr.AuthorizationPolicy:
+		return "AuthorizationPolicy"
+	case wellknown.BackendGVR:
+		return wellknown.BackendGVK.Kind
+	case wellknown.BackendConfigPolicyGVR:
+		return wellknown.BackendConfigPolicyGVK.Kind
+	case wellknown.TrafficPolicyGVR:
+		return wellknown.TrafficPolicyGVK.Kind
+	case wellknown.HTTPListenerPolicyGVR:
+		return wellknown.HTTPListenerPolicyGVK.Kind
+	case wellknown.ListenerPolicyGVR:
+		return wellknown.ListenerPolicyGVK.Kind
+	case wellknown.DirectResponseGVR:
+		return wellknown.DirectResponseGVK.Kind
+	case wellknown.GatewayExtensionGVR:
+		return wellknown.GatewayExtensionGVK.Kind
+	case wellknown.GatewayParametersGVR:
+		return wellknown.GatewayParametersGVK.Kind
+	default:
+		return resource.Resource
+	}
+}
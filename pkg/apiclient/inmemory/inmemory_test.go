@@ -1,4 +1,4 @@
-package fake
+package inmemory
 
 import (
 	"testing"
@@ -19,6 +19,7 @@ func TestKindForSeededCRD(t *testing.T) {
 		{name: "backend tls policy", gvr: gvr.BackendTLSPolicy, want: wellknown.BackendTLSPolicyKind},
 		{name: "grpc route", gvr: gvr.GRPCRoute, want: wellknown.GRPCRouteKind},
 		{name: "tls route v1alpha3", gvr: wellknown.TLSRouteV1Alpha3GVR, want: wellknown.TLSRouteKind},
+		{name: "backend traffic policy", gvr: wellknown.XBackendTrafficPolicyGVR, want: wellknown.XBackendTrafficPolicyKind},
 		{name: "listener policy", gvr: wellknown.ListenerPolicyGVR, want: wellknown.ListenerPolicyGVK.Kind},
 	}
 
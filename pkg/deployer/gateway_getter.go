@@ -32,7 +32,12 @@ func DefaultGatewayIRGetter(gw *gwv1.Gateway, commonCollections *collections.Com
 
 func GatewayIRFrom(gw *gwv1.Gateway, controllerNameGuess string) *ir.GatewayForDeployer {
 	ports := sets.New[int32]()
+	udpPorts := sets.New[int32]()
 	for _, l := range gw.Spec.Listeners {
+		if l.Protocol == gwv1.UDPProtocolType {
+			udpPorts.Insert(l.Port)
+			continue
+		}
 		ports.Insert(l.Port)
 	}
 	return &ir.GatewayForDeployer{
@@ -44,5 +49,6 @@ func GatewayIRFrom(gw *gwv1.Gateway, controllerNameGuess string) *ir.GatewayForD
 		},
 		ControllerName: controllerNameGuess,
 		Ports:          smallset.New(ports.UnsortedList()...),
+		UDPPorts:       smallset.New(udpPorts.UnsortedList()...),
 	}
 }
@@ -44,9 +44,11 @@ func GetSupportedFeaturesForStandardGateway(enableExperimentalGatewayAPIFeatures
 		exemptFeatures.Insert(
 			features.TLSRouteModeMixedFeature,
 		)
-	}
-	for _, feature := range features.UDPRouteFeatures.UnsortedList() {
-		exemptFeatures.Insert(feature)
+		// UDPRoute is only served by the v1alpha2 API, which is watched only when
+		// experimental Gateway API features are enabled.
+		for _, feature := range features.UDPRouteFeatures.UnsortedList() {
+			exemptFeatures.Insert(feature)
+		}
 	}
 
 	return getSupportedFeatures(exemptFeatures)
@@ -50,6 +50,18 @@ func GetPortsValues(gw *ir.GatewayForDeployer, gwp *kgateway.GatewayParameters)
 		}
 		gwPorts = AppendPortValue(gwPorts, port, portName, gwp)
 	}
+	for _, port := range gw.UDPPorts.List() {
+		portName := listener.GenerateListenerNameFromPort(port)
+		if gw.Ports.Contains(port) {
+			// The port is also served over TCP, e.g. HTTP/3 alongside HTTPS, and Service port names must be unique
+			portName = fmt.Sprintf("udp-%d", port)
+		}
+		if err := validate.ListenerPort(ir.Listener{Listener: gwv1.Listener{Port: port}}, port); err != nil {
+			logger.Error("skipping port", "gateway", gw.ResourceName(), "error", err)
+			continue
+		}
+		gwPorts = appendPortValueWithProtocol(gwPorts, port, portName, "UDP", gwp)
+	}
 
 	// Add ports from GatewayParameters.Service.Ports
 	// Merge user-defined service ports with auto-generated listener ports
@@ -86,12 +98,15 @@ func SanitizePortName(name string) string {
 }
 
 func AppendPortValue(gwPorts []HelmPort, port int32, name string, gwp *kgateway.GatewayParameters) []HelmPort {
-	if istioslices.IndexFunc(gwPorts, func(p HelmPort) bool { return *p.Port == port }) != -1 {
+	return appendPortValueWithProtocol(gwPorts, port, name, "TCP", gwp)
+}
+
+func appendPortValueWithProtocol(gwPorts []HelmPort, port int32, name, protocol string, gwp *kgateway.GatewayParameters) []HelmPort {
+	if istioslices.IndexFunc(gwPorts, func(p HelmPort) bool { return *p.Port == port && *p.Protocol == protocol }) != -1 {
 		return gwPorts
 	}
 
 	portName := SanitizePortName(name)
-	protocol := "TCP"
 
 	// Search for static NodePort set from the GatewayParameters spec.
 	// NodePort and LoadBalancer both support explicit node ports; if not set, nil renders nothing.
@@ -4,11 +4,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"istio.io/istio/pkg/util/smallset"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
 func TestComponentLogLevelsToString(t *testing.T) {
@@ -291,3 +293,17 @@ func TestGetServiceValues(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPortsValues(t *testing.T) {
+	gw := &ir.GatewayForDeployer{
+		Ports:    smallset.New[int32](8080, 8443),
+		UDPPorts: smallset.New[int32](5353, 8443),
+	}
+
+	assert.Equal(t, []HelmPort{
+		{Port: new(int32(8080)), TargetPort: new(int32(8080)), Name: new("listener-8080"), Protocol: new("TCP")},
+		{Port: new(int32(8443)), TargetPort: new(int32(8443)), Name: new("listener-8443"), Protocol: new("TCP")},
+		{Port: new(int32(5353)), TargetPort: new(int32(5353)), Name: new("listener-5353"), Protocol: new("UDP")},
+		{Port: new(int32(8443)), TargetPort: new(int32(8443)), Name: new("udp-8443"), Protocol: new("UDP")},
+	}, GetPortsValues(gw, nil))
+}
@@ -1,24 +1,40 @@
 package admin
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"net/http"
+	"slices"
 
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// protojsonFormat is the value of the format query parameter that renders each snapshot's
+// resources with protojson, in the same shape as the output of `kgateway translate`.
+const protojsonFormat = "protojson"
+
 // The xDS Snapshot is intended to return the full in-memory xDS cache that the Control Plane manages
 // and serves up to running proxies.
+// With ?format=protojson, the resources are rendered with protojson so that they can be read back,
+// for example to diff the live configuration against a set of manifests.
 func addXdsSnapshotHandler(path string, mux *http.ServeMux, profiles map[string]dynamicProfileDescription, cache cache.SnapshotCache) {
 	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
 		if cache == nil {
 			writeJSON(w, map[string]string{"error": "Envoy xDS cache not available (Envoy controller may be disabled)"}, r)
 			return
 		}
-		response := getXdsSnapshotDataFromCache(cache)
+		var response SnapshotResponseData
+		if r.URL.Query().Get("format") == protojsonFormat {
+			response = getXdsSnapshotResourcesFromCache(cache)
+		} else {
+			response = getXdsSnapshotDataFromCache(cache)
+		}
 		writeJSON(w, response, r)
 	})
 	profiles[path] = func() string { return "XDS Snapshot (Envoy only)" }
@@ -40,6 +56,67 @@ func getXdsSnapshotDataFromCache(xdsCache cache.SnapshotCache) SnapshotResponseD
 	return completeSnapshotResponse(cacheEntries)
 }
 
+// xdsSnapshotResources are the resources of a single snapshot, each rendered with protojson
+// and sorted by name.
+type xdsSnapshotResources struct {
+	Listeners []json.RawMessage `json:"listeners,omitempty"`
+	Routes    []json.RawMessage `json:"routes,omitempty"`
+	Clusters  []json.RawMessage `json:"clusters,omitempty"`
+	Secrets   []json.RawMessage `json:"secrets,omitempty"`
+}
+
+func getXdsSnapshotResourcesFromCache(xdsCache cache.SnapshotCache) SnapshotResponseData {
+	cacheKeys := xdsCache.GetStatusKeys()
+	cacheEntries := make(map[string]any, len(cacheKeys))
+
+	for _, k := range cacheKeys {
+		xdsSnapshot, err := getXdsSnapshot(xdsCache, k)
+		if err != nil {
+			cacheEntries[k] = err.Error()
+			continue
+		}
+		resources, err := marshalSnapshotResources(xdsSnapshot)
+		if err != nil {
+			cacheEntries[k] = err.Error()
+			continue
+		}
+		cacheEntries[k] = resources
+	}
+
+	return completeSnapshotResponse(cacheEntries)
+}
+
+func marshalSnapshotResources(snap cache.ResourceSnapshot) (*xdsSnapshotResources, error) {
+	out := &xdsSnapshotResources{}
+	var err error
+	if out.Listeners, err = marshalResources(snap.GetResources(resource.ListenerType)); err != nil {
+		return nil, err
+	}
+	if out.Routes, err = marshalResources(snap.GetResources(resource.RouteType)); err != nil {
+		return nil, err
+	}
+	if out.Clusters, err = marshalResources(snap.GetResources(resource.ClusterType)); err != nil {
+		return nil, err
+	}
+	if out.Secrets, err = marshalResources(snap.GetResources(resource.SecretType)); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func marshalResources(resources map[string]types.Resource) ([]json.RawMessage, error) {
+	names := slices.SortedFunc(maps.Keys(resources), cmp.Compare)
+	result := make([]json.RawMessage, 0, len(names))
+	for _, name := range names {
+		data, err := protojson.Marshal(resources[name])
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling %s: %w", name, err)
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
 func getXdsSnapshot(xdsCache cache.SnapshotCache, k string) (c cache.ResourceSnapshot, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -9,6 +9,7 @@ import (
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/testing/protocmp"
@@ -159,3 +160,28 @@ func TestRedactSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalSnapshotResources(t *testing.T) {
+	r := require.New(t)
+
+	snap, err := cache.NewSnapshot("v1", map[resource.Type][]types.Resource{
+		resource.ClusterType: {
+			&envoyclusterv3.Cluster{Name: "cluster-b"},
+			&envoyclusterv3.Cluster{Name: "cluster-a"},
+		},
+		resource.SecretType: {
+			&envoytlsv3.Secret{Name: "secret-foo"},
+		},
+	})
+	r.NoError(err)
+
+	got, err := marshalSnapshotResources(snap)
+	r.NoError(err)
+	r.Empty(got.Listeners)
+	r.Empty(got.Routes)
+	r.Len(got.Clusters, 2)
+	r.JSONEq(`{"name":"cluster-a"}`, string(got.Clusters[0]))
+	r.JSONEq(`{"name":"cluster-b"}`, string(got.Clusters[1]))
+	r.Len(got.Secrets, 1)
+	r.JSONEq(`{"name":"secret-foo"}`, string(got.Secrets[0]))
+}
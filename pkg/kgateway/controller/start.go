@@ -102,6 +102,7 @@ type ControllerBuilder struct {
 	cfg         StartConfig
 	mgr         ctrl.Manager
 	commoncol   *collections.CommonCollections
+	plugins     sdk.Plugin
 
 	ready atomic.Bool
 }
@@ -186,6 +187,7 @@ func NewControllerBuilder(ctx context.Context, cfg StartConfig) (*ControllerBuil
 		cfg:         cfg,
 		mgr:         cfg.Manager,
 		commoncol:   cfg.CommonCollections,
+		plugins:     mergedPlugins,
 	}
 
 	// wait for the ControllerBuilder to Start
@@ -285,6 +287,11 @@ func (c *ControllerBuilder) Build(ctx context.Context) error {
 	return nil
 }
 
+// Plugins returns the merged builtin and extra plugins the controller translates with.
+func (c *ControllerBuilder) Plugins() sdk.Plugin {
+	return c.plugins
+}
+
 func (c *ControllerBuilder) HasSynced() bool {
 	if c.proxySyncer != nil && !c.proxySyncer.HasSynced() {
 		return false
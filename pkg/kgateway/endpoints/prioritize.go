@@ -2,6 +2,7 @@ package endpoints
 
 import (
 	"log/slog"
+	"maps"
 	"slices"
 	"strings"
 
@@ -171,6 +172,9 @@ func getEndpoints(eps []ir.EndpointWithMd, lbinfo LoadBalancingInfo) []*envoyend
 	if lbinfo.PriorityInfo != nil && lbinfo.PriorityInfo.FailoverPriority != nil {
 		return applyFailoverPriorityPerLocality(eps, lbinfo)
 	}
+	if hasEndpointPriorities(eps) {
+		return splitByEndpointPriority(eps)
+	}
 	epsOut := []*envoyendpointv3.LocalityLbEndpoints{{
 		LbEndpoints: make([]*envoyendpointv3.LbEndpoint, 0, len(eps)),
 	}}
@@ -190,6 +194,38 @@ func getEndpoints(eps []ir.EndpointWithMd, lbinfo LoadBalancingInfo) []*envoyend
 	return epsOut
 }
 
+func hasEndpointPriorities(eps []ir.EndpointWithMd) bool {
+	return slices.ContainsFunc(eps, func(ep ir.EndpointWithMd) bool {
+		return ep.EndpointMd.Priority != 0
+	})
+}
+
+// splitByEndpointPriority groups endpoints that set an explicit priority (e.g. from
+// DNS SRV records) into one LocalityLbEndpoints per priority level.
+func splitByEndpointPriority(eps []ir.EndpointWithMd) []*envoyendpointv3.LocalityLbEndpoints {
+	priorityMap := map[uint32][]int{}
+	for i, ep := range eps {
+		priorityMap[ep.EndpointMd.Priority] = append(priorityMap[ep.EndpointMd.Priority], i)
+	}
+	priorities := slices.Sorted(maps.Keys(priorityMap))
+
+	out := make([]*envoyendpointv3.LocalityLbEndpoints, len(priorities))
+	for i, priority := range priorities {
+		out[i] = &envoyendpointv3.LocalityLbEndpoints{Priority: priority}
+		var weight uint32
+		for _, index := range priorityMap[priority] {
+			out[i].LbEndpoints = append(out[i].GetLbEndpoints(), eps[index].LbEndpoint)
+			weight += eps[index].GetLoadBalancingWeight().GetValue()
+		}
+		if weight > 0 {
+			out[i].LoadBalancingWeight = &wrapperspb.UInt32Value{
+				Value: weight,
+			}
+		}
+	}
+	return out
+}
+
 func applyFailoverPriorityPerLocality(
 	eps []ir.EndpointWithMd, lbinfo LoadBalancingInfo,
 ) []*envoyendpointv3.LocalityLbEndpoints {
@@ -197,6 +233,9 @@ func applyFailoverPriorityPerLocality(
 	priorityMap := map[int][]int{}
 	for i, ep := range eps {
 		priority := lbinfo.PriorityInfo.FailoverPriority.GetPriority(lbinfo.PodLabels, ep.EndpointMd.Labels)
+		// An explicit endpoint priority takes precedence over the failover priority,
+		// which ranges from 0 to lowestPriority.
+		priority += int(ep.EndpointMd.Priority) * (lbinfo.PriorityInfo.FailoverPriority.lowestPriority + 1)
 		priorityMap[priority] = append(priorityMap[priority], i)
 	}
 
@@ -210,7 +249,7 @@ func applyFailoverPriorityPerLocality(
 	out := make([]*envoyendpointv3.LocalityLbEndpoints, len(priorityMap))
 	for i, priority := range priorities {
 		out[i] = &envoyendpointv3.LocalityLbEndpoints{}
-		out[i].Priority = uint32(priority) //nolint:gosec // G115: priority is bounded by locality failover (0-4)
+		out[i].Priority = uint32(priority) //nolint:gosec // G115: priority is bounded by the endpoint priority levels and locality failover (0-4)
 		var weight uint32
 		for _, index := range priorityMap[priority] {
 			out[i].LbEndpoints = append(out[i].GetLbEndpoints(), eps[index].LbEndpoint)
@@ -129,12 +129,26 @@ func processLambda(ir *LambdaIr, out *envoyclusterv3.Cluster) error {
 				Seconds: 30,
 			},
 		}
-		opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
-			Name: lambdaFilterName,
-			ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
-				TypedConfig: ir.lambdaFilters.lambdaConfigAny,
-			},
-		})
+		// In Stream mode, the aws-lambda filter of the dynamic module builds the invocation
+		// request in place of the Envoy aws_lambda filter.
+		if ir.lambdaFilters.lambdaConfigAny != nil {
+			opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
+				Name: lambdaFilterName,
+				ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
+					TypedConfig: ir.lambdaFilters.lambdaConfigAny,
+				},
+			})
+		}
+		// The aws-lambda filter rewrites the invocation request, so it must run before the
+		// request is signed.
+		if ir.lambdaFilters.lambdaModuleAny != nil {
+			opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
+				Name: lambdaModuleUpstreamFilterName,
+				ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
+					TypedConfig: ir.lambdaFilters.lambdaModuleAny,
+				},
+			})
+		}
 		opts.HttpFilters = append(opts.GetHttpFilters(), &envoy_hcm.HttpFilter{
 			Name: awsRequestSigningFilterName,
 			ConfigType: &envoy_hcm.HttpFilter_TypedConfig{
@@ -216,6 +230,10 @@ type lambdaFilters struct {
 	awsRequestSigningAny *anypb.Any
 	// +noKrtEquals
 	codecConfigAny *anypb.Any
+	// lambdaModuleAny is the config of the aws-lambda filter of the dynamic module, only set
+	// for the features the Envoy aws_lambda filter does not support.
+	// +noKrtEquals
+	lambdaModuleAny *anypb.Any
 }
 
 // Equals checks if two lambdaFilters objects are equal.
@@ -223,7 +241,8 @@ func (u *lambdaFilters) Equals(other *lambdaFilters) bool {
 	return cmputils.CompareWithNils(u, other, func(a, b *lambdaFilters) bool {
 		return proto.Equal(a.lambdaConfigAny, b.lambdaConfigAny) &&
 			proto.Equal(a.awsRequestSigningAny, b.awsRequestSigningAny) &&
-			proto.Equal(a.codecConfigAny, b.codecConfigAny)
+			proto.Equal(a.codecConfigAny, b.codecConfigAny) &&
+			proto.Equal(a.lambdaModuleAny, b.lambdaModuleAny)
 	})
 }
 
@@ -235,6 +254,7 @@ func buildLambdaFilters(
 	secret *ir.Secret,
 	invokeMode envoy_lambda_v3.Config_InvocationMode,
 	payloadTransformMode kgateway.AWSLambdaPayloadTransformMode,
+	moduleConfig *lambdaModuleConfig,
 ) (*lambdaFilters, error) {
 	var payloadPassthrough bool
 	switch payloadTransformMode {
@@ -244,13 +264,23 @@ func buildLambdaFilters(
 		payloadPassthrough = false
 	}
 
-	lambdaConfigAny, err := utils.MessageToAny(&envoy_lambda_v3.Config{
-		Arn:                arn,
-		InvocationMode:     invokeMode,
-		PayloadPassthrough: payloadPassthrough,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create lambda config: %v", err)
+	var lambdaConfigAny, lambdaModuleAny *anypb.Any
+	var err error
+	if moduleConfig == nil || !moduleConfig.Stream {
+		lambdaConfigAny, err = utils.MessageToAny(&envoy_lambda_v3.Config{
+			Arn:                arn,
+			InvocationMode:     invokeMode,
+			PayloadPassthrough: payloadPassthrough,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create lambda config: %v", err)
+		}
+	}
+	if moduleConfig != nil {
+		lambdaModuleAny, err = toLambdaModuleFilterConfig(moduleConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create aws-lambda filter config: %v", err)
+		}
 	}
 
 	awsRequestSigning, err := configureAWSAuth(auth, secret, region)
@@ -271,6 +301,7 @@ func buildLambdaFilters(
 		lambdaConfigAny:      lambdaConfigAny,
 		awsRequestSigningAny: awsRequestSigningAny,
 		codecConfigAny:       codecConfigAny,
+		lambdaModuleAny:      lambdaModuleAny,
 	}, nil
 }
 
@@ -283,7 +314,8 @@ func getLambdaHostname(in *kgateway.AwsBackend) string {
 	return fmt.Sprintf("lambda.%s.amazonaws.com", defaultAwsRegion(in.Region))
 }
 
-// getLambdaInvocationMode returns the Lambda invocation mode. Default is synchronous.
+// getLambdaInvocationMode returns the Lambda invocation mode of the Envoy aws_lambda filter.
+// Default is synchronous. The Stream mode is implemented by the aws-lambda filter instead.
 func getLambdaInvocationMode(in *kgateway.AwsBackend) envoy_lambda_v3.Config_InvocationMode {
 	invokeMode := envoy_lambda_v3.Config_SYNCHRONOUS
 	if in.Lambda.InvocationMode == kgateway.AwsLambdaInvocationModeAsynchronous {
@@ -304,12 +336,8 @@ func getLambdaAccountID(in *kgateway.AwsBackend) string {
 // valid; an unset qualifier defaults to "$LATEST".
 // An error is returned if the arn is not a valid lambda arn.
 func buildLambdaARN(in *kgateway.AwsBackend, region string) (string, error) {
-	qualifier := in.Lambda.Qualifier
-	if qualifier == "" {
-		qualifier = "$LATEST"
-	}
 	// TODO(tim): url.QueryEscape(...)?
-	arnStr := fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s:%s", region, getLambdaAccountID(in), in.Lambda.FunctionName, qualifier)
+	arnStr := fmt.Sprintf("%s:%s", lambdaFunctionARN(in, region), lambdaQualifier(in.Lambda))
 	parsedARN, err := arnutils.Parse(arnStr)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse lambda arn: %v", err)
@@ -317,6 +345,19 @@ func buildLambdaARN(in *kgateway.AwsBackend, region string) (string, error) {
 	return parsedARN.String(), nil
 }
 
+// lambdaFunctionARN returns the ARN of the lambda function, without a qualifier.
+func lambdaFunctionARN(in *kgateway.AwsBackend, region string) string {
+	return fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s", region, getLambdaAccountID(in), in.Lambda.FunctionName)
+}
+
+// lambdaQualifier returns the qualifier of the lambda function, "$LATEST" when unset.
+func lambdaQualifier(in *kgateway.AwsLambda) string {
+	if in.Qualifier == "" {
+		return "$LATEST"
+	}
+	return in.Qualifier
+}
+
 // lambdaEndpointConfig is a helper struct to store the endpoint configuration for the Lambda backend.
 type lambdaEndpointConfig struct {
 	hostname string
@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+
+	extensiondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
+	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+)
+
+const (
+	lambdaModuleName = "rust_module"
+	// lambdaModuleFilterName is the name of the aws-lambda filter of the dynamic module, which
+	// implements what the Envoy aws_lambda filter does not: response streaming, the response to
+	// asynchronous invocations, and the selection of the qualifier from a request header.
+	lambdaModuleFilterName = "aws-lambda"
+	// lambdaModuleUpstreamFilterName is the name of the upstream HTTP filter running the aws-lambda
+	// filter in the cluster of the backend.
+	lambdaModuleUpstreamFilterName = "dynamic_modules/" + lambdaModuleFilterName
+)
+
+// lambdaModuleConfig is the configuration of the aws-lambda filter.
+type lambdaModuleConfig struct {
+	// FunctionArn is the ARN of the function, without the qualifier.
+	FunctionArn string `json:"functionArn"`
+	Qualifier   string `json:"qualifier"`
+	// Stream invokes the function with InvokeWithResponseStream. The filter then builds the
+	// invocation request itself, in place of the Envoy aws_lambda filter.
+	Stream             bool                         `json:"stream,omitempty"`
+	PayloadPassthrough bool                         `json:"payloadPassthrough,omitempty"`
+	QualifierHeader    *lambdaModuleQualifierHeader `json:"qualifierHeader,omitempty"`
+	AsyncResponse      *lambdaModuleAsyncResponse   `json:"asyncResponse,omitempty"`
+}
+
+type lambdaModuleQualifierHeader struct {
+	Name       string   `json:"name"`
+	Qualifiers []string `json:"qualifiers"`
+}
+
+type lambdaModuleAsyncResponse struct {
+	StatusCode int32                `json:"statusCode"`
+	Headers    []lambdaModuleHeader `json:"headers,omitempty"`
+}
+
+type lambdaModuleHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// buildLambdaModuleConfig returns the configuration of the aws-lambda filter for the given
+// backend, or nil when the backend only uses features of the Envoy aws_lambda filter.
+func buildLambdaModuleConfig(in *kgateway.AwsBackend, region string) *lambdaModuleConfig {
+	lambda := in.Lambda
+	stream := lambda.InvocationMode == kgateway.AwsLambdaInvocationModeStreaming
+	if !stream && lambda.QualifierHeader == nil && lambda.AsyncResponse == nil {
+		return nil
+	}
+
+	cfg := &lambdaModuleConfig{
+		FunctionArn:        lambdaFunctionARN(in, region),
+		Qualifier:          lambdaQualifier(lambda),
+		Stream:             stream,
+		PayloadPassthrough: lambda.PayloadTransformMode == kgateway.AWSLambdaPayloadTransformNone,
+	}
+	if lambda.QualifierHeader != nil {
+		cfg.QualifierHeader = &lambdaModuleQualifierHeader{
+			Name:       string(lambda.QualifierHeader.Name),
+			Qualifiers: lambda.QualifierHeader.Qualifiers,
+		}
+	}
+	if lambda.AsyncResponse != nil && lambda.InvocationMode == kgateway.AwsLambdaInvocationModeAsynchronous {
+		asyncResponse := &lambdaModuleAsyncResponse{StatusCode: 202}
+		if lambda.AsyncResponse.StatusCode != nil {
+			asyncResponse.StatusCode = *lambda.AsyncResponse.StatusCode
+		}
+		for _, h := range lambda.AsyncResponse.Headers {
+			asyncResponse.Headers = append(asyncResponse.Headers, lambdaModuleHeader{
+				Name:  string(h.Name),
+				Value: h.Value,
+			})
+		}
+		cfg.AsyncResponse = asyncResponse
+	}
+	return cfg
+}
+
+// toLambdaModuleFilterConfig converts the configuration of the aws-lambda filter to the typed
+// config of its upstream HTTP filter.
+func toLambdaModuleFilterConfig(cfg *lambdaModuleConfig) (*anypb.Any, error) {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal aws-lambda filter config: %w", err)
+	}
+	filterCfg, err := utils.MessageToAny(&wrapperspb.StringValue{Value: string(raw)})
+	if err != nil {
+		return nil, err
+	}
+	return utils.MessageToAny(&dynamicmodulesv3.DynamicModuleFilter{
+		DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
+			Name: lambdaModuleName,
+		},
+		FilterName:   lambdaModuleFilterName,
+		FilterConfig: filterCfg,
+	})
+}
@@ -5,13 +5,17 @@ import (
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoydnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dns/v3"
+	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
+	envoy_upstreams_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/istio/pkg/kube/krt"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
@@ -119,7 +123,7 @@ func TestBuildLambdaARNFallsBackToDeprecatedBackendAccountID(t *testing.T) {
 }
 
 func TestBuildTranslateFuncFailsClosedForLambdaEndpointWithoutPort(t *testing.T) {
-	translate := buildTranslateFunc(nil, nil, true)
+	translate := buildTranslateFunc(nil, nil, translateOptions{enableAwsEc2Discovery: true})
 
 	backendIR := translate(krt.TestingDummyContext{}, newLambdaBackend("lambda-backend", "https://lambda.us-east-1.amazonaws.com"))
 
@@ -141,7 +145,7 @@ func TestBackendIrEqualsDetectsLambdaErrorOnlyChanges(t *testing.T) {
 		},
 	}
 
-	missingSecretIR := buildTranslateFunc(nil, newSecretIndexForTest(t), true)(krt.TestingDummyContext{}, backend)
+	missingSecretIR := buildTranslateFunc(nil, newSecretIndexForTest(t), translateOptions{enableAwsEc2Discovery: true})(krt.TestingDummyContext{}, backend)
 	invalidSecretIR := buildTranslateFunc(nil, newSecretIndexForTest(t, &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            "lambda-secret",
@@ -151,7 +155,7 @@ func TestBackendIrEqualsDetectsLambdaErrorOnlyChanges(t *testing.T) {
 		Data: map[string][]byte{
 			"token": []byte("sk-test-secret"),
 		},
-	}), true)(krt.TestingDummyContext{}, backend)
+	}), translateOptions{enableAwsEc2Discovery: true})(krt.TestingDummyContext{}, backend)
 
 	require.NotEmpty(t, missingSecretIR.errors)
 	require.NotEmpty(t, invalidSecretIR.errors)
@@ -161,6 +165,92 @@ func TestBackendIrEqualsDetectsLambdaErrorOnlyChanges(t *testing.T) {
 	assert.False(t, invalidSecretIR.Equals(missingSecretIR), "backend IR equality should remain symmetric")
 }
 
+func TestBuildLambdaModuleConfig(t *testing.T) {
+	backend := newLambdaBackend("lambda-backend", "https://lambda.us-east-1.amazonaws.com:443").Spec.Aws
+	assert.Nil(t, buildLambdaModuleConfig(backend, "us-east-1"), "backends using only Envoy aws_lambda features should not use the aws-lambda filter")
+
+	backend.Lambda.InvocationMode = kgateway.AwsLambdaInvocationModeStreaming
+	backend.Lambda.PayloadTransformMode = kgateway.AWSLambdaPayloadTransformNone
+	backend.Lambda.QualifierHeader = &kgateway.AwsLambdaQualifierHeader{
+		Name:       "x-lambda-version",
+		Qualifiers: []string{"canary", "7"},
+	}
+	assert.Equal(t, &lambdaModuleConfig{
+		FunctionArn:        "arn:aws:lambda:us-east-1:111111111111:function:hello-function",
+		Qualifier:          "live",
+		Stream:             true,
+		PayloadPassthrough: true,
+		QualifierHeader: &lambdaModuleQualifierHeader{
+			Name:       "x-lambda-version",
+			Qualifiers: []string{"canary", "7"},
+		},
+	}, buildLambdaModuleConfig(backend, "us-east-1"))
+
+	backend.Lambda.InvocationMode = kgateway.AwsLambdaInvocationModeAsynchronous
+	backend.Lambda.PayloadTransformMode = kgateway.AWSLambdaPayloadTransformEnvoy
+	backend.Lambda.QualifierHeader = nil
+	backend.Lambda.AsyncResponse = &kgateway.AwsLambdaAsyncResponse{
+		Headers: []gwv1.HTTPHeader{{Name: "location", Value: "/jobs"}},
+	}
+	assert.Equal(t, &lambdaModuleConfig{
+		FunctionArn: "arn:aws:lambda:us-east-1:111111111111:function:hello-function",
+		Qualifier:   "live",
+		AsyncResponse: &lambdaModuleAsyncResponse{
+			StatusCode: 202,
+			Headers:    []lambdaModuleHeader{{Name: "location", Value: "/jobs"}},
+		},
+	}, buildLambdaModuleConfig(backend, "us-east-1"))
+}
+
+func TestProcessLambdaRunsModuleBeforeSigning(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		mode          string
+		wantFilters   []string
+		wantModuleCfg string
+	}{
+		{
+			name:        "sync",
+			mode:        kgateway.AwsLambdaInvocationModeSynchronous,
+			wantFilters: []string{lambdaFilterName, awsRequestSigningFilterName, upstreamCodecFilterName},
+		},
+		{
+			name:          "stream",
+			mode:          kgateway.AwsLambdaInvocationModeStreaming,
+			wantFilters:   []string{lambdaModuleUpstreamFilterName, awsRequestSigningFilterName, upstreamCodecFilterName},
+			wantModuleCfg: `{"functionArn":"arn:aws:lambda:us-east-1:111111111111:function:hello-function","qualifier":"live","stream":true}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			be := newLambdaBackend("lambda-backend", "https://lambda.us-east-1.amazonaws.com:443")
+			be.Spec.Aws.Lambda.InvocationMode = tc.mode
+			backendIR := buildTranslateFunc(nil, nil, translateOptions{})(krt.TestingDummyContext{}, be)
+			require.Empty(t, backendIR.errors)
+
+			cluster := &envoyclusterv3.Cluster{Name: "test-cluster"}
+			require.NoError(t, processAws(backendIR.awsIr, cluster))
+
+			var opts envoy_upstreams_v3.HttpProtocolOptions
+			require.NoError(t, cluster.GetTypedExtensionProtocolOptions()["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"].UnmarshalTo(&opts))
+			var names []string
+			for _, f := range opts.GetHttpFilters() {
+				names = append(names, f.GetName())
+			}
+			assert.Equal(t, tc.wantFilters, names)
+
+			if tc.wantModuleCfg == "" {
+				return
+			}
+			var module dynamicmodulesv3.DynamicModuleFilter
+			require.NoError(t, opts.GetHttpFilters()[0].GetTypedConfig().UnmarshalTo(&module))
+			assert.Equal(t, lambdaModuleFilterName, module.GetFilterName())
+			var cfg wrapperspb.StringValue
+			require.NoError(t, module.GetFilterConfig().UnmarshalTo(&cfg))
+			assert.JSONEq(t, tc.wantModuleCfg, cfg.GetValue())
+		})
+	}
+}
+
 func newLambdaBackend(name, endpointURL string) *kgateway.Backend {
 	return &kgateway.Backend{
 		Spec: kgateway.BackendSpec{
@@ -0,0 +1,512 @@
+package backend
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	plugincollections "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/discovery"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
+)
+
+const (
+	consulTokenSecretKey       = "token"
+	consulTokenHeader          = "X-Consul-Token"
+	consulIndexHeader          = "X-Consul-Index"
+	defaultConsulWaitTime      = 5 * time.Minute
+	defaultConsulRetryInterval = 10 * time.Second
+	// consulMinQueryInterval rate limits the blocking queries of a scope. Consul
+	// may return before the wait time elapses, e.g. when the index is reset or the
+	// agent does not support blocking, and this keeps such responses from turning
+	// the watch into a busy loop.
+	consulMinQueryInterval = time.Second
+	// consulInitialQueryTimeout bounds the non-blocking queries issued at startup
+	// before the endpoints are marked synced.
+	consulInitialQueryTimeout = 30 * time.Second
+)
+
+var errConsulDiscoveryDisabled = errors.New("consul discovery is disabled by controller settings")
+
+// ConsulIr is the internal representation of a Consul backend.
+//
+// Every field is compared in Equals below, but the krtequals analyzer can't
+// trace fields through the CompareWithNils closure, so each is marked
+// +noKrtEquals to suppress it.
+type ConsulIr struct {
+	query consulQuery // +noKrtEquals
+}
+
+func (u *ConsulIr) Equals(other *ConsulIr) bool {
+	return cmputils.CompareWithNils(u, other, func(a, b *ConsulIr) bool {
+		return a.query.Equals(b.query)
+	})
+}
+
+// consulQuery holds the parameters of the health query of a Consul backend.
+type consulQuery struct {
+	address    string
+	service    string
+	tags       []string
+	datacenter string
+	namespace  string
+	token      string
+	wait       time.Duration
+}
+
+func (q consulQuery) Equals(other consulQuery) bool {
+	return q.token == other.token &&
+		q.wait == other.wait &&
+		q.endpointSemanticsEqual(other)
+}
+
+// endpointSemanticsEqual reports whether two queries select the same service
+// instances, i.e. whether they differ at most in how the instances are fetched.
+func (q consulQuery) endpointSemanticsEqual(other consulQuery) bool {
+	return q.address == other.address &&
+		q.service == other.service &&
+		slices.Equal(q.tags, other.tags) &&
+		q.datacenter == other.datacenter &&
+		q.namespace == other.namespace
+}
+
+func buildConsulIr(in *kgateway.ConsulBackend, secret *ir.Secret) (*ConsulIr, error) {
+	if in == nil {
+		return nil, fmt.Errorf("consul config is nil")
+	}
+	address, err := url.Parse(in.Address)
+	if err != nil || (address.Scheme != "http" && address.Scheme != "https") || address.Host == "" {
+		return nil, fmt.Errorf("consul address %q must be an http or https URL", in.Address)
+	}
+
+	query := consulQuery{
+		address: strings.TrimSuffix(in.Address, "/"),
+		service: in.Service,
+		wait:    defaultConsulWaitTime,
+	}
+	if len(in.Tags) > 0 {
+		query.tags = slices.Clone(in.Tags)
+		slices.Sort(query.tags)
+		query.tags = slices.Compact(query.tags)
+	}
+	if in.Datacenter != nil {
+		query.datacenter = *in.Datacenter
+	}
+	if in.Namespace != nil {
+		query.namespace = *in.Namespace
+	}
+	if in.WaitTime != nil && in.WaitTime.Duration > 0 {
+		query.wait = in.WaitTime.Duration
+	}
+	if secret != nil {
+		token, ok := secret.Data[consulTokenSecretKey]
+		if !ok || len(token) == 0 {
+			return nil, fmt.Errorf("consul token secret %s has no %q key", secret.ResourceName(), consulTokenSecretKey)
+		}
+		query.token = strings.TrimSpace(string(token))
+	}
+	return &ConsulIr{query: query}, nil
+}
+
+// loadConsulTokenSecret resolves the Secret holding the ACL token of a Consul backend.
+func loadConsulTokenSecret(krtctx krt.HandlerContext, secrets *krtcollections.SecretIndex, backend *kgateway.Backend) (*ir.Secret, error) {
+	ref := backend.Spec.Consul.TokenSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+	if secrets == nil {
+		return nil, errors.New("consul token secret lookup is unavailable")
+	}
+	secret, err := secrets.GetSecretWithoutRefGrant(krtctx, ref.Name, backend.GetNamespace())
+	if err != nil {
+		logger.Error(
+			"referenced Consul token secret does not exist or could not be loaded",
+			"backend", fmt.Sprintf("%s/%s", backend.GetNamespace(), backend.GetName()),
+			"secret", fmt.Sprintf("%s/%s", backend.GetNamespace(), ref.Name),
+			"error", err,
+		)
+		return nil, err
+	}
+	return secret, nil
+}
+
+func processConsul(_ *ConsulIr, out *envoyclusterv3.Cluster) {
+	out.ClusterDiscoveryType = &envoyclusterv3.Cluster_Type{
+		Type: envoyclusterv3.Cluster_EDS,
+	}
+	out.EdsClusterConfig = &envoyclusterv3.Cluster_EdsClusterConfig{
+		EdsConfig: &envoycorev3.ConfigSource{
+			ResourceApiVersion: envoycorev3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoycorev3.ConfigSource_Ads{
+				Ads: &envoycorev3.AggregatedConfigSource{},
+			},
+		},
+	}
+	out.IgnoreHealthOnHostRemoval = true
+}
+
+type consulBackendConfig struct {
+	resourceName string
+	query        consulQuery
+}
+
+// ListScope groups the backends that issue the same health query. The token is
+// hashed so that it never shows up in logs.
+func (c consulBackendConfig) ListScope() string {
+	token := sha256.Sum256([]byte(c.query.token))
+	return strings.Join([]string{
+		c.query.address,
+		c.query.service,
+		strings.Join(c.query.tags, ","),
+		c.query.datacenter,
+		c.query.namespace,
+		c.query.wait.String(),
+		hex.EncodeToString(token[:]),
+	}, "\x00")
+}
+
+func (c consulBackendConfig) Equals(other consulBackendConfig) bool {
+	return c.query.Equals(other.query)
+}
+
+func (c consulBackendConfig) EndpointsEqual(other consulBackendConfig) bool {
+	return c.query.endpointSemanticsEqual(other.query)
+}
+
+// consulServiceInstance is a passing instance of a service, as returned by the
+// Consul health API.
+type consulServiceInstance struct {
+	address    string
+	port       uint32
+	datacenter string
+	region     string
+	zone       string
+}
+
+type consulHealthClient interface {
+	// HealthService returns the passing instances of the queried service. A
+	// non-zero index makes it a blocking query that returns once the index
+	// changes or the wait time of the query elapses.
+	HealthService(ctx context.Context, query consulQuery, index uint64) ([]consulServiceInstance, uint64, error)
+}
+
+// consulStatusError is returned when the Consul API answers with an error status.
+type consulStatusError struct {
+	code int
+	body string
+}
+
+func (e *consulStatusError) Error() string {
+	if e.body == "" {
+		return fmt.Sprintf("consul returned %d %s", e.code, http.StatusText(e.code))
+	}
+	return fmt.Sprintf("consul returned %d %s: %s", e.code, http.StatusText(e.code), e.body)
+}
+
+type httpConsulHealthClient struct {
+	client *http.Client
+}
+
+var newConsulHealthClient = func() consulHealthClient {
+	return &httpConsulHealthClient{client: &http.Client{}}
+}
+
+type consulHealthEntry struct {
+	Node struct {
+		Address    string
+		Datacenter string
+	}
+	Service struct {
+		Address  string
+		Port     int
+		Tags     []string
+		Locality *struct {
+			Region string
+			Zone   string
+		}
+	}
+}
+
+func (c *httpConsulHealthClient) HealthService(ctx context.Context, query consulQuery, index uint64) ([]consulServiceInstance, uint64, error) {
+	params := url.Values{}
+	params.Set("passing", "true")
+	for _, tag := range query.tags {
+		params.Add("tag", tag)
+	}
+	if query.datacenter != "" {
+		params.Set("dc", query.datacenter)
+	}
+	if query.namespace != "" {
+		params.Set("ns", query.namespace)
+	}
+	if index > 0 {
+		params.Set("index", strconv.FormatUint(index, 10))
+		params.Set("wait", fmt.Sprintf("%ds", int64(query.wait/time.Second)))
+		// Consul adds up to wait/16 of jitter to the wait time.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, query.wait+query.wait/16+consulInitialQueryTimeout)
+		defer cancel()
+	} else {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, consulInitialQueryTimeout)
+		defer cancel()
+	}
+
+	reqURL := fmt.Sprintf("%s/v1/health/service/%s?%s", query.address, url.PathEscape(query.service), params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if query.token != "" {
+		req.Header.Set(consulTokenHeader, query.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return nil, 0, &consulStatusError{code: resp.StatusCode, body: strings.TrimSpace(string(body))}
+	}
+	var entries []consulHealthEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode consul health response: %w", err)
+	}
+	newIndex, err := strconv.ParseUint(resp.Header.Get(consulIndexHeader), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("consul response has an invalid %s header: %w", consulIndexHeader, err)
+	}
+
+	instances := make([]consulServiceInstance, 0, len(entries))
+	for _, entry := range entries {
+		// Older agents ignore all but the last tag parameter, so check them here too.
+		if !containsAllTags(entry.Service.Tags, query.tags) {
+			continue
+		}
+		instance := consulServiceInstance{
+			address:    cmp.Or(entry.Service.Address, entry.Node.Address),
+			datacenter: entry.Node.Datacenter,
+		}
+		if entry.Service.Port > 0 && entry.Service.Port <= 65535 {
+			instance.port = uint32(entry.Service.Port)
+		}
+		if entry.Service.Locality != nil {
+			instance.region = entry.Service.Locality.Region
+			instance.zone = entry.Service.Locality.Zone
+		}
+		instances = append(instances, instance)
+	}
+	return instances, newIndex, nil
+}
+
+func containsAllTags(tags, required []string) bool {
+	for _, tag := range required {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// consulProvider discovers the endpoints of Consul backends. Each scope is
+// watched with blocking queries against the Consul health API, so changes are
+// picked up as soon as Consul reports them.
+type consulProvider struct {
+	client consulHealthClient
+}
+
+var (
+	_ discovery.BlockingLister[consulBackendConfig, consulServiceInstance] = consulProvider{}
+	_ discovery.UnpollableReporter                                         = consulProvider{}
+)
+
+func newConsulEndpointsCollection(
+	ctx context.Context,
+	commoncol *plugincollections.CommonCollections,
+	backends krt.Collection[ir.BackendObjectIR],
+) *discovery.Collection[consulBackendConfig, consulServiceInstance] {
+	return discovery.NewCollection(ctx, backends, discovery.Provider[consulBackendConfig, consulServiceInstance](consulProvider{
+		client: newConsulHealthClient(),
+	}), discovery.Options{
+		Enabled:         commoncol.Settings.EnableConsulDiscovery,
+		RefreshInterval: consulMinQueryInterval,
+		PollTimeout:     consulInitialQueryTimeout,
+		// Failed queries are retried after consulMinQueryInterval, backing off
+		// up to the retry interval.
+		MaxBackoff: configuredConsulRetryInterval(commoncol.Settings),
+		KrtOpts:    commoncol.KrtOpts,
+	})
+}
+
+func configuredConsulRetryInterval(settings apisettings.Settings) time.Duration {
+	if settings.ConsulRetryInterval <= 0 {
+		return defaultConsulRetryInterval
+	}
+	return settings.ConsulRetryInterval
+}
+
+func (consulProvider) Name() string {
+	return "consul"
+}
+
+func (consulProvider) Config(backend ir.BackendObjectIR) (consulBackendConfig, bool) {
+	cfg := consulConfigFromBackend(backend)
+	if cfg == nil {
+		return consulBackendConfig{}, false
+	}
+	return *cfg, true
+}
+
+func (p consulProvider) List(ctx context.Context, cfg consulBackendConfig) ([]consulServiceInstance, error) {
+	instances, _, err := p.client.HealthService(ctx, cfg.query, 0)
+	return instances, err
+}
+
+// ListBlocking issues a blocking query from the X-Consul-Index of the previous
+// one.
+func (p consulProvider) ListBlocking(ctx context.Context, cfg consulBackendConfig, index uint64) ([]consulServiceInstance, uint64, error) {
+	instances, newIndex, err := p.client.HealthService(ctx, cfg.query, index)
+	if err != nil {
+		return nil, 0, err
+	}
+	if newIndex < index {
+		// The index went backwards, e.g. after a Consul snapshot restore, so it
+		// can no longer be used to block on.
+		newIndex = 0
+	}
+	return instances, newIndex, nil
+}
+
+// ClassifyError maps ACL rejections to AuthorizationError, and everything else
+// to DiscoveryError.
+func (consulProvider) ClassifyError(err error) (string, string) {
+	var statusErr *consulStatusError
+	if errors.As(err, &statusErr) &&
+		(statusErr.code == http.StatusUnauthorized || statusErr.code == http.StatusForbidden) {
+		return string(kgateway.BackendReasonAuthorizationError), err.Error()
+	}
+	return string(kgateway.BackendReasonDiscoveryError), err.Error()
+}
+
+// UnpollableStatus reports a CredentialError for backends whose token secret is
+// unresolved, which never build a query.
+func (consulProvider) UnpollableStatus(backend ir.BackendObjectIR) (discovery.Status, bool) {
+	obj, ok := backend.Obj.(*kgateway.Backend)
+	if !ok || obj.Spec.Consul == nil || obj.Spec.Consul.TokenSecretRef == nil || consulConfigFromBackend(backend) != nil {
+		return discovery.Status{}, false
+	}
+	return discovery.Status{
+		Status: metav1.ConditionFalse,
+		Reason: string(kgateway.BackendReasonCredentialError),
+		Message: fmt.Sprintf("consul token secret %q in namespace %q could not be resolved or has no %q key",
+			obj.Spec.Consul.TokenSecretRef.Name, obj.GetNamespace(), consulTokenSecretKey),
+	}, true
+}
+
+func consulConfigFromBackend(backend ir.BackendObjectIR) *consulBackendConfig {
+	obj, ok := backend.Obj.(*kgateway.Backend)
+	if !ok || obj.Spec.Consul == nil {
+		return nil
+	}
+	backendIR, ok := backend.ObjIr.(*backendIr)
+	if !ok || backendIR.consulIr == nil {
+		return nil
+	}
+	return &consulBackendConfig{
+		resourceName: backend.ResourceName(),
+		query:        backendIR.consulIr.query,
+	}
+}
+
+func (consulProvider) Select(cfg consulBackendConfig, instances []consulServiceInstance) ([]discovery.Endpoint, string) {
+	var endpoints []discovery.Endpoint
+	for _, instance := range instances {
+		if instance.address == "" || instance.port == 0 {
+			continue
+		}
+		endpoints = append(endpoints, discovery.Endpoint{
+			Address: instance.address,
+			Port:    instance.port,
+			Locality: ir.PodLocality{
+				Region: cmp.Or(instance.region, instance.datacenter),
+				Zone:   instance.zone,
+			},
+		})
+	}
+	slices.SortFunc(endpoints, func(a, b discovery.Endpoint) int {
+		return cmp.Or(
+			strings.Compare(a.Locality.Region, b.Locality.Region),
+			strings.Compare(a.Locality.Zone, b.Locality.Zone),
+			strings.Compare(a.Address, b.Address),
+			cmp.Compare(a.Port, b.Port),
+		)
+	})
+	// Several registrations can share an address and port, e.g. when a service
+	// is registered with more than one agent.
+	endpoints = slices.Compact(endpoints)
+
+	message := fmt.Sprintf("last poll succeeded but service %q has no passing instances", cfg.query.service)
+	if len(cfg.query.tags) > 0 {
+		message += fmt.Sprintf(" with tags [%s]", strings.Join(cfg.query.tags, ", "))
+	}
+	return endpoints, message
+}
+
+type TestConsulInstance struct {
+	Address    string
+	Port       uint32
+	Datacenter string
+	Zone       string
+}
+
+type staticConsulHealthClient struct {
+	instances []consulServiceInstance
+}
+
+func (s staticConsulHealthClient) HealthService(_ context.Context, _ consulQuery, _ uint64) ([]consulServiceInstance, uint64, error) {
+	return slices.Clone(s.instances), 1, nil
+}
+
+// SetConsulInstancesForTest replaces Consul discovery with a static test client
+// that returns the given instances for every service. The returned function
+// restores the default implementation.
+func SetConsulInstancesForTest(instances []TestConsulInstance) func() {
+	old := newConsulHealthClient
+	converted := make([]consulServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		converted = append(converted, consulServiceInstance{
+			address:    instance.Address,
+			port:       instance.Port,
+			datacenter: instance.Datacenter,
+			zone:       instance.Zone,
+		})
+	}
+	newConsulHealthClient = func() consulHealthClient {
+		return staticConsulHealthClient{instances: converted}
+	}
+	return func() {
+		newConsulHealthClient = old
+	}
+}
@@ -0,0 +1,325 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	plugincollections "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/discovery"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestBuildConsulIr(t *testing.T) {
+	in := &kgateway.ConsulBackend{
+		Address:    "https://consul.example.com:8501/",
+		Service:    "billing",
+		Tags:       []string{"v2", "primary", "v2"},
+		Datacenter: new("dc2"),
+	}
+
+	got, err := buildConsulIr(in, &ir.Secret{Data: map[string][]byte{"token": []byte("acl-token\n")}})
+	if err != nil {
+		t.Fatalf("buildConsulIr() error = %v", err)
+	}
+	want := consulQuery{
+		address:    "https://consul.example.com:8501",
+		service:    "billing",
+		tags:       []string{"primary", "v2"},
+		datacenter: "dc2",
+		token:      "acl-token",
+		wait:       defaultConsulWaitTime,
+	}
+	if !got.query.Equals(want) {
+		t.Fatalf("buildConsulIr() query = %+v, want %+v", got.query, want)
+	}
+
+	if _, err := buildConsulIr(in, &ir.Secret{Data: map[string][]byte{"other": []byte("x")}}); err == nil {
+		t.Fatal("buildConsulIr() error = nil, want an error for a secret without a token key")
+	}
+	if _, err := buildConsulIr(&kgateway.ConsulBackend{Address: "consul:8500", Service: "billing"}, nil); err == nil {
+		t.Fatal("buildConsulIr() error = nil, want an error for an address without a scheme")
+	}
+}
+
+func TestHttpConsulHealthClientBlockingQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/health/service/billing" {
+			t.Errorf("path = %q, want /v1/health/service/billing", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("passing") != "true" || q.Get("dc") != "dc2" || q.Get("ns") != "team-a" ||
+			q.Get("index") != "41" || q.Get("wait") != "30s" || !slices.Equal(q["tag"], []string{"primary", "v2"}) {
+			t.Errorf("query = %v, want a passing blocking query in dc2/team-a for tags primary,v2", q)
+		}
+		if got := r.Header.Get("X-Consul-Token"); got != "acl-token" {
+			t.Errorf("X-Consul-Token = %q, want acl-token", got)
+		}
+		w.Header().Set("X-Consul-Index", "42")
+		_, _ = w.Write([]byte(`[
+			{"Node":{"Address":"10.0.0.1","Datacenter":"dc2"},"Service":{"Address":"10.1.0.1","Port":8080,"Tags":["primary","v2"]}},
+			{"Node":{"Address":"10.0.0.2","Datacenter":"dc2"},"Service":{"Port":8081,"Tags":["v2","primary"],"Locality":{"Region":"us-east-1","Zone":"us-east-1a"}}},
+			{"Node":{"Address":"10.0.0.3","Datacenter":"dc2"},"Service":{"Address":"10.1.0.3","Port":8080,"Tags":["v2"]}}
+		]`))
+	}))
+	defer server.Close()
+
+	client := &httpConsulHealthClient{client: server.Client()}
+	instances, index, err := client.HealthService(context.Background(), consulQuery{
+		address:    server.URL,
+		service:    "billing",
+		tags:       []string{"primary", "v2"},
+		datacenter: "dc2",
+		namespace:  "team-a",
+		token:      "acl-token",
+		wait:       30 * time.Second,
+	}, 41)
+	if err != nil {
+		t.Fatalf("HealthService() error = %v", err)
+	}
+	if index != 42 {
+		t.Fatalf("HealthService() index = %d, want 42", index)
+	}
+	want := []consulServiceInstance{
+		{address: "10.1.0.1", port: 8080, datacenter: "dc2"},
+		{address: "10.0.0.2", port: 8081, datacenter: "dc2", region: "us-east-1", zone: "us-east-1a"},
+	}
+	if !slices.Equal(instances, want) {
+		t.Fatalf("HealthService() instances = %+v, want %+v", instances, want)
+	}
+}
+
+func TestHttpConsulHealthClientClassifiesACLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "ACL not found", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &httpConsulHealthClient{client: server.Client()}
+	_, _, err := client.HealthService(context.Background(), consulQuery{address: server.URL, service: "billing"}, 0)
+	if err == nil {
+		t.Fatal("HealthService() error = nil, want a status error")
+	}
+	reason, message := consulProvider{}.ClassifyError(err)
+	if reason != string(kgateway.BackendReasonAuthorizationError) {
+		t.Fatalf("ClassifyError() reason = %q, want %q", reason, kgateway.BackendReasonAuthorizationError)
+	}
+	if message != "consul returned 403 Forbidden: ACL not found" {
+		t.Fatalf("ClassifyError() message = %q", message)
+	}
+
+	reason, _ = consulProvider{}.ClassifyError(errors.New("connection refused"))
+	if reason != string(kgateway.BackendReasonDiscoveryError) {
+		t.Fatalf("ClassifyError() reason = %q, want %q", reason, kgateway.BackendReasonDiscoveryError)
+	}
+}
+
+func TestConsulProviderSelect(t *testing.T) {
+	cfg := consulBackendConfig{
+		resourceName: "default/billing",
+		query:        consulQuery{address: "http://consul:8500", service: "billing", tags: []string{"v2"}},
+	}
+
+	endpoints, _ := consulProvider{}.Select(cfg, []consulServiceInstance{
+		{address: "10.0.0.2", port: 8080, datacenter: "dc1"},
+		{address: "10.0.0.1", port: 8080, datacenter: "dc1"},
+		{address: "10.0.0.1", port: 8080, datacenter: "dc1"},
+		{address: "10.0.0.3", port: 8080, datacenter: "dc1", region: "us-east-1", zone: "us-east-1a"},
+		// Instances without an address or port are skipped.
+		{address: "10.0.0.4", datacenter: "dc1"},
+	})
+	want := []discovery.Endpoint{
+		{Address: "10.0.0.1", Port: 8080, Locality: ir.PodLocality{Region: "dc1"}},
+		{Address: "10.0.0.2", Port: 8080, Locality: ir.PodLocality{Region: "dc1"}},
+		{Address: "10.0.0.3", Port: 8080, Locality: ir.PodLocality{Region: "us-east-1", Zone: "us-east-1a"}},
+	}
+	if !slices.Equal(endpoints, want) {
+		t.Fatalf("Select() endpoints = %+v, want %+v", endpoints, want)
+	}
+
+	if endpoints, message := (consulProvider{}).Select(cfg, nil); len(endpoints) != 0 || !strings.Contains(message, "with tags [v2]") {
+		t.Fatalf("Select() without instances = %+v, %q, want no endpoints and a message naming the tags", endpoints, message)
+	}
+}
+
+func TestConsulProviderListBlockingResetsIndex(t *testing.T) {
+	cfg := consulBackendConfig{query: consulQuery{address: "http://consul:8500", service: "billing"}}
+	p := consulProvider{client: &fakeConsulHealthClient{indexes: []uint64{3}}}
+
+	// The index went backwards, so the next query must not block on it.
+	if _, index, err := p.ListBlocking(t.Context(), cfg, 7); err != nil || index != 0 {
+		t.Fatalf("ListBlocking() = %d, %v, want index 0", index, err)
+	}
+}
+
+func TestConsulListScopeHidesToken(t *testing.T) {
+	cfg := consulBackendConfig{query: consulQuery{address: "http://consul:8500", service: "billing", token: "acl-token"}}
+	other := cfg
+	other.query.token = "other-token"
+
+	if strings.Contains(cfg.ListScope(), "acl-token") {
+		t.Fatalf("ListScope() = %q, want the token to be hashed", cfg.ListScope())
+	}
+	if cfg.ListScope() == other.ListScope() {
+		t.Fatal("ListScope() is the same for queries with different tokens")
+	}
+}
+
+func TestConsulEndpointsCollectionWatchesBackends(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &fakeConsulHealthClient{
+		instances: []consulServiceInstance{{address: "10.0.0.1", port: 8080, datacenter: "dc1"}},
+		updates:   make(chan []consulServiceInstance),
+	}
+	defer setConsulHealthClientForTest(client)()
+
+	backends := krt.NewStaticCollection(nil, []ir.BackendObjectIR{
+		consulBackendObjectIR(t, newConsulBackend("billing")),
+	})
+	c := newConsulEndpointsCollection(ctx, &plugincollections.CommonCollections{
+		Settings: apisettings.Settings{EnableConsulDiscovery: true},
+	}, backends)
+
+	if !c.Endpoints.WaitUntilSynced(ctx.Done()) {
+		t.Fatal("Endpoints failed to sync")
+	}
+	assertConsulEndpoints(t, c, "10.0.0.1")
+
+	// The watcher blocks on the index of the initial query and picks up the change.
+	client.updates <- []consulServiceInstance{
+		{address: "10.0.0.1", port: 8080, datacenter: "dc1"},
+		{address: "10.0.0.2", port: 8080, datacenter: "dc1"},
+	}
+	assertConsulEndpoints(t, c, "10.0.0.1", "10.0.0.2")
+
+	statuses := c.DiscoveryStatus.List()
+	if len(statuses) != 1 || statuses[0].Conditions[0].Reason != string(kgateway.BackendReasonDiscovered) {
+		t.Fatalf("DiscoveryStatus = %+v, want a single Discovered condition", statuses)
+	}
+}
+
+func TestConsulProviderReportsCredentialError(t *testing.T) {
+	be := newConsulBackend("billing")
+	be.Spec.Consul.TokenSecretRef = &corev1.LocalObjectReference{Name: "consul-token"}
+	backend := ir.NewBackendObjectIR(ir.ObjectSource{Namespace: be.Namespace, Name: be.Name}, 0, "", ExtensionName)
+	backend.Obj = be
+	backend.ObjIr = buildTranslateFunc(nil, newSecretIndexForTest(t), translateOptions{enableConsulDiscovery: true})(krt.TestingDummyContext{}, be)
+
+	if _, ok := (consulProvider{}).Config(backend); ok {
+		t.Fatal("Config() = true, want backends with an unresolved token to be unpollable")
+	}
+	status, ok := consulProvider{}.UnpollableStatus(backend)
+	if !ok || status.Reason != string(kgateway.BackendReasonCredentialError) {
+		t.Fatalf("UnpollableStatus() = %+v, %v, want a CredentialError condition", status, ok)
+	}
+}
+
+func TestBuildTranslateFuncRejectsConsulWhenDiscoveryDisabled(t *testing.T) {
+	backendIR := buildTranslateFunc(nil, nil, translateOptions{})(krt.TestingDummyContext{}, newConsulBackend("billing"))
+
+	if len(backendIR.errors) != 1 || !errors.Is(backendIR.errors[0], errConsulDiscoveryDisabled) {
+		t.Fatalf("translate() errors = %v, want %v", backendIR.errors, errConsulDiscoveryDisabled)
+	}
+	if backendIR.consulIr != nil {
+		t.Fatal("translate() unexpectedly built Consul IR while Consul discovery was disabled")
+	}
+}
+
+// fakeConsulHealthClient answers non-blocking queries with instances and
+// blocking ones with the next value sent on updates, or with the next of
+// indexes while there are any.
+type fakeConsulHealthClient struct {
+	instances []consulServiceInstance
+	updates   chan []consulServiceInstance
+	indexes   []uint64
+}
+
+func (f *fakeConsulHealthClient) HealthService(ctx context.Context, _ consulQuery, index uint64) ([]consulServiceInstance, uint64, error) {
+	if len(f.indexes) > 0 {
+		next := f.indexes[0]
+		f.indexes = f.indexes[1:]
+		return f.instances, next, nil
+	}
+	if index == 0 {
+		return f.instances, 1, nil
+	}
+	select {
+	case instances := <-f.updates:
+		return instances, index + 1, nil
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	}
+}
+
+func setConsulHealthClientForTest(client consulHealthClient) func() {
+	old := newConsulHealthClient
+	newConsulHealthClient = func() consulHealthClient { return client }
+	return func() { newConsulHealthClient = old }
+}
+
+func assertConsulEndpoints(t *testing.T, c *discovery.Collection[consulBackendConfig, consulServiceInstance], want ...string) {
+	t.Helper()
+	var got []string
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		got = nil
+		for _, eps := range c.Endpoints.List() {
+			for _, lbEps := range eps.LbEps {
+				for _, ep := range lbEps {
+					got = append(got, ep.GetEndpoint().GetAddress().GetSocketAddress().GetAddress())
+				}
+			}
+		}
+		slices.Sort(got)
+		if slices.Equal(got, want) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("endpoints = %v, want %v", got, want)
+}
+
+func newConsulBackend(name string) *kgateway.Backend {
+	return &kgateway.Backend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: kgateway.BackendSpec{
+			Consul: &kgateway.ConsulBackend{
+				Address: "http://consul.consul.svc:8500",
+				Service: name,
+			},
+		},
+	}
+}
+
+func consulBackendObjectIR(t *testing.T, be *kgateway.Backend) ir.BackendObjectIR {
+	t.Helper()
+	out := ir.NewBackendObjectIR(ir.ObjectSource{
+		Group:     "gateway.kgateway.dev",
+		Kind:      "Backend",
+		Namespace: be.Namespace,
+		Name:      be.Name,
+	}, 0, "", ExtensionName)
+	out.Obj = be
+	consulIr, err := buildConsulIr(be.Spec.Consul, nil)
+	if err != nil {
+		t.Fatalf("buildConsulIr() error = %v", err)
+	}
+	out.ObjIr = &backendIr{consulIr: consulIr}
+	return out
+}
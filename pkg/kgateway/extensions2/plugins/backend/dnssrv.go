@@ -0,0 +1,464 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/miekg/dns"
+	"istio.io/istio/pkg/kube/krt"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	plugincollections "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/discovery"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
+)
+
+const (
+	defaultDnsSrvMinRefreshInterval = 5 * time.Second
+	// dnsSrvMaxRefreshInterval caps both the TTL of the records and the backoff
+	// after failed resolutions, so that changes are picked up in a bounded time.
+	dnsSrvMaxRefreshInterval = 5 * time.Minute
+	dnsSrvResolveTimeout     = 10 * time.Second
+	dnsSrvResolvConf         = "/etc/resolv.conf"
+)
+
+var errDnsSrvDiscoveryDisabled = errors.New("dns srv discovery is disabled by controller settings")
+
+// DnsSrvIr is the internal representation of a DNS SRV backend.
+type DnsSrvIr struct {
+	query dnsSrvQuery // +noKrtEquals
+}
+
+func (u *DnsSrvIr) Equals(other *DnsSrvIr) bool {
+	return cmputils.CompareWithNils(u, other, func(a, b *DnsSrvIr) bool {
+		return a.query.Equals(b.query)
+	})
+}
+
+type dnsSrvQuery struct {
+	name string
+	// nameservers are host:port addresses. When empty, the nameservers of the
+	// resolv.conf of the controller are used.
+	nameservers []string
+}
+
+func (q dnsSrvQuery) Equals(other dnsSrvQuery) bool {
+	return q.name == other.name && slices.Equal(q.nameservers, other.nameservers)
+}
+
+func buildDnsSrvIr(in *kgateway.DnsSrvBackend) (*DnsSrvIr, error) {
+	if in == nil {
+		return nil, fmt.Errorf("dns srv config is nil")
+	}
+	query := dnsSrvQuery{name: dns.Fqdn(strings.ToLower(in.Name))}
+	if _, ok := dns.IsDomainName(query.name); !ok {
+		return nil, fmt.Errorf("dns srv name %q is not a valid domain name", in.Name)
+	}
+	for _, nameserver := range in.Nameservers {
+		address, err := dnsSrvNameserverAddress(nameserver)
+		if err != nil {
+			return nil, err
+		}
+		query.nameservers = append(query.nameservers, address)
+	}
+	return &DnsSrvIr{query: query}, nil
+}
+
+// dnsSrvNameserverAddress normalizes a nameserver to a host:port address,
+// defaulting to port 53.
+func dnsSrvNameserverAddress(nameserver string) (string, error) {
+	host, port := strings.Trim(nameserver, "[]"), "53"
+	if net.ParseIP(host) == nil {
+		var err error
+		host, port, err = net.SplitHostPort(nameserver)
+		if err != nil || net.ParseIP(host) == nil {
+			return "", fmt.Errorf("dns srv nameserver %q must be an IP address with an optional port", nameserver)
+		}
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return "", fmt.Errorf("dns srv nameserver %q has an invalid port", nameserver)
+		}
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+func processDnsSrv(_ *DnsSrvIr, out *envoyclusterv3.Cluster) {
+	out.ClusterDiscoveryType = &envoyclusterv3.Cluster_Type{
+		Type: envoyclusterv3.Cluster_EDS,
+	}
+	out.EdsClusterConfig = &envoyclusterv3.Cluster_EdsClusterConfig{
+		EdsConfig: &envoycorev3.ConfigSource{
+			ResourceApiVersion: envoycorev3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoycorev3.ConfigSource_Ads{
+				Ads: &envoycorev3.AggregatedConfigSource{},
+			},
+		},
+	}
+	out.IgnoreHealthOnHostRemoval = true
+}
+
+type dnsSrvBackendConfig struct {
+	resourceName string
+	query        dnsSrvQuery
+}
+
+// ListScope groups the backends that resolve the same record from the same
+// nameservers.
+func (c dnsSrvBackendConfig) ListScope() string {
+	return strings.Join(append([]string{c.query.name}, c.query.nameservers...), "\x00")
+}
+
+func (c dnsSrvBackendConfig) Equals(other dnsSrvBackendConfig) bool {
+	return c.query.Equals(other.query)
+}
+
+// EndpointsEqual ignores the nameservers, which only change where the record is
+// resolved from.
+func (c dnsSrvBackendConfig) EndpointsEqual(other dnsSrvBackendConfig) bool {
+	return c.query.name == other.query.name
+}
+
+// dnsSrvRecord is an SRV record with the addresses of its target.
+type dnsSrvRecord struct {
+	addresses []string
+	port      uint32
+	priority  uint16
+	weight    uint16
+}
+
+type dnsSrvResolver interface {
+	// ResolveSRV returns the SRV records of the query along with the lowest TTL of
+	// the records used to resolve them.
+	ResolveSRV(ctx context.Context, query dnsSrvQuery) ([]dnsSrvRecord, time.Duration, error)
+}
+
+// dnsRcodeError is returned when a nameserver answers with an error code.
+type dnsRcodeError struct {
+	name  string
+	rcode int
+}
+
+func (e *dnsRcodeError) Error() string {
+	return fmt.Sprintf("resolving %s failed with %s", e.name, dns.RcodeToString[e.rcode])
+}
+
+type miekgDnsSrvResolver struct {
+	resolvConf string
+}
+
+var newDnsSrvResolver = func() dnsSrvResolver {
+	return &miekgDnsSrvResolver{resolvConf: dnsSrvResolvConf}
+}
+
+func (r *miekgDnsSrvResolver) ResolveSRV(ctx context.Context, query dnsSrvQuery) ([]dnsSrvRecord, time.Duration, error) {
+	nameservers := query.nameservers
+	if len(nameservers) == 0 {
+		cfg, err := dns.ClientConfigFromFile(r.resolvConf)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read nameservers: %w", err)
+		}
+		for _, server := range cfg.Servers {
+			nameservers = append(nameservers, net.JoinHostPort(server, cfg.Port))
+		}
+	}
+
+	resp, err := exchangeDns(ctx, nameservers, query.name, dns.TypeSRV)
+	if err != nil {
+		return nil, 0, err
+	}
+	ttl := dnsSrvMaxRefreshInterval
+	observeTTL := func(rr dns.RR) {
+		ttl = min(ttl, time.Duration(rr.Header().Ttl)*time.Second)
+	}
+
+	// Nameservers usually return the addresses of the targets in the additional section.
+	additional := map[string][]string{}
+	for _, rr := range resp.Extra {
+		switch rr := rr.(type) {
+		case *dns.A:
+			additional[strings.ToLower(rr.Hdr.Name)] = append(additional[strings.ToLower(rr.Hdr.Name)], rr.A.String())
+		case *dns.AAAA:
+			additional[strings.ToLower(rr.Hdr.Name)] = append(additional[strings.ToLower(rr.Hdr.Name)], rr.AAAA.String())
+		}
+	}
+
+	var records []dnsSrvRecord
+	for _, rr := range resp.Answer {
+		srv, ok := rr.(*dns.SRV)
+		if !ok {
+			continue
+		}
+		observeTTL(srv)
+		// A target of "." means that the service is decidedly not available.
+		if srv.Target == "." {
+			continue
+		}
+		target := strings.ToLower(srv.Target)
+		addresses, ok := additional[target]
+		if ok {
+			for _, extra := range resp.Extra {
+				if strings.EqualFold(extra.Header().Name, target) {
+					observeTTL(extra)
+				}
+			}
+		} else {
+			addresses, err = resolveDnsTarget(ctx, nameservers, target, observeTTL)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		records = append(records, dnsSrvRecord{
+			addresses: addresses,
+			port:      uint32(srv.Port),
+			priority:  srv.Priority,
+			weight:    srv.Weight,
+		})
+	}
+	return records, ttl, nil
+}
+
+// resolveDnsTarget returns the IPv4 addresses of a target, or its IPv6 addresses
+// when it has none.
+func resolveDnsTarget(ctx context.Context, nameservers []string, target string, observeTTL func(dns.RR)) ([]string, error) {
+	var addresses []string
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		resp, err := exchangeDns(ctx, nameservers, target, qtype)
+		var rcodeErr *dnsRcodeError
+		if errors.As(err, &rcodeErr) && rcodeErr.rcode == dns.RcodeNameError {
+			// The record points at a target that does not exist, which only
+			// removes that target from the backend.
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, rr := range resp.Answer {
+			switch rr := rr.(type) {
+			case *dns.A:
+				addresses = append(addresses, rr.A.String())
+				observeTTL(rr)
+			case *dns.AAAA:
+				addresses = append(addresses, rr.AAAA.String())
+				observeTTL(rr)
+			}
+		}
+		if len(addresses) > 0 {
+			break
+		}
+	}
+	return addresses, nil
+}
+
+// exchangeDns sends a query to each nameserver in turn until one of them
+// answers it, retrying over TCP when the UDP response is truncated.
+func exchangeDns(ctx context.Context, nameservers []string, name string, qtype uint16) (*dns.Msg, error) {
+	if len(nameservers) == 0 {
+		return nil, errors.New("no nameservers configured")
+	}
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qtype)
+	msg.RecursionDesired = true
+
+	var errs []error
+	for _, nameserver := range nameservers {
+		resp, _, err := (&dns.Client{Net: "udp"}).ExchangeContext(ctx, msg, nameserver)
+		if err == nil && resp.Truncated {
+			resp, _, err = (&dns.Client{Net: "tcp"}).ExchangeContext(ctx, msg, nameserver)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", nameserver, err))
+			continue
+		}
+		switch resp.Rcode {
+		case dns.RcodeSuccess:
+			return resp, nil
+		case dns.RcodeNameError:
+			// The name does not exist, which other nameservers would confirm.
+			return nil, &dnsRcodeError{name: name, rcode: resp.Rcode}
+		default:
+			errs = append(errs, fmt.Errorf("%s: %w", nameserver, &dnsRcodeError{name: name, rcode: resp.Rcode}))
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// dnsSrvProvider discovers the endpoints of DNS SRV backends by resolving their
+// records again whenever their TTL expires.
+type dnsSrvProvider struct {
+	resolver dnsSrvResolver
+}
+
+var _ discovery.BlockingLister[dnsSrvBackendConfig, dnsSrvRecord] = dnsSrvProvider{}
+
+func newDnsSrvEndpointsCollection(
+	ctx context.Context,
+	commoncol *plugincollections.CommonCollections,
+	backends krt.Collection[ir.BackendObjectIR],
+) *discovery.Collection[dnsSrvBackendConfig, dnsSrvRecord] {
+	return discovery.NewCollection(ctx, backends, discovery.Provider[dnsSrvBackendConfig, dnsSrvRecord](dnsSrvProvider{
+		resolver: newDnsSrvResolver(),
+	}), discovery.Options{
+		Enabled: commoncol.Settings.EnableDnsSrvDiscovery,
+		// Records are resolved again when their TTL expires, but no sooner than
+		// the min refresh interval.
+		RefreshInterval: configuredDnsSrvMinRefreshInterval(commoncol.Settings),
+		PollTimeout:     dnsSrvResolveTimeout,
+		MaxBackoff:      dnsSrvMaxRefreshInterval,
+		KrtOpts:         commoncol.KrtOpts,
+	})
+}
+
+func configuredDnsSrvMinRefreshInterval(settings apisettings.Settings) time.Duration {
+	if settings.DnsSrvMinRefreshInterval <= 0 {
+		return defaultDnsSrvMinRefreshInterval
+	}
+	return min(settings.DnsSrvMinRefreshInterval, dnsSrvMaxRefreshInterval)
+}
+
+func (dnsSrvProvider) Name() string {
+	return "dnssrv"
+}
+
+func (dnsSrvProvider) Config(backend ir.BackendObjectIR) (dnsSrvBackendConfig, bool) {
+	cfg := dnsSrvConfigFromBackend(backend)
+	if cfg == nil {
+		return dnsSrvBackendConfig{}, false
+	}
+	return *cfg, true
+}
+
+func (p dnsSrvProvider) List(ctx context.Context, cfg dnsSrvBackendConfig) ([]dnsSrvRecord, error) {
+	records, _, err := p.ListBlocking(ctx, cfg, 0)
+	return records, err
+}
+
+// ListBlocking resolves the records of cfg once the records of the resolution
+// identified by index expired. The index of a resolution is the time its
+// records expire, in Unix nanoseconds.
+func (p dnsSrvProvider) ListBlocking(ctx context.Context, cfg dnsSrvBackendConfig, index uint64) ([]dnsSrvRecord, uint64, error) {
+	if index > 0 {
+		expiry := time.Unix(0, int64(index)) //nolint:gosec // G115: the index is a time returned by a previous resolution
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case <-time.After(time.Until(expiry)):
+		}
+	}
+
+	rctx, cancel := context.WithTimeout(ctx, dnsSrvResolveTimeout)
+	defer cancel()
+	records, ttl, err := p.resolver.ResolveSRV(rctx, cfg.query)
+	if err != nil {
+		return nil, 0, err
+	}
+	expiry := time.Now().Add(min(ttl, dnsSrvMaxRefreshInterval))
+	return records, uint64(expiry.UnixNano()), nil //nolint:gosec // G115: the current time is after the epoch
+}
+
+func (dnsSrvProvider) ClassifyError(err error) (string, string) {
+	return string(kgateway.BackendReasonDiscoveryError), err.Error()
+}
+
+func dnsSrvConfigFromBackend(backend ir.BackendObjectIR) *dnsSrvBackendConfig {
+	obj, ok := backend.Obj.(*kgateway.Backend)
+	if !ok || obj.Spec.DnsSrv == nil {
+		return nil
+	}
+	backendIR, ok := backend.ObjIr.(*backendIr)
+	if !ok || backendIR.dnsSrvIr == nil {
+		return nil
+	}
+	return &dnsSrvBackendConfig{
+		resourceName: backend.ResourceName(),
+		query:        backendIR.dnsSrvIr.query,
+	}
+}
+
+// Select returns an endpoint for every address and port that the records target.
+// The priority of an endpoint is the rank of its SRV priority among the
+// priorities of all the records, so that the levels are contiguous.
+func (dnsSrvProvider) Select(cfg dnsSrvBackendConfig, records []dnsSrvRecord) ([]discovery.Endpoint, string) {
+	// An address and port can be the target of several records, in which case
+	// the record with the highest priority wins.
+	type target struct {
+		address string
+		port    uint32
+	}
+	targets := map[target]dnsSrvRecord{}
+	for _, record := range records {
+		for _, address := range record.addresses {
+			key := target{address: address, port: record.port}
+			if existing, ok := targets[key]; ok && existing.priority <= record.priority {
+				continue
+			}
+			targets[key] = record
+		}
+	}
+
+	priorities := map[uint16]struct{}{}
+	for _, record := range targets {
+		priorities[record.priority] = struct{}{}
+	}
+	ranks := slices.Sorted(maps.Keys(priorities))
+
+	var endpoints []discovery.Endpoint
+	for key, record := range targets {
+		rank, _ := slices.BinarySearch(ranks, record.priority)
+		endpoints = append(endpoints, discovery.Endpoint{
+			Address:  key.address,
+			Port:     key.port,
+			Priority: uint32(rank), //nolint:gosec // G115: rank is bounded by the number of distinct uint16 priorities
+			// A weight of 0 is only meant to make the target unlikely to be
+			// selected, while Envoy requires a weight of at least 1.
+			Weight: max(uint32(record.weight), 1),
+		})
+	}
+	return endpoints, fmt.Sprintf("last poll succeeded but SRV record %s has no targets with an address", cfg.query.name)
+}
+
+type TestDnsSrvRecord struct {
+	Addresses []string
+	Port      uint32
+	Priority  uint16
+	Weight    uint16
+}
+
+type staticDnsSrvResolver struct {
+	records []dnsSrvRecord
+}
+
+func (s staticDnsSrvResolver) ResolveSRV(_ context.Context, _ dnsSrvQuery) ([]dnsSrvRecord, time.Duration, error) {
+	return slices.Clone(s.records), dnsSrvMaxRefreshInterval, nil
+}
+
+// SetDnsSrvRecordsForTest replaces DNS SRV resolution with a static test resolver
+// that returns the given records for every name. The returned function restores
+// the default implementation.
+func SetDnsSrvRecordsForTest(records []TestDnsSrvRecord) func() {
+	old := newDnsSrvResolver
+	converted := make([]dnsSrvRecord, 0, len(records))
+	for _, record := range records {
+		converted = append(converted, dnsSrvRecord{
+			addresses: slices.Clone(record.Addresses),
+			port:      record.Port,
+			priority:  record.Priority,
+			weight:    record.Weight,
+		})
+	}
+	newDnsSrvResolver = func() dnsSrvResolver {
+		return staticDnsSrvResolver{records: converted}
+	}
+	return func() {
+		newDnsSrvResolver = old
+	}
+}
@@ -0,0 +1,191 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/discovery"
+)
+
+func TestBuildDnsSrvIr(t *testing.T) {
+	got, err := buildDnsSrvIr(&kgateway.DnsSrvBackend{
+		Name:        "_grpc._tcp.Billing.example.com",
+		Nameservers: []string{"10.0.0.10", "10.0.0.11:5353", "fd00::10", "[fd00::11]:5353"},
+	})
+	if err != nil {
+		t.Fatalf("buildDnsSrvIr() error = %v", err)
+	}
+	want := dnsSrvQuery{
+		name:        "_grpc._tcp.billing.example.com.",
+		nameservers: []string{"10.0.0.10:53", "10.0.0.11:5353", "[fd00::10]:53", "[fd00::11]:5353"},
+	}
+	if !got.query.Equals(want) {
+		t.Fatalf("buildDnsSrvIr() query = %+v, want %+v", got.query, want)
+	}
+
+	for _, nameserver := range []string{"dns.example.com", "10.0.0.10:0", "10.0.0.10:dns"} {
+		if _, err := buildDnsSrvIr(&kgateway.DnsSrvBackend{Name: "_http._tcp.example.com", Nameservers: []string{nameserver}}); err == nil {
+			t.Errorf("buildDnsSrvIr() with nameserver %q succeeded, want an error", nameserver)
+		}
+	}
+}
+
+func TestMiekgDnsSrvResolver(t *testing.T) {
+	nameserver := startDnsServerForTest(t, dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		q := req.Question[0]
+		switch {
+		case q.Name == "_http._tcp.billing.example.com." && q.Qtype == dns.TypeSRV:
+			resp.Answer = []dns.RR{
+				mustRR(t, "_http._tcp.billing.example.com. 300 IN SRV 10 60 8080 a.billing.example.com."),
+				mustRR(t, "_http._tcp.billing.example.com. 120 IN SRV 20 0 8080 b.billing.example.com."),
+				mustRR(t, "_http._tcp.billing.example.com. 300 IN SRV 0 0 0 ."),
+			}
+			resp.Extra = []dns.RR{mustRR(t, "a.billing.example.com. 60 IN A 10.0.0.1")}
+		case q.Name == "b.billing.example.com." && q.Qtype == dns.TypeAAAA:
+			resp.Answer = []dns.RR{mustRR(t, "b.billing.example.com. 30 IN AAAA fd00::2")}
+		case q.Name == "missing.example.com.":
+			resp.Rcode = dns.RcodeNameError
+		}
+		_ = w.WriteMsg(resp)
+	}))
+	resolver := &miekgDnsSrvResolver{}
+
+	records, ttl, err := resolver.ResolveSRV(t.Context(), dnsSrvQuery{name: "_http._tcp.billing.example.com.", nameservers: []string{nameserver}})
+	if err != nil {
+		t.Fatalf("ResolveSRV() error = %v", err)
+	}
+	want := []dnsSrvRecord{
+		{addresses: []string{"10.0.0.1"}, port: 8080, priority: 10, weight: 60},
+		// b has no IPv4 address, so it is resolved to its IPv6 address.
+		{addresses: []string{"fd00::2"}, port: 8080, priority: 20, weight: 0},
+	}
+	if !slices.EqualFunc(records, want, func(a, b dnsSrvRecord) bool {
+		return slices.Equal(a.addresses, b.addresses) && a.port == b.port && a.priority == b.priority && a.weight == b.weight
+	}) {
+		t.Fatalf("ResolveSRV() records = %+v, want %+v", records, want)
+	}
+	if ttl != 30*time.Second {
+		t.Fatalf("ResolveSRV() ttl = %v, want the lowest TTL of the records used", ttl)
+	}
+
+	_, _, err = resolver.ResolveSRV(t.Context(), dnsSrvQuery{name: "missing.example.com.", nameservers: []string{nameserver}})
+	var rcodeErr *dnsRcodeError
+	if !errors.As(err, &rcodeErr) || rcodeErr.rcode != dns.RcodeNameError {
+		t.Fatalf("ResolveSRV() error = %v, want NXDOMAIN", err)
+	}
+}
+
+func TestDnsSrvProviderSelectRanksPriorities(t *testing.T) {
+	cfg := dnsSrvBackendConfig{resourceName: "billing", query: dnsSrvQuery{name: "_http._tcp.billing.example.com."}}
+	endpoints, _ := dnsSrvProvider{}.Select(cfg, []dnsSrvRecord{
+		{addresses: []string{"10.0.0.3"}, port: 8080, priority: 50, weight: 0},
+		{addresses: []string{"10.0.0.1", "10.0.0.2"}, port: 8080, priority: 10, weight: 60},
+		// The same target with a lower priority is ignored.
+		{addresses: []string{"10.0.0.1"}, port: 8080, priority: 50, weight: 10},
+	})
+	slices.SortFunc(endpoints, func(a, b discovery.Endpoint) int { return strings.Compare(a.Address, b.Address) })
+
+	want := []discovery.Endpoint{
+		{Address: "10.0.0.1", Port: 8080, Priority: 0, Weight: 60},
+		{Address: "10.0.0.2", Port: 8080, Priority: 0, Weight: 60},
+		{Address: "10.0.0.3", Port: 8080, Priority: 1, Weight: 1},
+	}
+	if !slices.Equal(endpoints, want) {
+		t.Fatalf("Select() endpoints = %+v, want %+v", endpoints, want)
+	}
+
+	if endpoints, message := (dnsSrvProvider{}).Select(cfg, nil); len(endpoints) != 0 || !strings.Contains(message, cfg.query.name) {
+		t.Fatalf("Select() without records = %+v, %q, want no endpoints and a message naming the record", endpoints, message)
+	}
+}
+
+func TestDnsSrvProviderListBlockingWaitsForTTL(t *testing.T) {
+	cfg := dnsSrvBackendConfig{resourceName: "billing", query: dnsSrvQuery{name: "_http._tcp.billing.example.com."}}
+
+	before := time.Now()
+	_, index, err := dnsSrvProvider{resolver: fakeDnsSrvResolver{ttl: time.Hour}}.ListBlocking(t.Context(), cfg, 0)
+	if err != nil {
+		t.Fatalf("ListBlocking() error = %v", err)
+	}
+	expiry := time.Unix(0, int64(index)) //nolint:gosec // G115: the index is a time
+	if expiry.Before(before.Add(dnsSrvMaxRefreshInterval)) || expiry.After(time.Now().Add(dnsSrvMaxRefreshInterval)) {
+		t.Errorf("ListBlocking() with a high TTL expires at %v, want %v after the resolution", expiry, dnsSrvMaxRefreshInterval)
+	}
+
+	// A resolution is not repeated before its records expire.
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if _, _, err := (dnsSrvProvider{resolver: fakeDnsSrvResolver{ttl: time.Hour}}).ListBlocking(ctx, cfg, index); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ListBlocking() before the records expire error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if _, _, err := (dnsSrvProvider{resolver: fakeDnsSrvResolver{err: errors.New("i/o timeout")}}).ListBlocking(t.Context(), cfg, 0); err == nil {
+		t.Error("ListBlocking() error = nil, want the resolution error")
+	}
+}
+
+func TestBuildTranslateFuncRejectsDnsSrvWhenDiscoveryDisabled(t *testing.T) {
+	be := &kgateway.Backend{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "default"},
+		Spec: kgateway.BackendSpec{
+			Type:   new(kgateway.BackendTypeDnsSrv),
+			DnsSrv: &kgateway.DnsSrvBackend{Name: "_http._tcp.billing.example.com"},
+		},
+	}
+	backendIR := buildTranslateFunc(nil, nil, translateOptions{})(krt.TestingDummyContext{}, be)
+
+	if len(backendIR.errors) != 1 || !errors.Is(backendIR.errors[0], errDnsSrvDiscoveryDisabled) {
+		t.Fatalf("translate() errors = %v, want %v", backendIR.errors, errDnsSrvDiscoveryDisabled)
+	}
+	if backendIR.dnsSrvIr != nil {
+		t.Fatal("translate() unexpectedly built DNS SRV IR while DNS SRV discovery was disabled")
+	}
+}
+
+type fakeDnsSrvResolver struct {
+	ttl time.Duration
+	err error
+}
+
+func (f fakeDnsSrvResolver) ResolveSRV(context.Context, dnsSrvQuery) ([]dnsSrvRecord, time.Duration, error) {
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+	return []dnsSrvRecord{{addresses: []string{"10.0.0.1"}, port: 8080}}, f.ttl, nil
+}
+
+// startDnsServerForTest serves handler over UDP on a loopback port and returns its address.
+func startDnsServerForTest(t *testing.T, handler dns.Handler) string {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: pc, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	<-started
+	return pc.LocalAddr().String()
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+	rr, err := dns.NewRR(s)
+	if err != nil {
+		t.Fatalf("dns.NewRR(%q) error = %v", s, err)
+	}
+	return rr
+}
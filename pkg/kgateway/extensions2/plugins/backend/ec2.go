@@ -21,15 +21,13 @@ import (
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	"golang.org/x/sync/singleflight"
-	"istio.io/istio/pkg/kube"
-	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/krt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
-	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	plugincollections "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/discovery"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
 )
@@ -141,13 +139,6 @@ type ec2BackendConfig struct {
 	secret       *ir.Secret
 }
 
-type ec2CredentialKey struct {
-	region                string
-	roleArn               string
-	secretResourceName    string
-	secretResourceVersion string
-}
-
 type ec2CredentialSource struct {
 	region  string
 	roleArn string
@@ -162,36 +153,6 @@ type ec2DiscoveredInstance struct {
 	tags       map[string]string
 }
 
-type ec2ResolvedEndpoint struct {
-	address    string
-	instanceID string
-	region     string
-	zone       string
-}
-
-type ec2ResolvedBackend struct {
-	port      uint32
-	config    ec2BackendStateKey
-	endpoints []ec2ResolvedEndpoint
-	status    ec2DiscoveryStatus
-}
-
-func (b ec2ResolvedBackend) Equals(other ec2ResolvedBackend) bool {
-	return b.port == other.port &&
-		b.config.Equals(other.config) &&
-		slices.Equal(b.endpoints, other.endpoints) &&
-		b.status == other.status
-}
-
-// ec2DiscoveryStatus captures the outcome of the most recent discovery poll for a
-// backend, used to build the Backend's EndpointsDiscovered condition. The zero value
-// (empty reason) indicates discovery has not yet run for the backend.
-type ec2DiscoveryStatus struct {
-	status  metav1.ConditionStatus
-	reason  string
-	message string
-}
-
 type ec2InstanceLister interface {
 	ListInstances(ctx context.Context, source ec2CredentialSource) ([]ec2DiscoveredInstance, error)
 }
@@ -400,89 +361,30 @@ func (l *awsEc2InstanceLister) ListInstances(ctx context.Context, source ec2Cred
 	return instances, nil
 }
 
-type ec2EndpointsCollection struct {
-	enabled         bool
-	backends        krt.Collection[ir.BackendObjectIR]
-	trigger         *krt.RecomputeTrigger
-	refreshInterval time.Duration
-	lister          ec2InstanceLister
-	// refreshCh requests an immediate discovery pass (buffered, size 1, so
-	// concurrent requests coalesce). Used when a backend has no cached state
-	// yet (newly created) or its cached state was resolved under an outdated
-	// config, so reconciliation doesn't have to wait out the refresh interval.
-	refreshCh chan struct{}
-
-	stateMu sync.RWMutex
-	state   map[string]ec2ResolvedBackend
-
-	Endpoints krt.Collection[ir.EndpointsForBackend]
-	// DiscoveryStatus contributes the EndpointsDiscovered condition for every EC2
-	// backend, derived from the latest discovery poll (or, for backends with
-	// unresolved secret credentials, synchronously from the backend IR).
-	DiscoveryStatus krt.Collection[ir.BackendObjectStatus]
+// ec2Provider discovers the endpoints of EC2 backends by listing the running
+// instances of their region, once per credential scope.
+type ec2Provider struct {
+	lister ec2InstanceLister
 }
 
+var _ discovery.UnpollableReporter = ec2Provider{}
+
 func newEc2EndpointsCollection(
 	ctx context.Context,
 	commoncol *plugincollections.CommonCollections,
 	backends krt.Collection[ir.BackendObjectIR],
-) *ec2EndpointsCollection {
-	c := &ec2EndpointsCollection{
-		enabled:  commoncol.Settings.EnableAwsEc2Discovery,
-		backends: backends,
-		// Start the trigger unsynced so that dependent collections (and thus
-		// Endpoints.HasSynced) block until the initial refresh has populated
-		// c.state and we explicitly MarkSynced in run(). This avoids a startup
-		// race where Envoy could observe the empty pre-refresh EDS view while
-		// HasSynced already reports complete.
-		trigger:         krt.NewRecomputeTrigger(false),
-		refreshInterval: configuredEc2RefreshInterval(commoncol.Settings),
-		lister:          newEc2InstanceLister(),
-		refreshCh:       make(chan struct{}, 1),
-		state:           map[string]ec2ResolvedBackend{},
-	}
-
-	if !c.enabled {
-		c.Endpoints = krt.NewStaticCollection[ir.EndpointsForBackend](nil, nil, commoncol.KrtOpts.ToOptions("disable/AwsEc2Endpoints")...)
-		c.DiscoveryStatus = krt.NewStaticCollection[ir.BackendObjectStatus](nil, nil, commoncol.KrtOpts.ToOptions("disable/AwsEc2DiscoveryStatus")...)
-		return c
-	}
-
-	c.Endpoints = krt.NewCollection(backends, func(kctx krt.HandlerContext, backend ir.BackendObjectIR) *ir.EndpointsForBackend {
-		cfg := ec2ConfigFromBackend(backend)
-		if cfg == nil {
-			return nil
-		}
-		c.trigger.MarkDependant(kctx)
-		return c.endpointsForBackend(backend, cfg)
-	}, commoncol.KrtOpts.ToOptions("AwsEc2Endpoints")...)
-
-	c.DiscoveryStatus = krt.NewCollection(backends, func(kctx krt.HandlerContext, backend ir.BackendObjectIR) *ir.BackendObjectStatus {
-		return c.discoveryStatusForBackend(kctx, backend)
-	}, commoncol.KrtOpts.ToOptions("AwsEc2DiscoveryStatus")...)
-
-	// Drop per-Backend discovery metric series when a Backend is deleted so stale
-	// gauges don't remain visible indefinitely. Registered unconditionally (not via
-	// metrics.RegisterEvents, which skips registration when metrics are inactive at
-	// setup): the per-poll recording in the discovery loop is guarded dynamically by
-	// metrics.Active(), so cleanup must stay symmetric with it rather than gated once
-	// at startup. deleteEc2DiscoveryMetrics is a harmless no-op when no series exist.
-	backends.Register(func(o krt.Event[ir.BackendObjectIR]) {
-		if o.Event != controllers.EventDelete {
-			return
-		}
-		// Delete by object-source identity rather than inspecting the deleted Obj:
-		// the identity is always populated, and deleteEc2DiscoveryMetrics is a no-op
-		// for non-EC2 backends (which never recorded any series). Every backend is
-		// uniquely keyed by namespace/name, so this only ever clears the series of
-		// the backend being removed.
-		src := o.Latest().GetObjectSource()
-		deleteEc2DiscoveryMetrics(src.Namespace, src.Name)
+) *discovery.Collection[ec2BackendConfig, ec2DiscoveredInstance] {
+	return discovery.NewCollection(ctx, backends, discovery.Provider[ec2BackendConfig, ec2DiscoveredInstance](ec2Provider{
+		lister: newEc2InstanceLister(),
+	}), discovery.Options{
+		Enabled:         commoncol.Settings.EnableAwsEc2Discovery,
+		RefreshInterval: configuredEc2RefreshInterval(commoncol.Settings),
+		PollTimeout:     ec2RefreshTimeout,
+		// The EC2 metrics predate the shared backend discovery metrics, so they
+		// keep their kgateway_ec2_discovery_* names.
+		Metrics: ec2MetricsRecorder{},
+		KrtOpts: commoncol.KrtOpts,
 	})
-
-	go c.run(ctx)
-
-	return c
 }
 
 func configuredEc2RefreshInterval(settings apisettings.Settings) time.Duration {
@@ -492,339 +394,97 @@ func configuredEc2RefreshInterval(settings apisettings.Settings) time.Duration {
 	return settings.AwsEc2RefreshInterval
 }
 
-func (c *ec2EndpointsCollection) HasSynced() bool {
-	// When enabled, Endpoints depends on the recompute trigger, which is only
-	// marked synced in run() once the initial refresh has populated c.state.
-	// So Endpoints.HasSynced() already implies the first refresh has propagated.
-	return c.Endpoints.HasSynced()
+func (ec2Provider) Name() string {
+	return "ec2"
 }
 
-// run drives EC2 discovery until ctx is cancelled (on controller shutdown),
-// which also cancels any in-flight AWS API call made by refreshOnce.
-func (c *ec2EndpointsCollection) run(ctx context.Context) {
-	if ctx == nil {
-		logger.Debug("EC2 endpoint refresher not started because context is nil")
-		return
+// Config filters out backends configured for secret auth whose secret cannot be
+// resolved, which UnpollableStatus reports instead.
+func (ec2Provider) Config(backend ir.BackendObjectIR) (ec2BackendConfig, bool) {
+	obj, ok := backend.Obj.(*kgateway.Backend)
+	if !ok || obj.Spec.Aws == nil || obj.Spec.Aws.Ec2 == nil {
+		return ec2BackendConfig{}, false
 	}
-
-	logger.Debug("starting EC2 endpoint refresher", "refresh_interval", c.refreshInterval)
-	if !kube.WaitForCacheSync("ec2 backends", ctx.Done(), c.backends.HasSynced) {
-		logger.Debug("EC2 endpoint refresher stopped before backend cache sync completed")
-		return
+	if _, unresolved := ec2UnresolvedSecretCredential(backend, obj); unresolved {
+		return ec2BackendConfig{}, false
 	}
-	logger.Debug("EC2 backend cache synced; running initial refresh")
-
-	// Drop any refresh request queued before this point: the initial refresh
-	// below covers every backend already in the (synced) collection. Requests
-	// arriving after the drain are kept and served by the loop.
-	c.drainRefreshRequest()
-	c.refreshOnce(ctx)
-	// Mark the trigger synced only after the initial refresh has populated
-	// c.state (and fired any resulting recomputation). This unblocks
-	// Endpoints.HasSynced(), guaranteeing consumers never observe the empty
-	// pre-refresh EDS view as a fully-synced state.
-	c.trigger.MarkSynced()
-
-	ticker := time.NewTicker(c.refreshInterval)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ctx.Done():
-			logger.Debug("stopping EC2 endpoint refresher")
-			return
-		case <-ticker.C:
-			logger.Debug("running scheduled EC2 endpoint refresh")
-			c.refreshOnce(ctx)
-		case <-c.refreshCh:
-			logger.Debug("running on-demand EC2 endpoint refresh")
-			c.refreshOnce(ctx)
-		}
+	cfg := ec2ConfigFromBackend(backend)
+	if cfg == nil {
+		return ec2BackendConfig{}, false
 	}
+	return *cfg, true
 }
 
-// requestRefresh asks the run loop for an immediate discovery pass. The send is
-// non-blocking: pending requests coalesce in the single-slot buffer, and a nil
-// channel (as in unit tests that construct the collection directly) is a no-op.
-func (c *ec2EndpointsCollection) requestRefresh() {
-	select {
-	case c.refreshCh <- struct{}{}:
-	default:
-	}
+func (p ec2Provider) List(ctx context.Context, cfg ec2BackendConfig) ([]ec2DiscoveredInstance, error) {
+	return p.lister.ListInstances(ctx, ec2CredentialSource{
+		region:  cfg.region,
+		roleArn: cfg.roleArn,
+		secret:  cfg.secret,
+	})
 }
 
-func (c *ec2EndpointsCollection) drainRefreshRequest() {
-	select {
-	case <-c.refreshCh:
-	default:
-	}
+func (ec2Provider) ClassifyError(err error) (string, string) {
+	return classifyEc2DiscoveryError(err)
 }
 
-func (c *ec2EndpointsCollection) refreshOnce(ctx context.Context) {
-	ctx, cancel := context.WithTimeout(ctx, ec2RefreshTimeout)
-	defer cancel()
-
-	logger.Debug("refreshing EC2 backends")
-	nextState, err := c.computeState(ctx)
-	if err != nil {
-		logger.Error("failed to refresh EC2 backends", "error", err)
+// UnpollableStatus reports a CredentialError for backends configured for secret
+// auth whose secret cannot be resolved, which never build a pollable config, so
+// that the failure is never silent.
+func (ec2Provider) UnpollableStatus(backend ir.BackendObjectIR) (discovery.Status, bool) {
+	obj, ok := backend.Obj.(*kgateway.Backend)
+	if !ok || obj.Spec.Aws == nil || obj.Spec.Aws.Ec2 == nil {
+		return discovery.Status{}, false
 	}
-
-	c.stateMu.Lock()
-	changed := !maps.EqualFunc(c.state, nextState, ec2ResolvedBackend.Equals)
-	c.state = nextState
-	c.stateMu.Unlock()
-
-	totalEndpoints := 0
-	for _, backendState := range nextState {
-		totalEndpoints += len(backendState.endpoints)
-	}
-	logger.Debug(
-		"completed EC2 backend refresh",
-		"backends", len(nextState),
-		"total_endpoints", totalEndpoints,
-		"changed", changed,
-	)
-
-	if changed {
-		logger.Debug("triggering EC2 endpoint recomputation")
-		c.trigger.TriggerRecomputation()
+	message, unresolved := ec2UnresolvedSecretCredential(backend, obj)
+	if !unresolved {
+		return discovery.Status{}, false
 	}
+	return discovery.Status{
+		Status:  metav1.ConditionFalse,
+		Reason:  string(kgateway.BackendReasonCredentialError),
+		Message: message,
+	}, true
 }
 
-func (c *ec2EndpointsCollection) computeState(ctx context.Context) (map[string]ec2ResolvedBackend, error) {
-	configs := make([]ec2BackendConfig, 0)
-	for _, backend := range c.backends.List() {
-		cfg := ec2ConfigFromBackend(backend)
-		if cfg != nil {
-			configs = append(configs, *cfg)
-		}
-	}
-
-	nextState := make(map[string]ec2ResolvedBackend, len(configs))
-	if len(configs) == 0 {
-		logger.Debug("no EC2 backends found during refresh")
-		return nextState, nil
-	}
-
-	// Carry forward the prior resolution for every backend so a transient
-	// failure in one credential group doesn't wipe healthy endpoints. Prior
-	// endpoints are kept as long as the endpoint semantics are unchanged: a
-	// credential-only change (rotated secret, new role) doesn't invalidate the
-	// instances resolved under the old credentials, so they keep serving if the
-	// re-list under the new credentials fails.
-	c.stateMu.RLock()
-	for _, cfg := range configs {
-		nextBackendState := ec2ResolvedBackend{
-			port:   cfg.port,
-			config: cfg.stateKey(),
-		}
-		if prior, ok := c.state[cfg.resourceName]; ok && prior.config.endpointSemanticsEqual(nextBackendState.config) {
-			nextBackendState.endpoints = prior.endpoints
-		}
-		nextState[cfg.resourceName] = nextBackendState
-	}
-	c.stateMu.RUnlock()
-
-	byCredential := make(map[ec2CredentialKey][]ec2BackendConfig)
-	for _, cfg := range configs {
-		key := ec2CredentialKey{
-			region:  cfg.region,
-			roleArn: cfg.roleArn,
-		}
-		if cfg.secret != nil {
-			key.secretResourceName = cfg.secret.ResourceName()
-			if cfg.secret.Obj != nil {
-				key.secretResourceVersion = cfg.secret.Obj.GetResourceVersion()
-			}
-		}
-		byCredential[key] = append(byCredential[key], cfg)
-	}
-	logger.Debug(
-		"computing EC2 backend state",
-		"backend_count", len(configs),
-		"credential_groups", len(byCredential),
-	)
-
-	// Process credential groups concurrently. Each group is independent — a
-	// failure in one must not cancel the others, so use a plain WaitGroup
-	// and collect errors manually.
-	var (
-		wg          sync.WaitGroup
-		nextStateMu sync.Mutex
-		errs        []error
-	)
-	for key, groupedBackends := range byCredential {
-		wg.Go(func() {
-			source := ec2CredentialSource{
-				region:  key.region,
-				roleArn: key.roleArn,
-			}
-			if len(groupedBackends) > 0 {
-				source.secret = groupedBackends[0].secret
-			}
-			start := time.Now()
-			instances, err := c.lister.ListInstances(ctx, source)
-			pollSeconds := time.Since(start).Seconds()
-			if err != nil {
-				reason, message := classifyEc2DiscoveryError(err)
-				nextStateMu.Lock()
-				errs = append(errs, fmt.Errorf("list ec2 instances for region %s: %w", key.region, err))
-				// Reflect the failure in each backend's discovery status while
-				// preserving its carried-forward endpoints (NFR-3): the status
-				// update is independent of whether endpoints are flushed.
-				for _, cfg := range groupedBackends {
-					backendState := nextState[cfg.resourceName]
-					carried := len(backendState.endpoints)
-					// A failed poll that still carries forward endpoints leaves the
-					// backend degraded-but-serving; report Degraded so operators can
-					// distinguish it from a hard-down backend (no endpoints), which
-					// keeps the specific failure reason. The cause stays in the message.
-					backendReason := reason
-					if carried > 0 {
-						backendReason = string(kgateway.BackendReasonDegraded)
-					}
-					backendState.status = ec2DiscoveryStatus{
-						status:  metav1.ConditionFalse,
-						reason:  backendReason,
-						message: ec2DiscoveryFailureMessage(message, carried),
-					}
-					nextState[cfg.resourceName] = backendState
-					// Record the poll outcome with the underlying classification
-					// reason (not the Degraded override) so the counter always
-					// attributes a concrete failure cause.
-					recordEc2PollError(cfg.namespace, cfg.name, reason)
-					recordEc2PollDuration(cfg.namespace, cfg.name, ec2PollResultError, pollSeconds)
-				}
-				nextStateMu.Unlock()
-				return
-			}
-			logger.Debug(
-				"listed EC2 instances for credential scope",
-				"region", key.region,
-				"role_arn", key.roleArn,
-				"secret", key.secretResourceName,
-				"instance_count", len(instances),
-				"backend_count", len(groupedBackends),
-			)
-			resolved := make(map[string]ec2ResolvedBackend, len(groupedBackends))
-			for _, cfg := range groupedBackends {
-				backendState := selectResolvedEc2Backend(cfg, instances)
-				resolved[cfg.resourceName] = backendState
-				recordEc2PollSuccess(cfg.namespace, cfg.name, len(backendState.endpoints))
-				recordEc2PollDuration(cfg.namespace, cfg.name, ec2PollResultSuccess, pollSeconds)
-				logger.Debug(
-					"resolved EC2 backend endpoints",
-					"backend", cfg.resourceName,
-					"region", cfg.region,
-					"address_type", cfg.addressType,
-					"filters", len(cfg.filters),
-					"resolved_endpoints", len(backendState.endpoints),
-				)
-			}
-			nextStateMu.Lock()
-			maps.Copy(nextState, resolved)
-			nextStateMu.Unlock()
-		})
-	}
-	wg.Wait()
+// ListScope groups the backends that share a region and credentials, which are
+// listed with a single DescribeInstances pass.
+func (c ec2BackendConfig) ListScope() string {
+	key := c.stateKey()
+	return strings.Join([]string{
+		key.region,
+		key.roleArn,
+		key.secretResourceName,
+		key.secretResourceVersion,
+	}, "\x00")
+}
 
-	return nextState, errors.Join(errs...)
+func (c ec2BackendConfig) Equals(other ec2BackendConfig) bool {
+	return c.stateKey().Equals(other.stateKey())
 }
 
-func (c *ec2EndpointsCollection) endpointsForBackend(backend ir.BackendObjectIR, cfg *ec2BackendConfig) *ir.EndpointsForBackend {
-	eps := ir.NewEndpointsForBackend(backend)
+func (c ec2BackendConfig) EndpointsEqual(other ec2BackendConfig) bool {
+	return c.stateKey().endpointSemanticsEqual(other.stateKey())
+}
 
-	c.stateMu.RLock()
-	state, ok := c.state[backend.ResourceName()]
-	c.stateMu.RUnlock()
-	if !ok {
-		// Newly created backend that no discovery pass has covered yet; ask for
-		// an immediate refresh rather than waiting out the refresh interval.
-		logger.Debug("no cached EC2 endpoint state for backend", "backend", backend.ResourceName())
-		c.requestRefresh()
-		return eps
-	}
-	if current := cfg.stateKey(); !state.config.Equals(current) {
-		// The backend spec changed since the cached state was resolved; ask for
-		// an immediate refresh to reconcile.
-		c.requestRefresh()
-		if !state.config.endpointSemanticsEqual(current) {
-			// The cached endpoints were resolved under a different port, address
-			// type, filters, or region; serving them would route traffic to the
-			// wrong targets. Serve none until the refresh lands.
-			logger.Debug(
-				"discarding cached EC2 endpoint state resolved under an outdated config",
-				"backend", backend.ResourceName(),
-			)
-			return eps
-		}
-		// Only credentials changed; the cached endpoints are still the right
-		// targets, so keep serving them while the refresh re-lists.
-	}
+// ec2MetricsRecorder records the kgateway_ec2_discovery_* metrics.
+type ec2MetricsRecorder struct{}
 
-	for _, endpoint := range state.endpoints {
-		lbEndpoint := krtcollections.CreateLBEndpoint(endpoint.address, state.port, nil, false)
-		eps.Add(ir.PodLocality{
-			Region: endpoint.region,
-			Zone:   endpoint.zone,
-		}, ir.EndpointWithMd{
-			LbEndpoint: lbEndpoint,
-		})
-	}
-	logger.Debug(
-		"built EC2 endpoints for backend",
-		"backend", backend.ResourceName(),
-		"port", state.port,
-		"endpoint_count", len(state.endpoints),
-	)
-	return eps
+func (ec2MetricsRecorder) PollSucceeded(namespace, name string, endpointCount int, duration time.Duration) {
+	recordEc2PollSuccess(namespace, name, endpointCount)
+	recordEc2PollDuration(namespace, name, ec2PollResultSuccess, duration.Seconds())
 }
 
-// discoveryStatusForBackend builds the EndpointsDiscovered condition for a single EC2
-// backend. Backends whose secret credentials are unresolved report CredentialError
-// synchronously (they never become pollable); pollable backends report the outcome of
-// the most recent poll, which is recomputed whenever the discovery state changes.
-func (c *ec2EndpointsCollection) discoveryStatusForBackend(kctx krt.HandlerContext, backend ir.BackendObjectIR) *ir.BackendObjectStatus {
-	obj, ok := backend.Obj.(*kgateway.Backend)
-	if !ok || obj.Spec.Aws == nil || obj.Spec.Aws.Ec2 == nil {
-		return nil
-	}
-
-	// A Backend configured for secret-based auth whose secret cannot be resolved is
-	// filtered out before the discovery loop builds a pollable config. Surface a
-	// CredentialError here so the failure is never silent (FR-8, NFR-2).
-	if message, unresolved := ec2UnresolvedSecretCredential(backend, obj); unresolved {
-		// This backend never enters the poll loop, so reflect its error state in the
-		// metrics here; otherwise an unresolvable secret (the most common
-		// misconfiguration) would be invisible to error_state alerting.
-		src := backend.GetObjectSource()
-		recordEc2CredentialErrorState(src.Namespace, src.Name)
-		return ec2DiscoveryStatusUpdate(backend, ec2DiscoveryStatus{
-			status:  metav1.ConditionFalse,
-			reason:  string(kgateway.BackendReasonCredentialError),
-			message: message,
-		})
-	}
+func (ec2MetricsRecorder) PollFailed(namespace, name, reason string, duration time.Duration) {
+	recordEc2PollError(namespace, name, reason)
+	recordEc2PollDuration(namespace, name, ec2PollResultError, duration.Seconds())
+}
 
-	cfg := ec2ConfigFromBackend(backend)
-	if cfg == nil {
-		return nil
-	}
+func (ec2MetricsRecorder) Unpollable(namespace, name string) {
+	recordEc2CredentialErrorState(namespace, name)
+}
 
-	// Depend on the recompute trigger so this status is recomputed after every
-	// poll, even when endpoints are unchanged (e.g. a transient failure that
-	// preserves carried-forward endpoints but flips the condition to False).
-	c.trigger.MarkDependant(kctx)
-
-	c.stateMu.RLock()
-	state, ok := c.state[backend.ResourceName()]
-	c.stateMu.RUnlock()
-	if !ok || state.status.reason == "" {
-		// Discovery has not completed a poll for this backend yet; the condition
-		// will appear after the next refresh cycle.
-		return nil
-	}
-	return ec2DiscoveryStatusUpdate(backend, state.status)
+func (ec2MetricsRecorder) Delete(namespace, name string) {
+	deleteEc2DiscoveryMetrics(namespace, name)
 }
 
 // ec2UnresolvedSecretCredential reports whether an EC2 backend is configured for
@@ -848,20 +508,6 @@ func ec2UnresolvedSecretCredential(backend ir.BackendObjectIR, obj *kgateway.Bac
 	return fmt.Sprintf("aws auth secret %q in namespace %q could not be resolved", name, obj.GetNamespace()), true
 }
 
-// ec2DiscoveryStatusUpdate wraps a discovery status as a BackendObjectStatus carrying
-// the EndpointsDiscovered condition for the given backend.
-func ec2DiscoveryStatusUpdate(backend ir.BackendObjectIR, status ec2DiscoveryStatus) *ir.BackendObjectStatus {
-	return &ir.BackendObjectStatus{
-		Source: backend.GetObjectSource(),
-		Conditions: []metav1.Condition{{
-			Type:    string(kgateway.BackendConditionEndpointsDiscovered),
-			Status:  status.status,
-			Reason:  status.reason,
-			Message: status.message,
-		}},
-	}
-}
-
 func ec2ConfigFromBackend(backend ir.BackendObjectIR) *ec2BackendConfig {
 	obj, ok := backend.Obj.(*kgateway.Backend)
 	if !ok || obj.Spec.Aws == nil || obj.Spec.Aws.Ec2 == nil {
@@ -874,7 +520,7 @@ func ec2ConfigFromBackend(backend ir.BackendObjectIR) *ec2BackendConfig {
 	// An EC2 backend with a secret-auth credential that could not be resolved never
 	// builds an ec2Ir (translation records the error and leaves awsIr nil), so a
 	// non-nil ec2Ir here implies the secret resolved; no missing-secret guard is
-	// needed. Such backends are surfaced as CredentialError via discoveryStatusForBackend.
+	// needed. Such backends are surfaced as CredentialError via UnpollableStatus.
 	ec2Ir := backendIR.awsIr.ec2Ir
 
 	src := backend.GetObjectSource()
@@ -892,11 +538,8 @@ func ec2ConfigFromBackend(backend ir.BackendObjectIR) *ec2BackendConfig {
 	return cfg
 }
 
-func selectResolvedEc2Backend(cfg ec2BackendConfig, instances []ec2DiscoveredInstance) ec2ResolvedBackend {
-	selected := ec2ResolvedBackend{
-		port:   cfg.port,
-		config: cfg.stateKey(),
-	}
+func (ec2Provider) Select(cfg ec2BackendConfig, instances []ec2DiscoveredInstance) ([]discovery.Endpoint, string) {
+	var endpoints []discovery.Endpoint
 	matchedFilters := 0
 	for _, instance := range instances {
 		if !matchesEc2Filters(instance, cfg.filters) {
@@ -910,27 +553,17 @@ func selectResolvedEc2Backend(cfg ec2BackendConfig, instances []ec2DiscoveredIns
 		if address == "" {
 			continue
 		}
-		selected.endpoints = append(selected.endpoints, ec2ResolvedEndpoint{
-			address:    address,
-			instanceID: instance.instanceID,
-			region:     cfg.region,
-			zone:       instance.zone,
+		endpoints = append(endpoints, discovery.Endpoint{
+			Address: address,
+			Port:    cfg.port,
+			Locality: ir.PodLocality{
+				Region: cfg.region,
+				Zone:   instance.zone,
+			},
+			ID: instance.instanceID,
 		})
 	}
 
-	slices.SortFunc(selected.endpoints, func(a, b ec2ResolvedEndpoint) int {
-		switch {
-		case a.region != b.region:
-			return strings.Compare(a.region, b.region)
-		case a.zone != b.zone:
-			return strings.Compare(a.zone, b.zone)
-		case a.address != b.address:
-			return strings.Compare(a.address, b.address)
-		default:
-			return strings.Compare(a.instanceID, b.instanceID)
-		}
-	})
-
 	if len(cfg.filters) > 0 && matchedFilters == 0 {
 		logger.Warn(
 			"no EC2 instances matched configured filters",
@@ -943,35 +576,7 @@ func selectResolvedEc2Backend(cfg ec2BackendConfig, instances []ec2DiscoveredIns
 		)
 	}
 
-	if len(selected.endpoints) > 0 {
-		selected.status = ec2DiscoveryStatus{
-			status:  metav1.ConditionTrue,
-			reason:  string(kgateway.BackendReasonDiscovered),
-			message: fmt.Sprintf("%d endpoints active", len(selected.endpoints)),
-		}
-	} else {
-		selected.status = ec2DiscoveryStatus{
-			status:  metav1.ConditionFalse,
-			reason:  string(kgateway.BackendReasonNoMatchingInstances),
-			message: ec2NoMatchMessage(cfg),
-		}
-	}
-
-	return selected
-}
-
-// ec2DiscoveryFailureMessage augments a discovery-failure cause with whether the
-// backend is still serving endpoints carried forward from the last successful poll.
-// A failed poll preserves the prior endpoints (NFR-3), so the EndpointsDiscovered
-// condition alone (False) cannot tell an operator whether the backend is degraded but
-// still serving traffic or has never resolved any endpoints; this distinction makes
-// that explicit. The carried-forward count is stable across consecutive failures (no
-// successful poll updates it), so embedding it here does not churn the condition.
-func ec2DiscoveryFailureMessage(cause string, carriedEndpoints int) string {
-	if carriedEndpoints > 0 {
-		return fmt.Sprintf("%s; serving %d endpoints from the last successful poll", cause, carriedEndpoints)
-	}
-	return fmt.Sprintf("%s; no endpoints available from a previous poll", cause)
+	return endpoints, ec2NoMatchMessage(cfg)
 }
 
 // ec2NoMatchMessage builds an operator-facing message for a successful poll that
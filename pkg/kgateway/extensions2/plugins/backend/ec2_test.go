@@ -25,6 +25,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	plugincollections "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/discovery"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
@@ -43,7 +44,7 @@ func TestProcessEc2ConfiguresEdsCluster(t *testing.T) {
 	}
 }
 
-func TestSelectResolvedEc2BackendUsesConfiguredAddressType(t *testing.T) {
+func TestEc2ProviderSelectUsesConfiguredAddressType(t *testing.T) {
 	cfg := ec2BackendConfig{
 		region:      "us-east-1",
 		port:        8080,
@@ -53,7 +54,7 @@ func TestSelectResolvedEc2BackendUsesConfiguredAddressType(t *testing.T) {
 		}},
 	}
 
-	got := selectResolvedEc2Backend(cfg, []ec2DiscoveredInstance{{
+	got, _ := ec2Provider{}.Select(cfg, []ec2DiscoveredInstance{{
 		instanceID: "i-public",
 		privateIP:  "10.0.0.1",
 		publicIP:   "54.0.0.1",
@@ -62,39 +63,15 @@ func TestSelectResolvedEc2BackendUsesConfiguredAddressType(t *testing.T) {
 		},
 	}})
 
-	if len(got.endpoints) != 1 {
-		t.Fatalf("selectResolvedEc2Backend() endpoints = %d, want 1", len(got.endpoints))
+	if len(got) != 1 {
+		t.Fatalf("Select() endpoints = %d, want 1", len(got))
 	}
-	if got.endpoints[0].address != "54.0.0.1" {
-		t.Fatalf("selectResolvedEc2Backend() address = %q, want public IP", got.endpoints[0].address)
+	if got[0].Address != "54.0.0.1" {
+		t.Fatalf("Select() address = %q, want public IP", got[0].Address)
 	}
 }
 
-func TestSelectResolvedEc2BackendSetsDiscoveredStatus(t *testing.T) {
-	cfg := ec2BackendConfig{
-		resourceName: "backend-a",
-		region:       "us-east-1",
-		port:         8080,
-		addressType:  kgateway.AwsAddressTypePrivateIP,
-	}
-
-	got := selectResolvedEc2Backend(cfg, []ec2DiscoveredInstance{
-		{instanceID: "i-1", privateIP: "10.0.0.1"},
-		{instanceID: "i-2", privateIP: "10.0.0.2"},
-	})
-
-	if got.status.status != metav1.ConditionTrue {
-		t.Fatalf("status = %q, want True", got.status.status)
-	}
-	if got.status.reason != string(kgateway.BackendReasonDiscovered) {
-		t.Fatalf("reason = %q, want Discovered", got.status.reason)
-	}
-	if got.status.message != "2 endpoints active" {
-		t.Fatalf("message = %q, want %q", got.status.message, "2 endpoints active")
-	}
-}
-
-func TestSelectResolvedEc2BackendSetsNoMatchingInstancesStatus(t *testing.T) {
+func TestEc2ProviderSelectReportsConfiguredFiltersWhenNothingMatches(t *testing.T) {
 	cfg := ec2BackendConfig{
 		resourceName: "backend-a",
 		region:       "us-east-1",
@@ -103,23 +80,17 @@ func TestSelectResolvedEc2BackendSetsNoMatchingInstancesStatus(t *testing.T) {
 		filters:      []ec2TagFilter{{key: "app", value: "payments", exact: true}},
 	}
 
-	got := selectResolvedEc2Backend(cfg, []ec2DiscoveredInstance{
+	got, noMatchMessage := ec2Provider{}.Select(cfg, []ec2DiscoveredInstance{
 		{instanceID: "i-1", privateIP: "10.0.0.1", tags: map[string]string{"app": "billing"}},
 	})
 
-	if len(got.endpoints) != 0 {
-		t.Fatalf("endpoints = %d, want 0", len(got.endpoints))
-	}
-	if got.status.status != metav1.ConditionFalse {
-		t.Fatalf("status = %q, want False", got.status.status)
-	}
-	if got.status.reason != string(kgateway.BackendReasonNoMatchingInstances) {
-		t.Fatalf("reason = %q, want NoMatchingInstances", got.status.reason)
+	if len(got) != 0 {
+		t.Fatalf("endpoints = %d, want 0", len(got))
 	}
 	// The message must describe the filters in use so an operator can tell a
 	// misconfiguration from an empty fleet.
-	if !strings.Contains(got.status.message, "app=payments") {
-		t.Fatalf("message = %q, want it to describe the filter app=payments", got.status.message)
+	if !strings.Contains(noMatchMessage, "app=payments") {
+		t.Fatalf("message = %q, want it to describe the filter app=payments", noMatchMessage)
 	}
 }
 
@@ -163,116 +134,68 @@ func TestClassifyEc2DiscoveryError(t *testing.T) {
 	}
 }
 
-func TestComputeStateReflectsAuthorizationFailureInStatus(t *testing.T) {
+func TestEc2DiscoveryReflectsAuthorizationFailureInStatus(t *testing.T) {
 	secret := newTestAWSSecret("aws-creds", "default", "1")
 	backend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", nil)
 	backendIR := backendObjectIR(backend, secret)
 
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendIR}),
-		lister: &fakeEc2InstanceLister{
-			err: fmt.Errorf("describe instances: %w", &smithy.GenericAPIError{Code: "AuthFailure", Message: "auth failed"}),
-		},
-	}
-
-	state, err := c.computeState(context.Background())
-	if err == nil {
-		t.Fatal("computeState() error = nil, want error")
-	}
+	c := newTestEc2Collection(krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendIR}), &fakeEc2InstanceLister{
+		err: fmt.Errorf("describe instances: %w", &smithy.GenericAPIError{Code: "AuthFailure", Message: "auth failed"}),
+	})
+	c.Refresh(context.Background(), true)
 
-	got := state[backendIR.ResourceName()].status
-	if got.status != metav1.ConditionFalse {
-		t.Fatalf("status = %q, want False", got.status)
+	_, got, _ := c.Discovered(backendIR.ResourceName())
+	if got.Status != metav1.ConditionFalse {
+		t.Fatalf("status = %q, want False", got.Status)
 	}
-	if got.reason != string(kgateway.BackendReasonAuthorizationError) {
-		t.Fatalf("reason = %q, want AuthorizationError", got.reason)
+	if got.Reason != string(kgateway.BackendReasonAuthorizationError) {
+		t.Fatalf("reason = %q, want AuthorizationError", got.Reason)
 	}
 	// No prior successful poll, so the message must make clear no endpoints are served.
-	if !strings.Contains(got.message, "no endpoints available from a previous poll") {
-		t.Fatalf("message = %q, want it to note no endpoints are available", got.message)
+	if !strings.Contains(got.Message, "no endpoints available from a previous poll") {
+		t.Fatalf("message = %q, want it to note no endpoints are available", got.Message)
 	}
 }
 
-func TestComputeStateReportsDegradedWhenFailureCarriesEndpoints(t *testing.T) {
+func TestEc2DiscoveryReportsDegradedWhenFailureCarriesEndpoints(t *testing.T) {
 	secret := newTestAWSSecret("aws-creds", "default", "1")
 	backend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", nil)
 	backendIR := backendObjectIR(backend, secret)
 
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendIR}),
-		lister: &fakeEc2InstanceLister{
-			instances: []ec2DiscoveredInstance{
-				{instanceID: "i-1", privateIP: "10.0.0.10"},
-			},
+	lister := &fakeEc2InstanceLister{
+		instances: []ec2DiscoveredInstance{
+			{instanceID: "i-1", privateIP: "10.0.0.10"},
 		},
 	}
+	c := newTestEc2Collection(krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendIR}), lister)
 
 	// First poll succeeds and resolves endpoints.
-	state, err := c.computeState(context.Background())
-	if err != nil {
-		t.Fatalf("computeState() error = %v", err)
-	}
-	if got := len(state[backendIR.ResourceName()].endpoints); got == 0 {
+	c.Refresh(context.Background(), true)
+	if endpoints, _, _ := c.Discovered(backendIR.ResourceName()); len(endpoints) == 0 {
 		t.Fatal("expected endpoints from the initial successful poll")
 	}
-	c.state = state
 
 	// Second poll fails: endpoints are carried forward, so the backend is degraded
 	// rather than hard down, and must not keep the raw AuthorizationError reason.
-	c.lister = &fakeEc2InstanceLister{
-		err: fmt.Errorf("describe instances: %w", &smithy.GenericAPIError{Code: "AuthFailure", Message: "auth failed"}),
-	}
-	state, err = c.computeState(context.Background())
-	if err == nil {
-		t.Fatal("computeState() error = nil, want error")
-	}
-	got := state[backendIR.ResourceName()].status
-	if got.status != metav1.ConditionFalse {
-		t.Fatalf("status = %q, want False", got.status)
+	lister.setErr(fmt.Errorf("describe instances: %w", &smithy.GenericAPIError{Code: "AuthFailure", Message: "auth failed"}))
+	c.Refresh(context.Background(), true)
+
+	endpoints, got, _ := c.Discovered(backendIR.ResourceName())
+	if got.Status != metav1.ConditionFalse {
+		t.Fatalf("status = %q, want False", got.Status)
 	}
-	if got.reason != string(kgateway.BackendReasonDegraded) {
-		t.Fatalf("reason = %q, want Degraded", got.reason)
+	if got.Reason != string(kgateway.BackendReasonDegraded) {
+		t.Fatalf("reason = %q, want Degraded", got.Reason)
 	}
-	if got := len(state[backendIR.ResourceName()].endpoints); got == 0 {
+	if len(endpoints) == 0 {
 		t.Fatal("expected endpoints to be carried forward across the failed poll")
 	}
-	if !strings.Contains(got.message, "serving") {
-		t.Fatalf("message = %q, want it to note endpoints are still served", got.message)
-	}
-}
-
-func TestEc2DiscoveryFailureMessage(t *testing.T) {
-	const cause = "AuthFailure: auth failed"
-	tests := []struct {
-		name             string
-		carriedEndpoints int
-		wantSubstr       string
-	}{
-		{
-			name:             "no carried-forward endpoints",
-			carriedEndpoints: 0,
-			wantSubstr:       "no endpoints available from a previous poll",
-		},
-		{
-			name:             "serving carried-forward endpoints",
-			carriedEndpoints: 3,
-			wantSubstr:       "serving 3 endpoints from the last successful poll",
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := ec2DiscoveryFailureMessage(cause, tt.carriedEndpoints)
-			if !strings.HasPrefix(got, cause) {
-				t.Fatalf("message = %q, want it to start with the cause %q", got, cause)
-			}
-			if !strings.Contains(got, tt.wantSubstr) {
-				t.Fatalf("message = %q, want it to contain %q", got, tt.wantSubstr)
-			}
-		})
+	if !strings.Contains(got.Message, "serving") {
+		t.Fatalf("message = %q, want it to note endpoints are still served", got.Message)
 	}
 }
 
-func TestDiscoveryStatusForBackendReportsCredentialErrorForUnresolvedSecret(t *testing.T) {
+func TestEc2ProviderReportsCredentialErrorForUnresolvedSecret(t *testing.T) {
 	// A Secret-auth backend whose secret is unresolved (nil on the IR) is filtered
 	// out of the discovery loop, but must still surface a CredentialError.
 	be := newEc2Backend("backend-a", "", nil)
@@ -282,42 +205,32 @@ func TestDiscoveryStatusForBackendReportsCredentialErrorForUnresolvedSecret(t *t
 	}
 	backend := backendObjectIR(be, nil)
 
-	c := &ec2EndpointsCollection{enabled: true}
-	got := c.discoveryStatusForBackend(krt.TestingDummyContext{}, backend)
-	if got == nil {
-		t.Fatal("discoveryStatusForBackend() = nil, want a CredentialError status")
-	}
-	if len(got.Conditions) != 1 {
-		t.Fatalf("conditions = %d, want 1", len(got.Conditions))
+	if _, ok := (ec2Provider{}).Config(backend); ok {
+		t.Fatal("Config() = true, want false for an unresolved secret")
 	}
-	cond := got.Conditions[0]
-	if cond.Type != string(kgateway.BackendConditionEndpointsDiscovered) {
-		t.Fatalf("condition type = %q, want EndpointsDiscovered", cond.Type)
+	got, ok := ec2Provider{}.UnpollableStatus(backend)
+	if !ok {
+		t.Fatal("UnpollableStatus() = false, want a CredentialError status")
 	}
-	if cond.Status != metav1.ConditionFalse {
-		t.Fatalf("condition status = %q, want False", cond.Status)
+	if got.Status != metav1.ConditionFalse {
+		t.Fatalf("status = %q, want False", got.Status)
 	}
-	if cond.Reason != string(kgateway.BackendReasonCredentialError) {
-		t.Fatalf("condition reason = %q, want CredentialError", cond.Reason)
+	if got.Reason != string(kgateway.BackendReasonCredentialError) {
+		t.Fatalf("reason = %q, want CredentialError", got.Reason)
 	}
 	// The message must not expose secret values.
-	if strings.Contains(cond.Message, "access") || strings.Contains(cond.Message, "secret-value") {
-		t.Fatalf("message = %q, must not expose secret values", cond.Message)
+	if strings.Contains(got.Message, "access") || strings.Contains(got.Message, "secret-value") {
+		t.Fatalf("message = %q, must not expose secret values", got.Message)
 	}
 }
 
-func TestComputeStateBatchesByCredentialScopeAndFiltersInstances(t *testing.T) {
+func TestEc2DiscoveryBatchesByCredentialScopeAndFiltersInstances(t *testing.T) {
 	secret := newTestAWSSecret("aws-creds", "default", "1")
 
 	backendA := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", []kgateway.AwsTagFilter{tagKeyValue("app", "payments")})
 	backendB := newEc2Backend("backend-b", "arn:aws:iam::123456789012:role/shared", []kgateway.AwsTagFilter{tagKey("owner")})
 	backendC := newEc2Backend("backend-c", "arn:aws:iam::123456789012:role/other", nil)
 
-	backends := krt.NewStaticCollection(nil, []ir.BackendObjectIR{
-		backendObjectIR(backendA, secret),
-		backendObjectIR(backendB, secret),
-		backendObjectIR(backendC, secret),
-	})
 	lister := &fakeEc2InstanceLister{
 		instances: []ec2DiscoveredInstance{
 			{
@@ -337,282 +250,87 @@ func TestComputeStateBatchesByCredentialScopeAndFiltersInstances(t *testing.T) {
 			},
 		},
 	}
-	c := &ec2EndpointsCollection{
-		backends: backends,
-		lister:   lister,
-	}
+	c := newTestEc2Collection(krt.NewStaticCollection(nil, []ir.BackendObjectIR{
+		backendObjectIR(backendA, secret),
+		backendObjectIR(backendB, secret),
+		backendObjectIR(backendC, secret),
+	}), lister)
 
-	state, err := c.computeState(context.Background())
-	if err != nil {
-		t.Fatalf("computeState() error = %v", err)
-	}
+	c.Refresh(context.Background(), true)
 	if len(lister.calls) != 2 {
-		t.Fatalf("computeState() AWS calls = %d, want 2", len(lister.calls))
+		t.Fatalf("Refresh() AWS calls = %d, want 2", len(lister.calls))
 	}
 	if lister.calls[0].secret == nil || lister.calls[1].secret == nil {
-		t.Fatal("computeState() did not load the configured secret")
-	}
-
-	if got := len(state[backendObjectIR(backendA, secret).ResourceName()].endpoints); got != 1 {
-		t.Fatalf("backend-a endpoints = %d, want 1", got)
-	}
-	if got := len(state[backendObjectIR(backendB, secret).ResourceName()].endpoints); got != 2 {
-		t.Fatalf("backend-b endpoints = %d, want 2", got)
-	}
-	if got := len(state[backendObjectIR(backendC, secret).ResourceName()].endpoints); got != 2 {
-		t.Fatalf("backend-c endpoints = %d, want 2", got)
-	}
-}
-
-func TestComputeStatePreservesEndpointsOnRefreshFailureWhenConfigIsUnchanged(t *testing.T) {
-	secret := newTestAWSSecret("aws-creds", "default", "1")
-	backend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", []kgateway.AwsTagFilter{tagKeyValue("app", "payments")})
-	backendIR := backendObjectIR(backend, secret)
-	cfg := ec2ConfigFromBackend(backendIR)
-	if cfg == nil {
-		t.Fatal("ec2ConfigFromBackend() returned nil")
-	}
-
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendIR}),
-		lister: &fakeEc2InstanceLister{
-			err: errors.New("boom"),
-		},
-		state: map[string]ec2ResolvedBackend{
-			backendIR.ResourceName(): {
-				port:   cfg.port,
-				config: cfg.stateKey(),
-				endpoints: []ec2ResolvedEndpoint{{
-					address:    "10.0.0.10",
-					instanceID: "i-1",
-					region:     cfg.region,
-					zone:       "us-east-1a",
-				}},
-			},
-		},
-	}
-
-	state, err := c.computeState(context.Background())
-	if err == nil {
-		t.Fatal("computeState() error = nil, want error")
+		t.Fatal("Refresh() did not load the configured secret")
 	}
 
-	got := state[backendIR.ResourceName()]
-	if len(got.endpoints) != 1 {
-		t.Fatalf("backend endpoints = %d, want 1", len(got.endpoints))
-	}
-	if got.endpoints[0].address != "10.0.0.10" {
-		t.Fatalf("backend endpoint address = %q, want 10.0.0.10", got.endpoints[0].address)
-	}
-	if !got.config.Equals(cfg.stateKey()) {
-		t.Fatal("backend config key changed unexpectedly")
+	for be, want := range map[*kgateway.Backend]int{backendA: 1, backendB: 2, backendC: 2} {
+		if endpoints, _, _ := c.Discovered(backendObjectIR(be, secret).ResourceName()); len(endpoints) != want {
+			t.Fatalf("%s endpoints = %d, want %d", be.Name, len(endpoints), want)
+		}
 	}
 }
 
-func TestComputeStateClearsEndpointsOnRefreshFailureAfterConfigChange(t *testing.T) {
+func TestEc2DiscoveryClearsEndpointsOnRefreshFailureAfterConfigChange(t *testing.T) {
 	secret := newTestAWSSecret("aws-creds", "default", "1")
 	priorBackend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", []kgateway.AwsTagFilter{tagKeyValue("app", "payments")})
 	currentBackend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/updated", []kgateway.AwsTagFilter{tagKeyValue("app", "payments")})
 	currentBackend.Spec.Aws.Ec2.Port = 9090
 
-	priorBackendIR := backendObjectIR(priorBackend, secret)
-	currentBackendIR := backendObjectIR(currentBackend, secret)
-	priorCfg := ec2ConfigFromBackend(priorBackendIR)
-	currentCfg := ec2ConfigFromBackend(currentBackendIR)
-	if priorCfg == nil || currentCfg == nil {
-		t.Fatal("ec2ConfigFromBackend() returned nil")
-	}
-
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{currentBackendIR}),
-		lister: &fakeEc2InstanceLister{
-			err: errors.New("boom"),
-		},
-		state: map[string]ec2ResolvedBackend{
-			priorBackendIR.ResourceName(): {
-				port:   priorCfg.port,
-				config: priorCfg.stateKey(),
-				endpoints: []ec2ResolvedEndpoint{{
-					address:    "10.0.0.10",
-					instanceID: "i-1",
-					region:     priorCfg.region,
-					zone:       "us-east-1a",
-				}},
-			},
+	lister := &fakeEc2InstanceLister{
+		instances: []ec2DiscoveredInstance{
+			{instanceID: "i-1", privateIP: "10.0.0.10", tags: map[string]string{"app": "payments"}},
 		},
 	}
+	backends := krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendObjectIR(priorBackend, secret)})
+	c := newTestEc2Collection(backends, lister)
+	c.Refresh(context.Background(), true)
 
-	state, err := c.computeState(context.Background())
-	if err == nil {
-		t.Fatal("computeState() error = nil, want error")
-	}
+	lister.setErr(errors.New("boom"))
+	currentBackendIR := backendObjectIR(currentBackend, secret)
+	backends.UpdateObject(currentBackendIR)
+	c.Refresh(context.Background(), false)
 
-	got := state[currentBackendIR.ResourceName()]
-	if got.port != 9090 {
-		t.Fatalf("backend port = %d, want 9090", got.port)
-	}
-	if len(got.endpoints) != 0 {
-		t.Fatalf("backend endpoints = %d, want 0 after config change", len(got.endpoints))
-	}
-	if !got.config.Equals(currentCfg.stateKey()) {
-		t.Fatal("backend config key did not update to the current config")
+	if endpoints, _, _ := c.Discovered(currentBackendIR.ResourceName()); len(endpoints) != 0 {
+		t.Fatalf("backend endpoints = %d, want 0 after config change", len(endpoints))
 	}
 }
 
-func TestComputeStatePreservesEndpointsOnRefreshFailureAfterCredentialOnlyChange(t *testing.T) {
+func TestEc2DiscoveryPreservesEndpointsOnRefreshFailureAfterCredentialOnlyChange(t *testing.T) {
 	secret := newTestAWSSecret("aws-creds", "default", "1")
 	priorBackend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", []kgateway.AwsTagFilter{tagKeyValue("app", "payments")})
 	currentBackend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/updated", []kgateway.AwsTagFilter{tagKeyValue("app", "payments")})
 
-	priorBackendIR := backendObjectIR(priorBackend, secret)
-	currentBackendIR := backendObjectIR(currentBackend, secret)
-	priorCfg := ec2ConfigFromBackend(priorBackendIR)
-	currentCfg := ec2ConfigFromBackend(currentBackendIR)
-	if priorCfg == nil || currentCfg == nil {
-		t.Fatal("ec2ConfigFromBackend() returned nil")
-	}
-
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{currentBackendIR}),
-		lister: &fakeEc2InstanceLister{
-			err: errors.New("boom"),
-		},
-		state: map[string]ec2ResolvedBackend{
-			priorBackendIR.ResourceName(): {
-				port:   priorCfg.port,
-				config: priorCfg.stateKey(),
-				endpoints: []ec2ResolvedEndpoint{{
-					address:    "10.0.0.10",
-					instanceID: "i-1",
-					region:     priorCfg.region,
-					zone:       "us-east-1a",
-				}},
-			},
+	lister := &fakeEc2InstanceLister{
+		instances: []ec2DiscoveredInstance{
+			{instanceID: "i-1", privateIP: "10.0.0.10", tags: map[string]string{"app": "payments"}},
 		},
 	}
+	backends := krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendObjectIR(priorBackend, secret)})
+	c := newTestEc2Collection(backends, lister)
+	c.Refresh(context.Background(), true)
 
-	state, err := c.computeState(context.Background())
-	if err == nil {
-		t.Fatal("computeState() error = nil, want error")
-	}
-
-	got := state[currentBackendIR.ResourceName()]
-	if len(got.endpoints) != 1 {
-		t.Fatalf("backend endpoints = %d, want 1 preserved after credential-only change", len(got.endpoints))
-	}
-	if got.endpoints[0].address != "10.0.0.10" {
-		t.Fatalf("backend endpoint address = %q, want 10.0.0.10", got.endpoints[0].address)
-	}
-	if !got.config.Equals(currentCfg.stateKey()) {
-		t.Fatal("backend config key did not update to the current config")
-	}
-}
-
-func TestEndpointsForBackendRequestsRefreshWhenStateIsMissing(t *testing.T) {
-	secret := newTestAWSSecret("aws-creds", "default", "1")
-	backendIR := backendObjectIR(newEc2Backend("backend-a", "", nil), secret)
-	cfg := ec2ConfigFromBackend(backendIR)
-	if cfg == nil {
-		t.Fatal("ec2ConfigFromBackend() returned nil")
-	}
-
-	c := &ec2EndpointsCollection{
-		refreshCh: make(chan struct{}, 1),
-		state:     map[string]ec2ResolvedBackend{},
-	}
-
-	eps := c.endpointsForBackend(backendIR, cfg)
-	if got := countEndpoints(eps); got != 0 {
-		t.Fatalf("endpointsForBackend() endpoints = %d, want 0 before first discovery", got)
-	}
-	requireRefreshRequested(t, c)
-}
-
-func TestEndpointsForBackendDiscardsStaleStateAfterSemanticChange(t *testing.T) {
-	secret := newTestAWSSecret("aws-creds", "default", "1")
-	priorBackendIR := backendObjectIR(newEc2Backend("backend-a", "", nil), secret)
-	priorCfg := ec2ConfigFromBackend(priorBackendIR)
-
-	currentBackend := newEc2Backend("backend-a", "", nil)
-	currentBackend.Spec.Aws.Ec2.Port = 9090
+	lister.setErr(errors.New("boom"))
 	currentBackendIR := backendObjectIR(currentBackend, secret)
-	currentCfg := ec2ConfigFromBackend(currentBackendIR)
-	if priorCfg == nil || currentCfg == nil {
-		t.Fatal("ec2ConfigFromBackend() returned nil")
-	}
-
-	c := &ec2EndpointsCollection{
-		refreshCh: make(chan struct{}, 1),
-		state: map[string]ec2ResolvedBackend{
-			priorBackendIR.ResourceName(): {
-				port:   priorCfg.port,
-				config: priorCfg.stateKey(),
-				endpoints: []ec2ResolvedEndpoint{{
-					address:    "10.0.0.10",
-					instanceID: "i-1",
-					region:     priorCfg.region,
-					zone:       "us-east-1a",
-				}},
-			},
-		},
-	}
+	backends.UpdateObject(currentBackendIR)
+	c.Refresh(context.Background(), false)
 
-	eps := c.endpointsForBackend(currentBackendIR, currentCfg)
-	if got := countEndpoints(eps); got != 0 {
-		t.Fatalf("endpointsForBackend() endpoints = %d, want 0 after the port changed", got)
-	}
-	requireRefreshRequested(t, c)
-}
-
-func TestEndpointsForBackendServesCachedEndpointsAfterCredentialOnlyChange(t *testing.T) {
-	secret := newTestAWSSecret("aws-creds", "default", "1")
-	priorBackendIR := backendObjectIR(newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", nil), secret)
-	priorCfg := ec2ConfigFromBackend(priorBackendIR)
-
-	currentBackendIR := backendObjectIR(newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/updated", nil), secret)
-	currentCfg := ec2ConfigFromBackend(currentBackendIR)
-	if priorCfg == nil || currentCfg == nil {
-		t.Fatal("ec2ConfigFromBackend() returned nil")
-	}
-
-	c := &ec2EndpointsCollection{
-		refreshCh: make(chan struct{}, 1),
-		state: map[string]ec2ResolvedBackend{
-			priorBackendIR.ResourceName(): {
-				port:   priorCfg.port,
-				config: priorCfg.stateKey(),
-				endpoints: []ec2ResolvedEndpoint{{
-					address:    "10.0.0.10",
-					instanceID: "i-1",
-					region:     priorCfg.region,
-					zone:       "us-east-1a",
-				}},
-			},
-		},
+	if len(lister.calls) != 2 {
+		t.Fatalf("AWS calls = %d, want the credential change to be polled again", len(lister.calls))
 	}
-
-	eps := c.endpointsForBackend(currentBackendIR, currentCfg)
-	if got := countEndpoints(eps); got != 1 {
-		t.Fatalf("endpointsForBackend() endpoints = %d, want 1 served across a credential-only change", got)
+	endpoints, _, _ := c.Discovered(currentBackendIR.ResourceName())
+	if len(endpoints) != 1 {
+		t.Fatalf("backend endpoints = %d, want 1 preserved after credential-only change", len(endpoints))
 	}
-	requireRefreshRequested(t, c)
-}
-
-func countEndpoints(eps *ir.EndpointsForBackend) int {
-	total := 0
-	for _, lbEps := range eps.LbEps {
-		total += len(lbEps)
+	if endpoints[0].Address != "10.0.0.10" {
+		t.Fatalf("backend endpoint address = %q, want 10.0.0.10", endpoints[0].Address)
 	}
-	return total
 }
 
-func requireRefreshRequested(t *testing.T, c *ec2EndpointsCollection) {
-	t.Helper()
-	select {
-	case <-c.refreshCh:
-	default:
-		t.Fatal("expected an on-demand refresh request")
-	}
+func newTestEc2Collection(backends krt.Collection[ir.BackendObjectIR], lister ec2InstanceLister) *discovery.Collection[ec2BackendConfig, ec2DiscoveredInstance] {
+	return discovery.NewCollection(nil, backends,
+		discovery.Provider[ec2BackendConfig, ec2DiscoveredInstance](ec2Provider{lister: lister}),
+		discovery.Options{Enabled: true, Metrics: ec2MetricsRecorder{}})
 }
 
 func TestSetEc2InstancesForTestPreservesTagKeyCase(t *testing.T) {
@@ -794,7 +512,7 @@ func TestAwsEc2InstanceListerClientForPrunesSupersededSecretVersions(t *testing.
 }
 
 func TestBuildTranslateFuncRejectsEc2WhenDiscoveryDisabled(t *testing.T) {
-	translate := buildTranslateFunc(nil, nil, false)
+	translate := buildTranslateFunc(nil, nil, translateOptions{})
 
 	backendIR := translate(nil, newEc2Backend("backend-a", "", nil))
 
@@ -810,7 +528,7 @@ func TestBuildTranslateFuncRejectsEc2WhenDiscoveryDisabled(t *testing.T) {
 }
 
 func TestBuildTranslateFuncFailsClosedForMissingEc2Secret(t *testing.T) {
-	translate := buildTranslateFunc(nil, newSecretIndexForTest(t), true)
+	translate := buildTranslateFunc(nil, newSecretIndexForTest(t), translateOptions{enableAwsEc2Discovery: true})
 
 	backend := newEc2Backend("backend-a", "", nil)
 	backend.Spec.Aws.Auth = &kgateway.AwsAuth{
@@ -846,43 +564,6 @@ func TestNewEc2EndpointsCollectionDisabledIsAlreadySynced(t *testing.T) {
 	}
 }
 
-func TestEc2EndpointsCollectionHasSyncedWaitsForInitialRefresh(t *testing.T) {
-	// Mirror the real Endpoints wiring: an unsynced recompute trigger that the
-	// transform marks as a dependant. The trigger is only marked synced after
-	// run() completes its initial refresh, so HasSynced() must report false
-	// until then (otherwise consumers could observe the empty pre-refresh EDS
-	// view as a fully-synced state).
-	trigger := krt.NewRecomputeTrigger(false)
-	backends := krt.NewStaticCollection(nil, []ir.BackendObjectIR{
-		backendObjectIR(newEc2Backend("backend-a", "", nil), nil),
-	})
-	endpoints := krt.NewCollection(backends, func(kctx krt.HandlerContext, backend ir.BackendObjectIR) *ir.EndpointsForBackend {
-		trigger.MarkDependant(kctx)
-		return nil
-	})
-
-	c := &ec2EndpointsCollection{
-		enabled:   true,
-		trigger:   trigger,
-		Endpoints: endpoints,
-	}
-
-	if c.HasSynced() {
-		t.Fatal("HasSynced() = true, want false before the initial refresh marks the trigger synced")
-	}
-
-	// Marking the trigger synced is what run() does after the initial refresh
-	// populates c.state.
-	trigger.MarkSynced()
-
-	if !endpoints.WaitUntilSynced(nil) {
-		t.Fatal("Endpoints failed to sync after the trigger was marked synced")
-	}
-	if !c.HasSynced() {
-		t.Fatal("HasSynced() = false, want true after the initial refresh completes")
-	}
-}
-
 type fakeEc2InstanceLister struct {
 	mu        sync.Mutex
 	calls     []ec2CredentialSource
@@ -892,14 +573,20 @@ type fakeEc2InstanceLister struct {
 
 func (f *fakeEc2InstanceLister) ListInstances(_ context.Context, source ec2CredentialSource) ([]ec2DiscoveredInstance, error) {
 	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.calls = append(f.calls, source)
-	f.mu.Unlock()
 	if f.err != nil {
 		return nil, f.err
 	}
 	return f.instances, nil
 }
 
+func (f *fakeEc2InstanceLister) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
 func newEc2Backend(name, roleArn string, filters []kgateway.AwsTagFilter) *kgateway.Backend {
 	be := &kgateway.Backend{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,632 @@
+package backend
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/fsnotify/fsnotify"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	plugincollections "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/discovery"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
+)
+
+const (
+	defaultFileEndpointsKey = "endpoints.yaml"
+	// fileEndpointsMaxSize caps the size of the documents read from the endpoints
+	// directory to the maximum size of a ConfigMap.
+	fileEndpointsMaxSize         = 1 << 20
+	fileEndpointsMaxEndpoints    = 4096
+	fileEndpointsMaxMetadata     = 16
+	fileEndpointsMaxWeight       = 65535
+	fileEndpointsMaxReportedErrs = 5
+	// fileEndpointsWatchDebounce is the quiet period after the last fsnotify event
+	// before the endpoints directory is read again, so that writers can finish
+	// updating several files.
+	fileEndpointsWatchDebounce = 500 * time.Millisecond
+	fileEndpointsLbMetadataKey = "envoy.lb"
+)
+
+var errFileEndpointsDirectoryNotConfigured = errors.New("file endpoints directory is not configured in controller settings")
+
+// FileIr is the internal representation of a File backend.
+type FileIr struct {
+	source fileEndpointsSource // +noKrtEquals
+}
+
+func (u *FileIr) Equals(other *FileIr) bool {
+	return cmputils.CompareWithNils(u, other, func(a, b *FileIr) bool {
+		return a.source == b.source
+	})
+}
+
+// fileEndpointsSource identifies the document listing the endpoints of a File
+// backend: either a key of a ConfigMap, or a path in the endpoints directory.
+type fileEndpointsSource struct {
+	configMap types.NamespacedName
+	key       string
+	path      string
+}
+
+func (s fileEndpointsSource) String() string {
+	if s.path != "" {
+		return fmt.Sprintf("file %q", s.path)
+	}
+	return fmt.Sprintf("key %q of ConfigMap %s", s.key, s.configMap)
+}
+
+func buildFileIr(in *kgateway.FileBackend, namespace, directory string) (*FileIr, error) {
+	if in == nil {
+		return nil, fmt.Errorf("file config is nil")
+	}
+	switch {
+	case in.ConfigMapRef != nil:
+		key := defaultFileEndpointsKey
+		if in.ConfigMapRef.Key != nil {
+			key = *in.ConfigMapRef.Key
+		}
+		return &FileIr{source: fileEndpointsSource{
+			configMap: types.NamespacedName{Namespace: namespace, Name: in.ConfigMapRef.Name},
+			key:       key,
+		}}, nil
+	case in.Path != nil:
+		if directory == "" {
+			return nil, errFileEndpointsDirectoryNotConfigured
+		}
+		if !validFileEndpointsPath(*in.Path) {
+			return nil, fmt.Errorf("file endpoints path %q must be a relative path without hidden segments", *in.Path)
+		}
+		return &FileIr{source: fileEndpointsSource{path: *in.Path}}, nil
+	default:
+		return nil, errors.New("file backend requires a configMapRef or a path")
+	}
+}
+
+// validFileEndpointsPath reports whether p is a slash-separated path inside the
+// endpoints directory. Segments starting with a dot are rejected, which excludes
+// ".." as well as the "..data" directories of ConfigMap and Secret volumes.
+func validFileEndpointsPath(p string) bool {
+	if p == "" || !filepath.IsLocal(filepath.FromSlash(p)) {
+		return false
+	}
+	for segment := range strings.SplitSeq(p, "/") {
+		if segment == "" || strings.HasPrefix(segment, ".") {
+			return false
+		}
+	}
+	return true
+}
+
+func processFile(_ *FileIr, out *envoyclusterv3.Cluster) {
+	out.ClusterDiscoveryType = &envoyclusterv3.Cluster_Type{
+		Type: envoyclusterv3.Cluster_EDS,
+	}
+	out.EdsClusterConfig = &envoyclusterv3.Cluster_EdsClusterConfig{
+		EdsConfig: &envoycorev3.ConfigSource{
+			ResourceApiVersion: envoycorev3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoycorev3.ConfigSource_Ads{
+				Ads: &envoycorev3.AggregatedConfigSource{},
+			},
+		},
+	}
+	out.IgnoreHealthOnHostRemoval = true
+}
+
+// fileEndpointsDocument is the document listing the endpoints of a File backend.
+type fileEndpointsDocument struct {
+	Endpoints []fileEndpointsDocumentEndpoint `json:"endpoints"`
+}
+
+type fileEndpointsDocumentEndpoint struct {
+	Address  string                        `json:"address"`
+	Port     uint32                        `json:"port"`
+	Weight   *uint32                       `json:"weight,omitempty"`
+	Priority uint32                        `json:"priority,omitempty"`
+	Locality fileEndpointsDocumentLocality `json:"locality,omitempty"`
+	Metadata map[string]string             `json:"metadata,omitempty"`
+}
+
+type fileEndpointsDocumentLocality struct {
+	Region  string `json:"region,omitempty"`
+	Zone    string `json:"zone,omitempty"`
+	SubZone string `json:"subZone,omitempty"`
+}
+
+type fileEndpoint struct {
+	address  string
+	port     uint32
+	weight   uint32
+	priority uint32
+	locality ir.PodLocality
+	metadata map[string]string
+}
+
+func (e fileEndpoint) Equals(other fileEndpoint) bool {
+	return e.address == other.address &&
+		e.port == other.port &&
+		e.weight == other.weight &&
+		e.priority == other.priority &&
+		e.locality == other.locality &&
+		maps.Equal(e.metadata, other.metadata)
+}
+
+// parseFileEndpoints parses and validates an endpoints document. The priorities
+// of the endpoints are renumbered contiguously from 0, as Envoy expects.
+func parseFileEndpoints(data []byte) ([]fileEndpoint, error) {
+	var doc fileEndpointsDocument
+	if err := yaml.UnmarshalStrict(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse endpoints document: %w", err)
+	}
+	if len(doc.Endpoints) > fileEndpointsMaxEndpoints {
+		return nil, fmt.Errorf("endpoints document lists %d endpoints, more than the maximum of %d", len(doc.Endpoints), fileEndpointsMaxEndpoints)
+	}
+
+	type target struct {
+		address string
+		port    uint32
+	}
+	var (
+		errs     []error
+		seen     = map[target]int{}
+		parsed   = make([]fileEndpoint, 0, len(doc.Endpoints))
+		priority = map[uint32]struct{}{}
+	)
+	for i, in := range doc.Endpoints {
+		endpointErrs := validateFileEndpoint(in)
+		addr, err := netip.ParseAddr(in.Address)
+		if err == nil {
+			key := target{address: addr.Unmap().String(), port: in.Port}
+			if first, ok := seen[key]; ok {
+				endpointErrs = append(endpointErrs, fmt.Errorf("duplicates endpoints[%d]", first))
+			} else {
+				seen[key] = i
+			}
+		}
+		for _, err := range endpointErrs {
+			errs = append(errs, fmt.Errorf("endpoints[%d]: %w", i, err))
+		}
+		if len(endpointErrs) > 0 {
+			continue
+		}
+
+		endpoint := fileEndpoint{
+			address:  addr.Unmap().String(),
+			port:     in.Port,
+			weight:   1,
+			priority: in.Priority,
+			locality: ir.PodLocality{
+				Region:  in.Locality.Region,
+				Zone:    in.Locality.Zone,
+				Subzone: in.Locality.SubZone,
+			},
+		}
+		if in.Weight != nil {
+			endpoint.weight = *in.Weight
+		}
+		if len(in.Metadata) > 0 {
+			endpoint.metadata = maps.Clone(in.Metadata)
+		}
+		priority[in.Priority] = struct{}{}
+		parsed = append(parsed, endpoint)
+	}
+	if len(errs) > fileEndpointsMaxReportedErrs {
+		errs = append(errs[:fileEndpointsMaxReportedErrs], fmt.Errorf("and %d more errors", len(errs)-fileEndpointsMaxReportedErrs))
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid endpoints document: %w", errors.Join(errs...))
+	}
+
+	ranks := slices.Sorted(maps.Keys(priority))
+	for i := range parsed {
+		rank, _ := slices.BinarySearch(ranks, parsed[i].priority)
+		parsed[i].priority = uint32(rank) //nolint:gosec // G115: rank is bounded by fileEndpointsMaxEndpoints
+	}
+	slices.SortFunc(parsed, func(a, b fileEndpoint) int {
+		return cmp.Or(
+			cmp.Compare(a.priority, b.priority),
+			strings.Compare(a.address, b.address),
+			cmp.Compare(a.port, b.port),
+		)
+	})
+	return parsed, nil
+}
+
+func validateFileEndpoint(in fileEndpointsDocumentEndpoint) []error {
+	var errs []error
+	if _, err := netip.ParseAddr(in.Address); err != nil {
+		errs = append(errs, fmt.Errorf("address %q is not an IP address", in.Address))
+	}
+	if in.Port == 0 || in.Port > 65535 {
+		errs = append(errs, fmt.Errorf("port %d is not between 1 and 65535", in.Port))
+	}
+	if in.Weight != nil && (*in.Weight == 0 || *in.Weight > fileEndpointsMaxWeight) {
+		errs = append(errs, fmt.Errorf("weight %d is not between 1 and %d", *in.Weight, fileEndpointsMaxWeight))
+	}
+	if len(in.Metadata) > fileEndpointsMaxMetadata {
+		errs = append(errs, fmt.Errorf("%d metadata entries exceed the maximum of %d", len(in.Metadata), fileEndpointsMaxMetadata))
+	}
+	for _, key := range slices.Sorted(maps.Keys(in.Metadata)) {
+		if msgs := validation.IsQualifiedName(key); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("metadata key %q is invalid: %s", key, strings.Join(msgs, "; ")))
+		}
+		if msgs := validation.IsValidLabelValue(in.Metadata[key]); len(msgs) > 0 {
+			errs = append(errs, fmt.Errorf("metadata value of %q is invalid: %s", key, strings.Join(msgs, "; ")))
+		}
+	}
+	return errs
+}
+
+// fileEndpointsFile is a document read from the endpoints directory.
+type fileEndpointsFile struct {
+	// path is slash-separated and relative to the endpoints directory.
+	path string
+	data []byte
+	// err is set when the file could not be read.
+	err string
+}
+
+func (f fileEndpointsFile) ResourceName() string {
+	return f.path
+}
+
+func (f fileEndpointsFile) Equals(other fileEndpointsFile) bool {
+	return f.path == other.path && f.err == other.err && bytes.Equal(f.data, other.data)
+}
+
+// newFileEndpointsFiles returns the documents of the endpoints directory, which
+// are read again when fsnotify reports a change in the directory.
+func newFileEndpointsFiles(ctx context.Context, directory string, krtOpts krtutil.KrtOptions) krt.Collection[fileEndpointsFile] {
+	if directory == "" {
+		return krt.NewStaticCollection[fileEndpointsFile](nil, nil, krtOpts.ToOptions("disable/FileEndpointsFiles")...)
+	}
+	files := krt.NewStaticCollection(nil, readFileEndpointsDirectory(directory), krtOpts.ToOptions("FileEndpointsFiles")...)
+	if ctx == nil {
+		return files
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logger.Error("failed to watch the file endpoints directory", "directory", directory, "error", err)
+		return files
+	}
+	// Add the watches before the goroutine starts so that changes made while it
+	// starts are not missed.
+	watchFileEndpointsDirectory(watcher, directory)
+	go func() {
+		defer watcher.Close()
+		runFileEndpointsWatcher(ctx, watcher, func() {
+			watchFileEndpointsDirectory(watcher, directory)
+			files.Reset(readFileEndpointsDirectory(directory))
+		})
+	}()
+	return files
+}
+
+// runFileEndpointsWatcher calls onUpdate once fsnotify events stop arriving for
+// fileEndpointsWatchDebounce, until ctx is cancelled.
+func runFileEndpointsWatcher(ctx context.Context, watcher *fsnotify.Watcher, onUpdate func()) {
+	debounce := time.NewTimer(fileEndpointsWatchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			logger.Debug("file endpoints directory changed", "event", event)
+			debounce.Reset(fileEndpointsWatchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Warn("error watching the file endpoints directory", "error", err)
+		case <-debounce.C:
+			onUpdate()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchFileEndpointsDirectory watches every directory under the endpoints
+// directory. Watching an already watched directory is a no-op.
+func watchFileEndpointsDirectory(watcher *fsnotify.Watcher, directory string) {
+	err := filepath.WalkDir(directory, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if err := watcher.Add(p); err != nil {
+			logger.Warn("failed to watch file endpoints directory", "directory", p, "error", err)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Warn("failed to walk the file endpoints directory", "directory", directory, "error", err)
+	}
+}
+
+// readFileEndpointsDirectory reads the files of the endpoints directory whose
+// paths are valid in a File backend. Symlinks to files are followed, as in the
+// volumes of ConfigMaps.
+func readFileEndpointsDirectory(directory string) []fileEndpointsFile {
+	var files []fileEndpointsFile
+	err := filepath.WalkDir(directory, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if p == directory {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(directory, p)
+		if err != nil {
+			return nil
+		}
+		file := fileEndpointsFile{path: filepath.ToSlash(rel)}
+		info, err := os.Stat(p)
+		switch {
+		case err != nil:
+			file.err = err.Error()
+		case !info.Mode().IsRegular():
+			return nil
+		case info.Size() > fileEndpointsMaxSize:
+			file.err = fmt.Sprintf("file is larger than the maximum size of %d bytes", fileEndpointsMaxSize)
+		default:
+			data, err := os.ReadFile(p)
+			if err != nil {
+				file.err = err.Error()
+			}
+			file.data = data
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		logger.Warn("failed to read the file endpoints directory", "directory", directory, "error", err)
+	}
+	return files
+}
+
+// fileEndpointsState is the endpoints and EndpointsDiscovered status of a File
+// backend, derived from its document.
+type fileEndpointsState struct {
+	backend   ir.BackendObjectIR
+	endpoints []fileEndpoint
+	status    discovery.Status
+}
+
+func (s fileEndpointsState) ResourceName() string {
+	return s.backend.ResourceName()
+}
+
+func (s fileEndpointsState) Equals(other fileEndpointsState) bool {
+	return s.backend.Equals(other.backend) &&
+		s.status == other.status &&
+		slices.EqualFunc(s.endpoints, other.endpoints, fileEndpoint.Equals)
+}
+
+// fileEndpointsCollection derives the endpoints of File backends from their
+// documents, which are read from ConfigMaps or from the endpoints directory.
+type fileEndpointsCollection struct {
+	configMaps krt.Collection[*corev1.ConfigMap]
+	files      krt.Collection[fileEndpointsFile]
+
+	// mu guards lastValid, the endpoints of the last valid document of each
+	// backend, which are carried while its document is invalid.
+	mu        sync.Mutex
+	lastValid map[string]fileEndpointsValid
+
+	Endpoints krt.Collection[ir.EndpointsForBackend]
+	// DiscoveryStatus contributes the EndpointsDiscovered condition for every File
+	// backend, reporting errors reading or validating its document.
+	DiscoveryStatus krt.Collection[ir.BackendObjectStatus]
+}
+
+type fileEndpointsValid struct {
+	source    fileEndpointsSource
+	endpoints []fileEndpoint
+}
+
+func newFileEndpointsCollection(
+	ctx context.Context,
+	commoncol *plugincollections.CommonCollections,
+	backends krt.Collection[ir.BackendObjectIR],
+) *fileEndpointsCollection {
+	files := newFileEndpointsFiles(ctx, commoncol.Settings.FileEndpointsDirectory, commoncol.KrtOpts)
+	return newFileEndpointsCollectionFrom(backends, commoncol.ConfigMaps.Collection(), files, commoncol.KrtOpts)
+}
+
+func newFileEndpointsCollectionFrom(
+	backends krt.Collection[ir.BackendObjectIR],
+	configMaps krt.Collection[*corev1.ConfigMap],
+	files krt.Collection[fileEndpointsFile],
+	krtOpts krtutil.KrtOptions,
+) *fileEndpointsCollection {
+	c := &fileEndpointsCollection{
+		configMaps: configMaps,
+		files:      files,
+		lastValid:  map[string]fileEndpointsValid{},
+	}
+
+	states := krt.NewCollection(backends, func(kctx krt.HandlerContext, backend ir.BackendObjectIR) *fileEndpointsState {
+		fileIr := fileIrFromBackend(backend)
+		if fileIr == nil {
+			return nil
+		}
+		return c.stateForBackend(kctx, backend, fileIr.source)
+	}, krtOpts.ToOptions("FileEndpointsState")...)
+
+	c.Endpoints = krt.NewCollection(states, func(_ krt.HandlerContext, state fileEndpointsState) *ir.EndpointsForBackend {
+		return fileEndpointsForBackend(state)
+	}, krtOpts.ToOptions("FileEndpoints")...)
+
+	c.DiscoveryStatus = krt.NewCollection(states, func(_ krt.HandlerContext, state fileEndpointsState) *ir.BackendObjectStatus {
+		return discovery.StatusUpdate(state.backend, state.status)
+	}, krtOpts.ToOptions("FileDiscoveryStatus")...)
+
+	backends.Register(func(o krt.Event[ir.BackendObjectIR]) {
+		if o.Event != controllers.EventDelete {
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.lastValid, o.Latest().ResourceName())
+	})
+
+	return c
+}
+
+func (c *fileEndpointsCollection) HasSynced() bool {
+	return c.Endpoints.HasSynced() && c.DiscoveryStatus.HasSynced()
+}
+
+// stateForBackend reads and validates the document of a backend. When it cannot,
+// the endpoints of the last valid document from the same source are carried.
+func (c *fileEndpointsCollection) stateForBackend(kctx krt.HandlerContext, backend ir.BackendObjectIR, source fileEndpointsSource) *fileEndpointsState {
+	state := &fileEndpointsState{backend: backend}
+	reason := kgateway.BackendReasonDiscoveryError
+
+	data, err := c.readDocument(kctx, source)
+	var endpoints []fileEndpoint
+	if err == nil {
+		endpoints, err = parseFileEndpoints(data)
+		reason = kgateway.BackendReasonInvalidEndpoints
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		c.lastValid[backend.ResourceName()] = fileEndpointsValid{source: source, endpoints: endpoints}
+		state.endpoints = endpoints
+		state.status = fileEndpointsStatus(source, len(endpoints))
+		return state
+	}
+
+	if last, ok := c.lastValid[backend.ResourceName()]; ok && last.source == source {
+		state.endpoints = last.endpoints
+	}
+	if len(state.endpoints) > 0 {
+		reason = kgateway.BackendReasonDegraded
+	}
+	state.status = discovery.Status{
+		Status:  metav1.ConditionFalse,
+		Reason:  string(reason),
+		Message: discovery.FailureMessage(err.Error(), len(state.endpoints)),
+	}
+	return state
+}
+
+func (c *fileEndpointsCollection) readDocument(kctx krt.HandlerContext, source fileEndpointsSource) ([]byte, error) {
+	if source.path != "" {
+		file := krt.FetchOne(kctx, c.files, krt.FilterKey(source.path))
+		if file == nil {
+			return nil, fmt.Errorf("%s not found in the endpoints directory", source)
+		}
+		if file.err != "" {
+			return nil, fmt.Errorf("failed to read %s: %s", source, file.err)
+		}
+		return file.data, nil
+	}
+
+	cm := krt.FetchOne(kctx, c.configMaps, krt.FilterObjectName(source.configMap))
+	if cm == nil {
+		return nil, fmt.Errorf("ConfigMap %s not found", source.configMap)
+	}
+	if data, ok := (*cm).Data[source.key]; ok {
+		return []byte(data), nil
+	}
+	if data, ok := (*cm).BinaryData[source.key]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("%s not found", source)
+}
+
+func fileEndpointsStatus(source fileEndpointsSource, count int) discovery.Status {
+	if count == 0 {
+		return discovery.Status{
+			Status:  metav1.ConditionFalse,
+			Reason:  string(kgateway.BackendReasonNoMatchingInstances),
+			Message: fmt.Sprintf("%s lists no endpoints", source),
+		}
+	}
+	return discovery.Status{
+		Status:  metav1.ConditionTrue,
+		Reason:  string(kgateway.BackendReasonDiscovered),
+		Message: fmt.Sprintf("%d endpoints active", count),
+	}
+}
+
+func fileEndpointsForBackend(state fileEndpointsState) *ir.EndpointsForBackend {
+	eps := ir.NewEndpointsForBackend(state.backend)
+	for _, endpoint := range state.endpoints {
+		lbEndpoint := krtcollections.CreateLBEndpoint(endpoint.address, endpoint.port, nil, false)
+		lbEndpoint.LoadBalancingWeight = wrapperspb.UInt32(endpoint.weight)
+		if len(endpoint.metadata) > 0 {
+			fields := make(map[string]*structpb.Value, len(endpoint.metadata))
+			for key, value := range endpoint.metadata {
+				fields[key] = structpb.NewStringValue(value)
+			}
+			lbEndpoint.Metadata = &envoycorev3.Metadata{
+				FilterMetadata: map[string]*structpb.Struct{
+					fileEndpointsLbMetadataKey: {Fields: fields},
+				},
+			}
+		}
+		eps.Add(endpoint.locality, ir.EndpointWithMd{
+			LbEndpoint: lbEndpoint,
+			EndpointMd: ir.EndpointMetadata{
+				Labels:   endpoint.metadata,
+				Priority: endpoint.priority,
+			},
+		})
+	}
+	return eps
+}
+
+func fileIrFromBackend(backend ir.BackendObjectIR) *FileIr {
+	obj, ok := backend.Obj.(*kgateway.Backend)
+	if !ok || obj.Spec.File == nil {
+		return nil
+	}
+	backendIR, ok := backend.ObjIr.(*backendIr)
+	if !ok || backendIR.fileIr == nil {
+		return nil
+	}
+	return backendIR.fileIr
+}
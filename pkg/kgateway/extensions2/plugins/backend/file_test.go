@@ -0,0 +1,287 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+func TestBuildFileIr(t *testing.T) {
+	got, err := buildFileIr(&kgateway.FileBackend{
+		ConfigMapRef: &kgateway.FileBackendConfigMapRef{Name: "billing-endpoints"},
+	}, "default", "")
+	if err != nil {
+		t.Fatalf("buildFileIr() error = %v", err)
+	}
+	if got.source.configMap.String() != "default/billing-endpoints" || got.source.key != defaultFileEndpointsKey {
+		t.Fatalf("buildFileIr() source = %+v, want the default key of default/billing-endpoints", got.source)
+	}
+
+	if _, err := buildFileIr(&kgateway.FileBackend{Path: new("billing.yaml")}, "default", ""); !errors.Is(err, errFileEndpointsDirectoryNotConfigured) {
+		t.Fatalf("buildFileIr() without a directory error = %v, want %v", err, errFileEndpointsDirectoryNotConfigured)
+	}
+	if _, err := buildFileIr(&kgateway.FileBackend{Path: new("team-a/billing.yaml")}, "default", "/etc/kgateway/endpoints"); err != nil {
+		t.Fatalf("buildFileIr() with a nested path error = %v", err)
+	}
+	for _, p := range []string{"/billing.yaml", "../billing.yaml", "team-a/../../billing.yaml", "..data/billing.yaml", "team-a//billing.yaml"} {
+		if _, err := buildFileIr(&kgateway.FileBackend{Path: new(p)}, "default", "/etc/kgateway/endpoints"); err == nil {
+			t.Errorf("buildFileIr() with path %q succeeded, want an error", p)
+		}
+	}
+}
+
+func TestParseFileEndpoints(t *testing.T) {
+	got, err := parseFileEndpoints([]byte(`
+endpoints:
+- address: 10.0.0.2
+  port: 8080
+  priority: 20
+- address: 10.0.0.1
+  port: 8080
+  weight: 3
+  priority: 10
+  locality:
+    region: us-east-1
+    zone: us-east-1a
+  metadata:
+    version: v2
+`))
+	if err != nil {
+		t.Fatalf("parseFileEndpoints() error = %v", err)
+	}
+	want := []fileEndpoint{
+		{
+			address:  "10.0.0.1",
+			port:     8080,
+			weight:   3,
+			priority: 0,
+			locality: ir.PodLocality{Region: "us-east-1", Zone: "us-east-1a"},
+			metadata: map[string]string{"version": "v2"},
+		},
+		{address: "10.0.0.2", port: 8080, weight: 1, priority: 1},
+	}
+	if !slices.EqualFunc(got, want, fileEndpoint.Equals) {
+		t.Fatalf("parseFileEndpoints() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseFileEndpointsRejectsInvalidDocuments(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr []string
+	}{
+		{
+			name:    "unknown field",
+			doc:     "endpoints:\n- address: 10.0.0.1\n  port: 8080\n  hostname: a.example.com\n",
+			wantErr: []string{"unknown field"},
+		},
+		{
+			name: "invalid endpoints",
+			doc: `
+endpoints:
+- address: billing.example.com
+  port: 8080
+- address: 10.0.0.1
+  port: 0
+  weight: 0
+- address: 10.0.0.2
+  port: 8080
+  metadata:
+    version: "not a label value"
+- address: 10.0.0.2
+  port: 8080
+`,
+			wantErr: []string{
+				`endpoints[0]: address "billing.example.com" is not an IP address`,
+				"endpoints[1]: port 0 is not between 1 and 65535",
+				"endpoints[1]: weight 0 is not between 1 and 65535",
+				`endpoints[2]: metadata value of "version" is invalid`,
+				"endpoints[3]: duplicates endpoints[2]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFileEndpoints([]byte(tt.doc))
+			if err == nil {
+				t.Fatal("parseFileEndpoints() succeeded, want an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("parseFileEndpoints() error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFileEndpointsCollectionCarriesEndpointsOfInvalidDocuments(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	backend := fileBackendObjectIR(t, "billing", &kgateway.FileBackend{
+		ConfigMapRef: &kgateway.FileBackendConfigMapRef{Name: "billing-endpoints"},
+	})
+	configMaps := krt.NewStaticCollection(nil, []*corev1.ConfigMap{
+		newEndpointsConfigMap("endpoints:\n- address: 10.0.0.1\n  port: 8080\n"),
+	})
+	c := newFileEndpointsCollectionFrom(
+		krt.NewStaticCollection(nil, []ir.BackendObjectIR{backend}),
+		configMaps,
+		krt.NewStaticCollection[fileEndpointsFile](nil, nil),
+		krtutil.KrtOptions{},
+	)
+	if !c.Endpoints.WaitUntilSynced(ctx.Done()) || !c.DiscoveryStatus.WaitUntilSynced(ctx.Done()) {
+		t.Fatal("file endpoints collection did not sync")
+	}
+	assertFileEndpoints(t, c, backend, kgateway.BackendReasonDiscovered, "10.0.0.1")
+
+	configMaps.UpdateObject(newEndpointsConfigMap("endpoints:\n- address: billing.example.com\n  port: 8080\n"))
+	assertFileEndpoints(t, c, backend, kgateway.BackendReasonDegraded, "10.0.0.1")
+
+	configMaps.UpdateObject(newEndpointsConfigMap("endpoints: []\n"))
+	assertFileEndpoints(t, c, backend, kgateway.BackendReasonNoMatchingInstances)
+
+	configMaps.DeleteObject("default/billing-endpoints")
+	assertFileEndpoints(t, c, backend, kgateway.BackendReasonDiscoveryError)
+}
+
+func TestFileEndpointsCollectionReadsEndpointsDirectory(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	directory := t.TempDir()
+	if err := os.Mkdir(filepath.Join(directory, "team-a"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeEndpointsFile(t, filepath.Join(directory, "team-a", "billing.yaml"), "endpoints:\n- address: 10.0.0.1\n  port: 8080\n")
+
+	backend := fileBackendObjectIR(t, "billing", &kgateway.FileBackend{Path: new("team-a/billing.yaml")})
+	c := newFileEndpointsCollectionFrom(
+		krt.NewStaticCollection(nil, []ir.BackendObjectIR{backend}),
+		krt.NewStaticCollection[*corev1.ConfigMap](nil, nil),
+		newFileEndpointsFiles(ctx, directory, krtutil.KrtOptions{}),
+		krtutil.KrtOptions{},
+	)
+	if !c.Endpoints.WaitUntilSynced(ctx.Done()) || !c.DiscoveryStatus.WaitUntilSynced(ctx.Done()) {
+		t.Fatal("file endpoints collection did not sync")
+	}
+	assertFileEndpoints(t, c, backend, kgateway.BackendReasonDiscovered, "10.0.0.1")
+
+	// Replace the file the way ConfigMap volumes are updated, so that the watcher
+	// sees a rename rather than a write.
+	writeEndpointsFile(t, filepath.Join(directory, "team-a", ".billing.yaml.tmp"), "endpoints:\n- address: 10.0.0.1\n  port: 8080\n- address: 10.0.0.2\n  port: 8080\n")
+	if err := os.Rename(filepath.Join(directory, "team-a", ".billing.yaml.tmp"), filepath.Join(directory, "team-a", "billing.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	assertFileEndpoints(t, c, backend, kgateway.BackendReasonDiscovered, "10.0.0.1", "10.0.0.2")
+
+	if err := os.Remove(filepath.Join(directory, "team-a", "billing.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	assertFileEndpoints(t, c, backend, kgateway.BackendReasonDegraded, "10.0.0.1", "10.0.0.2")
+}
+
+func TestBuildTranslateFuncRejectsFilePathWithoutDirectory(t *testing.T) {
+	be := newFileBackend("billing", &kgateway.FileBackend{Path: new("billing.yaml")})
+	backendIR := buildTranslateFunc(nil, nil, translateOptions{})(krt.TestingDummyContext{}, be)
+
+	if len(backendIR.errors) != 1 || !errors.Is(backendIR.errors[0], errFileEndpointsDirectoryNotConfigured) {
+		t.Fatalf("translate() errors = %v, want %v", backendIR.errors, errFileEndpointsDirectoryNotConfigured)
+	}
+	if backendIR.fileIr != nil {
+		t.Fatal("translate() unexpectedly built File IR without an endpoints directory")
+	}
+}
+
+// assertFileEndpoints waits for the endpoints and EndpointsDiscovered reason of
+// a backend to match.
+func assertFileEndpoints(t *testing.T, c *fileEndpointsCollection, backend ir.BackendObjectIR, reason kgateway.BackendConditionReason, addresses ...string) {
+	t.Helper()
+	var (
+		gotAddresses []string
+		gotStatus    *ir.BackendObjectStatus
+	)
+	for range 100 {
+		gotAddresses = nil
+		if eps := c.Endpoints.GetKey(backend.ResourceName()); eps != nil {
+			for _, lbEndpoints := range eps.LbEps {
+				for _, ep := range lbEndpoints {
+					gotAddresses = append(gotAddresses, ep.GetEndpoint().GetAddress().GetSocketAddress().GetAddress())
+				}
+			}
+		}
+		slices.Sort(gotAddresses)
+		gotStatus = nil
+		if statuses := c.DiscoveryStatus.List(); len(statuses) == 1 {
+			gotStatus = &statuses[0]
+		}
+		if slices.Equal(gotAddresses, addresses) && gotStatus != nil && gotStatus.Conditions[0].Reason == string(reason) {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("file endpoints = %v with status %+v, want %v with reason %s", gotAddresses, gotStatus, addresses, reason)
+}
+
+func newFileBackend(name string, file *kgateway.FileBackend) *kgateway.Backend {
+	return &kgateway.Backend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: kgateway.BackendSpec{
+			Type: new(kgateway.BackendTypeFile),
+			File: file,
+		},
+	}
+}
+
+func fileBackendObjectIR(t *testing.T, name string, file *kgateway.FileBackend) ir.BackendObjectIR {
+	t.Helper()
+	be := newFileBackend(name, file)
+	out := ir.NewBackendObjectIR(ir.ObjectSource{
+		Group:     "gateway.kgateway.dev",
+		Kind:      "Backend",
+		Namespace: be.Namespace,
+		Name:      be.Name,
+	}, 0, "", ExtensionName)
+	out.Obj = be
+	fileIr, err := buildFileIr(file, be.Namespace, "/etc/kgateway/endpoints")
+	if err != nil {
+		t.Fatalf("buildFileIr() error = %v", err)
+	}
+	out.ObjIr = &backendIr{fileIr: fileIr}
+	return out
+}
+
+func newEndpointsConfigMap(doc string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "billing-endpoints",
+			Namespace: "default",
+		},
+		Data: map[string]string{defaultFileEndpointsKey: doc},
+	}
+}
+
+func writeEndpointsFile(t *testing.T, name, doc string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
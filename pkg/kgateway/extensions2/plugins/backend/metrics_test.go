@@ -38,25 +38,20 @@ func pollDurationLabels(namespace, name, result string) []metrics.Label {
 	)
 }
 
-func TestComputeStateRecordsSuccessfulPollMetrics(t *testing.T) {
+func TestEc2DiscoveryRecordsSuccessfulPollMetrics(t *testing.T) {
 	ResetEc2DiscoveryMetrics()
 
 	backend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", nil)
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{
-			backendObjectIR(backend, newTestAWSSecret("aws-creds", "default", "1")),
-		}),
-		lister: &fakeEc2InstanceLister{
-			instances: []ec2DiscoveredInstance{
-				{instanceID: "i-1", privateIP: "10.0.0.10"},
-				{instanceID: "i-2", privateIP: "10.0.0.11"},
-			},
+	c := newTestEc2Collection(krt.NewStaticCollection(nil, []ir.BackendObjectIR{
+		backendObjectIR(backend, newTestAWSSecret("aws-creds", "default", "1")),
+	}), &fakeEc2InstanceLister{
+		instances: []ec2DiscoveredInstance{
+			{instanceID: "i-1", privateIP: "10.0.0.10"},
+			{instanceID: "i-2", privateIP: "10.0.0.11"},
 		},
-	}
+	})
 
-	if _, err := c.computeState(context.Background()); err != nil {
-		t.Fatalf("computeState() error = %v", err)
-	}
+	c.Refresh(context.Background(), true)
 
 	gathered := metricstest.MustGatherMetrics(t)
 	gathered.AssertMetricsInclude(pollTotalMetric, []metricstest.ExpectMetric{
@@ -82,24 +77,19 @@ func TestComputeStateRecordsSuccessfulPollMetrics(t *testing.T) {
 	gathered.AssertHistogramPopulated(pollDurationMetric)
 }
 
-func TestComputeStateRecordsNoMatchingInstancesMetrics(t *testing.T) {
+func TestEc2DiscoveryRecordsNoMatchingInstancesMetrics(t *testing.T) {
 	ResetEc2DiscoveryMetrics()
 
 	backend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", []kgateway.AwsTagFilter{tagKeyValue("app", "nope")})
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{
-			backendObjectIR(backend, newTestAWSSecret("aws-creds", "default", "1")),
-		}),
-		lister: &fakeEc2InstanceLister{
-			instances: []ec2DiscoveredInstance{
-				{instanceID: "i-1", privateIP: "10.0.0.10", tags: map[string]string{"app": "payments"}},
-			},
+	c := newTestEc2Collection(krt.NewStaticCollection(nil, []ir.BackendObjectIR{
+		backendObjectIR(backend, newTestAWSSecret("aws-creds", "default", "1")),
+	}), &fakeEc2InstanceLister{
+		instances: []ec2DiscoveredInstance{
+			{instanceID: "i-1", privateIP: "10.0.0.10", tags: map[string]string{"app": "payments"}},
 		},
-	}
+	})
 
-	if _, err := c.computeState(context.Background()); err != nil {
-		t.Fatalf("computeState() error = %v", err)
-	}
+	c.Refresh(context.Background(), true)
 
 	gathered := metricstest.MustGatherMetrics(t)
 	// A successful poll that matched nothing is still result=success, with
@@ -125,33 +115,23 @@ func TestComputeStateRecordsNoMatchingInstancesMetrics(t *testing.T) {
 	})
 }
 
-func TestComputeStateRecordsErrorPollMetricsAndRetainsEndpointGauge(t *testing.T) {
+func TestEc2DiscoveryRecordsErrorPollMetricsAndRetainsEndpointGauge(t *testing.T) {
 	ResetEc2DiscoveryMetrics()
 
 	backend := newEc2Backend("backend-a", "arn:aws:iam::123456789012:role/shared", nil)
 	backendIR := backendObjectIR(backend, newTestAWSSecret("aws-creds", "default", "1"))
-	c := &ec2EndpointsCollection{
-		backends: krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendIR}),
-		lister: &fakeEc2InstanceLister{
-			instances: []ec2DiscoveredInstance{{instanceID: "i-1", privateIP: "10.0.0.10"}},
-		},
+	lister := &fakeEc2InstanceLister{
+		instances: []ec2DiscoveredInstance{{instanceID: "i-1", privateIP: "10.0.0.10"}},
 	}
+	c := newTestEc2Collection(krt.NewStaticCollection(nil, []ir.BackendObjectIR{backendIR}), lister)
 
 	// First poll succeeds and resolves one endpoint.
-	state, err := c.computeState(context.Background())
-	if err != nil {
-		t.Fatalf("computeState() error = %v", err)
-	}
-	c.state = state
+	c.Refresh(context.Background(), true)
 
 	// Second poll fails: endpoints carry forward (degraded), but the counter must
 	// record the underlying classification reason, not the Degraded status reason.
-	c.lister = &fakeEc2InstanceLister{
-		err: fmt.Errorf("describe instances: %w", &smithy.GenericAPIError{Code: "AuthFailure", Message: "auth failed"}),
-	}
-	if _, err := c.computeState(context.Background()); err == nil {
-		t.Fatal("computeState() error = nil, want error")
-	}
+	lister.setErr(fmt.Errorf("describe instances: %w", &smithy.GenericAPIError{Code: "AuthFailure", Message: "auth failed"}))
+	c.Refresh(context.Background(), true)
 
 	gathered := metricstest.MustGatherMetrics(t)
 	gathered.AssertMetricsInclude(pollTotalMetric, []metricstest.ExpectMetric{
@@ -185,7 +165,7 @@ func TestComputeStateRecordsErrorPollMetricsAndRetainsEndpointGauge(t *testing.T
 	gathered.AssertHistogramPopulated(pollDurationMetric)
 }
 
-func TestDiscoveryStatusForBackendRecordsErrorStateForUnresolvedSecret(t *testing.T) {
+func TestEc2DiscoveryStatusRecordsErrorStateForUnresolvedSecret(t *testing.T) {
 	ResetEc2DiscoveryMetrics()
 
 	// A secret-auth backend whose secret cannot be resolved never enters the poll
@@ -198,9 +178,12 @@ func TestDiscoveryStatusForBackendRecordsErrorStateForUnresolvedSecret(t *testin
 	}
 	backend := backendObjectIR(be, nil)
 
-	c := &ec2EndpointsCollection{enabled: true}
-	if got := c.discoveryStatusForBackend(krt.TestingDummyContext{}, backend); got == nil {
-		t.Fatal("discoveryStatusForBackend() = nil, want a CredentialError status")
+	c := newTestEc2Collection(krt.NewStaticCollection(nil, []ir.BackendObjectIR{backend}), &fakeEc2InstanceLister{})
+	if !c.DiscoveryStatus.WaitUntilSynced(nil) {
+		t.Fatal("DiscoveryStatus failed to sync")
+	}
+	if got := c.DiscoveryStatus.List(); len(got) != 1 {
+		t.Fatalf("DiscoveryStatus.List() = %d, want a CredentialError status", len(got))
 	}
 
 	gathered := metricstest.MustGatherMetrics(t)
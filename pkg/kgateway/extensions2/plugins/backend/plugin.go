@@ -42,6 +42,9 @@ type backendIr struct {
 	dfpIr            *DfpIr
 	gcpIr            *GcpIr
 	priorityGroupsIr *PriorityGroupsIr
+	consulIr         *ConsulIr
+	dnsSrvIr         *DnsSrvIr
+	fileIr           *FileIr
 	errors           []error
 }
 
@@ -70,6 +73,18 @@ func (u *backendIr) Equals(other any) bool {
 	if !u.priorityGroupsIr.Equals(otherBackend.priorityGroupsIr) {
 		return false
 	}
+	// Consul
+	if !u.consulIr.Equals(otherBackend.consulIr) {
+		return false
+	}
+	// DNS SRV
+	if !u.dnsSrvIr.Equals(otherBackend.dnsSrvIr) {
+		return false
+	}
+	// File
+	if !u.fileIr.Equals(otherBackend.fileIr) {
+		return false
+	}
 	if len(u.errors) != len(otherBackend.errors) {
 		return false
 	}
@@ -102,7 +117,12 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections) sd
 	col := krt.WrapClient(cli, commoncol.KrtOpts.ToOptions("Backends")...)
 
 	gk := wellknown.BackendGVK.GroupKind()
-	translateFn := buildTranslateFunc(col, commoncol.Secrets, commoncol.Settings.EnableAwsEc2Discovery)
+	translateFn := buildTranslateFunc(col, commoncol.Secrets, translateOptions{
+		enableAwsEc2Discovery:  commoncol.Settings.EnableAwsEc2Discovery,
+		enableConsulDiscovery:  commoncol.Settings.EnableConsulDiscovery,
+		enableDnsSrvDiscovery:  commoncol.Settings.EnableDnsSrvDiscovery,
+		fileEndpointsDirectory: commoncol.Settings.FileEndpointsDirectory,
+	})
 	bcol := krt.NewCollection(col, func(krtctx krt.HandlerContext, i *kgateway.Backend) *ir.BackendObjectIR {
 		backendIR := translateFn(krtctx, i)
 		if len(backendIR.errors) > 0 {
@@ -126,6 +146,17 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections) sd
 		return &backend
 	})
 	ec2Endpoints := newEc2EndpointsCollection(ctx, commoncol, bcol)
+	consulEndpoints := newConsulEndpointsCollection(ctx, commoncol, bcol)
+	dnsSrvEndpoints := newDnsSrvEndpointsCollection(ctx, commoncol, bcol)
+	fileEndpoints := newFileEndpointsCollection(ctx, commoncol, bcol)
+	endpoints := krt.JoinCollection(
+		[]krt.Collection[ir.EndpointsForBackend]{ec2Endpoints.Endpoints, consulEndpoints.Endpoints, dnsSrvEndpoints.Endpoints, fileEndpoints.Endpoints},
+		commoncol.KrtOpts.ToOptions("BackendDiscoveredEndpoints")...,
+	)
+	discoveryStatus := krt.JoinCollection(
+		[]krt.Collection[ir.BackendObjectStatus]{ec2Endpoints.DiscoveryStatus, consulEndpoints.DiscoveryStatus, dnsSrvEndpoints.DiscoveryStatus, fileEndpoints.DiscoveryStatus},
+		commoncol.KrtOpts.ToOptions("BackendDiscoveryStatus")...,
+	)
 	return sdk.Plugin{
 		ContributesBackends: map[schema.GroupKind]sdk.BackendPlugin{
 			gk: {
@@ -133,8 +164,8 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections) sd
 					InitEnvoyBackend: processBackendForEnvoy,
 				},
 				Backends:        bcol,
-				Endpoints:       ec2Endpoints.Endpoints,
-				ExtraConditions: ec2Endpoints.DiscoveryStatus,
+				Endpoints:       endpoints,
+				ExtraConditions: discoveryStatus,
 			},
 		},
 		ContributesPolicies: map[schema.GroupKind]sdk.PolicyPlugin{
@@ -143,16 +174,31 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections) sd
 				NewGatewayTranslationPass: newPlug,
 			},
 		},
-		ExtraHasSynced: ec2Endpoints.HasSynced,
+		ExtraHasSynced: func() bool {
+			return ec2Endpoints.HasSynced() && consulEndpoints.HasSynced() && dnsSrvEndpoints.HasSynced() && fileEndpoints.HasSynced()
+		},
 	}
 }
 
+// translateOptions holds the settings that control how Backends are translated.
+type translateOptions struct {
+	// enableAwsEc2Discovery allows AWS EC2 Backends, whose instances the controller discovers.
+	enableAwsEc2Discovery bool
+	// enableConsulDiscovery allows Consul Backends, whose services the controller discovers.
+	enableConsulDiscovery bool
+	// enableDnsSrvDiscovery allows DNS SRV Backends, whose records the controller resolves.
+	enableDnsSrvDiscovery bool
+	// fileEndpointsDirectory is the directory that the paths of File Backends are
+	// resolved against. File Backends with a path are rejected when it is empty.
+	fileEndpointsDirectory string
+}
+
 // buildTranslateFunc builds a function that translates a Backend to a backendIr that
 // the plugin can use to build the envoy config.
 func buildTranslateFunc(
 	col krt.Collection[*kgateway.Backend],
 	secrets *krtcollections.SecretIndex,
-	enableAwsEc2Discovery bool,
+	opts translateOptions,
 ) func(krtctx krt.HandlerContext, i *kgateway.Backend) *backendIr {
 	return func(krtctx krt.HandlerContext, i *kgateway.Backend) *backendIr {
 		var beIr backendIr
@@ -216,7 +262,8 @@ func buildTranslateFunc(
 				}
 
 				lambdaFilters, err := buildLambdaFilters(
-					lambdaArn, region, i.Spec.Aws.Auth, secret, invokeMode, i.Spec.Aws.Lambda.PayloadTransformMode)
+					lambdaArn, region, i.Spec.Aws.Auth, secret, invokeMode, i.Spec.Aws.Lambda.PayloadTransformMode,
+					buildLambdaModuleConfig(i.Spec.Aws, region))
 				if err != nil {
 					beIr.errors = append(beIr.errors, err)
 					break
@@ -230,7 +277,7 @@ func buildTranslateFunc(
 					},
 				}
 			case i.Spec.Aws.Ec2 != nil:
-				if !enableAwsEc2Discovery {
+				if !opts.enableAwsEc2Discovery {
 					beIr.errors = append(beIr.errors, errAwsEc2DiscoveryDisabled)
 					break
 				}
@@ -252,6 +299,40 @@ func buildTranslateFunc(
 				beIr.errors = append(beIr.errors, err)
 			}
 			beIr.gcpIr = gcpIr
+		case i.Spec.Consul != nil:
+			if !opts.enableConsulDiscovery {
+				beIr.errors = append(beIr.errors, errConsulDiscoveryDisabled)
+				break
+			}
+			secret, err := loadConsulTokenSecret(krtctx, secrets, i)
+			if err != nil {
+				beIr.errors = append(beIr.errors, err)
+				break
+			}
+			consulIr, err := buildConsulIr(i.Spec.Consul, secret)
+			if err != nil {
+				beIr.errors = append(beIr.errors, err)
+				break
+			}
+			beIr.consulIr = consulIr
+		case i.Spec.DnsSrv != nil:
+			if !opts.enableDnsSrvDiscovery {
+				beIr.errors = append(beIr.errors, errDnsSrvDiscoveryDisabled)
+				break
+			}
+			dnsSrvIr, err := buildDnsSrvIr(i.Spec.DnsSrv)
+			if err != nil {
+				beIr.errors = append(beIr.errors, err)
+				break
+			}
+			beIr.dnsSrvIr = dnsSrvIr
+		case i.Spec.File != nil:
+			fileIr, err := buildFileIr(i.Spec.File, i.GetNamespace(), opts.fileEndpointsDirectory)
+			if err != nil {
+				beIr.errors = append(beIr.errors, err)
+				break
+			}
+			beIr.fileIr = fileIr
 		}
 		return &beIr
 	}
@@ -323,6 +404,21 @@ func processBackendForEnvoy(ctx context.Context, in ir.BackendObjectIR, out *env
 			logger.Error("failed to process gcp backend", "error", err)
 			beIr.errors = append(beIr.errors, err)
 		}
+	case spec.Consul != nil:
+		if beIr.consulIr == nil {
+			return nil
+		}
+		processConsul(beIr.consulIr, out)
+	case spec.DnsSrv != nil:
+		if beIr.dnsSrvIr == nil {
+			return nil
+		}
+		processDnsSrv(beIr.dnsSrvIr, out)
+	case spec.File != nil:
+		if beIr.fileIr == nil {
+			return nil
+		}
+		processFile(beIr.fileIr, out)
 	}
 	return nil
 }
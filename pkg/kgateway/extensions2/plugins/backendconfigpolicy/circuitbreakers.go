@@ -2,6 +2,9 @@ package backendconfigpolicy
 
 import (
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
@@ -26,6 +29,15 @@ func translateCircuitBreakers(cb *kgateway.CircuitBreakers) *envoyclusterv3.Circ
 	if cb.MaxRetries != nil {
 		threshold.MaxRetries = wrapperspb.UInt32(uint32(*cb.MaxRetries)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
 	}
+	if cb.RetryBudget != nil {
+		threshold.RetryBudget = &envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget{}
+		if cb.RetryBudget.Percent != nil {
+			threshold.RetryBudget.BudgetPercent = &typev3.Percent{Value: float64(*cb.RetryBudget.Percent)}
+		}
+		if cb.RetryBudget.MinRetryConcurrency != nil {
+			threshold.RetryBudget.MinRetryConcurrency = wrapperspb.UInt32(uint32(*cb.RetryBudget.MinRetryConcurrency)) // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+		}
+	}
 	if cb.TrackRemaining != nil {
 		threshold.TrackRemaining = *cb.TrackRemaining
 	}
@@ -34,3 +46,24 @@ func translateCircuitBreakers(cb *kgateway.CircuitBreakers) *envoyclusterv3.Circ
 		Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{threshold},
 	}
 }
+
+// applyCircuitBreakers returns the circuit breakers of the policy, keeping the retry budget already
+// set on the cluster, e.g. by an XBackendTrafficPolicy, unless the policy limits retries itself.
+// Backend policies are applied in no particular order, so the outcome must not depend on which
+// policy was applied first.
+func applyCircuitBreakers(existing, cb *envoyclusterv3.CircuitBreakers) *envoyclusterv3.CircuitBreakers {
+	var budget *envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget
+	for _, threshold := range existing.GetThresholds() {
+		if threshold.GetPriority() == envoycorev3.RoutingPriority_DEFAULT {
+			budget = threshold.GetRetryBudget()
+		}
+	}
+	threshold := cb.GetThresholds()[0]
+	if budget == nil || threshold.GetMaxRetries() != nil || threshold.GetRetryBudget() != nil {
+		return cb
+	}
+
+	merged := proto.Clone(cb).(*envoyclusterv3.CircuitBreakers)
+	merged.GetThresholds()[0].RetryBudget = budget
+	return merged
+}
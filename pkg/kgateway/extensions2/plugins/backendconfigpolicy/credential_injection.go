@@ -0,0 +1,358 @@
+package backendconfigpolicy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyendpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoydnsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dns/v3"
+	credentialinjectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/credential_injector/v3"
+	genericcredentialv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/generic/v3"
+	oauth2credentialv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/oauth2/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	eiutils "github.com/kgateway-dev/kgateway/v2/internal/envoyinit/pkg/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+)
+
+const (
+	credentialInjectorFilterNamePrefix = "credential_injector"
+	genericCredentialExtensionName     = "envoy.http.injected_credentials.generic"
+	oauth2CredentialExtensionName      = "envoy.http.injected_credentials.oauth2"
+
+	defaultCredentialKey   = "credential"
+	oauth2ClientSecretKey  = "client-secret"
+	tokenEndpointTimeout   = 10 * time.Second
+	tokenEndpointHTTPSPort = 443
+)
+
+// credentialInjectorFilterStage runs the credential injector after the upstream transformations,
+// so that they cannot read or remove the injected credential.
+var credentialInjectorFilterStage = filters.AfterStage(filters.TransformationStage)
+
+// credentialInjectionIR holds the credential injector upstream filter of a single policy.
+// The credential itself is not part of the filter: it is referenced by name and delivered
+// to Envoy as an SDS secret.
+type credentialInjectionIR struct {
+	filterName string
+	filter     *credentialinjectorv3.CredentialInjector
+	secret     *envoytlsv3.Secret
+	// tokenCluster is the cluster of the OAuth2 token endpoint, if any.
+	tokenCluster *envoyclusterv3.Cluster
+}
+
+func (c *credentialInjectionIR) Equals(other *credentialInjectionIR) bool {
+	if c == nil || other == nil {
+		return c == nil && other == nil
+	}
+	return c.filterName == other.filterName &&
+		proto.Equal(c.filter, other.filter) &&
+		proto.Equal(c.secret, other.secret) &&
+		proto.Equal(c.tokenCluster, other.tokenCluster)
+}
+
+func translateCredentialInjection(
+	secretGetter SecretGetter,
+	in *kgateway.CredentialInjection,
+	name, namespace string,
+) (*credentialInjectionIR, error) {
+	out := &credentialInjectionIR{
+		filterName: fmt.Sprintf("%s/%s/%s", credentialInjectorFilterNamePrefix, namespace, name),
+	}
+
+	var credential proto.Message
+	var extensionName string
+	switch {
+	case in.SecretRef != nil:
+		key := in.SecretRef.Key
+		if key == "" {
+			key = defaultCredentialKey
+		}
+		secret, err := credentialSecret(secretGetter, in.SecretRef.Name, key, namespace)
+		if err != nil {
+			return nil, err
+		}
+		out.secret = secret
+		credential = &genericcredentialv3.Generic{
+			Credential:        sdsSecretConfig(secret.GetName()),
+			Header:            ptr.Deref(in.SecretRef.Header, ""),
+			HeaderValuePrefix: ptr.Deref(in.SecretRef.Prefix, ""),
+		}
+		extensionName = genericCredentialExtensionName
+
+	case in.OAuth2 != nil:
+		secret, err := credentialSecret(secretGetter, in.OAuth2.Credentials.ClientSecretRef.Name, oauth2ClientSecretKey, namespace)
+		if err != nil {
+			return nil, err
+		}
+		out.secret = secret
+		tokenCluster, err := tokenEndpointCluster(in.OAuth2.TokenEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		out.tokenCluster = tokenCluster
+		oauth2 := &oauth2credentialv3.OAuth2{
+			TokenEndpoint: &envoycorev3.HttpUri{
+				Uri: in.OAuth2.TokenEndpoint.String(),
+				HttpUpstreamType: &envoycorev3.HttpUri_Cluster{
+					Cluster: tokenCluster.GetName(),
+				},
+				Timeout: durationpb.New(tokenEndpointTimeout),
+			},
+			Scopes: in.OAuth2.Scopes,
+			FlowType: &oauth2credentialv3.OAuth2_ClientCredentials_{
+				ClientCredentials: &oauth2credentialv3.OAuth2_ClientCredentials{
+					ClientId:     in.OAuth2.Credentials.ClientID,
+					ClientSecret: sdsSecretConfig(secret.GetName()),
+				},
+			},
+		}
+		if in.OAuth2.TokenFetchRetryInterval != nil {
+			oauth2.TokenFetchRetryInterval = durationpb.New(in.OAuth2.TokenFetchRetryInterval.Duration)
+		}
+		credential = oauth2
+		extensionName = oauth2CredentialExtensionName
+
+	default:
+		return nil, errors.New("one of secretRef or oauth2 must be set for the credential injection")
+	}
+
+	typedConfig, err := utils.MessageToAny(credential)
+	if err != nil {
+		return nil, err
+	}
+	out.filter = &credentialinjectorv3.CredentialInjector{
+		Overwrite:                     ptr.Deref(in.Overwrite, false),
+		AllowRequestWithoutCredential: ptr.Deref(in.AllowRequestWithoutCredential, false),
+		Credential: &envoycorev3.TypedExtensionConfig{
+			Name:        extensionName,
+			TypedConfig: typedConfig,
+		},
+	}
+	if err := out.filter.ValidateAll(); err != nil {
+		return nil, fmt.Errorf("invalid credential injection: %w", err)
+	}
+	return out, nil
+}
+
+// credentialSecret builds the SDS secret holding the value of the given Secret key.
+func credentialSecret(secretGetter SecretGetter, name, key, namespace string) (*envoytlsv3.Secret, error) {
+	secret, err := secretGetter.GetSecret(name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find secret %s: %w", name, err)
+	}
+	data, ok := secret.Data[key]
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("secret %s does not contain key '%s'", name, key)
+	}
+	return &envoytlsv3.Secret{
+		Name: fmt.Sprintf("%s/%s/%s/%s", credentialInjectorFilterNamePrefix, namespace, name, key),
+		Type: &envoytlsv3.Secret_GenericSecret{
+			GenericSecret: &envoytlsv3.GenericSecret{
+				Secret: &envoycorev3.DataSource{
+					Specifier: &envoycorev3.DataSource_InlineBytes{
+						InlineBytes: data,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func sdsSecretConfig(name string) *envoytlsv3.SdsSecretConfig {
+	return &envoytlsv3.SdsSecretConfig{
+		Name: name,
+		SdsConfig: &envoycorev3.ConfigSource{
+			ResourceApiVersion: envoycorev3.ApiVersion_V3,
+			ConfigSourceSpecifier: &envoycorev3.ConfigSource_Ads{
+				Ads: &envoycorev3.AggregatedConfigSource{},
+			},
+		},
+	}
+}
+
+// tokenEndpointCluster builds the cluster Envoy uses to fetch OAuth2 tokens from the given endpoint.
+// Its name only depends on the host and port, so that policies using the same authorization server
+// share the cluster.
+func tokenEndpointCluster(endpoint kgateway.HttpsUri) (*envoyclusterv3.Cluster, error) {
+	u, err := url.Parse(endpoint.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid token endpoint %s: %w", endpoint, err)
+	}
+	host := u.Hostname()
+	port := uint32(tokenEndpointHTTPSPort)
+	if p := u.Port(); p != "" {
+		parsed, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token endpoint port %s: %w", p, err)
+		}
+		port = uint32(parsed)
+	}
+	name := fmt.Sprintf("%s_token_%s_%d", credentialInjectorFilterNamePrefix, host, port)
+
+	tlsContext, err := utils.MessageToAny(&envoytlsv3.UpstreamTlsContext{
+		Sni: host,
+		CommonTlsContext: &envoytlsv3.CommonTlsContext{
+			ValidationContextType: &envoytlsv3.CommonTlsContext_CombinedValidationContext{
+				CombinedValidationContext: &envoytlsv3.CommonTlsContext_CombinedCertificateValidationContext{
+					DefaultValidationContext: &envoytlsv3.CertificateValidationContext{
+						MatchTypedSubjectAltNames: []*envoytlsv3.SubjectAltNameMatcher{{
+							SanType: envoytlsv3.SubjectAltNameMatcher_DNS,
+							Matcher: &envoymatcherv3.StringMatcher{
+								MatchPattern: &envoymatcherv3.StringMatcher_Exact{Exact: host},
+							},
+						}},
+					},
+					ValidationContextSdsSecretConfig: &envoytlsv3.SdsSecretConfig{
+						Name: eiutils.SystemCaSecretName,
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	dnsClusterConfig, err := utils.MessageToAny(&envoydnsv3.DnsCluster{
+		RespectDnsTtl: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &envoyclusterv3.Cluster{
+		Name:           name,
+		ConnectTimeout: durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &envoyclusterv3.Cluster_ClusterType{
+			ClusterType: &envoyclusterv3.Cluster_CustomClusterType{
+				Name:        dnsClusterExtensionName,
+				TypedConfig: dnsClusterConfig,
+			},
+		},
+		LoadAssignment: &envoyendpointv3.ClusterLoadAssignment{
+			ClusterName: name,
+			Endpoints: []*envoyendpointv3.LocalityLbEndpoints{{
+				LbEndpoints: []*envoyendpointv3.LbEndpoint{{
+					HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+						Endpoint: &envoyendpointv3.Endpoint{
+							Address: &envoycorev3.Address{
+								Address: &envoycorev3.Address_SocketAddress{
+									SocketAddress: &envoycorev3.SocketAddress{
+										Address: host,
+										PortSpecifier: &envoycorev3.SocketAddress_PortValue{
+											PortValue: port,
+										},
+									},
+								},
+							},
+						},
+					},
+				}},
+			}},
+		},
+		TransportSocket: &envoycorev3.TransportSocket{
+			Name: envoywellknown.TransportSocketTls,
+			ConfigType: &envoycorev3.TransportSocket_TypedConfig{
+				TypedConfig: tlsContext,
+			},
+		},
+	}, nil
+}
+
+// backendConfigPolicyPluginGwPass adds the upstream filters and resources of the policies attached
+// to the backends routed to by a Gateway. The cluster settings of the policies are applied when
+// translating the backends, in processBackend.
+type backendConfigPolicyPluginGwPass struct {
+	ir.UnimplementedProxyTranslationPass
+
+	credentialInjectorsInChain map[string][]*credentialInjectionIR
+	secrets                    map[string]*envoytlsv3.Secret
+	clusters                   map[string]*envoyclusterv3.Cluster
+}
+
+var _ ir.ProxyTranslationPass = &backendConfigPolicyPluginGwPass{}
+
+func newGatewayTranslationPass(_ ir.GwTranslationCtx, _ reporter.Reporter) ir.ProxyTranslationPass {
+	return &backendConfigPolicyPluginGwPass{}
+}
+
+// ApplyForBackend is called for every backend of a route, and enables the credential injector of the
+// policy attached to the backend for that backend only. The filter config is set on the weighted cluster
+// when the route has several backends, so the credential is only sent to the backend it belongs to.
+func (p *backendConfigPolicyPluginGwPass) ApplyForBackend(pCtx *ir.RouteBackendContext, _ ir.HttpBackend, _ *envoyroutev3.Route) error {
+	if pCtx.Backend == nil {
+		return nil
+	}
+	pols := pCtx.Backend.AttachedPolicies.Policies[wellknown.BackendConfigPolicyGVK.GroupKind()]
+	if len(pols) == 0 {
+		return nil
+	}
+	pol, ok := mergeAttachedPolicies(pols).PolicyIr.(*BackendConfigPolicyIR)
+	if !ok || pol.credentialInjection == nil {
+		return nil
+	}
+	in := pol.credentialInjection
+
+	pCtx.TypedFilterConfig.AddTypedConfig(in.filterName, &envoyroutev3.FilterConfig{Config: &anypb.Any{}})
+
+	if p.credentialInjectorsInChain == nil {
+		p.credentialInjectorsInChain = make(map[string][]*credentialInjectionIR)
+	}
+	if !slices.ContainsFunc(p.credentialInjectorsInChain[pCtx.FilterChainName], func(existing *credentialInjectionIR) bool {
+		return existing.filterName == in.filterName
+	}) {
+		p.credentialInjectorsInChain[pCtx.FilterChainName] = append(p.credentialInjectorsInChain[pCtx.FilterChainName], in)
+	}
+
+	if p.secrets == nil {
+		p.secrets = make(map[string]*envoytlsv3.Secret)
+	}
+	p.secrets[in.secret.GetName()] = in.secret
+	if in.tokenCluster != nil {
+		if p.clusters == nil {
+			p.clusters = make(map[string]*envoyclusterv3.Cluster)
+		}
+		p.clusters[in.tokenCluster.GetName()] = in.tokenCluster
+	}
+	return nil
+}
+
+// UpstreamHttpFilters adds a disabled-by-default credential injector for every policy used in the filter chain.
+func (p *backendConfigPolicyPluginGwPass) UpstreamHttpFilters(_ ir.HttpFiltersContext, fcc ir.FilterChainCommon) ([]filters.StagedUpstreamHttpFilter, error) {
+	var stagedFilters []filters.StagedUpstreamHttpFilter
+	for _, in := range p.credentialInjectorsInChain[fcc.FilterChainName] {
+		filter := filters.MustNewStagedUpstreamFilter(in.filterName, in.filter, credentialInjectorFilterStage)
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+	return stagedFilters, nil
+}
+
+func (p *backendConfigPolicyPluginGwPass) ResourcesToAdd() ir.Resources {
+	resources := ir.Resources{}
+	for _, secret := range p.secrets {
+		resources.Secrets = append(resources.Secrets, secret)
+	}
+	for _, cluster := range p.clusters {
+		resources.Clusters = append(resources.Clusters, cluster)
+	}
+	return resources
+}
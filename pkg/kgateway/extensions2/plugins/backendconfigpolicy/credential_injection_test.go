@@ -0,0 +1,172 @@
+package backendconfigpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	credentialinjectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/credential_injector/v3"
+	genericcredentialv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/generic/v3"
+	oauth2credentialv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/oauth2/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestTranslateCredentialInjection(t *testing.T) {
+	secretGetter := NewMockSecretGetter()
+	secretGetter.AddSecret("api-key", "default", &ir.Secret{
+		Data: map[string][]byte{"credential": []byte("secret-api-key")},
+	})
+	secretGetter.AddSecret("client", "default", &ir.Secret{
+		Data: map[string][]byte{"client-secret": []byte("client-secret")},
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		out, err := translateCredentialInjection(secretGetter, &kgateway.CredentialInjection{
+			SecretRef: &kgateway.CredentialSecretRef{Name: "api-key", Header: new("x-api-key")},
+			Overwrite: new(true),
+		}, "policy", "default")
+		require.NoError(t, err)
+		assert.Equal(t, "credential_injector/default/policy", out.filterName)
+		assert.True(t, out.filter.GetOverwrite())
+		assert.False(t, out.filter.GetAllowRequestWithoutCredential())
+		assert.Nil(t, out.tokenCluster)
+
+		assert.Equal(t, "credential_injector/default/api-key/credential", out.secret.GetName())
+		assert.Equal(t, []byte("secret-api-key"), out.secret.GetGenericSecret().GetSecret().GetInlineBytes())
+
+		generic := &genericcredentialv3.Generic{}
+		require.NoError(t, out.filter.GetCredential().GetTypedConfig().UnmarshalTo(generic))
+		assert.Equal(t, out.secret.GetName(), generic.GetCredential().GetName())
+		assert.Equal(t, "x-api-key", generic.GetHeader())
+		assert.Empty(t, generic.GetHeaderValuePrefix())
+	})
+
+	t.Run("oauth2", func(t *testing.T) {
+		out, err := translateCredentialInjection(secretGetter, &kgateway.CredentialInjection{
+			OAuth2: &kgateway.OAuth2ClientCredentials{
+				TokenEndpoint: "https://auth.example.com:8443/oauth2/token",
+				Credentials: kgateway.OAuth2Credentials{
+					ClientID:        "gateway",
+					ClientSecretRef: corev1.LocalObjectReference{Name: "client"},
+				},
+				Scopes:                  []string{"read"},
+				TokenFetchRetryInterval: &metav1.Duration{Duration: 5 * time.Second},
+			},
+			AllowRequestWithoutCredential: new(true),
+		}, "policy", "default")
+		require.NoError(t, err)
+		assert.True(t, out.filter.GetAllowRequestWithoutCredential())
+		assert.Equal(t, []byte("client-secret"), out.secret.GetGenericSecret().GetSecret().GetInlineBytes())
+
+		require.NotNil(t, out.tokenCluster)
+		assert.Equal(t, "credential_injector_token_auth.example.com_8443", out.tokenCluster.GetName())
+		address := out.tokenCluster.GetLoadAssignment().GetEndpoints()[0].GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress()
+		assert.Equal(t, "auth.example.com", address.GetAddress())
+		assert.Equal(t, uint32(8443), address.GetPortValue())
+		require.NoError(t, out.tokenCluster.ValidateAll())
+
+		oauth2 := &oauth2credentialv3.OAuth2{}
+		require.NoError(t, out.filter.GetCredential().GetTypedConfig().UnmarshalTo(oauth2))
+		assert.Equal(t, "https://auth.example.com:8443/oauth2/token", oauth2.GetTokenEndpoint().GetUri())
+		assert.Equal(t, out.tokenCluster.GetName(), oauth2.GetTokenEndpoint().GetCluster())
+		assert.Equal(t, []string{"read"}, oauth2.GetScopes())
+		assert.Equal(t, 5*time.Second, oauth2.GetTokenFetchRetryInterval().AsDuration())
+		assert.Equal(t, "gateway", oauth2.GetClientCredentials().GetClientId())
+		assert.Equal(t, out.secret.GetName(), oauth2.GetClientCredentials().GetClientSecret().GetName())
+	})
+
+	tests := []struct {
+		name     string
+		in       *kgateway.CredentialInjection
+		expected string
+	}{
+		{
+			name:     "missing secret",
+			in:       &kgateway.CredentialInjection{SecretRef: &kgateway.CredentialSecretRef{Name: "missing"}},
+			expected: "failed to find secret missing",
+		},
+		{
+			name:     "missing key",
+			in:       &kgateway.CredentialInjection{SecretRef: &kgateway.CredentialSecretRef{Name: "api-key", Key: "token"}},
+			expected: "secret api-key does not contain key 'token'",
+		},
+		{
+			name: "missing client secret key",
+			in: &kgateway.CredentialInjection{OAuth2: &kgateway.OAuth2ClientCredentials{
+				TokenEndpoint: "https://auth.example.com/oauth2/token",
+				Credentials: kgateway.OAuth2Credentials{
+					ClientID:        "gateway",
+					ClientSecretRef: corev1.LocalObjectReference{Name: "api-key"},
+				},
+			}},
+			expected: "secret api-key does not contain key 'client-secret'",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := translateCredentialInjection(secretGetter, tc.in, "policy", "default")
+			require.ErrorContains(t, err, tc.expected)
+			assert.Nil(t, out)
+		})
+	}
+}
+
+func TestCredentialInjectionGatewayPass(t *testing.T) {
+	secretGetter := NewMockSecretGetter()
+	secretGetter.AddSecret("api-key", "default", &ir.Secret{
+		Data: map[string][]byte{"credential": []byte("secret-api-key")},
+	})
+	credentialInjection, err := translateCredentialInjection(secretGetter, &kgateway.CredentialInjection{
+		SecretRef: &kgateway.CredentialSecretRef{Name: "api-key"},
+	}, "policy", "default")
+	require.NoError(t, err)
+
+	withPolicy := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Service", Namespace: "default", Name: "with-policy"}, 80, "", "")
+	withPolicy.AttachedPolicies = ir.AttachedPolicies{Policies: map[schema.GroupKind][]ir.PolicyAtt{
+		wellknown.BackendConfigPolicyGVK.GroupKind(): {{PolicyIr: &BackendConfigPolicyIR{credentialInjection: credentialInjection}}},
+	}}
+	withoutPolicy := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Service", Namespace: "default", Name: "without-policy"}, 80, "", "")
+
+	pass := newGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*backendConfigPolicyPluginGwPass)
+	for _, backend := range []*ir.BackendObjectIR{&withPolicy, &withPolicy, &withoutPolicy} {
+		typedFilterConfig := ir.TypedFilterConfigMap{}
+		require.NoError(t, pass.ApplyForBackend(&ir.RouteBackendContext{
+			FilterChainName:   "test-filter-chain",
+			Backend:           backend,
+			TypedFilterConfig: typedFilterConfig,
+		}, ir.HttpBackend{}, nil))
+		if backend == &withoutPolicy {
+			assert.Empty(t, typedFilterConfig)
+		} else {
+			filterConfig, ok := typedFilterConfig.GetTypedConfig(credentialInjection.filterName).(*envoyroutev3.FilterConfig)
+			require.True(t, ok)
+			assert.False(t, filterConfig.GetDisabled())
+		}
+	}
+
+	upstreamFilters, err := pass.UpstreamHttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, upstreamFilters, 1)
+	assert.Equal(t, credentialInjection.filterName, upstreamFilters[0].Filter.GetName())
+	assert.True(t, upstreamFilters[0].Filter.GetDisabled())
+	filter := &credentialinjectorv3.CredentialInjector{}
+	require.NoError(t, upstreamFilters[0].Filter.GetTypedConfig().UnmarshalTo(filter))
+	assert.NotContains(t, filter.String(), "secret-api-key")
+
+	upstreamFilters, err = pass.UpstreamHttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "other-filter-chain"})
+	require.NoError(t, err)
+	assert.Empty(t, upstreamFilters)
+
+	resources := pass.ResourcesToAdd()
+	require.Len(t, resources.Secrets, 1)
+	assert.Equal(t, credentialInjection.secret.GetName(), resources.Secrets[0].GetName())
+	assert.Empty(t, resources.Clusters)
+}
@@ -39,6 +39,7 @@ func mergeBackendConfigPolicies(
 		mergeDnsJitter,
 		mergeRespectDnsTtl,
 		mergeUpstreamProxyProtocol,
+		mergeCredentialInjection,
 	}
 
 	for _, mergeFunc := range mergeFuncs {
@@ -165,3 +166,11 @@ func mergeUpstreamProxyProtocol(p1, p2 *BackendConfigPolicyIR, p2Ref *ir.Attache
 	p1.upstreamProxyProtocol = p2.upstreamProxyProtocol
 	mergeOrigins.SetOne("upstreamProxyProtocol", p2Ref, p2MergeOrigins)
 }
+
+func mergeCredentialInjection(p1, p2 *BackendConfigPolicyIR, p2Ref *ir.AttachedPolicyRef, p2MergeOrigins ir.MergeOrigins, opts policy.MergeOptions, mergeOrigins ir.MergeOrigins) {
+	if !policy.IsMergeable(p1.credentialInjection, p2.credentialInjection, opts) {
+		return
+	}
+	p1.credentialInjection = p2.credentialInjection
+	mergeOrigins.SetOne("credentialInjection", p2Ref, p2MergeOrigins)
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/endpoints"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
@@ -60,6 +61,7 @@ type BackendConfigPolicyIR struct {
 	dnsJitter                     *durationpb.Duration
 	respectDnsTtl                 *bool
 	upstreamProxyProtocol         *envoycorev3.ProxyProtocolConfig
+	credentialInjection           *credentialInjectionIR
 }
 
 var logger = logging.New("plugin/backendconfigpolicy")
@@ -139,6 +141,9 @@ func (d *BackendConfigPolicyIR) Equals(other any) bool {
 	if !proto.Equal(d.upstreamProxyProtocol, d2.upstreamProxyProtocol) {
 		return false
 	}
+	if !d.credentialInjection.Equals(d2.credentialInjection) {
+		return false
+	}
 	return true
 }
 
@@ -151,11 +156,16 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, v
 	col := krt.WrapClient(cli, commoncol.KrtOpts.ToOptions("BackendConfigPolicy")...)
 	gk := wellknown.BackendConfigPolicyGVK.GroupKind()
 
-	policyStatusMarker, backendConfigPolicyCol := krt.NewStatusCollection(col, func(krtctx krt.HandlerContext, b *kgateway.BackendConfigPolicy) (*krtcollections.StatusMarker, *ir.PolicyWrapper) {
+	buildIR := func(krtctx krt.HandlerContext, b *kgateway.BackendConfigPolicy) (*BackendConfigPolicyIR, []error) {
 		policyIR, errs := translate(commoncol, krtctx, b)
 		if err := validateXDS(ctx, policyIR, v, commoncol.Settings.ValidationMode); err != nil {
 			errs = append(errs, err)
 		}
+		return policyIR, errs
+	}
+
+	policyStatusMarker, backendConfigPolicyCol := krt.NewStatusCollection(col, func(krtctx krt.HandlerContext, b *kgateway.BackendConfigPolicy) (*krtcollections.StatusMarker, *ir.PolicyWrapper) {
+		policyIR, errs := buildIR(krtctx, b)
 
 		// Create status marker if existing status has kgateway controller
 		var statusMarker *krtcollections.StatusMarker
@@ -212,16 +222,24 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, v
 				ProcessPolicyStaleStatusMarkers: processMarkers,
 				ProcessBackend:                  processBackend,
 				PerClientProcessEndpoints:       endpointPlugin.processEndpoints,
-				MergePolicies: func(pols []ir.PolicyAtt) ir.PolicyAtt {
-					return policy.MergePolicies(sortForMerge(pols), mergeBackendConfigPolicies, "")
-				},
-				GetPolicyStatus:   getPolicyStatusFn(cli),
-				PatchPolicyStatus: patchPolicyStatusFn(cli),
+				NewGatewayTranslationPass:       newGatewayTranslationPass,
+				MergePolicies:                   mergeAttachedPolicies,
+				GetPolicyStatus:                 getPolicyStatusFn(cli),
+				PatchPolicyStatus:               patchPolicyStatusFn(cli),
+				ValidatePolicy: pluginutils.ValidatePolicyFn(func(krtctx krt.HandlerContext, b *kgateway.BackendConfigPolicy) []error {
+					_, errs := buildIR(krtctx, b)
+					return errs
+				}),
 			},
 		},
 	}
 }
 
+// mergeAttachedPolicies merges the policies attached to a backend into the effective policy.
+func mergeAttachedPolicies(pols []ir.PolicyAtt) ir.PolicyAtt {
+	return policy.MergePolicies(sortForMerge(pols), mergeBackendConfigPolicies, "")
+}
+
 // sortForMerge sorts policies by precedence weight (desc), creation time
 // (asc), ref string. The ref string is the tie-breaker when two policies
 // share a creation timestamp.
@@ -311,7 +329,7 @@ func processBackend(_ context.Context, polir ir.PolicyIR, backend ir.BackendObje
 	}
 
 	if pol.circuitBreakers != nil {
-		out.CircuitBreakers = pol.circuitBreakers
+		out.CircuitBreakers = applyCircuitBreakers(out.GetCircuitBreakers(), pol.circuitBreakers)
 	}
 
 	applyDnsClusterConfig(pol, out)
@@ -427,6 +445,13 @@ func translate(
 	if pol.Spec.UpstreamProxyProtocol != nil {
 		ir.upstreamProxyProtocol = translateUpstreamProxyProtocol(pol.Spec.UpstreamProxyProtocol)
 	}
+	if pol.Spec.CredentialInjection != nil {
+		credentialInjection, err := translateCredentialInjection(NewDefaultSecretGetter(commoncol.Secrets, krtctx), pol.Spec.CredentialInjection, pol.Name, pol.Namespace)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		ir.credentialInjection = credentialInjection
+	}
 	return &ir, errs
 }
 
@@ -14,6 +14,7 @@ import (
 	envoyrawbufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/raw_buffer/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_upstreams_http_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	envoywellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -363,6 +364,34 @@ func TestBackendConfigPolicyTranslation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "circuit breakers with retry budget",
+			policy: &kgateway.BackendConfigPolicy{
+				Spec: kgateway.BackendConfigPolicySpec{
+					CircuitBreakers: &kgateway.CircuitBreakers{
+						MaxRequests: new(int32(500)),
+						RetryBudget: &kgateway.RetryBudget{
+							Percent:             new(int32(25)),
+							MinRetryConcurrency: new(int32(5)),
+						},
+					},
+				},
+			},
+			want: &envoyclusterv3.Cluster{
+				CircuitBreakers: &envoyclusterv3.CircuitBreakers{
+					Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{
+						{
+							MaxRequests: &wrapperspb.UInt32Value{Value: 500},
+							RetryBudget: &envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget{
+								BudgetPercent:       &typev3.Percent{Value: 25},
+								MinRetryConcurrency: &wrapperspb.UInt32Value{Value: 5},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "upstream proxy protocol V1 without TLS",
 			policy: &kgateway.BackendConfigPolicy{
@@ -703,6 +732,53 @@ func TestBackendConfigPolicyHealthCheckHostnameOverride(t *testing.T) {
 	})
 }
 
+func TestBackendConfigPolicyCircuitBreakersKeepRetryBudget(t *testing.T) {
+	budget := &envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget{
+		BudgetPercent:       &typev3.Percent{Value: 25},
+		MinRetryConcurrency: wrapperspb.UInt32(10),
+	}
+	clusterWithBudget := func() *envoyclusterv3.Cluster {
+		return &envoyclusterv3.Cluster{
+			CircuitBreakers: &envoyclusterv3.CircuitBreakers{
+				Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{{RetryBudget: budget}},
+			},
+		}
+	}
+
+	t.Run("keeps the retry budget set on the cluster", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				CircuitBreakers: &kgateway.CircuitBreakers{MaxConnections: new(int32(100))},
+			},
+		})
+		require.Empty(t, errs)
+
+		cluster := clusterWithBudget()
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+
+		threshold := cluster.GetCircuitBreakers().GetThresholds()[0]
+		assert.Equal(t, uint32(100), threshold.GetMaxConnections().GetValue())
+		assert.True(t, proto.Equal(budget, threshold.GetRetryBudget()))
+		assert.Nil(t, policyIR.circuitBreakers.GetThresholds()[0].GetRetryBudget(), "the policy IR must not be mutated")
+	})
+
+	t.Run("retry limits of the policy take precedence", func(t *testing.T) {
+		policyIR, errs := translate(nil, nil, &kgateway.BackendConfigPolicy{
+			Spec: kgateway.BackendConfigPolicySpec{
+				CircuitBreakers: &kgateway.CircuitBreakers{MaxRetries: new(int32(5))},
+			},
+		})
+		require.Empty(t, errs)
+
+		cluster := clusterWithBudget()
+		processBackend(context.Background(), policyIR, ir.BackendObjectIR{}, cluster)
+
+		threshold := cluster.GetCircuitBreakers().GetThresholds()[0]
+		assert.Equal(t, uint32(5), threshold.GetMaxRetries().GetValue())
+		assert.Nil(t, threshold.GetRetryBudget())
+	})
+}
+
 func TestProcessEndpointsZoneAwarePolicy(t *testing.T) {
 	localLabels := map[string]string{corev1.LabelTopologyZone: "zone-a"}
 	remoteLabels := map[string]string{corev1.LabelTopologyZone: "zone-b"}
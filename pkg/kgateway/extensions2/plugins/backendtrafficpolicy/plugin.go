@@ -0,0 +1,272 @@
+package backendtrafficpolicy
+
+import (
+	"context"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	stateful_sessionv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/stateful_session/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gwxv1a1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
+	pluginutils "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+)
+
+var logger = logging.New("plugin/backendtrafficpolicy")
+
+const (
+	// statefulSessionFilterName is the name of the stateful session filter used for the session
+	// persistence of backends. It is distinct from the filter used for the session persistence of
+	// HTTPRoute rules, which runs after this one so the rule takes precedence when both are set.
+	statefulSessionFilterName = "envoy.filters.http.stateful_session/backend"
+
+	// Gateway API defaults for a retry constraint.
+	defaultRetryBudgetPercent  = 20
+	defaultMinRetryConcurrency = 10
+)
+
+var backendTrafficPolicyGroupKind = wellknown.XBackendTrafficPolicyGVK.GroupKind()
+
+// backendTrafficPolicy is the IR of an XBackendTrafficPolicy. The retry budget is applied to the
+// clusters of the targeted backends, the session persistence to the routes that send traffic to them.
+type backendTrafficPolicy struct {
+	// +noKrtEquals
+	ct                 time.Time
+	retryBudget        *envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget
+	sessionPersistence *stateful_sessionv3.StatefulSessionPerRoute
+}
+
+var _ ir.PolicyIR = &backendTrafficPolicy{}
+
+func (d *backendTrafficPolicy) CreationTime() time.Time {
+	return d.ct
+}
+
+func (d *backendTrafficPolicy) Equals(in any) bool {
+	d2, ok := in.(*backendTrafficPolicy)
+	if !ok {
+		return false
+	}
+	return proto.Equal(d.retryBudget, d2.retryBudget) &&
+		proto.Equal(d.sessionPersistence, d2.sessionPersistence)
+}
+
+func (d *backendTrafficPolicy) PolicyHash() uint64 {
+	if d == nil {
+		return 0
+	}
+	return utils.HashProto(d.retryBudget) ^ utils.HashProto(d.sessionPersistence)
+}
+
+// NewPlugin returns the plugin for the experimental XBackendTrafficPolicy. The CRD is optional: it
+// is only watched once it is installed, so the plugin is inert on clusters without it.
+func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections) sdk.Plugin {
+	if !commoncol.Settings.EnableExperimentalGatewayAPIFeatures {
+		return sdk.Plugin{}
+	}
+
+	inf := collections.NewOptionalInformer[*gwxv1a1.XBackendTrafficPolicy](
+		commoncol.Client,
+		wellknown.XBackendTrafficPolicyGVR,
+		kclient.Filter{ObjectFilter: commoncol.Client.ObjectFilter()},
+	)
+	col := krt.WrapClient(inf, commoncol.KrtOpts.ToOptions("XBackendTrafficPolicy")...)
+
+	policyStatusMarker, policyCol := krt.NewStatusCollection(col, func(krtctx krt.HandlerContext, i *gwxv1a1.XBackendTrafficPolicy) (*krtcollections.StatusMarker, *ir.PolicyWrapper) {
+		// Create status marker if existing status has kgateway controller
+		var statusMarker *krtcollections.StatusMarker
+		for _, ancestor := range i.Status.Ancestors {
+			if string(ancestor.ControllerName) == commoncol.ControllerName {
+				statusMarker = &krtcollections.StatusMarker{}
+				break
+			}
+		}
+
+		pol := &ir.PolicyWrapper{
+			ObjectSource: ir.ObjectSource{
+				Group:     backendTrafficPolicyGroupKind.Group,
+				Kind:      backendTrafficPolicyGroupKind.Kind,
+				Namespace: i.Namespace,
+				Name:      i.Name,
+			},
+			Policy:     i,
+			PolicyIR:   translate(i),
+			TargetRefs: pluginutils.TargetRefsToPolicyRefsV1(i.Spec.TargetRefs),
+		}
+		return statusMarker, pol
+	})
+
+	// processMarkers for policies that have existing status but no current report
+	processMarkers := func(kctx krt.HandlerContext, reportMap *reports.ReportMap) {
+		objStatus := krt.Fetch(kctx, policyStatusMarker)
+		for _, status := range objStatus {
+			policyKey := reporter.PolicyKey{
+				Group:     backendTrafficPolicyGroupKind.Group,
+				Kind:      backendTrafficPolicyGroupKind.Kind,
+				Namespace: status.Obj.GetNamespace(),
+				Name:      status.Obj.GetName(),
+			}
+
+			// Add empty status to clear stale status for policies with no valid targets
+			if reportMap.Policies[policyKey] == nil {
+				rp := reports.NewReporter(reportMap)
+				// create empty policy report entry with no ancestor refs
+				rp.Policy(policyKey, 0)
+			}
+		}
+	}
+
+	return sdk.Plugin{
+		ContributesPolicies: map[schema.GroupKind]sdk.PolicyPlugin{
+			backendTrafficPolicyGroupKind: {
+				Name:                            "XBackendTrafficPolicy",
+				Policies:                        policyCol,
+				ProcessPolicyStaleStatusMarkers: processMarkers,
+				ProcessBackend:                  processBackend,
+				NewGatewayTranslationPass:       newGatewayTranslationPass,
+				MergePolicies:                   mergePolicies,
+				GetPolicyStatus:                 getPolicyStatusFn(inf),
+				PatchPolicyStatus:               patchPolicyStatusFn(inf, kclient.NewWriteClient[*gwxv1a1.XBackendTrafficPolicy](commoncol.Client)),
+			},
+		},
+	}
+}
+
+func translate(in *gwxv1a1.XBackendTrafficPolicy) *backendTrafficPolicy {
+	out := &backendTrafficPolicy{
+		ct: in.CreationTimestamp.Time,
+	}
+	if in.Spec.RetryConstraint != nil {
+		out.retryBudget = translateRetryConstraint(in.Spec.RetryConstraint)
+	}
+	if in.Spec.SessionPersistence != nil {
+		out.sessionPersistence = krtcollections.ConvertSessionPersistence(in.Spec.SessionPersistence)
+	}
+	return out
+}
+
+// translateRetryConstraint maps a retry constraint to an Envoy retry budget. Envoy budgets retries
+// against the requests active on the cluster rather than those seen over an interval, so the
+// budget interval is not used, and the minimum retry rate becomes a minimum retry concurrency.
+func translateRetryConstraint(in *gwxv1a1.RetryConstraint) *envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget {
+	percent := defaultRetryBudgetPercent
+	if in.Budget != nil && in.Budget.Percent != nil {
+		percent = *in.Budget.Percent
+	}
+	minRetryConcurrency := defaultMinRetryConcurrency
+	if in.MinRetryRate != nil && in.MinRetryRate.Count != nil {
+		minRetryConcurrency = *in.MinRetryRate.Count
+	}
+	return &envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget{
+		BudgetPercent:       &typev3.Percent{Value: float64(percent)},
+		MinRetryConcurrency: wrapperspb.UInt32(uint32(minRetryConcurrency)), // nolint:gosec // G115: kubebuilder validation ensures safe for uint32
+	}
+}
+
+// mergePolicies returns the policy that applies to a backend targeted by several policies: per
+// Gateway API conflict resolution, the oldest one wins.
+func mergePolicies(policies []ir.PolicyAtt) ir.PolicyAtt {
+	if len(policies) == 0 {
+		return ir.PolicyAtt{}
+	}
+	return policies[ir.WinnerPolicyIndexByCreationTimeAndRef(policies)]
+}
+
+// processBackend adds the retry budget of the policy to the default circuit breaker thresholds of
+// the cluster. A BackendConfigPolicy that limits retries on the same backend takes precedence, which
+// it also enforces when it is applied after this policy.
+func processBackend(_ context.Context, polir ir.PolicyIR, _ ir.BackendObjectIR, out *envoyclusterv3.Cluster) {
+	pol, ok := polir.(*backendTrafficPolicy)
+	if !ok || pol.retryBudget == nil {
+		return
+	}
+
+	circuitBreakers := &envoyclusterv3.CircuitBreakers{}
+	if out.GetCircuitBreakers() != nil {
+		circuitBreakers = proto.Clone(out.GetCircuitBreakers()).(*envoyclusterv3.CircuitBreakers)
+	}
+	var threshold *envoyclusterv3.CircuitBreakers_Thresholds
+	for _, t := range circuitBreakers.GetThresholds() {
+		if t.GetPriority() == envoycorev3.RoutingPriority_DEFAULT {
+			threshold = t
+			break
+		}
+	}
+	if threshold == nil {
+		threshold = &envoyclusterv3.CircuitBreakers_Thresholds{}
+		circuitBreakers.Thresholds = append(circuitBreakers.Thresholds, threshold)
+	}
+	if threshold.GetMaxRetries() != nil || threshold.GetRetryBudget() != nil {
+		logger.Debug("retries of the backend are already limited, skipping the retry budget", "cluster", out.GetName())
+		return
+	}
+
+	threshold.RetryBudget = pol.retryBudget
+	out.CircuitBreakers = circuitBreakers
+}
+
+type backendTrafficPolicyGwPass struct {
+	ir.UnimplementedProxyTranslationPass
+
+	statefulSessionInChain map[string]bool
+}
+
+var _ ir.ProxyTranslationPass = &backendTrafficPolicyGwPass{}
+
+func newGatewayTranslationPass(_ ir.GwTranslationCtx, _ reporter.Reporter) ir.ProxyTranslationPass {
+	return &backendTrafficPolicyGwPass{}
+}
+
+// ApplyForBackend enables session persistence for every backend of a route targeted by a policy.
+// The config is set on the weighted cluster when the route has several backends, so only the
+// backends targeted by the policy are sticky.
+func (p *backendTrafficPolicyGwPass) ApplyForBackend(pCtx *ir.RouteBackendContext, _ ir.HttpBackend, _ *envoyroutev3.Route) error {
+	if pCtx.Backend == nil {
+		return nil
+	}
+	pols := pCtx.Backend.AttachedPolicies.Policies[backendTrafficPolicyGroupKind]
+	if len(pols) == 0 {
+		return nil
+	}
+	att := mergePolicies(pols)
+	pol, ok := att.PolicyIr.(*backendTrafficPolicy)
+	if !ok || len(att.Errors) > 0 || pol.sessionPersistence == nil {
+		return nil
+	}
+
+	pCtx.TypedFilterConfig.AddTypedConfig(statefulSessionFilterName, pol.sessionPersistence)
+	if p.statefulSessionInChain == nil {
+		p.statefulSessionInChain = make(map[string]bool)
+	}
+	p.statefulSessionInChain[pCtx.FilterChainName] = true
+	return nil
+}
+
+func (p *backendTrafficPolicyGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.FilterChainCommon) ([]filters.StagedHttpFilter, error) {
+	if !p.statefulSessionInChain[fcc.FilterChainName] {
+		return nil, nil
+	}
+	// Without a per-route config the filter does nothing, so it is not disabled on the listener.
+	stagedFilter, err := filters.NewStagedFilter(statefulSessionFilterName, &stateful_sessionv3.StatefulSession{}, filters.BeforeStage(filters.AcceptedStage))
+	if err != nil {
+		return nil, err
+	}
+	return []filters.StagedHttpFilter{stagedFilter}, nil
+}
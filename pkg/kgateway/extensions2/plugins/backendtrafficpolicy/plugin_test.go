@@ -0,0 +1,136 @@
+package backendtrafficpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwxv1a1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestTranslateRetryConstraint(t *testing.T) {
+	t.Run("applies the Gateway API defaults", func(t *testing.T) {
+		got := translateRetryConstraint(&gwxv1a1.RetryConstraint{})
+		assert.True(t, proto.Equal(&envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget{
+			BudgetPercent:       &typev3.Percent{Value: 20},
+			MinRetryConcurrency: wrapperspb.UInt32(10),
+		}, got))
+	})
+
+	t.Run("maps the budget percent and minimum retry rate", func(t *testing.T) {
+		got := translateRetryConstraint(&gwxv1a1.RetryConstraint{
+			Budget: &gwxv1a1.BudgetDetails{
+				Percent:  new(35),
+				Interval: new(gwv1.Duration("30s")),
+			},
+			MinRetryRate: &gwxv1a1.RequestRate{
+				Count:    new(5),
+				Interval: new(gwv1.Duration("1s")),
+			},
+		})
+		assert.True(t, proto.Equal(&envoyclusterv3.CircuitBreakers_Thresholds_RetryBudget{
+			BudgetPercent:       &typev3.Percent{Value: 35},
+			MinRetryConcurrency: wrapperspb.UInt32(5),
+		}, got))
+	})
+}
+
+func TestProcessBackend(t *testing.T) {
+	budget := translateRetryConstraint(&gwxv1a1.RetryConstraint{})
+	pol := &backendTrafficPolicy{retryBudget: budget}
+
+	t.Run("sets the retry budget on a cluster without circuit breakers", func(t *testing.T) {
+		cluster := &envoyclusterv3.Cluster{}
+		processBackend(context.Background(), pol, ir.BackendObjectIR{}, cluster)
+
+		require.Len(t, cluster.GetCircuitBreakers().GetThresholds(), 1)
+		assert.True(t, proto.Equal(budget, cluster.GetCircuitBreakers().GetThresholds()[0].GetRetryBudget()))
+	})
+
+	t.Run("keeps the existing thresholds", func(t *testing.T) {
+		existing := &envoyclusterv3.CircuitBreakers{
+			Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{{MaxConnections: wrapperspb.UInt32(100)}},
+		}
+		cluster := &envoyclusterv3.Cluster{CircuitBreakers: existing}
+		processBackend(context.Background(), pol, ir.BackendObjectIR{}, cluster)
+
+		threshold := cluster.GetCircuitBreakers().GetThresholds()[0]
+		assert.Equal(t, uint32(100), threshold.GetMaxConnections().GetValue())
+		assert.True(t, proto.Equal(budget, threshold.GetRetryBudget()))
+		assert.Nil(t, existing.GetThresholds()[0].GetRetryBudget(), "the existing circuit breakers must not be mutated")
+	})
+
+	t.Run("does not override retry limits set by another policy", func(t *testing.T) {
+		cluster := &envoyclusterv3.Cluster{
+			CircuitBreakers: &envoyclusterv3.CircuitBreakers{
+				Thresholds: []*envoyclusterv3.CircuitBreakers_Thresholds{{MaxRetries: wrapperspb.UInt32(3)}},
+			},
+		}
+		processBackend(context.Background(), pol, ir.BackendObjectIR{}, cluster)
+
+		threshold := cluster.GetCircuitBreakers().GetThresholds()[0]
+		assert.Equal(t, uint32(3), threshold.GetMaxRetries().GetValue())
+		assert.Nil(t, threshold.GetRetryBudget())
+	})
+}
+
+func TestMergePolicies(t *testing.T) {
+	older := &backendTrafficPolicy{ct: time.Unix(100, 0)}
+	newer := &backendTrafficPolicy{ct: time.Unix(200, 0)}
+
+	got := mergePolicies([]ir.PolicyAtt{
+		{PolicyIr: newer, PolicyRef: &ir.AttachedPolicyRef{Name: "newer"}},
+		{PolicyIr: older, PolicyRef: &ir.AttachedPolicyRef{Name: "older"}},
+	})
+	assert.Equal(t, "older", got.PolicyRef.Name)
+}
+
+func TestSessionPersistence(t *testing.T) {
+	pol := translate(&gwxv1a1.XBackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "sticky", Namespace: "default"},
+		Spec: gwxv1a1.BackendTrafficPolicySpec{
+			SessionPersistence: &gwv1.SessionPersistence{
+				SessionName: new("session"),
+				Type:        new(gwv1.CookieBasedSessionPersistence),
+			},
+		},
+	})
+	require.NotNil(t, pol.sessionPersistence)
+
+	backend := &ir.BackendObjectIR{
+		AttachedPolicies: ir.AttachedPolicies{
+			Policies: map[schema.GroupKind][]ir.PolicyAtt{
+				backendTrafficPolicyGroupKind: {{PolicyIr: pol}},
+			},
+		},
+	}
+
+	pass := newGatewayTranslationPass(ir.GwTranslationCtx{}, nil).(*backendTrafficPolicyGwPass)
+	pCtx := &ir.RouteBackendContext{
+		FilterChainName:   "http",
+		Backend:           backend,
+		TypedFilterConfig: ir.TypedFilterConfigMap{},
+	}
+	require.NoError(t, pass.ApplyForBackend(pCtx, ir.HttpBackend{}, nil))
+	assert.True(t, proto.Equal(pol.sessionPersistence, pCtx.TypedFilterConfig.GetTypedConfig(statefulSessionFilterName)))
+
+	filters, err := pass.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "http"})
+	require.NoError(t, err)
+	require.Len(t, filters, 1)
+	assert.Equal(t, statefulSessionFilterName, filters[0].Filter.GetName())
+
+	filters, err = pass.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "other"})
+	require.NoError(t, err)
+	assert.Empty(t, filters)
+}
@@ -0,0 +1,97 @@
+package listenerpolicy
+
+import (
+	"fmt"
+	"time"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	defaultAltSvcMaxAge = 24 * time.Hour
+
+	// Bounds of the QUIC flow-control windows accepted by Envoy.
+	maxQuicStreamWindowSizeBytes     int64 = 16777216
+	maxQuicConnectionWindowSizeBytes int64 = 25165824
+)
+
+// HTTP3 returns the HTTP/3 configuration of the policy for the given port, or nil if it does not enable HTTP/3.
+func (d *ListenerPolicyIR) HTTP3(port uint32) *ir.QuicIR {
+	if d == nil {
+		return nil
+	}
+	if perPortCfg, found := d.perPortPolicy[port]; found {
+		return perPortCfg.http3
+	}
+	return d.defaultPolicy.http3
+}
+
+var _ ir.HTTP3PolicyIR = &ListenerPolicyIR{}
+
+func convertHTTP3Settings(in *kgateway.HTTP3Settings) (*ir.QuicIR, []error) {
+	if in == nil {
+		return nil, nil
+	}
+	if errs := validateHTTP3Settings(in); len(errs) > 0 {
+		return nil, errs
+	}
+
+	out := &ir.QuicIR{
+		AltSvcMaxAge: defaultAltSvcMaxAge,
+		Options: &envoylistenerv3.QuicProtocolOptions{
+			QuicProtocolOptions: &envoycorev3.QuicProtocolOptions{},
+		},
+	}
+	if in.AltSvcMaxAge != nil {
+		out.AltSvcMaxAge = in.AltSvcMaxAge.Duration
+	}
+	if in.IdleTimeout != nil {
+		out.Options.IdleTimeout = durationpb.New(in.IdleTimeout.Duration)
+	}
+	if in.MaxConcurrentStreams != nil {
+		out.Options.QuicProtocolOptions.MaxConcurrentStreams = &wrapperspb.UInt32Value{Value: uint32(*in.MaxConcurrentStreams)} //nolint:gosec // G115: API type constrains value to be at least 1, safe for uint32
+	}
+	if in.InitialStreamWindowSize != nil {
+		out.Options.QuicProtocolOptions.InitialStreamWindowSize = &wrapperspb.UInt32Value{Value: uint32(in.InitialStreamWindowSize.Value())} //nolint:gosec // G115: validated to be within Envoy's bounds, safe for uint32
+	}
+	if in.InitialConnectionWindowSize != nil {
+		out.Options.QuicProtocolOptions.InitialConnectionWindowSize = &wrapperspb.UInt32Value{Value: uint32(in.InitialConnectionWindowSize.Value())} //nolint:gosec // G115: validated to be within Envoy's bounds, safe for uint32
+	}
+	return out, nil
+}
+
+func validateHTTP3Settings(in *kgateway.HTTP3Settings) []error {
+	var errs []error
+	if in.InitialStreamWindowSize != nil {
+		if err := validateQuicWindowSize("initialStreamWindowSize", in.InitialStreamWindowSize.Value(), maxQuicStreamWindowSizeBytes); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if in.InitialConnectionWindowSize != nil {
+		if err := validateQuicWindowSize("initialConnectionWindowSize", in.InitialConnectionWindowSize.Value(), maxQuicConnectionWindowSizeBytes); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func validateQuicWindowSize(fieldName string, value, maxValue int64) error {
+	if value < 1 || value > maxValue {
+		return fmt.Errorf("http3.%s must be between 1 and %d bytes (inclusive), got %d", fieldName, maxValue, value)
+	}
+	return nil
+}
+
+func http3Equal(a, b *ir.QuicIR) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.AltSvcMaxAge == b.AltSvcMaxAge && proto.Equal(a.Options, b.Options)
+}
@@ -0,0 +1,67 @@
+package listenerpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConvertHTTP3Settings(t *testing.T) {
+	t.Run("defaults the alt-svc max age", func(t *testing.T) {
+		out, errs := convertHTTP3Settings(&kgateway.HTTP3Settings{})
+		require.Empty(t, errs)
+		assert.Equal(t, 24*time.Hour, out.AltSvcMaxAge)
+		assert.Nil(t, out.Options.GetIdleTimeout())
+	})
+
+	t.Run("converts transport settings", func(t *testing.T) {
+		out, errs := convertHTTP3Settings(&kgateway.HTTP3Settings{
+			AltSvcMaxAge:                &metav1.Duration{Duration: time.Hour},
+			IdleTimeout:                 &metav1.Duration{Duration: 30 * time.Second},
+			MaxConcurrentStreams:        new(int32(50)),
+			InitialStreamWindowSize:     new(resource.MustParse("64Ki")),
+			InitialConnectionWindowSize: new(resource.MustParse("1Mi")),
+		})
+		require.Empty(t, errs)
+		assert.Equal(t, time.Hour, out.AltSvcMaxAge)
+		assert.Equal(t, 30*time.Second, out.Options.GetIdleTimeout().AsDuration())
+		assert.Equal(t, uint32(50), out.Options.GetQuicProtocolOptions().GetMaxConcurrentStreams().GetValue())
+		assert.Equal(t, uint32(65536), out.Options.GetQuicProtocolOptions().GetInitialStreamWindowSize().GetValue())
+		assert.Equal(t, uint32(1048576), out.Options.GetQuicProtocolOptions().GetInitialConnectionWindowSize().GetValue())
+	})
+
+	t.Run("rejects out of range window sizes", func(t *testing.T) {
+		out, errs := convertHTTP3Settings(&kgateway.HTTP3Settings{
+			InitialStreamWindowSize: new(resource.MustParse("32Mi")),
+		})
+		assert.Nil(t, out)
+		require.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "http3.initialStreamWindowSize must be between 1 and 16777216 bytes (inclusive), got 33554432")
+	})
+}
+
+func TestListenerPolicyHTTP3(t *testing.T) {
+	defaultCfg := &ir.QuicIR{AltSvcMaxAge: time.Hour}
+	perPortCfg := &ir.QuicIR{AltSvcMaxAge: time.Minute}
+	pol := &ListenerPolicyIR{
+		defaultPolicy: listenerPolicy{http3: defaultCfg},
+		perPortPolicy: map[uint32]listenerPolicy{
+			443:  {http3: perPortCfg},
+			8443: {},
+		},
+	}
+
+	assert.Same(t, defaultCfg, pol.HTTP3(80))
+	assert.Same(t, perPortCfg, pol.HTTP3(443))
+	assert.Nil(t, pol.HTTP3(8443))
+
+	var nilPol *ListenerPolicyIR
+	assert.Nil(t, nilPol.HTTP3(443))
+}
@@ -11,6 +11,7 @@ import (
 	healthcheckv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
 	proxy_protocol "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/proxy_protocol/v3"
 	envoy_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	udp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
 	preserve_case_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/header_formatters/preserve_case/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/proto"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/backendconfigpolicy"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	kgwwellknown "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
@@ -57,6 +59,8 @@ type listenerPolicy struct {
 	tcpKeepalive                  *envoycorev3.TcpKeepalive
 	perConnectionBufferLimitBytes *uint32
 	transportSocketConnectTimeout *durationpb.Duration
+	udpSessionIdleTimeout         *durationpb.Duration
+	http3                         *ir.QuicIR
 	// only for default policy
 	clientCertificateValidation *ir.ClientCertificateValidationIR
 	// +noKrtEquals
@@ -78,13 +82,21 @@ func newListenerPolicy(
 	if i.TransportSocketConnectTimeout != nil {
 		tsct = durationpb.New(i.TransportSocketConnectTimeout.Duration)
 	}
+	var udpSessionIdleTimeout *durationpb.Duration
+	if i.UDPSettings != nil && i.UDPSettings.SessionIdleTimeout != nil {
+		udpSessionIdleTimeout = durationpb.New(i.UDPSettings.SessionIdleTimeout.Duration)
+	}
 	http, errs := NewHttpListenerPolicy(krtctx, commoncol, i.HTTPSettings, objSrc)
+	http3, http3Errs := convertHTTP3Settings(i.HTTP3)
+	errs = append(errs, http3Errs...)
 
 	return listenerPolicy{
 		proxyProtocol:                 convertProxyProtocolConfig(objSrc, i.ProxyProtocol),
 		tcpKeepalive:                  backendconfigpolicy.TranslateTCPKeepalive(i.TCPKeepalive),
 		perConnectionBufferLimitBytes: perConnectionBufferLimitBytes,
 		transportSocketConnectTimeout: tsct,
+		udpSessionIdleTimeout:         udpSessionIdleTimeout,
+		http3:                         http3,
 		http:                          http,
 	}, errs
 }
@@ -154,6 +166,14 @@ func (d listenerPolicy) Equals(d2 listenerPolicy) bool {
 		return false
 	}
 
+	if !proto.Equal(d.udpSessionIdleTimeout, d2.udpSessionIdleTimeout) {
+		return false
+	}
+
+	if !http3Equal(d.http3, d2.http3) {
+		return false
+	}
+
 	if (d.clientCertificateValidation == nil) != (d2.clientCertificateValidation == nil) {
 		return false
 	}
@@ -310,6 +330,11 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections) sd
 				ProcessPolicyStaleStatusMarkers: processMarkers,
 				GetPolicyStatus:                 getPolicyStatusFn(cli),
 				PatchPolicyStatus:               patchPolicyStatusFn(cli),
+				ValidatePolicy: pluginutils.ValidatePolicyFn(func(krtctx krt.HandlerContext, i *kgateway.ListenerPolicy) []error {
+					objSrc := ir.ObjectSource{Group: gk.Group, Kind: gk.Kind, Namespace: i.Namespace, Name: i.Name}
+					_, errs := NewListenerPolicyIR(krtctx, commoncol, i.CreationTimestamp.Time, &i.Spec, objSrc)
+					return errs
+				}),
 				MergePolicies: func(pols []ir.PolicyAtt) ir.PolicyAtt {
 					return policy.MergePolicies(pols, MergePolicies, "" /*no merge settings*/)
 				},
@@ -350,6 +375,13 @@ func (p *listenerPolicyPluginGwPass) ApplyListenerPlugin(
 	cfg := p.getPolicy(pCtx.Policy, pCtx.Port)
 
 	logger.Debug("listenerPolicy found", "proxy_protocol", cfg.proxyProtocol, "per_connection_buffer_limit_bytes", cfg.perConnectionBufferLimitBytes)
+	// UDP listeners have no connections, so only the UDP settings apply to them.
+	if out.GetAddress().GetSocketAddress().GetProtocol() == envoycorev3.SocketAddress_UDP {
+		if cfg.udpSessionIdleTimeout != nil {
+			applyUdpSessionIdleTimeout(out, cfg.udpSessionIdleTimeout)
+		}
+		return
+	}
 	// Add proxy protocol listener filter if configured
 	if cfg.proxyProtocol != nil {
 		p.applyProxyProtocol(out, cfg.proxyProtocol)
@@ -366,6 +398,27 @@ func (p *listenerPolicyPluginGwPass) ApplyListenerPlugin(
 	}
 }
 
+// applyUdpSessionIdleTimeout sets the idle timeout of the UDP proxy listener filter.
+func applyUdpSessionIdleTimeout(out *envoylistenerv3.Listener, timeout *durationpb.Duration) {
+	for _, lf := range out.GetListenerFilters() {
+		if lf.GetName() != kgwwellknown.UdpProxyFilterName {
+			continue
+		}
+		cfg := &udp_proxy.UdpProxyConfig{}
+		if err := lf.GetTypedConfig().UnmarshalTo(cfg); err != nil {
+			logger.Error("failed to unmarshal UDP proxy config", "listener", out.GetName(), "error", err)
+			return
+		}
+		cfg.IdleTimeout = timeout
+		typedConfig, err := utils.MessageToAny(cfg)
+		if err != nil {
+			logger.Error("failed to marshal UDP proxy config", "listener", out.GetName(), "error", err)
+			return
+		}
+		lf.ConfigType = &envoylistenerv3.ListenerFilter_TypedConfig{TypedConfig: typedConfig}
+	}
+}
+
 // ApplyPostListener runs after FilterChains have been built so the plugin can set
 // FilterChain level fields like transport_socket_connect_timeout. It is invoked once per
 // FilterChain on the listener.
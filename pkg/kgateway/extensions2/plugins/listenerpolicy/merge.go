@@ -90,6 +90,8 @@ func mergeListenerPolicy(
 		mergeTCPKeepalive,
 		mergePerConnectionBufferLimitBytes,
 		mergeTransportSocketConnectTimeout,
+		mergeUdpSessionIdleTimeout,
+		mergeHTTP3,
 		mergeClientCertificateValidation,
 		mergeHttpSettings,
 	}
@@ -147,6 +149,38 @@ func mergeTransportSocketConnectTimeout(
 	mergeOrigins.SetOne(origin+"transportSocketConnectTimeout", p2Ref, p2MergeOrigins)
 }
 
+func mergeUdpSessionIdleTimeout(
+	origin string,
+	p1, p2 *listenerPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.udpSessionIdleTimeout, p2.udpSessionIdleTimeout, opts) {
+		return
+	}
+
+	p1.udpSessionIdleTimeout = p2.udpSessionIdleTimeout
+	mergeOrigins.SetOne(origin+"udpSettings.sessionIdleTimeout", p2Ref, p2MergeOrigins)
+}
+
+func mergeHTTP3(
+	origin string,
+	p1, p2 *listenerPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+) {
+	if !policy.IsMergeable(p1.http3, p2.http3, opts) {
+		return
+	}
+
+	p1.http3 = p2.http3
+	mergeOrigins.SetOne(origin+"http3", p2Ref, p2MergeOrigins)
+}
+
 func mergeTCPKeepalive(
 	origin string,
 	p1, p2 *listenerPolicy,
@@ -0,0 +1,155 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"time"
+
+	adaptiveconcurrencyv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/adaptive_concurrency/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	adaptiveConcurrencyFilterNamePrefix = "adaptive_concurrency"
+
+	defaultConcurrencyUpdateInterval = 100 * time.Millisecond
+	defaultMinRTTInterval            = 60 * time.Second
+)
+
+var adaptiveConcurrencyFilterStage = filters.DuringStage(filters.AcceptedStage)
+
+// adaptiveConcurrencyIR holds the adaptive concurrency filter of a single policy. The filter has no
+// per-route configuration and keeps its concurrency limit per filter instance, so each policy gets its
+// own filter in the chain and routes enable the filter of the policy that applies to them.
+type adaptiveConcurrencyIR struct {
+	filterName string
+	filter     *adaptiveconcurrencyv3.AdaptiveConcurrency
+}
+
+var _ PolicySubIR = &adaptiveConcurrencyIR{}
+
+func (a *adaptiveConcurrencyIR) Equals(other PolicySubIR) bool {
+	otherAdaptiveConcurrency, ok := other.(*adaptiveConcurrencyIR)
+	if !ok {
+		return false
+	}
+	if a == nil || otherAdaptiveConcurrency == nil {
+		return a == nil && otherAdaptiveConcurrency == nil
+	}
+	return a.filterName == otherAdaptiveConcurrency.filterName &&
+		proto.Equal(a.filter, otherAdaptiveConcurrency.filter)
+}
+
+func (a *adaptiveConcurrencyIR) Validate() error {
+	if a == nil || a.filter == nil {
+		return nil
+	}
+	return a.filter.ValidateAll()
+}
+
+// constructAdaptiveConcurrency constructs the adaptive concurrency policy IR from the policy specification.
+func constructAdaptiveConcurrency(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) {
+	spec := in.Spec.AdaptiveConcurrency
+	if spec == nil {
+		return
+	}
+
+	updateInterval := defaultConcurrencyUpdateInterval
+	if spec.ConcurrencyUpdateInterval != nil {
+		updateInterval = spec.ConcurrencyUpdateInterval.Duration
+	}
+	gradient := &adaptiveconcurrencyv3.GradientControllerConfig{
+		ConcurrencyLimitParams: &adaptiveconcurrencyv3.GradientControllerConfig_ConcurrencyLimitCalculationParams{
+			ConcurrencyUpdateInterval: durationpb.New(updateInterval),
+		},
+		MinRttCalcParams: translateMinRTTCalcParams(spec.MinRTT),
+	}
+	if spec.SampleAggregatePercentile != nil {
+		gradient.SampleAggregatePercentile = &typev3.Percent{Value: float64(*spec.SampleAggregatePercentile)}
+	}
+	if spec.MaxConcurrencyLimit != nil {
+		gradient.ConcurrencyLimitParams.MaxConcurrencyLimit = wrapperspb.UInt32(uint32(max(*spec.MaxConcurrencyLimit, 0))) //#nosec G115 - CRD validates Minimum=1
+	}
+
+	filter := &adaptiveconcurrencyv3.AdaptiveConcurrency{
+		ConcurrencyControllerConfig: &adaptiveconcurrencyv3.AdaptiveConcurrency_GradientControllerConfig{
+			GradientControllerConfig: gradient,
+		},
+	}
+	if spec.ConcurrencyLimitExceededStatus != nil {
+		filter.ConcurrencyLimitExceededStatus = &typev3.HttpStatus{
+			Code: typev3.StatusCode(*spec.ConcurrencyLimitExceededStatus),
+		}
+	}
+
+	out.adaptiveConcurrency = &adaptiveConcurrencyIR{
+		filterName: fmt.Sprintf("%s/%s/%s", adaptiveConcurrencyFilterNamePrefix, in.GetNamespace(), in.GetName()),
+		filter:     filter,
+	}
+}
+
+func translateMinRTTCalcParams(in *kgateway.AdaptiveConcurrencyMinRTT) *adaptiveconcurrencyv3.GradientControllerConfig_MinimumRTTCalculationParams {
+	out := &adaptiveconcurrencyv3.GradientControllerConfig_MinimumRTTCalculationParams{}
+	if in == nil || (in.Interval == nil && in.FixedValue == nil) {
+		// Envoy requires either the interval or a fixed value to be set
+		out.Interval = durationpb.New(defaultMinRTTInterval)
+	}
+	if in == nil {
+		return out
+	}
+
+	if in.Interval != nil {
+		out.Interval = durationpb.New(in.Interval.Duration)
+	}
+	if in.FixedValue != nil {
+		out.FixedValue = durationpb.New(in.FixedValue.Duration)
+	}
+	if in.RequestCount != nil {
+		out.RequestCount = wrapperspb.UInt32(uint32(max(*in.RequestCount, 0))) //#nosec G115 - CRD validates Minimum=1
+	}
+	if in.JitterPercent != nil {
+		out.Jitter = &typev3.Percent{Value: float64(*in.JitterPercent)}
+	}
+	if in.MinConcurrency != nil {
+		out.MinConcurrency = wrapperspb.UInt32(uint32(max(*in.MinConcurrency, 0))) //#nosec G115 - CRD validates Minimum=1
+	}
+	if in.BufferPercent != nil {
+		out.Buffer = &typev3.Percent{Value: float64(*in.BufferPercent)}
+	}
+	return out
+}
+
+func (p *trafficPolicyPluginGwPass) handleAdaptiveConcurrency(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *adaptiveConcurrencyIR) {
+	if in == nil {
+		return
+	}
+
+	pCtxTypedFilterConfig.AddTypedConfig(in.filterName, EnableFilterPerRoute())
+
+	if p.adaptiveConcurrencyInChain == nil {
+		p.adaptiveConcurrencyInChain = make(map[string][]*adaptiveConcurrencyIR)
+	}
+	for _, existing := range p.adaptiveConcurrencyInChain[fcn] {
+		if existing.filterName == in.filterName {
+			return
+		}
+	}
+	p.adaptiveConcurrencyInChain[fcn] = append(p.adaptiveConcurrencyInChain[fcn], in)
+}
+
+// addAdaptiveConcurrencyFiltersIfNeeded adds a disabled-by-default filter for every adaptive concurrency
+// policy used in the filter chain.
+func addAdaptiveConcurrencyFiltersIfNeeded(staged []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcn string) []filters.StagedHttpFilter {
+	for _, a := range p.adaptiveConcurrencyInChain[fcn] {
+		filter := filters.MustNewStagedFilter(a.filterName, a.filter, adaptiveConcurrencyFilterStage)
+		filter.Filter.Disabled = true
+		staged = append(staged, filter)
+	}
+	return staged
+}
@@ -0,0 +1,90 @@
+package trafficpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConstructAdaptiveConcurrency(t *testing.T) {
+	policy := func(spec *kgateway.AdaptiveConcurrencyPolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec:       kgateway.TrafficPolicySpec{AdaptiveConcurrency: spec},
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		constructAdaptiveConcurrency(policy(&kgateway.AdaptiveConcurrencyPolicy{}), out)
+		require.NotNil(t, out.adaptiveConcurrency)
+		assert.Equal(t, "adaptive_concurrency/default/policy", out.adaptiveConcurrency.filterName)
+		require.NoError(t, out.adaptiveConcurrency.Validate())
+
+		gradient := out.adaptiveConcurrency.filter.GetGradientControllerConfig()
+		assert.Equal(t, defaultConcurrencyUpdateInterval, gradient.GetConcurrencyLimitParams().GetConcurrencyUpdateInterval().AsDuration())
+		assert.Equal(t, defaultMinRTTInterval, gradient.GetMinRttCalcParams().GetInterval().AsDuration())
+		assert.Nil(t, out.adaptiveConcurrency.filter.GetConcurrencyLimitExceededStatus())
+	})
+
+	t.Run("full config", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		constructAdaptiveConcurrency(policy(&kgateway.AdaptiveConcurrencyPolicy{
+			SampleAggregatePercentile:      new(int32(90)),
+			ConcurrencyUpdateInterval:      &metav1.Duration{Duration: 200 * time.Millisecond},
+			MaxConcurrencyLimit:            new(int32(500)),
+			ConcurrencyLimitExceededStatus: new(int32(429)),
+			MinRTT: &kgateway.AdaptiveConcurrencyMinRTT{
+				FixedValue:     &metav1.Duration{Duration: 5 * time.Millisecond},
+				RequestCount:   new(int32(20)),
+				JitterPercent:  new(int32(15)),
+				MinConcurrency: new(int32(5)),
+				BufferPercent:  new(int32(30)),
+			},
+		}), out)
+		require.NotNil(t, out.adaptiveConcurrency)
+		require.NoError(t, out.adaptiveConcurrency.Validate())
+
+		filter := out.adaptiveConcurrency.filter
+		assert.EqualValues(t, 429, filter.GetConcurrencyLimitExceededStatus().GetCode())
+		gradient := filter.GetGradientControllerConfig()
+		assert.Equal(t, 90.0, gradient.GetSampleAggregatePercentile().GetValue())
+		assert.Equal(t, 200*time.Millisecond, gradient.GetConcurrencyLimitParams().GetConcurrencyUpdateInterval().AsDuration())
+		assert.Equal(t, uint32(500), gradient.GetConcurrencyLimitParams().GetMaxConcurrencyLimit().GetValue())
+		minRTT := gradient.GetMinRttCalcParams()
+		assert.Nil(t, minRTT.GetInterval())
+		assert.Equal(t, 5*time.Millisecond, minRTT.GetFixedValue().AsDuration())
+		assert.Equal(t, uint32(20), minRTT.GetRequestCount().GetValue())
+		assert.Equal(t, 15.0, minRTT.GetJitter().GetValue())
+		assert.Equal(t, uint32(5), minRTT.GetMinConcurrency().GetValue())
+		assert.Equal(t, 30.0, minRTT.GetBuffer().GetValue())
+	})
+}
+
+func TestHttpFiltersAdaptiveConcurrency(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	constructAdaptiveConcurrency(&kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec:       kgateway.TrafficPolicySpec{AdaptiveConcurrency: &kgateway.AdaptiveConcurrencyPolicy{}},
+	}, out)
+
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleAdaptiveConcurrency("test-filter-chain", &typedFilterConfig, out.adaptiveConcurrency)
+	// a second route using the same policy does not add another filter
+	plugin.handleAdaptiveConcurrency("test-filter-chain", &typedFilterConfig, out.adaptiveConcurrency)
+	assert.NotNil(t, typedFilterConfig.GetTypedConfig("adaptive_concurrency/default/policy"))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, "adaptive_concurrency/default/policy", httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+	assert.Equal(t, adaptiveConcurrencyFilterStage, httpFilters[0].Stage)
+}
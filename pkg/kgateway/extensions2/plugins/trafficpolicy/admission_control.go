@@ -0,0 +1,160 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"strconv"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	admissioncontrolv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/admission_control/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const admissionControlFilterNamePrefix = "admission_control"
+
+var admissionControlFilterStage = filters.DuringStage(filters.AcceptedStage)
+
+// admissionControlIR holds the admission control filter of a single policy. Like the adaptive concurrency
+// filter, the filter has no per-route configuration and tracks the success rate per filter instance.
+type admissionControlIR struct {
+	filterName string
+	filter     *admissioncontrolv3.AdmissionControl
+}
+
+var _ PolicySubIR = &admissionControlIR{}
+
+func (a *admissionControlIR) Equals(other PolicySubIR) bool {
+	otherAdmissionControl, ok := other.(*admissionControlIR)
+	if !ok {
+		return false
+	}
+	if a == nil || otherAdmissionControl == nil {
+		return a == nil && otherAdmissionControl == nil
+	}
+	return a.filterName == otherAdmissionControl.filterName &&
+		proto.Equal(a.filter, otherAdmissionControl.filter)
+}
+
+func (a *admissionControlIR) Validate() error {
+	if a == nil || a.filter == nil {
+		return nil
+	}
+	return a.filter.ValidateAll()
+}
+
+// constructAdmissionControl constructs the admission control policy IR from the policy specification.
+func constructAdmissionControl(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) error {
+	spec := in.Spec.AdmissionControl
+	if spec == nil {
+		return nil
+	}
+
+	// Envoy requires a runtime key for the runtime values, so use policy-specific keys
+	runtimeKeyPrefix := fmt.Sprintf("%s.%s.%s", admissionControlFilterNamePrefix, in.GetNamespace(), in.GetName())
+
+	filter := &admissioncontrolv3.AdmissionControl{
+		EvaluationCriteria: &admissioncontrolv3.AdmissionControl_SuccessCriteria_{
+			SuccessCriteria: translateAdmissionControlSuccessCriteria(spec.SuccessCriteria),
+		},
+	}
+	if spec.SamplingWindow != nil {
+		filter.SamplingWindow = durationpb.New(spec.SamplingWindow.Duration)
+	}
+	if spec.Aggression != nil {
+		aggression, err := strconv.ParseFloat(*spec.Aggression, 64)
+		if err != nil {
+			return fmt.Errorf("admission control: invalid aggression %q: %w", *spec.Aggression, err)
+		}
+		filter.Aggression = &envoycorev3.RuntimeDouble{
+			DefaultValue: aggression,
+			RuntimeKey:   runtimeKeyPrefix + ".aggression",
+		}
+	}
+	if spec.SuccessRateThreshold != nil {
+		filter.SrThreshold = &envoycorev3.RuntimePercent{
+			DefaultValue: &typev3.Percent{Value: float64(*spec.SuccessRateThreshold)},
+			RuntimeKey:   runtimeKeyPrefix + ".sr_threshold",
+		}
+	}
+	if spec.RPSThreshold != nil {
+		filter.RpsThreshold = &envoycorev3.RuntimeUInt32{
+			DefaultValue: uint32(max(*spec.RPSThreshold, 0)), //#nosec G115 - CRD validates Minimum=0
+			RuntimeKey:   runtimeKeyPrefix + ".rps_threshold",
+		}
+	}
+	if spec.MaxRejectionPercent != nil {
+		filter.MaxRejectionProbability = &envoycorev3.RuntimePercent{
+			DefaultValue: &typev3.Percent{Value: float64(*spec.MaxRejectionPercent)},
+			RuntimeKey:   runtimeKeyPrefix + ".max_rejection_probability",
+		}
+	}
+
+	out.admissionControl = &admissionControlIR{
+		filterName: fmt.Sprintf("%s/%s/%s", admissionControlFilterNamePrefix, in.GetNamespace(), in.GetName()),
+		filter:     filter,
+	}
+	return nil
+}
+
+// translateAdmissionControlSuccessCriteria returns the success criteria of the filter. Criteria that are
+// not specified are left unset so that Envoy uses its defaults.
+func translateAdmissionControlSuccessCriteria(in *kgateway.AdmissionControlSuccessCriteria) *admissioncontrolv3.AdmissionControl_SuccessCriteria {
+	out := &admissioncontrolv3.AdmissionControl_SuccessCriteria{}
+	if in == nil {
+		return out
+	}
+
+	if len(in.HTTPStatuses) > 0 {
+		httpCriteria := &admissioncontrolv3.AdmissionControl_SuccessCriteria_HttpCriteria{}
+		for _, r := range in.HTTPStatuses {
+			// The API range is inclusive, while the end of an Envoy range is exclusive
+			httpCriteria.HttpSuccessStatus = append(httpCriteria.HttpSuccessStatus, &typev3.Int32Range{
+				Start: r.Start,
+				End:   r.End + 1,
+			})
+		}
+		out.HttpCriteria = httpCriteria
+	}
+	if len(in.GRPCStatuses) > 0 {
+		grpcCriteria := &admissioncontrolv3.AdmissionControl_SuccessCriteria_GrpcCriteria{}
+		for _, s := range in.GRPCStatuses {
+			grpcCriteria.GrpcSuccessStatus = append(grpcCriteria.GrpcSuccessStatus, uint32(max(s, 0))) //#nosec G115 - CRD validates Minimum=0
+		}
+		out.GrpcCriteria = grpcCriteria
+	}
+	return out
+}
+
+func (p *trafficPolicyPluginGwPass) handleAdmissionControl(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *admissionControlIR) {
+	if in == nil {
+		return
+	}
+
+	pCtxTypedFilterConfig.AddTypedConfig(in.filterName, EnableFilterPerRoute())
+
+	if p.admissionControlInChain == nil {
+		p.admissionControlInChain = make(map[string][]*admissionControlIR)
+	}
+	for _, existing := range p.admissionControlInChain[fcn] {
+		if existing.filterName == in.filterName {
+			return
+		}
+	}
+	p.admissionControlInChain[fcn] = append(p.admissionControlInChain[fcn], in)
+}
+
+// addAdmissionControlFiltersIfNeeded adds a disabled-by-default filter for every admission control
+// policy used in the filter chain.
+func addAdmissionControlFiltersIfNeeded(staged []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcn string) []filters.StagedHttpFilter {
+	for _, a := range p.admissionControlInChain[fcn] {
+		filter := filters.MustNewStagedFilter(a.filterName, a.filter, admissionControlFilterStage)
+		filter.Filter.Disabled = true
+		staged = append(staged, filter)
+	}
+	return staged
+}
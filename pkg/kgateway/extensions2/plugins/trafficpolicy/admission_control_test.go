@@ -0,0 +1,89 @@
+package trafficpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConstructAdmissionControl(t *testing.T) {
+	policy := func(spec *kgateway.AdmissionControlPolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec:       kgateway.TrafficPolicySpec{AdmissionControl: spec},
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		require.NoError(t, constructAdmissionControl(policy(&kgateway.AdmissionControlPolicy{}), out))
+		require.NotNil(t, out.admissionControl)
+		assert.Equal(t, "admission_control/default/policy", out.admissionControl.filterName)
+		require.NoError(t, out.admissionControl.Validate())
+
+		filter := out.admissionControl.filter
+		assert.NotNil(t, filter.GetSuccessCriteria())
+		assert.Nil(t, filter.GetSuccessCriteria().GetHttpCriteria())
+		assert.Nil(t, filter.GetAggression())
+		assert.Nil(t, filter.GetSrThreshold())
+	})
+
+	t.Run("full config", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		require.NoError(t, constructAdmissionControl(policy(&kgateway.AdmissionControlPolicy{
+			SamplingWindow:       &metav1.Duration{Duration: 10 * time.Second},
+			Aggression:           new("1.5"),
+			SuccessRateThreshold: new(int32(90)),
+			RPSThreshold:         new(int32(5)),
+			MaxRejectionPercent:  new(int32(70)),
+			SuccessCriteria: &kgateway.AdmissionControlSuccessCriteria{
+				HTTPStatuses: []kgateway.StatusCodeRange{{Start: 200, End: 299}, {Start: 404, End: 404}},
+				GRPCStatuses: []int32{0, 5},
+			},
+		}), out))
+		require.NotNil(t, out.admissionControl)
+		require.NoError(t, out.admissionControl.Validate())
+
+		filter := out.admissionControl.filter
+		assert.Equal(t, 10*time.Second, filter.GetSamplingWindow().AsDuration())
+		assert.Equal(t, 1.5, filter.GetAggression().GetDefaultValue())
+		assert.Equal(t, "admission_control.default.policy.aggression", filter.GetAggression().GetRuntimeKey())
+		assert.Equal(t, 90.0, filter.GetSrThreshold().GetDefaultValue().GetValue())
+		assert.Equal(t, uint32(5), filter.GetRpsThreshold().GetDefaultValue())
+		assert.Equal(t, 70.0, filter.GetMaxRejectionProbability().GetDefaultValue().GetValue())
+
+		httpStatuses := filter.GetSuccessCriteria().GetHttpCriteria().GetHttpSuccessStatus()
+		require.Len(t, httpStatuses, 2)
+		assert.Equal(t, int32(200), httpStatuses[0].GetStart())
+		assert.Equal(t, int32(300), httpStatuses[0].GetEnd())
+		assert.Equal(t, int32(404), httpStatuses[1].GetStart())
+		assert.Equal(t, int32(405), httpStatuses[1].GetEnd())
+		assert.Equal(t, []uint32{0, 5}, filter.GetSuccessCriteria().GetGrpcCriteria().GetGrpcSuccessStatus())
+	})
+}
+
+func TestHttpFiltersAdmissionControl(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	require.NoError(t, constructAdmissionControl(&kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec:       kgateway.TrafficPolicySpec{AdmissionControl: &kgateway.AdmissionControlPolicy{}},
+	}, out))
+
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleAdmissionControl("test-filter-chain", &typedFilterConfig, out.admissionControl)
+	assert.NotNil(t, typedFilterConfig.GetTypedConfig("admission_control/default/policy"))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, "admission_control/default/policy", httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+	assert.Equal(t, admissionControlFilterStage, httpFilters[0].Stage)
+}
@@ -1,15 +1,26 @@
 package trafficpolicy
 
 import (
+	"cmp"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extensiondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
 	envoyapikeyauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
+	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
@@ -19,15 +30,60 @@ import (
 const (
 	apiKeyAuthFilterNamePrefix = "envoy.filters.http.api_key_auth" //nolint:gosec
 
+	// The api-key-auth filter of the rust dynamic module verifies the keys stored in the Hashed format.
+	hashedAPIKeyAuthModuleName       = "rust_module"
+	hashedAPIKeyAuthFilterName       = "api-key-auth"
+	hashedAPIKeyAuthFilterNamePrefix = "dynamic_modules/" + hashedAPIKeyAuthFilterName
+
 	APIKeyAuthEnabledFilterName = "api_key_auth_enabled" //nolint:gosec // G101: Potential hardcoded credentials
 )
 
 // apiKeyAuthIR is the internal representation of an API key authentication policy.
 type apiKeyAuthIR struct {
-	config  *envoyapikeyauthv3.ApiKeyAuthPerRoute
-	disable bool
+	config *envoyapikeyauthv3.ApiKeyAuthPerRoute
+	// hashedConfig is set instead of config when the keys are stored in the Hashed format.
+	hashedConfig *dynamicmodulesv3.DynamicModuleFilterPerRoute
+	// hashedKeySources are the sources the key is read from by the hashedConfig filter.
+	hashedKeySources []*envoyapikeyauthv3.KeySource
+	// activeKeys and expiredKeys count the hashed keys, expired keys are not part of hashedConfig.
+	activeKeys  int
+	expiredKeys int
+	disable     bool
+}
+
+// hashedAPIKeyEntry is the JSON value of a Secret entry in the Hashed format.
+type hashedAPIKeyEntry struct {
+	SHA256    string            `json:"sha256"`
+	Salt      string            `json:"salt,omitempty"`
+	ExpiresAt *time.Time        `json:"expiresAt,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// hashedAPIKeyAuthConfig is the per-route config of the api-key-auth dynamic module filter.
+type hashedAPIKeyAuthConfig struct {
+	KeySources      []hashedAPIKeySource `json:"keySources"`
+	HideCredentials bool                 `json:"hideCredentials"`
+	ClientIDHeader  string               `json:"clientIdHeader,omitempty"`
+	Keys            []hashedAPIKey       `json:"keys"`
+}
+
+type hashedAPIKeySource struct {
+	Header string `json:"header,omitempty"`
+	Query  string `json:"query,omitempty"`
+	Cookie string `json:"cookie,omitempty"`
+}
+
+type hashedAPIKey struct {
+	Client    string            `json:"client"`
+	SHA256    string            `json:"sha256"`
+	Salt      string            `json:"salt,omitempty"`
+	ExpiresAt *int64            `json:"expiresAt,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
 }
 
+// Dynamic metadata keys set by the api-key-auth filter, which the key metadata must not use.
+var reservedAPIKeyMetadataKeys = []string{"client", "denied-by", "expiresAt"}
+
 func (a *apiKeyAuthIR) Equals(other *apiKeyAuthIR) bool {
 	if a == nil && other == nil {
 		return true
@@ -38,6 +94,12 @@ func (a *apiKeyAuthIR) Equals(other *apiKeyAuthIR) bool {
 	if a.disable != other.disable {
 		return false
 	}
+	if a.activeKeys != other.activeKeys || a.expiredKeys != other.expiredKeys {
+		return false
+	}
+	if !proto.Equal(a.hashedConfig, other.hashedConfig) {
+		return false
+	}
 	if a.config == nil && other.config == nil {
 		return true
 	}
@@ -53,12 +115,23 @@ func (a *apiKeyAuthIR) Validate() error {
 	if a == nil {
 		return nil
 	}
+	if a.hashedConfig != nil {
+		return a.hashedConfig.Validate()
+	}
 	if a.config == nil {
 		return nil
 	}
 	return a.config.Validate()
 }
 
+// keySources returns the sources the API key is read from, whichever format the keys are stored in.
+func (a *apiKeyAuthIR) keySources() []*envoyapikeyauthv3.KeySource {
+	if a.hashedConfig != nil {
+		return a.hashedKeySources
+	}
+	return a.config.GetKeySources()
+}
+
 // constructAPIKeyAuth translates the API key authentication spec into an Envoy API key auth per-route configuration
 func constructAPIKeyAuth(
 	krtctx krt.HandlerContext,
@@ -116,40 +189,6 @@ func constructAPIKeyAuth(
 		return fmt.Errorf("either secretRef or secretSelector must be specified")
 	}
 
-	// Parse secrets and build credentials
-	var credentials []*envoyapikeyauthv3.Credential
-	var errs []error
-
-	for _, secret := range secrets {
-		for keyName, keyValue := range secret.Data {
-			// Skip empty values
-			if len(keyValue) == 0 {
-				continue
-			}
-
-			// The value is expected to be a plain string representing the API key
-			// The secret key name becomes the client identifier
-			apiKey := string(keyValue)
-			if apiKey == "" {
-				errs = append(errs, fmt.Errorf("secret %s key %s has empty API key value", secret.ObjectSource.Name, keyName))
-				continue
-			}
-
-			credentials = append(credentials, &envoyapikeyauthv3.Credential{
-				Key:    apiKey,
-				Client: keyName,
-			})
-		}
-	}
-
-	if len(errs) > 0 {
-		return fmt.Errorf("errors processing API key secrets: %v", errs)
-	}
-
-	if len(credentials) == 0 {
-		return fmt.Errorf("no valid API keys found in secrets")
-	}
-
 	// Convert API KeySources to Envoy KeySource format
 	var envoyKeySources []*envoyapikeyauthv3.KeySource
 	if len(ak.KeySources) > 0 {
@@ -186,6 +225,49 @@ func constructAPIKeyAuth(
 		hideCredentials = !(*ak.ForwardCredential)
 	}
 
+	if ptr.Deref(ak.SecretFormat, kgateway.APIKeySecretFormatPlain) == kgateway.APIKeySecretFormatHashed {
+		hashed, err := constructHashedAPIKeyAuth(secrets, envoyKeySources, hideCredentials, ptr.Deref(ak.ClientIdHeader, ""), time.Now())
+		if err != nil {
+			return err
+		}
+		out.apiKeyAuth = hashed
+		return nil
+	}
+
+	// Parse secrets and build credentials
+	var credentials []*envoyapikeyauthv3.Credential
+	var errs []error
+
+	for _, secret := range secrets {
+		for keyName, keyValue := range secret.Data {
+			// Skip empty values
+			if len(keyValue) == 0 {
+				continue
+			}
+
+			// The value is expected to be a plain string representing the API key
+			// The secret key name becomes the client identifier
+			apiKey := string(keyValue)
+			if apiKey == "" {
+				errs = append(errs, fmt.Errorf("secret %s key %s has empty API key value", secret.ObjectSource.Name, keyName))
+				continue
+			}
+
+			credentials = append(credentials, &envoyapikeyauthv3.Credential{
+				Key:    apiKey,
+				Client: keyName,
+			})
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors processing API key secrets: %v", errs)
+	}
+
+	if len(credentials) == 0 {
+		return fmt.Errorf("no valid API keys found in secrets")
+	}
+
 	// Build Envoy API key auth per-route configuration
 	apiKeyAuthPolicy := &envoyapikeyauthv3.ApiKeyAuthPerRoute{
 		Credentials: credentials,
@@ -207,6 +289,122 @@ func constructAPIKeyAuth(
 	return nil
 }
 
+// constructHashedAPIKeyAuth builds the api-key-auth dynamic module filter config from secrets in the
+// Hashed format. Keys that expired before now are left out of the config, while the filter rejects the
+// keys that expire later on.
+func constructHashedAPIKeyAuth(
+	secrets []ir.Secret,
+	keySources []*envoyapikeyauthv3.KeySource,
+	hideCredentials bool,
+	clientIDHeader string,
+	now time.Time,
+) (*apiKeyAuthIR, error) {
+	cfg := hashedAPIKeyAuthConfig{
+		HideCredentials: hideCredentials,
+		ClientIDHeader:  clientIDHeader,
+	}
+	for _, source := range keySources {
+		cfg.KeySources = append(cfg.KeySources, hashedAPIKeySource{
+			Header: source.GetHeader(),
+			Query:  source.GetQuery(),
+			Cookie: source.GetCookie(),
+		})
+	}
+
+	var errs []error
+	expired := 0
+	// Sort the secrets and their entries so that the config does not change between translations.
+	secrets = slices.SortedFunc(slices.Values(secrets), func(a, b ir.Secret) int {
+		return cmp.Compare(a.ObjectSource.Name, b.ObjectSource.Name)
+	})
+	for _, secret := range secrets {
+		for _, client := range slices.Sorted(maps.Keys(secret.Data)) {
+			value := secret.Data[client]
+			if len(value) == 0 {
+				continue
+			}
+			var entry hashedAPIKeyEntry
+			if err := json.Unmarshal(value, &entry); err != nil {
+				errs = append(errs, fmt.Errorf("secret %s key %s is not a valid hashed API key: %w", secret.ObjectSource.Name, client, err))
+				continue
+			}
+			if digest, err := hex.DecodeString(entry.SHA256); err != nil || len(digest) != 32 {
+				errs = append(errs, fmt.Errorf("secret %s key %s must have a hex encoded sha256 digest", secret.ObjectSource.Name, client))
+				continue
+			}
+			if reserved := slices.IndexFunc(reservedAPIKeyMetadataKeys, func(k string) bool {
+				_, ok := entry.Metadata[k]
+				return ok
+			}); reserved >= 0 {
+				errs = append(errs, fmt.Errorf("secret %s key %s uses the reserved metadata key %s", secret.ObjectSource.Name, client, reservedAPIKeyMetadataKeys[reserved]))
+				continue
+			}
+
+			key := hashedAPIKey{
+				Client:   client,
+				SHA256:   entry.SHA256,
+				Salt:     entry.Salt,
+				Metadata: entry.Metadata,
+			}
+			if entry.ExpiresAt != nil {
+				if !entry.ExpiresAt.After(now) {
+					expired++
+					continue
+				}
+				key.ExpiresAt = new(entry.ExpiresAt.Unix())
+				key.Metadata = maps.Clone(entry.Metadata)
+				if key.Metadata == nil {
+					key.Metadata = map[string]string{}
+				}
+				key.Metadata["expiresAt"] = entry.ExpiresAt.UTC().Format(time.RFC3339)
+			}
+			cfg.Keys = append(cfg.Keys, key)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("errors processing API key secrets: %v", errs)
+	}
+	if len(cfg.Keys) == 0 {
+		return nil, fmt.Errorf("no valid API keys found in secrets, %d expired", expired)
+	}
+
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	filterCfg, err := utils.MessageToAny(&wrapperspb.StringValue{
+		Value: string(cfgJSON),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &apiKeyAuthIR{
+		hashedConfig: &dynamicmodulesv3.DynamicModuleFilterPerRoute{
+			DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
+				Name: hashedAPIKeyAuthModuleName,
+			},
+			FilterName:         hashedAPIKeyAuthFilterName,
+			PerRouteConfigName: hashedAPIKeyAuthFilterName,
+			FilterConfig:       filterCfg,
+		},
+		hashedKeySources: keySources,
+		activeKeys:       len(cfg.Keys),
+		expiredKeys:      expired,
+	}, nil
+}
+
+// apiKeyAuthWarnings reports the hashed API keys that are left out of the policy because they expired.
+func apiKeyAuthWarnings(spec *trafficPolicySpecIr) []string {
+	if spec.apiKeyAuth == nil || spec.apiKeyAuth.expiredKeys == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"apiKeyAuth: expired API keys are not accepted (active: %d, expired: %d)",
+		spec.apiKeyAuth.activeKeys, spec.apiKeyAuth.expiredKeys,
+	)}
+}
+
 // handleAPIKeyAuth configures the API key auth filter and per-route API key auth configuration.
 // This follows the same pattern as CORS: add the policy to the typed_per_filter_config.
 // Also requires API key auth http_filter to be added to the filter chain.
@@ -222,6 +420,7 @@ func (p *trafficPolicyPluginGwPass) handleAPIKeyAuth(
 	// Handle disable case - set disabled flag to override parent policy
 	if apiKeyAuthIr.disable {
 		pCtxTypedFilterConfig.AddTypedConfig(apiKeyAuthFilterNamePrefix, &envoyroutev3.FilterConfig{Disabled: true})
+		pCtxTypedFilterConfig.AddTypedConfig(hashedAPIKeyAuthFilterNamePrefix, &envoyroutev3.FilterConfig{Disabled: true})
 
 		// Explicitly set the APIKeyAuthEnabledFilterName to a blank transformation.
 		// This ensures that the metadata is not set if auth is not configured on the route
@@ -229,6 +428,16 @@ func (p *trafficPolicyPluginGwPass) handleAPIKeyAuth(
 		return
 	}
 
+	if apiKeyAuthIr.hashedConfig != nil {
+		pCtxTypedFilterConfig.AddTypedConfig(hashedAPIKeyAuthFilterNamePrefix, apiKeyAuthIr.hashedConfig)
+		AddAuthMetadataIfNeeded(pCtxTypedFilterConfig, APIKeyAuthEnabledFilterName, p.enableAuthMetadata)
+		if p.hashedAPIKeyAuthInChain == nil {
+			p.hashedAPIKeyAuthInChain = make(map[string]bool)
+		}
+		p.hashedAPIKeyAuthInChain[fcn] = true
+		return
+	}
+
 	if apiKeyAuthIr.config == nil {
 		return
 	}
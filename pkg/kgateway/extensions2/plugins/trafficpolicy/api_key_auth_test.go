@@ -1,13 +1,17 @@
 package trafficpolicy
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoyapikeyauthv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
+	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
@@ -388,6 +392,120 @@ func TestHttpFiltersAPIKeyAuth(t *testing.T) {
 		assert.Equal(t, APIKeyAuthEnabledFilterName, httpFilters[1].Filter.GetName())
 		assert.Equal(t, filters.AfterStage(filters.AuthNStage), httpFilters[1].Stage)
 	})
+
+	t.Run("adds hashed api key auth filter to chain", func(t *testing.T) {
+		plugin := &trafficPolicyPluginGwPass{}
+		typedFilterConfig := &ir.TypedFilterConfigMap{}
+		hashed, err := constructHashedAPIKeyAuth([]ir.Secret{hashedAPIKeySecret("keys", map[string]string{
+			"client1": `{"sha256":"` + testAPIKeyDigest + `"}`,
+		})}, []*envoyapikeyauthv3.KeySource{{Header: "api-key"}}, true, "", time.Now())
+		require.NoError(t, err)
+		plugin.handleAPIKeyAuth("test-filter-chain", typedFilterConfig, hashed)
+		assert.Equal(t, hashed.hashedConfig, typedFilterConfig.GetTypedConfig(hashedAPIKeyAuthFilterNamePrefix))
+		assert.Nil(t, typedFilterConfig.GetTypedConfig(apiKeyAuthFilterNamePrefix))
+
+		httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+		require.NoError(t, err)
+		require.Len(t, httpFilters, 1)
+		assert.Equal(t, hashedAPIKeyAuthFilterNamePrefix, httpFilters[0].Filter.GetName())
+		assert.True(t, httpFilters[0].Filter.GetDisabled())
+		assert.Equal(t, filters.DuringStage(filters.AuthNStage), httpFilters[0].Stage)
+		filter := &dynamicmodulesv3.DynamicModuleFilter{}
+		require.NoError(t, httpFilters[0].Filter.GetTypedConfig().UnmarshalTo(filter))
+		assert.Equal(t, hashedAPIKeyAuthFilterName, filter.GetFilterName())
+	})
+}
+
+// sha256 of "s1" followed by "k-123"
+const testAPIKeyDigest = "42403fc45854b1038cfd7743f83188ed54b9348686303984268bb68b026f6f15"
+
+func hashedAPIKeySecret(name string, entries map[string]string) ir.Secret {
+	data := map[string][]byte{}
+	for k, v := range entries {
+		data[k] = []byte(v)
+	}
+	return ir.Secret{ObjectSource: ir.ObjectSource{Name: name, Namespace: "default"}, Data: data}
+}
+
+func TestConstructHashedAPIKeyAuth(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	keySources := []*envoyapikeyauthv3.KeySource{{Header: "x-api-key"}, {Query: "key"}}
+
+	t.Run("builds the filter config and excludes expired keys", func(t *testing.T) {
+		out, err := constructHashedAPIKeyAuth([]ir.Secret{
+			hashedAPIKeySecret("b-keys", map[string]string{
+				"client3": `{"sha256":"` + testAPIKeyDigest + `","expiresAt":"2025-12-31T23:59:59Z"}`,
+			}),
+			hashedAPIKeySecret("a-keys", map[string]string{
+				"client2": `{"sha256":"` + testAPIKeyDigest + `"}`,
+				"client1": `{"sha256":"` + testAPIKeyDigest + `","salt":"s1","expiresAt":"2027-01-01T00:00:00Z","metadata":{"tenant":"acme"}}`,
+				"empty":   "",
+			}),
+		}, keySources, false, "x-client-id", now)
+		require.NoError(t, err)
+		require.NoError(t, out.Validate())
+		assert.Equal(t, 2, out.activeKeys)
+		assert.Equal(t, 1, out.expiredKeys)
+		assert.Nil(t, out.config)
+		assert.Equal(t, keySources, out.keySources())
+		assert.Equal(t, hashedAPIKeyAuthFilterName, out.hashedConfig.GetPerRouteConfigName())
+
+		cfgValue := &wrapperspb.StringValue{}
+		require.NoError(t, out.hashedConfig.GetFilterConfig().UnmarshalTo(cfgValue))
+		var cfg hashedAPIKeyAuthConfig
+		require.NoError(t, json.Unmarshal([]byte(cfgValue.GetValue()), &cfg))
+		assert.Equal(t, hashedAPIKeyAuthConfig{
+			KeySources:     []hashedAPIKeySource{{Header: "x-api-key"}, {Query: "key"}},
+			ClientIDHeader: "x-client-id",
+			Keys: []hashedAPIKey{
+				{
+					Client:    "client1",
+					SHA256:    testAPIKeyDigest,
+					Salt:      "s1",
+					ExpiresAt: new(int64(1798761600)),
+					Metadata:  map[string]string{"tenant": "acme", "expiresAt": "2027-01-01T00:00:00Z"},
+				},
+				{Client: "client2", SHA256: testAPIKeyDigest},
+			},
+		}, cfg)
+
+		assert.Equal(t, []string{"apiKeyAuth: expired API keys are not accepted (active: 2, expired: 1)"},
+			apiKeyAuthWarnings(&trafficPolicySpecIr{apiKeyAuth: out}))
+	})
+
+	tests := []struct {
+		name     string
+		entries  map[string]string
+		expected string
+	}{
+		{
+			name:     "plain key",
+			entries:  map[string]string{"client1": "k-123"},
+			expected: "secret keys key client1 is not a valid hashed API key",
+		},
+		{
+			name:     "invalid digest",
+			entries:  map[string]string{"client1": `{"sha256":"abcd"}`},
+			expected: "secret keys key client1 must have a hex encoded sha256 digest",
+		},
+		{
+			name:     "reserved metadata key",
+			entries:  map[string]string{"client1": `{"sha256":"` + testAPIKeyDigest + `","metadata":{"client":"other"}}`},
+			expected: "secret keys key client1 uses the reserved metadata key client",
+		},
+		{
+			name:     "all keys expired",
+			entries:  map[string]string{"client1": `{"sha256":"` + testAPIKeyDigest + `","expiresAt":"2025-01-01T00:00:00Z"}`},
+			expected: "no valid API keys found in secrets, 1 expired",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := constructHashedAPIKeyAuth([]ir.Secret{hashedAPIKeySecret("keys", tc.entries)}, keySources, true, "", now)
+			require.ErrorContains(t, err, tc.expected)
+			assert.Nil(t, out)
+		})
+	}
 }
 
 func TestAPIKeyAuthPolicyPlugin(t *testing.T) {
@@ -0,0 +1,119 @@
+package trafficpolicy
+
+import (
+	bandwidthlimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/bandwidth_limit/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	bandwidthLimitFilterName = "envoy.filters.http.bandwidth_limit"
+	// bandwidthLimitStatPrefix is the stat prefix used when the route has no stat prefix of its own.
+	bandwidthLimitStatPrefix = "http_bandwidth_limiter"
+)
+
+type bandwidthLimitIR struct {
+	// perRoute is nil when the policy disables the filter.
+	perRoute *bandwidthlimitv3.BandwidthLimit
+}
+
+var _ PolicySubIR = &bandwidthLimitIR{}
+
+func (b *bandwidthLimitIR) Equals(other PolicySubIR) bool {
+	otherBandwidthLimit, ok := other.(*bandwidthLimitIR)
+	if !ok {
+		return false
+	}
+	if b == nil || otherBandwidthLimit == nil {
+		return b == nil && otherBandwidthLimit == nil
+	}
+	return proto.Equal(b.perRoute, otherBandwidthLimit.perRoute)
+}
+
+func (b *bandwidthLimitIR) Validate() error {
+	if b == nil || b.perRoute == nil {
+		return nil
+	}
+	return b.perRoute.ValidateAll()
+}
+
+// constructBandwidthLimit constructs the bandwidth limit policy IR from the policy specification.
+func constructBandwidthLimit(spec kgateway.TrafficPolicySpec, out *trafficPolicySpecIr) {
+	if spec.BandwidthLimit == nil {
+		return
+	}
+
+	bl := spec.BandwidthLimit
+	if bl.Disable != nil {
+		out.bandwidthLimit = &bandwidthLimitIR{}
+		return
+	}
+
+	perRoute := &bandwidthlimitv3.BandwidthLimit{
+		StatPrefix: bandwidthLimitStatPrefix,
+		EnableMode: toBandwidthLimitEnableMode(bl.Direction),
+	}
+	if bl.KiBPerSecond != nil {
+		perRoute.LimitKbps = wrapperspb.UInt64(*bl.KiBPerSecond)
+	}
+	if bl.FillInterval != nil {
+		perRoute.FillInterval = durationpb.New(bl.FillInterval.Duration)
+	}
+	out.bandwidthLimit = &bandwidthLimitIR{
+		perRoute: perRoute,
+	}
+}
+
+func toBandwidthLimitEnableMode(direction *kgateway.BandwidthLimitDirection) bandwidthlimitv3.BandwidthLimit_EnableMode {
+	if direction == nil {
+		return bandwidthlimitv3.BandwidthLimit_REQUEST_AND_RESPONSE
+	}
+	switch *direction {
+	case kgateway.BandwidthLimitDirectionRequest:
+		return bandwidthlimitv3.BandwidthLimit_REQUEST
+	case kgateway.BandwidthLimitDirectionResponse:
+		return bandwidthlimitv3.BandwidthLimit_RESPONSE
+	default:
+		return bandwidthlimitv3.BandwidthLimit_REQUEST_AND_RESPONSE
+	}
+}
+
+func (p *trafficPolicyPluginGwPass) handleBandwidthLimit(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, bl *bandwidthLimitIR) {
+	if bl == nil {
+		return
+	}
+
+	if bl.perRoute != nil {
+		pCtxTypedFilterConfig.AddTypedConfig(bandwidthLimitFilterName, bl.perRoute)
+	} else {
+		// The policy has disable set. Disable the filter to override any bandwidth
+		// limit configured at a higher level (e.g. Gateway-attached policy).
+		pCtxTypedFilterConfig.AddTypedConfig(bandwidthLimitFilterName, DisableFilterPerRoute())
+	}
+
+	// The filter in the chain only takes effect on the routes that configure it.
+	if p.bandwidthLimitInChain == nil {
+		p.bandwidthLimitInChain = make(map[string]*bandwidthlimitv3.BandwidthLimit)
+	}
+	if _, ok := p.bandwidthLimitInChain[fcn]; !ok {
+		p.bandwidthLimitInChain[fcn] = &bandwidthlimitv3.BandwidthLimit{
+			StatPrefix: bandwidthLimitStatPrefix,
+		}
+	}
+}
+
+// applyBandwidthLimitStatPrefix emits the statistics of a route-level bandwidth limit under the
+// stat prefix of the route, so they can be told apart from the ones of other routes. It must run
+// after the route stat prefix has been resolved.
+func applyBandwidthLimitStatPrefix(bl *bandwidthLimitIR, statPrefix string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap) {
+	if bl == nil || bl.perRoute == nil || statPrefix == "" {
+		return
+	}
+	perRoute := proto.Clone(bl.perRoute).(*bandwidthlimitv3.BandwidthLimit)
+	perRoute.StatPrefix = statPrefix
+	pCtxTypedFilterConfig.AddTypedConfig(bandwidthLimitFilterName, perRoute)
+}
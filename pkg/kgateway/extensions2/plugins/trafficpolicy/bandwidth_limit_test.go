@@ -0,0 +1,116 @@
+package trafficpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	bandwidthlimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/bandwidth_limit/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConstructBandwidthLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *kgateway.BandwidthLimitPolicy
+		want *bandwidthlimitv3.BandwidthLimit
+	}{
+		{
+			name: "defaults to both directions",
+			spec: &kgateway.BandwidthLimitPolicy{KiBPerSecond: new(uint64(1024))},
+			want: &bandwidthlimitv3.BandwidthLimit{
+				StatPrefix: bandwidthLimitStatPrefix,
+				EnableMode: bandwidthlimitv3.BandwidthLimit_REQUEST_AND_RESPONSE,
+				LimitKbps:  wrapperspb.UInt64(1024),
+			},
+		},
+		{
+			name: "response only with fill interval",
+			spec: &kgateway.BandwidthLimitPolicy{
+				Direction:    new(kgateway.BandwidthLimitDirectionResponse),
+				KiBPerSecond: new(uint64(512)),
+				FillInterval: &metav1.Duration{Duration: 100 * time.Millisecond},
+			},
+			want: &bandwidthlimitv3.BandwidthLimit{
+				StatPrefix:   bandwidthLimitStatPrefix,
+				EnableMode:   bandwidthlimitv3.BandwidthLimit_RESPONSE,
+				LimitKbps:    wrapperspb.UInt64(512),
+				FillInterval: durationpb.New(100 * time.Millisecond),
+			},
+		},
+		{
+			name: "request only",
+			spec: &kgateway.BandwidthLimitPolicy{
+				Direction:    new(kgateway.BandwidthLimitDirectionRequest),
+				KiBPerSecond: new(uint64(64)),
+			},
+			want: &bandwidthlimitv3.BandwidthLimit{
+				StatPrefix: bandwidthLimitStatPrefix,
+				EnableMode: bandwidthlimitv3.BandwidthLimit_REQUEST,
+				LimitKbps:  wrapperspb.UInt64(64),
+			},
+		},
+		{
+			name: "disable",
+			spec: &kgateway.BandwidthLimitPolicy{Disable: &shared.PolicyDisable{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			constructBandwidthLimit(kgateway.TrafficPolicySpec{BandwidthLimit: tt.spec}, out)
+			require.NotNil(t, out.bandwidthLimit)
+			require.NoError(t, out.bandwidthLimit.Validate())
+			assert.True(t, proto.Equal(tt.want, out.bandwidthLimit.perRoute), "got %v", out.bandwidthLimit.perRoute)
+		})
+	}
+}
+
+func TestHandleBandwidthLimit(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	constructBandwidthLimit(kgateway.TrafficPolicySpec{
+		BandwidthLimit: &kgateway.BandwidthLimitPolicy{KiBPerSecond: new(uint64(1024))},
+	}, out)
+
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleBandwidthLimit("test-filter-chain", &typedFilterConfig, out.bandwidthLimit)
+	assert.True(t, proto.Equal(out.bandwidthLimit.perRoute, typedFilterConfig.GetTypedConfig(bandwidthLimitFilterName)))
+
+	t.Run("uses the route stat prefix", func(t *testing.T) {
+		applyBandwidthLimitStatPrefix(out.bandwidthLimit, "my-ns.my-route", &typedFilterConfig)
+		cfg, ok := typedFilterConfig.GetTypedConfig(bandwidthLimitFilterName).(*bandwidthlimitv3.BandwidthLimit)
+		require.True(t, ok)
+		assert.Equal(t, "my-ns.my-route", cfg.GetStatPrefix())
+		assert.Equal(t, bandwidthLimitStatPrefix, out.bandwidthLimit.perRoute.GetStatPrefix(), "the IR must not be mutated")
+	})
+
+	t.Run("disable overrides higher level policies", func(t *testing.T) {
+		disabled := &trafficPolicySpecIr{}
+		constructBandwidthLimit(kgateway.TrafficPolicySpec{
+			BandwidthLimit: &kgateway.BandwidthLimitPolicy{Disable: &shared.PolicyDisable{}},
+		}, disabled)
+		typedFilterConfig := ir.TypedFilterConfigMap{}
+		plugin.handleBandwidthLimit("test-filter-chain", &typedFilterConfig, disabled.bandwidthLimit)
+		applyBandwidthLimitStatPrefix(disabled.bandwidthLimit, "my-ns.my-route", &typedFilterConfig)
+		cfg, ok := typedFilterConfig.GetTypedConfig(bandwidthLimitFilterName).(*envoyroutev3.FilterConfig)
+		require.True(t, ok)
+		assert.True(t, cfg.GetDisabled())
+	})
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, bandwidthLimitFilterName, httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+}
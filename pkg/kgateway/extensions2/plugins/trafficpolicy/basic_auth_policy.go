@@ -1,19 +1,26 @@
 package trafficpolicy
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extensiondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
 	envoy_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
+	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
@@ -24,12 +31,55 @@ const (
 	defaultSecretKey    = ".htpasswd"
 	shaPrefix           = "{SHA}"
 
+	// The basic-auth filter of the rust dynamic module verifies the bcrypt and argon2 hashes
+	// that the Envoy basic auth filter does not support.
+	moduleBasicAuthModuleName       = "rust_module"
+	moduleBasicAuthFilterName       = "basic-auth"
+	moduleBasicAuthFilterNamePrefix = "dynamic_modules/" + moduleBasicAuthFilterName
+
 	BasicAuthEnabledFilterName = "basic_auth_enabled"
 )
 
+// htpasswdHashFormat is the hash format of the password of an htpasswd entry.
+type htpasswdHashFormat int
+
+const (
+	htpasswdHashSHA htpasswdHashFormat = iota
+	htpasswdHashBcrypt
+	htpasswdHashArgon2
+)
+
+// htpasswdUser is a valid htpasswd entry.
+type htpasswdUser struct {
+	username string
+	hash     string
+	format   htpasswdHashFormat
+}
+
+func (u htpasswdUser) String() string {
+	return u.username + ":" + u.hash
+}
+
 type basicAuthIR struct {
-	policy  *envoy_basic_auth_v3.BasicAuthPerRoute
-	disable bool
+	policy *envoy_basic_auth_v3.BasicAuthPerRoute
+	// moduleConfig is set instead of policy when the users are verified by the dynamic module.
+	moduleConfig *dynamicmodulesv3.DynamicModuleFilterPerRoute
+	// weakUsers are the users whose password is hashed in the {SHA} format.
+	weakUsers []string
+	disable   bool
+}
+
+// moduleBasicAuthConfig is the per-route config of the basic-auth dynamic module filter.
+type moduleBasicAuthConfig struct {
+	ForwardUsernameHeader string                `json:"forwardUsernameHeader,omitempty"`
+	ForwardRealmHeader    string                `json:"forwardRealmHeader,omitempty"`
+	Users                 []moduleBasicAuthUser `json:"users"`
+}
+
+type moduleBasicAuthUser struct {
+	Username string `json:"username"`
+	Hash     string `json:"hash"`
+	Realm    string `json:"realm,omitempty"`
 }
 
 var _ PolicySubIR = &basicAuthIR{}
@@ -45,11 +95,23 @@ func (b *basicAuthIR) Equals(other PolicySubIR) bool {
 	if b.disable != otherBasicAuth.disable {
 		return false
 	}
+	if !slices.Equal(b.weakUsers, otherBasicAuth.weakUsers) {
+		return false
+	}
+	if !proto.Equal(b.moduleConfig, otherBasicAuth.moduleConfig) {
+		return false
+	}
 	return proto.Equal(b.policy, otherBasicAuth.policy)
 }
 
 func (b *basicAuthIR) Validate() error {
-	if b == nil || b.policy == nil {
+	if b == nil {
+		return nil
+	}
+	if b.moduleConfig != nil {
+		return b.moduleConfig.Validate()
+	}
+	if b.policy == nil {
 		return nil
 	}
 	return b.policy.Validate()
@@ -68,6 +130,7 @@ func (p *trafficPolicyPluginGwPass) handleBasicAuth(
 	// Handle disable case - enable the filter with empty config to override parent policy
 	if basicAuth.disable {
 		pCtxTypedFilterConfig.AddTypedConfig(basicAuthFilterName, &envoyroutev3.FilterConfig{Config: &anypb.Any{}, Disabled: true})
+		pCtxTypedFilterConfig.AddTypedConfig(moduleBasicAuthFilterNamePrefix, &envoyroutev3.FilterConfig{Disabled: true})
 
 		// Explicitly set the BasicAuthEnabledFilterName to a blank transformation.
 		// This ensures that the metadata is not set if auth is not configured on the route
@@ -75,6 +138,16 @@ func (p *trafficPolicyPluginGwPass) handleBasicAuth(
 		return
 	}
 
+	if basicAuth.moduleConfig != nil {
+		pCtxTypedFilterConfig.AddTypedConfig(moduleBasicAuthFilterNamePrefix, basicAuth.moduleConfig)
+		AddAuthMetadataIfNeeded(pCtxTypedFilterConfig, BasicAuthEnabledFilterName, p.enableAuthMetadata)
+		if p.moduleBasicAuthInChain == nil {
+			p.moduleBasicAuthInChain = make(map[string]bool)
+		}
+		p.moduleBasicAuthInChain[fcn] = true
+		return
+	}
+
 	// Add per-route config using BasicAuthPerRoute
 	pCtxTypedFilterConfig.AddTypedConfig(basicAuthFilterName, basicAuth.policy)
 
@@ -136,20 +209,46 @@ func constructBasicAuth(
 		return fmt.Errorf("basic auth: either users or secretRef must be specified")
 	}
 
-	// Validate and filter users to only include SHA hashed passwords
-	validUsers, invalidUsers := validateAndFilterSHAUsers(htpasswdData)
+	// Validate and filter users to only include supported hash formats
+	validUsers, invalidUsers := validateAndFilterUsers(htpasswdData)
 
 	// If there are no valid users after filtering, return an error
 	if len(validUsers) == 0 {
-		return fmt.Errorf("basic auth: no valid users with {SHA} hash format found")
+		return fmt.Errorf("basic auth: no valid users with bcrypt, argon2 or {SHA} hash format found")
 	}
 
 	// Report invalid users if any were found
 	if len(invalidUsers) > 0 {
-		err = fmt.Errorf("basic auth: dropped %d user(s) with invalid hash format (only {SHA} is supported) or duplicate usernames.", len(invalidUsers))
+		err = fmt.Errorf("basic auth: dropped %d user(s) with invalid hash format (only bcrypt, argon2 and {SHA} are supported) or duplicate usernames.", len(invalidUsers))
+	}
+
+	var weakUsers []string
+	needsModule := len(spec.Realms) > 0 || spec.ForwardUsernameHeader != nil || spec.ForwardRealmHeader != nil
+	for _, u := range validUsers {
+		if u.format == htpasswdHashSHA {
+			weakUsers = append(weakUsers, u.username)
+		} else {
+			needsModule = true
+		}
 	}
 
-	allUsers := strings.Join(validUsers, "\n")
+	if needsModule {
+		moduleConfig, moduleErr := constructModuleBasicAuth(spec, validUsers)
+		if moduleErr != nil {
+			return fmt.Errorf("basic auth: %w", moduleErr)
+		}
+		out.basicAuth = &basicAuthIR{
+			moduleConfig: moduleConfig,
+			weakUsers:    weakUsers,
+		}
+		return err
+	}
+
+	lines := make([]string, 0, len(validUsers))
+	for _, u := range validUsers {
+		lines = append(lines, u.String())
+	}
+	allUsers := strings.Join(lines, "\n")
 	if len(allUsers) == 0 {
 		allUsers = "#"
 	}
@@ -164,11 +263,72 @@ func constructBasicAuth(
 				},
 			},
 		},
+		weakUsers: weakUsers,
 	}
 
 	return err
 }
 
+// constructModuleBasicAuth builds the basic-auth dynamic module filter config, which verifies
+// every supported hash format and forwards the username and realm of the authenticated user.
+func constructModuleBasicAuth(
+	spec *kgateway.BasicAuthPolicy,
+	users []htpasswdUser,
+) (*dynamicmodulesv3.DynamicModuleFilterPerRoute, error) {
+	realms := make(map[string]string)
+	for _, realm := range spec.Realms {
+		for _, username := range realm.Users {
+			if other, ok := realms[username]; ok && other != realm.Name {
+				return nil, fmt.Errorf("user %s is in both the %s and %s realms", username, other, realm.Name)
+			}
+			realms[username] = realm.Name
+		}
+	}
+
+	cfg := moduleBasicAuthConfig{
+		ForwardUsernameHeader: ptr.Deref(spec.ForwardUsernameHeader, ""),
+		ForwardRealmHeader:    ptr.Deref(spec.ForwardRealmHeader, ""),
+		Users:                 make([]moduleBasicAuthUser, 0, len(users)),
+	}
+	for _, u := range users {
+		cfg.Users = append(cfg.Users, moduleBasicAuthUser{
+			Username: u.username,
+			Hash:     u.hash,
+			Realm:    realms[u.username],
+		})
+	}
+
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	filterCfg, err := utils.MessageToAny(&wrapperspb.StringValue{
+		Value: string(cfgJSON),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dynamicmodulesv3.DynamicModuleFilterPerRoute{
+		DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
+			Name: moduleBasicAuthModuleName,
+		},
+		FilterName:         moduleBasicAuthFilterName,
+		PerRouteConfigName: moduleBasicAuthFilterName,
+		FilterConfig:       filterCfg,
+	}, nil
+}
+
+// basicAuthWarnings reports the users whose password is hashed in the weak {SHA} format.
+func basicAuthWarnings(spec *trafficPolicySpecIr) []string {
+	if spec.basicAuth == nil || len(spec.basicAuth.weakUsers) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"basicAuth: the passwords of %d user(s) are hashed with the weak {SHA} format, rehash them with bcrypt or argon2: %s",
+		len(spec.basicAuth.weakUsers), strings.Join(spec.basicAuth.weakUsers, ", "),
+	)}
+}
+
 // fetchHtpasswdFromSecret retrieves htpasswd data from a Kubernetes secret
 func fetchHtpasswdFromSecret(
 	krtctx krt.HandlerContext,
@@ -219,12 +379,12 @@ func fetchHtpasswdFromSecret(
 	return strings.TrimSpace(string(data)), nil
 }
 
-// validateAndFilterSHAUsers validates htpasswd entries and filters out users with non-SHA hash formats.
-// Returns a slice of valid users, a slice of invalid usernames, and an error if validation fails.
-// Envoy only supports {SHA} hash format for basic auth.
-func validateAndFilterSHAUsers(htpasswdData string) (validUsers []string, invalidUsernames []string) {
+// validateAndFilterUsers validates htpasswd entries and filters out users with unsupported hash formats.
+// Returns a slice of valid users and a slice of invalid usernames.
+// Envoy only supports the {SHA} hash format, the bcrypt and argon2 formats are verified by the dynamic module.
+func validateAndFilterUsers(htpasswdData string) (validUsers []htpasswdUser, invalidUsernames []string) {
 	lines := strings.Split(htpasswdData, "\n")
-	validUsers = make([]string, 0, len(lines))
+	validUsers = make([]htpasswdUser, 0, len(lines))
 	validUsernames := sets.New[string]()
 	invalidUsernames = make([]string, 0)
 
@@ -248,13 +408,11 @@ func validateAndFilterSHAUsers(htpasswdData string) (validUsers []string, invali
 		username := parts[0]
 		passwordHash := parts[1]
 
-		// 5=len("{SHA}"), 28=SHA1 base64 length. these validations are copied from envoy source code.
-		validHash := strings.HasPrefix(passwordHash, shaPrefix) && len(passwordHash) == (28+5)
+		format, validHash := htpasswdFormat(passwordHash)
 		isDuplicate := validUsernames.Has(username)
 
-		// Check if the password hash uses {SHA} format
 		if validHash && !isDuplicate {
-			validUsers = append(validUsers, line)
+			validUsers = append(validUsers, htpasswdUser{username: username, hash: passwordHash, format: format})
 			validUsernames.Insert(username)
 		} else {
 			logger.Warn("invalid basic auth user", "user", username, "isDuplicate", isDuplicate, "validHash", validHash)
@@ -264,3 +422,22 @@ func validateAndFilterSHAUsers(htpasswdData string) (validUsers []string, invali
 
 	return validUsers, invalidUsernames
 }
+
+// htpasswdFormat returns the format of an htpasswd password hash, and whether the format is supported.
+func htpasswdFormat(passwordHash string) (htpasswdHashFormat, bool) {
+	switch {
+	case strings.HasPrefix(passwordHash, shaPrefix):
+		// 5=len("{SHA}"), 28=SHA1 base64 length. these validations are copied from envoy source code.
+		return htpasswdHashSHA, len(passwordHash) == (28 + 5)
+	case strings.HasPrefix(passwordHash, "$2a$"), strings.HasPrefix(passwordHash, "$2b$"), strings.HasPrefix(passwordHash, "$2y$"):
+		// $2y$NN$ followed by the 22 characters of the salt and the 31 characters of the hash.
+		return htpasswdHashBcrypt, len(passwordHash) == 60 && !strings.Contains(passwordHash, ":")
+	case strings.HasPrefix(passwordHash, "$argon2i$"), strings.HasPrefix(passwordHash, "$argon2d$"), strings.HasPrefix(passwordHash, "$argon2id$"):
+		// The PHC string format: $argon2id$v=19$m=...,t=...,p=...$salt$hash.
+		// The version is omitted in hashes of argon2 versions before 0x13.
+		n := strings.Count(passwordHash, "$")
+		return htpasswdHashArgon2, (n == 4 || n == 5) && !strings.Contains(passwordHash, ":")
+	default:
+		return 0, false
+	}
+}
@@ -1,6 +1,7 @@
 package trafficpolicy
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -8,12 +9,15 @@ import (
 	envoy_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/utils/ptr"
 
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 )
 
-func TestValidateAndFilterSHAUsers(t *testing.T) {
+func TestValidateAndFilterUsers(t *testing.T) {
 	tests := []struct {
 		name            string
 		htpasswdData    string
@@ -51,11 +55,29 @@ user3:{SHA}d95o2uzYI7q7tY7bHI4U1xBug7s=`,
 			expectedInvalid: []string{"alice"},
 		},
 		{
-			name:            "bcrypt hash is filtered out",
+			name:            "bcrypt hash is valid",
 			htpasswdData:    "bob:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0cL2",
+			expectedValid:   []string{"bob:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0cL2"},
+			expectedInvalid: []string{},
+		},
+		{
+			name:            "truncated bcrypt hash is filtered out",
+			htpasswdData:    "bob:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0",
 			expectedValid:   []string{},
 			expectedInvalid: []string{"bob"},
 		},
+		{
+			name:            "argon2 hash is valid",
+			htpasswdData:    "carol:$argon2id$v=19$m=19456,t=2,p=1$a2dhdGV3YXlzYWx0MTIzNA$Nep+a1/fGFlqWX0M6i+ZVokadnsjtFAgW9EBgWfDtI8",
+			expectedValid:   []string{"carol:$argon2id$v=19$m=19456,t=2,p=1$a2dhdGV3YXlzYWx0MTIzNA$Nep+a1/fGFlqWX0M6i+ZVokadnsjtFAgW9EBgWfDtI8"},
+			expectedInvalid: []string{},
+		},
+		{
+			name:            "malformed argon2 hash is filtered out",
+			htpasswdData:    "carol:$argon2id$Nep+a1/fGFlqWX0M6i+ZVokadnsjtFAgW9EBgWfDtI8",
+			expectedValid:   []string{},
+			expectedInvalid: []string{"carol"},
+		},
 		{
 			name: "mixed valid and invalid users",
 			htpasswdData: `user1:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=
@@ -66,9 +88,10 @@ user3:{SHA}d95o2uzYI7q7tY7bHI4U1xBug7s=`,
 			expectedValid: []string{
 				"user1:{SHA}NWoZK3kTsExUV00Ywo1G5jlUKKs=",
 				"user2:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=",
+				"bob:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0cL2",
 				"user3:{SHA}d95o2uzYI7q7tY7bHI4U1xBug7s=",
 			},
-			expectedInvalid: []string{"alice", "bob"},
+			expectedInvalid: []string{"alice"},
 		},
 		{
 			name: "empty lines and comments are skipped",
@@ -131,9 +154,9 @@ user2:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=`,
 			expectedInvalid: []string{"user"},
 		},
 		{
-			name: "all users invalid - only MD5 and bcrypt",
+			name: "all users invalid - only MD5 and crypt",
 			htpasswdData: `alice:$apr1$3zSE0Abt$IuETi4l5yO87MuOrbSE4V.
-bob:$2y$05$r3J4d3VepzFkedkd/q1vI.pBYIpSqjfN0qOARV3ScUHysatnS0cL2`,
+bob:rl5FQ9fW/7E6A`,
 			expectedValid:   []string{},
 			expectedInvalid: []string{"alice", "bob"},
 		},
@@ -148,8 +171,12 @@ user:{SHA}2kuSN7rMzfGcB2DKt67EqDWQELA=`,
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			validUsers, invalidUsernames := validateAndFilterSHAUsers(tt.htpasswdData)
-			assert.Equal(t, tt.expectedValid, validUsers, "valid users mismatch")
+			validUsers, invalidUsernames := validateAndFilterUsers(tt.htpasswdData)
+			validLines := make([]string, 0, len(validUsers))
+			for _, u := range validUsers {
+				validLines = append(validLines, u.String())
+			}
+			assert.Equal(t, tt.expectedValid, validLines, "valid users mismatch")
 			assert.Equal(t, tt.expectedInvalid, invalidUsernames, "invalid usernames mismatch")
 		})
 	}
@@ -176,6 +203,117 @@ func TestHttpFiltersBasicAuth(t *testing.T) {
 		assert.Equal(t, BasicAuthEnabledFilterName, httpFilters[1].Filter.GetName())
 		assert.Equal(t, filters.AfterStage(filters.AuthNStage), httpFilters[1].Stage)
 	})
+
+	t.Run("adds dynamic module basic auth filter once alongside the envoy filter", func(t *testing.T) {
+		plugin := &trafficPolicyPluginGwPass{
+			enableAuthMetadata: true,
+			basicAuthInChain: map[string]*envoy_basic_auth_v3.BasicAuth{
+				"test-filter-chain": {},
+			},
+			moduleBasicAuthInChain: map[string]bool{"test-filter-chain": true},
+		}
+		fcc := ir.FilterChainCommon{FilterChainName: "test-filter-chain"}
+
+		httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, fcc)
+
+		require.NoError(t, err)
+		require.Len(t, httpFilters, 3)
+		assert.Equal(t, basicAuthFilterName, httpFilters[0].Filter.GetName())
+		assert.Equal(t, BasicAuthEnabledFilterName, httpFilters[1].Filter.GetName())
+		assert.Equal(t, moduleBasicAuthFilterNamePrefix, httpFilters[2].Filter.GetName())
+		assert.Equal(t, filters.DuringStage(filters.AuthNStage), httpFilters[2].Stage)
+		assert.True(t, httpFilters[2].Filter.GetDisabled())
+	})
+}
+
+func TestConstructBasicAuth(t *testing.T) {
+	const (
+		aliceBcrypt = "alice:$2a$04$URH0IYxVk4WiQOCGD4oqj.YfDJBYWG249ysWHrY.zRTWd8oRLmMUS"
+		carolArgon2 = "carol:$argon2id$v=19$m=19456,t=2,p=1$a2dhdGV3YXlzYWx0MTIzNA$Nep+a1/fGFlqWX0M6i+ZVokadnsjtFAgW9EBgWfDtI8"
+		daveSHA     = "dave:{SHA}5LbiinPZLaG3OODOB1LgUzKSGfo="
+	)
+	policy := func(spec kgateway.BasicAuthPolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{Spec: kgateway.TrafficPolicySpec{BasicAuth: &spec}}
+	}
+	moduleConfig := func(t *testing.T, out *basicAuthIR) moduleBasicAuthConfig {
+		t.Helper()
+		require.NotNil(t, out.moduleConfig)
+		cfgValue := &wrapperspb.StringValue{}
+		require.NoError(t, out.moduleConfig.GetFilterConfig().UnmarshalTo(cfgValue))
+		var cfg moduleBasicAuthConfig
+		require.NoError(t, json.Unmarshal([]byte(cfgValue.GetValue()), &cfg))
+		return cfg
+	}
+
+	t.Run("SHA users use the envoy filter and are reported as weak", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructBasicAuth(nil, policy(kgateway.BasicAuthPolicy{Users: []string{daveSHA}}), out, nil)
+		require.NoError(t, err)
+		require.NotNil(t, out.basicAuth.policy)
+		assert.Nil(t, out.basicAuth.moduleConfig)
+		assert.Equal(t, daveSHA, out.basicAuth.policy.GetUsers().GetInlineString())
+		assert.Equal(t, []string{
+			"basicAuth: the passwords of 1 user(s) are hashed with the weak {SHA} format, rehash them with bcrypt or argon2: dave",
+		}, basicAuthWarnings(out))
+	})
+
+	t.Run("bcrypt and argon2 users use the dynamic module", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructBasicAuth(nil, policy(kgateway.BasicAuthPolicy{
+			Users: []string{aliceBcrypt, carolArgon2, daveSHA},
+			Realms: []kgateway.BasicAuthRealm{
+				{Name: "eng", Users: []string{"alice", "mallory"}},
+				{Name: "ops", Users: []string{"carol"}},
+			},
+			ForwardRealmHeader: ptr.To("x-realm"),
+		}), out, nil)
+		require.NoError(t, err)
+		require.NoError(t, out.basicAuth.Validate())
+		assert.Nil(t, out.basicAuth.policy)
+		assert.Equal(t, moduleBasicAuthFilterName, out.basicAuth.moduleConfig.GetPerRouteConfigName())
+		assert.Equal(t, moduleBasicAuthConfig{
+			ForwardRealmHeader: "x-realm",
+			Users: []moduleBasicAuthUser{
+				{Username: "alice", Hash: aliceBcrypt[len("alice:"):], Realm: "eng"},
+				{Username: "carol", Hash: carolArgon2[len("carol:"):], Realm: "ops"},
+				{Username: "dave", Hash: daveSHA[len("dave:"):]},
+			},
+		}, moduleConfig(t, out.basicAuth))
+		assert.Equal(t, []string{"dave"}, out.basicAuth.weakUsers)
+	})
+
+	t.Run("forwarding the username uses the dynamic module", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructBasicAuth(nil, policy(kgateway.BasicAuthPolicy{
+			Users:                 []string{daveSHA},
+			ForwardUsernameHeader: ptr.To("x-user"),
+		}), out, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "x-user", moduleConfig(t, out.basicAuth).ForwardUsernameHeader)
+	})
+
+	t.Run("user in two realms is rejected", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructBasicAuth(nil, policy(kgateway.BasicAuthPolicy{
+			Users: []string{aliceBcrypt},
+			Realms: []kgateway.BasicAuthRealm{
+				{Name: "eng", Users: []string{"alice"}},
+				{Name: "ops", Users: []string{"alice"}},
+			},
+		}), out, nil)
+		require.EqualError(t, err, "basic auth: user alice is in both the eng and ops realms")
+		assert.Nil(t, out.basicAuth)
+	})
+
+	t.Run("unsupported users are dropped", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructBasicAuth(nil, policy(kgateway.BasicAuthPolicy{
+			Users: []string{aliceBcrypt, "bob:$apr1$3zSE0Abt$IuETi4l5yO87MuOrbSE4V."},
+		}), out, nil)
+		require.ErrorContains(t, err, "dropped 1 user(s)")
+		assert.Len(t, moduleConfig(t, out.basicAuth).Users, 1)
+		assert.Empty(t, basicAuthWarnings(out))
+	})
 }
 
 func TestBasicAuthPolicyPlugin(t *testing.T) {
@@ -0,0 +1,232 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cache/v3"
+	envoycompositev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/composite/v3"
+	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	simplehttpcachev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/cache/simple_http_cache/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	cacheFilterName                           = "envoy.filters.http.cache"
+	cacheFilterNamePrefix                     = "cache"
+	cacheGlobalDisableFilterName              = "global_disable/cache"
+	cacheGlobalDisableFilterMetadataNamespace = "dev.kgateway.disable_cache"
+)
+
+// cacheFilterStage runs the cache after authentication, authorization and rate limiting, so that
+// cached responses are only served to requests those filters allow.
+var cacheFilterStage = filters.DuringStage(filters.AcceptedStage)
+
+// cacheIR holds the cache filter of a single policy. The cache filter has no per-route configuration,
+// so each policy gets its own filter in the chain and routes enable the filter of the policy that
+// applies to them. A disabled cache turns off the filters of the policies applied at a higher level.
+type cacheIR struct {
+	filterName string
+	filter     *cachev3.CacheConfig
+	disable    bool
+}
+
+var _ PolicySubIR = &cacheIR{}
+
+func (c *cacheIR) Equals(other PolicySubIR) bool {
+	otherCache, ok := other.(*cacheIR)
+	if !ok {
+		return false
+	}
+	if c == nil || otherCache == nil {
+		return c == nil && otherCache == nil
+	}
+	return c.filterName == otherCache.filterName &&
+		c.disable == otherCache.disable &&
+		proto.Equal(c.filter, otherCache.filter)
+}
+
+func (c *cacheIR) Validate() error {
+	if c == nil || c.filter == nil {
+		return nil
+	}
+	return c.filter.ValidateAll()
+}
+
+// constructCache constructs the cache policy IR from the policy specification.
+func constructCache(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) {
+	spec := in.Spec.Cache
+	if spec == nil {
+		return
+	}
+
+	if spec.Disable != nil {
+		out.cache = &cacheIR{disable: true}
+		return
+	}
+
+	filter := &cachev3.CacheConfig{
+		TypedConfig:                     utils.MustMessageToAny(&simplehttpcachev3.SimpleHttpCacheConfig{}),
+		IgnoreRequestCacheControlHeader: ptr.Deref(spec.IgnoreRequestCacheControl, false),
+	}
+	if spec.MaxBodyBytes != nil {
+		filter.MaxBodyBytes = uint32(max(*spec.MaxBodyBytes, 0)) //#nosec G115 - CRD validates Minimum=1
+	}
+	for _, header := range spec.AllowedVaryHeaders {
+		filter.AllowedVaryHeaders = append(filter.AllowedVaryHeaders, &envoymatcherv3.StringMatcher{
+			MatchPattern: &envoymatcherv3.StringMatcher_Exact{Exact: header},
+			IgnoreCase:   true,
+		})
+	}
+	if key := spec.Key; key != nil {
+		filter.KeyCreatorParams = &cachev3.CacheConfig_KeyCreatorParams{
+			ExcludeScheme:           ptr.Deref(key.ExcludeScheme, false),
+			ExcludeHost:             ptr.Deref(key.ExcludeHost, false),
+			QueryParametersIncluded: translateCacheKeyQueryParameters(key.IncludeQueryParameters),
+			QueryParametersExcluded: translateCacheKeyQueryParameters(key.ExcludeQueryParameters),
+		}
+	}
+
+	out.cache = &cacheIR{
+		filterName: fmt.Sprintf("%s/%s/%s", cacheFilterNamePrefix, in.GetNamespace(), in.GetName()),
+		filter:     filter,
+	}
+}
+
+func translateCacheKeyQueryParameters(names []string) []*envoyroutev3.QueryParameterMatcher {
+	var out []*envoyroutev3.QueryParameterMatcher
+	for _, name := range names {
+		out = append(out, &envoyroutev3.QueryParameterMatcher{
+			Name: name,
+			QueryParameterMatchSpecifier: &envoyroutev3.QueryParameterMatcher_PresentMatch{
+				PresentMatch: true,
+			},
+		})
+	}
+	return out
+}
+
+// cacheWarnings reports the settings of a policy that keep its cache from serving any response.
+// Envoy never serves requests with an Authorization header from the cache, so a cache combined
+// with authentication that reads the credentials from that header has no effect.
+func cacheWarnings(spec *trafficPolicySpecIr) []string {
+	if spec.cache == nil || spec.cache.disable {
+		return nil
+	}
+
+	var authPolicies []string
+	if spec.basicAuth != nil && !spec.basicAuth.disable {
+		authPolicies = append(authPolicies, "basicAuth")
+	}
+	if spec.apiKeyAuth != nil && !spec.apiKeyAuth.disable && apiKeyAuthReadsAuthorization(spec.apiKeyAuth) {
+		authPolicies = append(authPolicies, "apiKeyAuth")
+	}
+	if spec.jwt != nil && !spec.jwt.disableAllProviders && jwtReadsAuthorization(spec.jwt) {
+		authPolicies = append(authPolicies, "jwtAuth")
+	}
+	if len(authPolicies) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"cache: responses are not served from the cache for requests with an Authorization header, which %s of this policy reads credentials from",
+		strings.Join(authPolicies, " and "),
+	)}
+}
+
+func apiKeyAuthReadsAuthorization(in *apiKeyAuthIR) bool {
+	for _, source := range in.keySources() {
+		if strings.EqualFold(source.GetHeader(), "authorization") {
+			return true
+		}
+	}
+	return false
+}
+
+// jwtReadsAuthorization reports whether any JWT provider of the policy reads its token from the
+// Authorization header, which is the default when a provider has no token source.
+func jwtReadsAuthorization(in *jwtIr) bool {
+	for _, cfg := range in.perProviderConfig {
+		if cfg.provider == nil {
+			continue
+		}
+		for _, matcher := range cfg.provider.Jwt.GetXdsMatcher().GetMatcherList().GetMatchers() {
+			action := &envoycompositev3.ExecuteFilterAction{}
+			if err := matcher.GetOnMatch().GetAction().GetTypedConfig().UnmarshalTo(action); err != nil {
+				continue
+			}
+			jwtAuthn := &jwtauthnv3.JwtAuthentication{}
+			if err := action.GetTypedConfig().GetTypedConfig().UnmarshalTo(jwtAuthn); err != nil {
+				continue
+			}
+			for _, provider := range jwtAuthn.GetProviders() {
+				if len(provider.GetFromHeaders()) == 0 && len(provider.GetFromParams()) == 0 && len(provider.GetFromCookies()) == 0 {
+					return true
+				}
+				for _, header := range provider.GetFromHeaders() {
+					if strings.EqualFold(header.GetName(), "authorization") {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (p *trafficPolicyPluginGwPass) handleCache(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *cacheIR) {
+	if in == nil {
+		return
+	}
+
+	// Routes are translated before the policies attached above them, so a disabled route cannot
+	// name the cache filters it overrides and turns all of them off through metadata instead.
+	if in.disable {
+		pCtxTypedFilterConfig.AddTypedConfig(cacheGlobalDisableFilterName, EnableFilterPerRoute())
+		return
+	}
+
+	pCtxTypedFilterConfig.AddTypedConfig(in.filterName, EnableFilterPerRoute())
+
+	if p.cacheInChain == nil {
+		p.cacheInChain = make(map[string][]*cacheIR)
+	}
+	for _, existing := range p.cacheInChain[fcn] {
+		if existing.filterName == in.filterName {
+			return
+		}
+	}
+	p.cacheInChain[fcn] = append(p.cacheInChain[fcn], in)
+}
+
+// addCacheFiltersIfNeeded adds a disabled-by-default filter for every cache policy used in the filter chain,
+// each skipped on routes that disable the cache.
+func addCacheFiltersIfNeeded(staged []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcn string) []filters.StagedHttpFilter {
+	if len(p.cacheInChain[fcn]) == 0 {
+		return staged
+	}
+
+	staged = AddDisableFilterIfNeeded(staged, cacheGlobalDisableFilterName, cacheGlobalDisableFilterMetadataNamespace)
+	for _, c := range p.cacheInChain[fcn] {
+		composite := buildCompositeFilter(
+			"composite_cache",
+			cacheGlobalDisableFilterMetadataNamespace,
+			&envoycorev3.TypedExtensionConfig{
+				Name:        cacheFilterName,
+				TypedConfig: utils.MustMessageToAny(c.filter),
+			},
+		)
+		filter := filters.MustNewStagedFilter(c.filterName, composite, cacheFilterStage)
+		filter.Filter.Disabled = true
+		staged = append(staged, filter)
+	}
+	return staged
+}
@@ -0,0 +1,149 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	envoy_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
+	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConstructCache(t *testing.T) {
+	policy := func(spec *kgateway.CachePolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec:       kgateway.TrafficPolicySpec{Cache: spec},
+		}
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		constructCache(policy(&kgateway.CachePolicy{}), out)
+		require.NotNil(t, out.cache)
+		assert.Equal(t, "cache/default/policy", out.cache.filterName)
+		assert.False(t, out.cache.disable)
+		require.NoError(t, out.cache.Validate())
+		assert.NotNil(t, out.cache.filter.GetTypedConfig())
+		assert.Nil(t, out.cache.filter.GetKeyCreatorParams())
+	})
+
+	t.Run("full config", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		constructCache(policy(&kgateway.CachePolicy{
+			MaxBodyBytes:              new(int32(1024)),
+			AllowedVaryHeaders:        []string{"Accept-Encoding"},
+			IgnoreRequestCacheControl: new(true),
+			Key: &kgateway.CacheKey{
+				ExcludeHost:            new(true),
+				IncludeQueryParameters: []string{"page"},
+				ExcludeQueryParameters: []string{"utm_source"},
+			},
+		}), out)
+		require.NotNil(t, out.cache)
+		require.NoError(t, out.cache.Validate())
+
+		filter := out.cache.filter
+		assert.Equal(t, uint32(1024), filter.GetMaxBodyBytes())
+		assert.True(t, filter.GetIgnoreRequestCacheControlHeader())
+		require.Len(t, filter.GetAllowedVaryHeaders(), 1)
+		assert.Equal(t, "Accept-Encoding", filter.GetAllowedVaryHeaders()[0].GetExact())
+		assert.True(t, filter.GetAllowedVaryHeaders()[0].GetIgnoreCase())
+
+		key := filter.GetKeyCreatorParams()
+		assert.True(t, key.GetExcludeHost())
+		assert.False(t, key.GetExcludeScheme())
+		require.Len(t, key.GetQueryParametersIncluded(), 1)
+		assert.Equal(t, "page", key.GetQueryParametersIncluded()[0].GetName())
+		require.Len(t, key.GetQueryParametersExcluded(), 1)
+		assert.Equal(t, "utm_source", key.GetQueryParametersExcluded()[0].GetName())
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		constructCache(policy(&kgateway.CachePolicy{Disable: &shared.PolicyDisable{}}), out)
+		assert.True(t, out.cache.Equals(&cacheIR{disable: true}))
+	})
+}
+
+func TestCacheWarnings(t *testing.T) {
+	jwtProvider := func(provider *jwtauthnv3.JwtProvider) *jwtIr {
+		return &jwtIr{perProviderConfig: []*perProviderJwtConfig{{
+			provider: &TrafficPolicyGatewayExtensionIR{
+				Jwt: buildCompositeJwtFilter(&jwtauthnv3.JwtAuthentication{
+					Providers: map[string]*jwtauthnv3.JwtProvider{"provider": provider},
+				}),
+			},
+		}}}
+	}
+	cache := &cacheIR{filterName: "cache/default/policy"}
+
+	tests := []struct {
+		name string
+		spec trafficPolicySpecIr
+		want []string
+	}{
+		{
+			name: "no auth",
+			spec: trafficPolicySpecIr{cache: cache},
+		},
+		{
+			name: "basic auth",
+			spec: trafficPolicySpecIr{cache: cache, basicAuth: &basicAuthIR{policy: &envoy_basic_auth_v3.BasicAuthPerRoute{}}},
+			want: []string{"cache: responses are not served from the cache for requests with an Authorization header, which basicAuth of this policy reads credentials from"},
+		},
+		{
+			name: "disabled cache",
+			spec: trafficPolicySpecIr{cache: &cacheIR{disable: true}, basicAuth: &basicAuthIR{policy: &envoy_basic_auth_v3.BasicAuthPerRoute{}}},
+		},
+		{
+			name: "jwt from the default header",
+			spec: trafficPolicySpecIr{cache: cache, jwt: jwtProvider(&jwtauthnv3.JwtProvider{Issuer: "issuer"})},
+			want: []string{"cache: responses are not served from the cache for requests with an Authorization header, which jwtAuth of this policy reads credentials from"},
+		},
+		{
+			name: "jwt from another header",
+			spec: trafficPolicySpecIr{cache: cache, jwt: jwtProvider(&jwtauthnv3.JwtProvider{
+				Issuer:      "issuer",
+				FromHeaders: []*jwtauthnv3.JwtHeader{{Name: "x-token"}},
+			})},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, cacheWarnings(&tc.spec))
+		})
+	}
+}
+
+func TestHttpFiltersCache(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	constructCache(&kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec:       kgateway.TrafficPolicySpec{Cache: &kgateway.CachePolicy{}},
+	}, out)
+
+	plugin := &trafficPolicyPluginGwPass{}
+	gatewayFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleCache("test-filter-chain", &gatewayFilterConfig, out.cache)
+	assert.Equal(t, EnableFilterPerRoute(), gatewayFilterConfig.GetTypedConfig("cache/default/policy"))
+
+	// a route with a disabled cache turns off the filters of the policies applied above it
+	routeFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleCache("test-filter-chain", &routeFilterConfig, &cacheIR{disable: true})
+	assert.Equal(t, EnableFilterPerRoute(), routeFilterConfig.GetTypedConfig(cacheGlobalDisableFilterName))
+	assert.Nil(t, routeFilterConfig.GetTypedConfig("cache/default/policy"))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 2)
+	assert.Equal(t, cacheGlobalDisableFilterName, httpFilters[0].Filter.GetName())
+	assert.Equal(t, "cache/default/policy", httpFilters[1].Filter.GetName())
+	assert.True(t, httpFilters[1].Filter.GetDisabled())
+	assert.Equal(t, cacheFilterStage, httpFilters[1].Stage)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -17,6 +18,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
 // FetchGatewayExtensionFunc defines the signature for fetching gateway extensions
@@ -25,6 +27,7 @@ type FetchGatewayExtensionFunc func(krtctx krt.HandlerContext, extensionRef shar
 type TrafficPolicyConstructor struct {
 	commoncol         *collections.CommonCollections
 	gatewayExtensions krt.Collection[TrafficPolicyGatewayExtensionIR]
+	directResponses   krt.Collection[*kgateway.DirectResponse]
 	extBuilder        func(krtctx krt.HandlerContext, gExt ir.GatewayExtension) *TrafficPolicyGatewayExtensionIR
 }
 
@@ -37,9 +40,18 @@ func NewTrafficPolicyConstructor(
 		return extBuilder(krtctx, gExt)
 	}
 	gatewayExtensions := krt.NewCollection(commoncol.GatewayExtensions, defaultExtBuilder)
+	directResponses := krt.WrapClient(
+		kclient.NewFilteredDelayed[*kgateway.DirectResponse](
+			commoncol.Client,
+			wellknown.DirectResponseGVR,
+			kclient.Filter{ObjectFilter: commoncol.Client.ObjectFilter()},
+		),
+		commoncol.KrtOpts.ToOptions("TrafficPolicyDirectResponses")...,
+	)
 	return &TrafficPolicyConstructor{
 		commoncol:         commoncol,
 		gatewayExtensions: gatewayExtensions,
+		directResponses:   directResponses,
 		extBuilder:        extBuilder,
 	}
 }
@@ -63,6 +75,14 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 	if err := constructExtProc(krtctx, policyCR, c.FetchGatewayExtension, &outSpec); err != nil {
 		errors = append(errors, err)
 	}
+	// Construct wasm specific IR
+	if err := constructWasm(krtctx, policyCR, c.FetchGatewayExtension, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
+	// Construct geoip specific IR
+	if err := constructGeoIP(krtctx, policyCR, c.FetchGatewayExtension, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct extauth specific IR
 	if err := constructExtAuth(krtctx, policyCR, c.FetchGatewayExtension, &outSpec); err != nil {
 		errors = append(errors, err)
@@ -84,18 +104,46 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 	if err := constructHeaderModifiers(krtctx, policyCR, c.commoncol.Secrets, &outSpec); err != nil {
 		errors = append(errors, err)
 	}
+	// Construct lua specific IR
+	if err := constructLua(krtctx, policyCR, c.commoncol.ConfigMaps.Collection(), &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct auto host rewrite specific IR
 	constructAutoHostRewrite(policyCR.Spec, &outSpec)
 	// Construct buffer specific IR
 	constructBuffer(policyCR.Spec, &outSpec)
 	// Construct fault injection specific IR
 	constructFaultInjection(policyCR.Spec, &outSpec)
+	// Construct bandwidth limit specific IR
+	constructBandwidthLimit(policyCR.Spec, &outSpec)
+	// Construct adaptive concurrency specific IR
+	constructAdaptiveConcurrency(policyCR, &outSpec)
+	// Construct admission control specific IR
+	if err := constructAdmissionControl(policyCR, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
+	// Construct cache specific IR
+	constructCache(policyCR, &outSpec)
+	// Construct gRPC-JSON transcoder specific IR
+	if err := constructGRPCJSONTranscoder(krtctx, policyCR, c.commoncol.ConfigMaps.Collection(), c.commoncol.Secrets, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
+	// Construct custom response specific IR
+	if err := constructCustomResponse(krtctx, policyCR, c.commoncol.ConfigMaps.Collection(), c.directResponses, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct HTTP ACL specific IR
 	if err := constructHttpACL(policyCR, &outSpec); err != nil {
 		errors = append(errors, err)
 	}
+	// Construct request limits specific IR
+	if err := constructRequestLimits(policyCR, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct timeout and retry specific IR
-	constructTimeoutRetry(policyCR.Spec, &outSpec)
+	if err := constructTimeoutRetry(policyCR.Spec, &outSpec); err != nil {
+		errors = append(errors, err)
+	}
 	// Construct internal redirect specific IR
 	constructInternalRedirect(policyCR.Spec, &outSpec)
 
@@ -133,6 +181,8 @@ func (c *TrafficPolicyConstructor) ConstructIR(
 		logger.Error("error translating traffic policy", "namespace", policyCR.GetNamespace(), "name", policyCR.GetName(), "error", err)
 	}
 	policyIr.spec = outSpec
+	policyIr.warnings = append(cacheWarnings(&outSpec), apiKeyAuthWarnings(&outSpec)...)
+	policyIr.warnings = append(policyIr.warnings, basicAuthWarnings(&outSpec)...)
 
 	return &policyIr, errors
 }
@@ -160,7 +210,7 @@ func (c *TrafficPolicyConstructor) FetchGatewayExtension(krtctx krt.HandlerConte
 	}
 
 	gwExtNN := types.NamespacedName{Name: string(extensionRef.Name), Namespace: string(namespace)}
-	gatewayExtension := krt.FetchOne(krtctx, c.gatewayExtensions, krt.FilterObjectName(gwExtNN))
+	gatewayExtension := krtutil.FetchOne(krtctx, c.gatewayExtensions, krt.FilterObjectName(gwExtNN))
 	if gatewayExtension == nil {
 		return nil, fmt.Errorf("%s: %w", gwExtNN.String(), pluginutils.ErrGatewayExtensionNotFound)
 	}
@@ -0,0 +1,297 @@
+package trafficpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	xdscorev3 "github.com/cncf/xds/go/xds/core/v3"
+	xdsmatcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	customresponsev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/custom_response/v3"
+	localresponsepolicyv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/custom_response/local_response_policy/v3"
+	redirectpolicyv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/custom_response/redirect_policy/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+const customResponseFilterName = "envoy.filters.http.custom_response"
+
+// customResponseFilterStage runs the filter first, so that it also sees the local replies of all
+// the other filters, such as authentication failures and rate limited requests.
+var customResponseFilterStage = filters.BeforeStage(filters.FaultStage)
+
+type customResponseIR struct {
+	perRoute *customresponsev3.CustomResponse
+	disable  bool
+}
+
+var _ PolicySubIR = &customResponseIR{}
+
+func (c *customResponseIR) Equals(other PolicySubIR) bool {
+	otherCustomResponse, ok := other.(*customResponseIR)
+	if !ok {
+		return false
+	}
+	if c == nil || otherCustomResponse == nil {
+		return c == nil && otherCustomResponse == nil
+	}
+	return c.disable == otherCustomResponse.disable &&
+		proto.Equal(c.perRoute, otherCustomResponse.perRoute)
+}
+
+func (c *customResponseIR) Validate() error {
+	if c == nil || c.perRoute == nil {
+		return nil
+	}
+	return c.perRoute.ValidateAll()
+}
+
+// constructCustomResponse constructs the custom response policy IR from the policy specification.
+func constructCustomResponse(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	configMaps krt.Collection[*corev1.ConfigMap],
+	directResponses krt.Collection[*kgateway.DirectResponse],
+	out *trafficPolicySpecIr,
+) error {
+	spec := in.Spec.CustomResponse
+	if spec == nil {
+		return nil
+	}
+
+	if spec.Disable != nil {
+		out.customResponse = &customResponseIR{disable: true}
+		return nil
+	}
+
+	matchers := make([]*xdsmatcherv3.Matcher_MatcherList_FieldMatcher, 0, len(spec.Rules))
+	for i, rule := range spec.Rules {
+		action, err := customResponseAction(krtctx, rule, in.GetNamespace(), configMaps, directResponses)
+		if err != nil {
+			return fmt.Errorf("custom response: rule %d: %w", i, err)
+		}
+		matchers = append(matchers, &xdsmatcherv3.Matcher_MatcherList_FieldMatcher{
+			Predicate: statusCodesPredicate(rule.StatusCodes),
+			OnMatch: &xdsmatcherv3.Matcher_OnMatch{
+				OnMatch: &xdsmatcherv3.Matcher_OnMatch_Action{Action: action},
+			},
+		})
+	}
+
+	out.customResponse = &customResponseIR{
+		perRoute: &customresponsev3.CustomResponse{
+			CustomResponseMatcher: &xdsmatcherv3.Matcher{
+				MatcherType: &xdsmatcherv3.Matcher_MatcherList_{
+					MatcherList: &xdsmatcherv3.Matcher_MatcherList{Matchers: matchers},
+				},
+			},
+		},
+	}
+	return nil
+}
+
+func customResponseAction(
+	krtctx krt.HandlerContext,
+	rule kgateway.CustomResponseRule,
+	namespace string,
+	configMaps krt.Collection[*corev1.ConfigMap],
+	directResponses krt.Collection[*kgateway.DirectResponse],
+) (*xdscorev3.TypedExtensionConfig, error) {
+	var policy proto.Message
+	switch {
+	case rule.DirectResponseRef != nil:
+		dr, err := getDirectResponse(krtctx, directResponses, rule.DirectResponseRef.Name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		local := &localresponsepolicyv3.LocalResponsePolicy{
+			StatusCode: wrapperspb.UInt32(uint32(max(dr.Spec.StatusCode, 0))), //#nosec G115 - CRD validates Minimum=200
+		}
+		if dr.Spec.Body != nil {
+			local.Body = &envoycorev3.DataSource{
+				Specifier: &envoycorev3.DataSource_InlineString{InlineString: *dr.Spec.Body},
+			}
+		}
+		bodyFormat, err := pluginutils.EnvoyBodyFormat(dr.Spec.BodyFormat)
+		if err != nil {
+			return nil, fmt.Errorf("directresponse %s: %w", dr.Name, err)
+		}
+		local.BodyFormat = bodyFormat
+		policy = local
+
+	case rule.ConfigMapRef != nil:
+		ref := rule.ConfigMapRef
+		cm, err := GetConfigMap(krtctx, configMaps, ref.Name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find configmap %s: %w", ref.Name, err)
+		}
+		body, ok := cm.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("configmap %s key '%s' not found", ref.Name, ref.Key)
+		}
+		local := &localresponsepolicyv3.LocalResponsePolicy{
+			Body: &envoycorev3.DataSource{
+				Specifier: &envoycorev3.DataSource_InlineString{InlineString: body},
+			},
+		}
+		if ref.StatusCode != nil {
+			local.StatusCode = wrapperspb.UInt32(uint32(max(*ref.StatusCode, 0))) //#nosec G115 - CRD validates Minimum=200
+		}
+		if ref.ContentType != nil {
+			// The body is inserted as is, the format only sets its content type.
+			local.BodyFormat = &envoycorev3.SubstitutionFormatString{
+				Format: &envoycorev3.SubstitutionFormatString_TextFormatSource{
+					TextFormatSource: &envoycorev3.DataSource{
+						Specifier: &envoycorev3.DataSource_InlineString{InlineString: "%LOCAL_REPLY_BODY%"},
+					},
+				},
+				ContentType: *ref.ContentType,
+			}
+		}
+		policy = local
+
+	case rule.Redirect != nil:
+		redirect := &redirectpolicyv3.RedirectPolicy{
+			RedirectActionSpecifier: &redirectpolicyv3.RedirectPolicy_Uri{Uri: rule.Redirect.URI},
+		}
+		if rule.Redirect.StatusCode != nil {
+			redirect.StatusCode = wrapperspb.UInt32(uint32(max(*rule.Redirect.StatusCode, 0))) //#nosec G115 - CRD validates Minimum=200
+		}
+		policy = redirect
+
+	default:
+		return nil, errors.New("one of directResponseRef, configMapRef or redirect must be set")
+	}
+
+	return &xdscorev3.TypedExtensionConfig{
+		Name:        "action",
+		TypedConfig: utils.MustMessageToAny(policy),
+	}, nil
+}
+
+func getDirectResponse(
+	krtctx krt.HandlerContext,
+	directResponses krt.Collection[*kgateway.DirectResponse],
+	name, ns string,
+) (*kgateway.DirectResponse, error) {
+	if directResponses == nil {
+		return nil, errors.New("directresponses collection not available")
+	}
+	obj := krtutil.FetchOne(krtctx, directResponses, krt.FilterObjectName(types.NamespacedName{Namespace: ns, Name: name}))
+	if obj == nil {
+		return nil, &krtcollections.NotFoundError{NotFoundObj: ir.ObjectSource{Group: wellknown.DirectResponseGVK.Group, Kind: wellknown.DirectResponseGVK.Kind, Namespace: ns, Name: name}}
+	}
+	return *obj, nil
+}
+
+// statusCodesPredicate matches responses with a status code in any of the given ranges.
+func statusCodesPredicate(ranges []kgateway.StatusCodeRange) *xdsmatcherv3.Matcher_MatcherList_Predicate {
+	predicates := make([]*xdsmatcherv3.Matcher_MatcherList_Predicate, 0, len(ranges))
+	for _, r := range ranges {
+		predicates = append(predicates, statusCodeRangePredicate(int(r.Start), int(r.End)))
+	}
+	if len(predicates) == 1 {
+		return predicates[0]
+	}
+	return &xdsmatcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &xdsmatcherv3.Matcher_MatcherList_Predicate_OrMatcher{
+			OrMatcher: &xdsmatcherv3.Matcher_MatcherList_Predicate_PredicateList{Predicate: predicates},
+		},
+	}
+}
+
+func statusCodeRangePredicate(start, end int) *xdsmatcherv3.Matcher_MatcherList_Predicate {
+	valueMatch := &xdsmatcherv3.StringMatcher{
+		MatchPattern: &xdsmatcherv3.StringMatcher_Exact{Exact: strconv.Itoa(start)},
+	}
+	if start != end {
+		valueMatch = &xdsmatcherv3.StringMatcher{
+			MatchPattern: &xdsmatcherv3.StringMatcher_SafeRegex{
+				SafeRegex: &xdsmatcherv3.RegexMatcher{
+					EngineType: &xdsmatcherv3.RegexMatcher_GoogleRe2{GoogleRe2: &xdsmatcherv3.RegexMatcher_GoogleRE2{}},
+					Regex:      statusCodeRangeRegex(start, end),
+				},
+			},
+		}
+	}
+	return &xdsmatcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &xdsmatcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+			SinglePredicate: &xdsmatcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+				Input: &xdscorev3.TypedExtensionConfig{
+					Name:        "status_code",
+					TypedConfig: utils.MustMessageToAny(&envoymatcherv3.HttpResponseStatusCodeMatchInput{}),
+				},
+				Matcher: &xdsmatcherv3.Matcher_MatcherList_Predicate_SinglePredicate_ValueMatch{
+					ValueMatch: valueMatch,
+				},
+			},
+		},
+	}
+}
+
+// statusCodeRangeRegex builds a regex matching the status codes from start to end, using whole
+// hundreds and tens where possible, e.g. `^(40[4-9]|4[1-9]\d|5\d\d)$` for 404-599.
+func statusCodeRangeRegex(start, end int) string {
+	var parts []string
+	for code := start; code <= end; {
+		switch {
+		case code%100 == 0 && code+99 <= end:
+			parts = append(parts, fmt.Sprintf(`%d\d\d`, code/100))
+			code += 100
+		case code%10 == 0 && code+9 <= end:
+			last := code
+			for last%100 < 90 && last+19 <= end {
+				last += 10
+			}
+			if last == code {
+				parts = append(parts, fmt.Sprintf(`%d\d`, code/10))
+			} else {
+				parts = append(parts, fmt.Sprintf(`%d[%d-%d]\d`, code/100, code/10%10, last/10%10))
+			}
+			code = last + 10
+		default:
+			last := min(end, code-code%10+9)
+			if last == code {
+				parts = append(parts, strconv.Itoa(code))
+			} else {
+				parts = append(parts, fmt.Sprintf("%d[%d-%d]", code/10, code%10, last%10))
+			}
+			code = last + 1
+		}
+	}
+	return "^(" + strings.Join(parts, "|") + ")$"
+}
+
+func (p *trafficPolicyPluginGwPass) handleCustomResponse(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *customResponseIR) {
+	if in == nil {
+		return
+	}
+
+	// The responses are configured per route, so the filter in the chain has no default matcher
+	// and is disabled for routes without a custom response policy.
+	if in.disable {
+		pCtxTypedFilterConfig.AddTypedConfig(customResponseFilterName, DisableFilterPerRoute())
+	} else {
+		pCtxTypedFilterConfig.AddTypedConfig(customResponseFilterName, in.perRoute)
+	}
+
+	if p.customResponseInChain == nil {
+		p.customResponseInChain = make(map[string]bool)
+	}
+	p.customResponseInChain[fcn] = true
+}
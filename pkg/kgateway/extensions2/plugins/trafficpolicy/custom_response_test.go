@@ -0,0 +1,160 @@
+package trafficpolicy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	localresponsepolicyv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/custom_response/local_response_policy/v3"
+	redirectpolicyv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/custom_response/redirect_policy/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestStatusCodeRangeRegex(t *testing.T) {
+	assert.Equal(t, `^(40[4-9]|4[1-9]\d|5\d\d)$`, statusCodeRangeRegex(404, 599))
+	assert.Equal(t, `^(50[2-4])$`, statusCodeRangeRegex(502, 504))
+
+	ranges := [][2]int{{100, 599}, {404, 599}, {400, 499}, {401, 403}, {410, 419}, {395, 512}, {199, 201}, {500, 501}}
+	for _, r := range ranges {
+		t.Run(fmt.Sprintf("%d-%d", r[0], r[1]), func(t *testing.T) {
+			re := regexp.MustCompile(statusCodeRangeRegex(r[0], r[1]))
+			for code := 100; code <= 599; code++ {
+				assert.Equal(t, code >= r[0] && code <= r[1], re.MatchString(strconv.Itoa(code)), "status code %d", code)
+			}
+		})
+	}
+}
+
+func TestConstructCustomResponse(t *testing.T) {
+	configMaps := krt.NewStaticCollection(nil, []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pages", Namespace: "default"},
+			Data:       map[string]string{"404.html": "<h1>Not found</h1>"},
+		},
+	})
+	directResponses := krt.NewStaticCollection(nil, []*kgateway.DirectResponse{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unavailable", Namespace: "default"},
+			Spec:       kgateway.DirectResponseSpec{StatusCode: 503, Body: new("try again later")},
+		},
+	})
+	policy := func(spec *kgateway.CustomResponsePolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec:       kgateway.TrafficPolicySpec{CustomResponse: spec},
+		}
+	}
+
+	t.Run("rules", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructCustomResponse(krt.TestingDummyContext{}, policy(&kgateway.CustomResponsePolicy{
+			Rules: []kgateway.CustomResponseRule{
+				{
+					StatusCodes:  []kgateway.StatusCodeRange{{Start: 404, End: 404}},
+					ConfigMapRef: &kgateway.CustomResponseConfigMapRef{Name: "pages", Key: "404.html", ContentType: new("text/html")},
+				},
+				{
+					StatusCodes:       []kgateway.StatusCodeRange{{Start: 502, End: 504}, {Start: 429, End: 429}},
+					DirectResponseRef: &corev1.LocalObjectReference{Name: "unavailable"},
+				},
+				{
+					StatusCodes: []kgateway.StatusCodeRange{{Start: 500, End: 599}},
+					Redirect:    &kgateway.CustomResponseRedirect{URI: "http://errors.example.com/5xx", StatusCode: new(int32(500))},
+				},
+			},
+		}), configMaps, directResponses, out)
+		require.NoError(t, err)
+		require.NotNil(t, out.customResponse)
+		require.NoError(t, out.customResponse.Validate())
+
+		matchers := out.customResponse.perRoute.GetCustomResponseMatcher().GetMatcherList().GetMatchers()
+		require.Len(t, matchers, 3)
+
+		single := matchers[0].GetPredicate().GetSinglePredicate()
+		assert.Equal(t, "404", single.GetValueMatch().GetExact())
+		local := &localresponsepolicyv3.LocalResponsePolicy{}
+		require.NoError(t, matchers[0].GetOnMatch().GetAction().GetTypedConfig().UnmarshalTo(local))
+		assert.Equal(t, "<h1>Not found</h1>", local.GetBody().GetInlineString())
+		assert.Equal(t, "text/html", local.GetBodyFormat().GetContentType())
+		assert.Nil(t, local.GetStatusCode())
+
+		or := matchers[1].GetPredicate().GetOrMatcher().GetPredicate()
+		require.Len(t, or, 2)
+		assert.Equal(t, `^(50[2-4])$`, or[0].GetSinglePredicate().GetValueMatch().GetSafeRegex().GetRegex())
+		assert.Equal(t, "429", or[1].GetSinglePredicate().GetValueMatch().GetExact())
+		local = &localresponsepolicyv3.LocalResponsePolicy{}
+		require.NoError(t, matchers[1].GetOnMatch().GetAction().GetTypedConfig().UnmarshalTo(local))
+		assert.Equal(t, "try again later", local.GetBody().GetInlineString())
+		assert.Equal(t, uint32(503), local.GetStatusCode().GetValue())
+
+		redirect := &redirectpolicyv3.RedirectPolicy{}
+		require.NoError(t, matchers[2].GetOnMatch().GetAction().GetTypedConfig().UnmarshalTo(redirect))
+		assert.Equal(t, "http://errors.example.com/5xx", redirect.GetUri())
+		assert.Equal(t, uint32(500), redirect.GetStatusCode().GetValue())
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructCustomResponse(krt.TestingDummyContext{}, policy(&kgateway.CustomResponsePolicy{
+			Disable: &shared.PolicyDisable{},
+		}), configMaps, directResponses, out)
+		require.NoError(t, err)
+		assert.True(t, out.customResponse.Equals(&customResponseIR{disable: true}))
+	})
+
+	tests := []struct {
+		name     string
+		rule     kgateway.CustomResponseRule
+		expected string
+	}{
+		{
+			name: "missing directresponse",
+			rule: kgateway.CustomResponseRule{
+				StatusCodes:       []kgateway.StatusCodeRange{{Start: 503, End: 503}},
+				DirectResponseRef: &corev1.LocalObjectReference{Name: "missing"},
+			},
+			expected: "custom response: rule 0: DirectResponse default/missing not found",
+		},
+		{
+			name: "missing configmap key",
+			rule: kgateway.CustomResponseRule{
+				StatusCodes:  []kgateway.StatusCodeRange{{Start: 404, End: 404}},
+				ConfigMapRef: &kgateway.CustomResponseConfigMapRef{Name: "pages", Key: "500.html"},
+			},
+			expected: "custom response: rule 0: configmap pages key '500.html' not found",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructCustomResponse(krt.TestingDummyContext{}, policy(&kgateway.CustomResponsePolicy{
+				Rules: []kgateway.CustomResponseRule{tc.rule},
+			}), configMaps, directResponses, out)
+			require.ErrorContains(t, err, tc.expected)
+			assert.Nil(t, out.customResponse)
+		})
+	}
+}
+
+func TestHttpFiltersCustomResponse(t *testing.T) {
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleCustomResponse("test-filter-chain", &typedFilterConfig, &customResponseIR{disable: true})
+	assert.Equal(t, DisableFilterPerRoute(), typedFilterConfig.GetTypedConfig(customResponseFilterName))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, customResponseFilterName, httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+	assert.Equal(t, customResponseFilterStage, httpFilters[0].Stage)
+}
@@ -16,8 +16,10 @@ import (
 	envoyextprocv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
 	envoyjwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	ratev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	maxmindv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/geoip_providers/maxmind/v3"
 	envoynetworkv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/common_inputs/network/v3"
 	envoymetadatav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/matching/input_matchers/metadata/v3"
+	envoywasmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/wasm/v3"
 	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	envoytypev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/protobuf/proto"
@@ -25,6 +27,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/istio/pkg/kube/krt"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
@@ -42,6 +45,8 @@ type TrafficPolicyGatewayExtensionIR struct {
 	RateLimit        *ratev3.RateLimit
 	Jwt              *envoymatchingv3.ExtensionWithMatcher
 	OAuth2           *oauthPerProviderConfig
+	Wasm             *envoywasmv3.PluginConfig
+	GeoIP            *maxmindv3.MaxMindConfig
 	PrecedenceWeight int32
 	FilterStage      *kgateway.FilterStageSpec
 	Err              error
@@ -68,6 +73,12 @@ func (e TrafficPolicyGatewayExtensionIR) Equals(other TrafficPolicyGatewayExtens
 	if !e.OAuth2.Equals(other.OAuth2) {
 		return false
 	}
+	if !proto.Equal(e.Wasm, other.Wasm) {
+		return false
+	}
+	if !proto.Equal(e.GeoIP, other.GeoIP) {
+		return false
+	}
 	if e.PrecedenceWeight != other.PrecedenceWeight {
 		return false
 	}
@@ -114,6 +125,11 @@ func (e TrafficPolicyGatewayExtensionIR) Validate() error {
 			return err
 		}
 	}
+	if e.Wasm != nil {
+		if err := e.Wasm.ValidateAll(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -226,6 +242,17 @@ func TranslateGatewayExtensionBuilder(
 				return p
 			}
 			p.OAuth2 = out
+
+		case gExt.Wasm != nil:
+			out, err := buildWasmPluginConfig(krtctx, &gExt, commoncol.ConfigMaps.Collection(), commoncol.BackendIndex)
+			if err != nil {
+				p.Err = fmt.Errorf("wasm: %w", err)
+				return p
+			}
+			p.Wasm = out
+
+		case gExt.GeoIP != nil:
+			p.GeoIP = buildMaxMindConfig(gExt.GeoIP)
 		}
 		return p
 	}
@@ -461,6 +488,16 @@ func buildRateLimitFilter(grpcService *envoycorev3.GrpcService, rateLimit *kgate
 	// Set timeout (we expect it always to have a valid value or default due to CRD validation)
 	envoyRateLimit.Timeout = durationpb.New(rateLimit.Timeout.Duration)
 
+	// In shadow mode the filter still calls the rate limit service, but never rejects requests
+	if ptr.Deref(rateLimit.ShadowMode, false) {
+		envoyRateLimit.FilterEnforced = &envoycorev3.RuntimeFractionalPercent{
+			DefaultValue: &envoytypev3.FractionalPercent{
+				Numerator:   0,
+				Denominator: envoytypev3.FractionalPercent_HUNDRED,
+			},
+		}
+	}
+
 	return envoyRateLimit
 }
 
@@ -0,0 +1,212 @@
+package trafficpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	mutation_rulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	geoipv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/geoip/v3"
+	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
+	geoipcommonv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/geoip_providers/common/v3"
+	maxmindv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/geoip_providers/maxmind/v3"
+	"google.golang.org/protobuf/proto"
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	geoIPFilterNamePrefix         = "geoip"
+	geoIPSanitizeFilterNamePrefix = headerMutationFilterName + "/geoip"
+	maxMindProviderName           = "envoy.geoip_providers.maxmind"
+)
+
+var (
+	// The geoip filters run first in the chain, so the lookup results are available to the ACL and
+	// every later filter. The filters removing spoofed geo headers run right before them.
+	geoIPFilterStage         = filters.BeforeStage(filters.FaultStage)
+	geoIPSanitizeFilterStage = filters.RelativeToStage(filters.FaultStage, -2)
+)
+
+// geoIPIR holds the geoip filter of a single policy. The geoip filter has no per-route configuration,
+// so each policy gets its own filter in the chain with the headers of the policy, and routes enable
+// the filter of the policy that applies to them.
+type geoIPIR struct {
+	filterName string
+	filter     *geoipv3.Geoip
+	// sanitize removes the geo headers sent by the client. Envoy only sets a geo header when the lookup
+	// finds a value, so a client could otherwise spoof the headers the ACL rules match on. Like the
+	// geoip filter, it is a filter per policy, so that a route-level policy does not replace the header
+	// removals of a gateway-level policy that still applies to the route.
+	sanitizeFilterName string
+	sanitize           *header_mutationv3.HeaderMutationPerRoute
+}
+
+var _ PolicySubIR = &geoIPIR{}
+
+func (g *geoIPIR) Equals(other PolicySubIR) bool {
+	otherGeoIP, ok := other.(*geoIPIR)
+	if !ok {
+		return false
+	}
+	if g == nil || otherGeoIP == nil {
+		return g == nil && otherGeoIP == nil
+	}
+	return g.filterName == otherGeoIP.filterName &&
+		proto.Equal(g.filter, otherGeoIP.filter) &&
+		g.sanitizeFilterName == otherGeoIP.sanitizeFilterName &&
+		proto.Equal(g.sanitize, otherGeoIP.sanitize)
+}
+
+func (g *geoIPIR) Validate() error {
+	if g == nil || g.filter == nil {
+		return nil
+	}
+	if err := g.filter.ValidateAll(); err != nil {
+		return err
+	}
+	return g.sanitize.ValidateAll()
+}
+
+// constructGeoIP constructs the Geo-IP policy IR from the policy specification.
+func constructGeoIP(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	fetchGatewayExtension FetchGatewayExtensionFunc,
+	out *trafficPolicySpecIr,
+) error {
+	spec := in.Spec.GeoIP
+	if spec == nil {
+		return nil
+	}
+
+	gatewayExtension, err := fetchGatewayExtension(krtctx, spec.ExtensionRef, in.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("geoip: %w", err)
+	}
+	if gatewayExtension.GeoIP == nil {
+		return pluginutils.ErrInvalidExtensionType(kgateway.GatewayExtensionTypeGeoIP)
+	}
+	if err := validateGeoIPHeaders(spec.Headers, gatewayExtension.GeoIP); err != nil {
+		return fmt.Errorf("geoip: %w", err)
+	}
+
+	fieldKeys := &geoipcommonv3.CommonGeoipProviderConfig_GeolocationFieldKeys{
+		Country: headerName(spec.Headers.Country),
+		Region:  headerName(spec.Headers.Region),
+		City:    headerName(spec.Headers.City),
+		Asn:     headerName(spec.Headers.ASN),
+	}
+	provider := proto.Clone(gatewayExtension.GeoIP).(*maxmindv3.MaxMindConfig)
+	provider.CommonProviderConfig = &geoipcommonv3.CommonGeoipProviderConfig{
+		GeoFieldKeys: fieldKeys,
+	}
+	if err := provider.ValidateAll(); err != nil {
+		return fmt.Errorf("geoip: %w", err)
+	}
+
+	sanitize := &header_mutationv3.HeaderMutationPerRoute{
+		Mutations: &header_mutationv3.Mutations{},
+	}
+	for _, h := range []string{fieldKeys.Country, fieldKeys.Region, fieldKeys.City, fieldKeys.Asn} {
+		if h == "" {
+			continue
+		}
+		sanitize.Mutations.RequestMutations = append(sanitize.Mutations.RequestMutations, &mutation_rulesv3.HeaderMutation{
+			Action: &mutation_rulesv3.HeaderMutation_Remove{Remove: h},
+		})
+	}
+
+	out.geoIP = &geoIPIR{
+		filterName: geoIPFilterName(geoIPFilterNamePrefix, in.GetNamespace(), in.GetName()),
+		filter: &geoipv3.Geoip{
+			Provider: &envoycorev3.TypedExtensionConfig{
+				Name:        maxMindProviderName,
+				TypedConfig: utils.MustMessageToAny(provider),
+			},
+		},
+		sanitizeFilterName: geoIPFilterName(geoIPSanitizeFilterNamePrefix, in.GetNamespace(), in.GetName()),
+		sanitize:           sanitize,
+	}
+	return nil
+}
+
+// validateGeoIPHeaders checks that the databases of the extension can provide every requested header.
+func validateGeoIPHeaders(headers kgateway.GeoIPHeaders, dbs *maxmindv3.MaxMindConfig) error {
+	var errs []error
+	if headers.Country != nil && dbs.GetCountryDbPath() == "" && dbs.GetCityDbPath() == "" {
+		errs = append(errs, errors.New("the country header requires a country or city database"))
+	}
+	if headers.Region != nil && dbs.GetCityDbPath() == "" {
+		errs = append(errs, errors.New("the region header requires a city database"))
+	}
+	if headers.City != nil && dbs.GetCityDbPath() == "" {
+		errs = append(errs, errors.New("the city header requires a city database"))
+	}
+	if headers.ASN != nil && dbs.GetAsnDbPath() == "" && dbs.GetIspDbPath() == "" {
+		errs = append(errs, errors.New("the asn header requires an ASN or ISP database"))
+	}
+	return errors.Join(errs...)
+}
+
+// buildMaxMindConfig translates a GeoIP GatewayExtension into the MaxMind provider configuration shared
+// by the filters of every policy that references it. The headers to add are set per policy.
+func buildMaxMindConfig(in *kgateway.GeoIPProvider) *maxmindv3.MaxMindConfig {
+	return &maxmindv3.MaxMindConfig{
+		CityDbPath:    ptr.Deref(in.MaxMind.CityDBPath, ""),
+		CountryDbPath: ptr.Deref(in.MaxMind.CountryDBPath, ""),
+		AsnDbPath:     ptr.Deref(in.MaxMind.ASNDBPath, ""),
+		IspDbPath:     ptr.Deref(in.MaxMind.ISPDBPath, ""),
+	}
+}
+
+func headerName[T ~string](h *T) string {
+	if h == nil {
+		return ""
+	}
+	return string(*h)
+}
+
+func geoIPFilterName(prefix, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", prefix, namespace, name)
+}
+
+func (p *trafficPolicyPluginGwPass) handleGeoIP(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *geoIPIR) {
+	if in == nil {
+		return
+	}
+
+	pCtxTypedFilterConfig.AddTypedConfig(in.filterName, EnableFilterPerRoute())
+	pCtxTypedFilterConfig.AddTypedConfig(in.sanitizeFilterName, in.sanitize)
+
+	if p.geoIPInChain == nil {
+		p.geoIPInChain = make(map[string][]*geoIPIR)
+	}
+	for _, existing := range p.geoIPInChain[fcn] {
+		if existing.filterName == in.filterName {
+			return
+		}
+	}
+	p.geoIPInChain[fcn] = append(p.geoIPInChain[fcn], in)
+}
+
+// addGeoIPFiltersIfNeeded adds a disabled-by-default geoip filter for every Geo-IP policy used in the
+// filter chain, along with the filter removing the geo headers of the policy sent by the client.
+func addGeoIPFiltersIfNeeded(staged []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcn string) []filters.StagedHttpFilter {
+	for _, g := range p.geoIPInChain[fcn] {
+		sanitize := filters.MustNewStagedFilter(g.sanitizeFilterName, &header_mutationv3.HeaderMutation{}, geoIPSanitizeFilterStage)
+		sanitize.Filter.Disabled = true
+		staged = append(staged, sanitize)
+
+		filter := filters.MustNewStagedFilter(g.filterName, g.filter, geoIPFilterStage)
+		filter.Filter.Disabled = true
+		staged = append(staged, filter)
+	}
+	return staged
+}
@@ -0,0 +1,269 @@
+package trafficpolicy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
+	maxmindv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/geoip_providers/maxmind/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/policy"
+)
+
+func TestBuildMaxMindConfig(t *testing.T) {
+	out := buildMaxMindConfig(&kgateway.GeoIPProvider{
+		MaxMind: kgateway.MaxMindDatabases{
+			CountryDBPath: new("/etc/geoip/GeoLite2-Country.mmdb"),
+			ASNDBPath:     new("/etc/geoip/GeoLite2-ASN.mmdb"),
+		},
+	})
+	assert.Equal(t, "/etc/geoip/GeoLite2-Country.mmdb", out.GetCountryDbPath())
+	assert.Equal(t, "/etc/geoip/GeoLite2-ASN.mmdb", out.GetAsnDbPath())
+	assert.Empty(t, out.GetCityDbPath())
+	assert.Empty(t, out.GetIspDbPath())
+}
+
+func TestConstructGeoIP(t *testing.T) {
+	provider := &TrafficPolicyGatewayExtensionIR{
+		Name: "ext-ns/geoip-ext",
+		GeoIP: &maxmindv3.MaxMindConfig{
+			CountryDbPath: "/etc/geoip/GeoLite2-Country.mmdb",
+			AsnDbPath:     "/etc/geoip/GeoLite2-ASN.mmdb",
+		},
+	}
+	fetch := func(_ krt.HandlerContext, _ shared.NamespacedObjectReference, _ string) (*TrafficPolicyGatewayExtensionIR, error) {
+		return provider, nil
+	}
+	makePolicy := func(headers kgateway.GeoIPHeaders) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec: kgateway.TrafficPolicySpec{
+				GeoIP: &kgateway.GeoIPPolicy{
+					ExtensionRef: shared.NamespacedObjectReference{Name: "geoip-ext"},
+					Headers:      headers,
+				},
+			},
+		}
+	}
+
+	policy := makePolicy(kgateway.GeoIPHeaders{
+		Country: new(gwv1.HTTPHeaderName("x-geo-country")),
+		ASN:     new(gwv1.HTTPHeaderName("x-geo-asn")),
+	})
+	out := &trafficPolicySpecIr{}
+	require.NoError(t, constructGeoIP(nil, policy, fetch, out))
+	require.NotNil(t, out.geoIP)
+	assert.Equal(t, "geoip/default/policy", out.geoIP.filterName)
+	require.NoError(t, out.geoIP.Validate())
+
+	cfg := &maxmindv3.MaxMindConfig{}
+	require.NoError(t, out.geoIP.filter.GetProvider().GetTypedConfig().UnmarshalTo(cfg))
+	assert.Equal(t, "/etc/geoip/GeoLite2-Country.mmdb", cfg.GetCountryDbPath())
+	keys := cfg.GetCommonProviderConfig().GetGeoFieldKeys()
+	assert.Equal(t, "x-geo-country", keys.GetCountry())
+	assert.Equal(t, "x-geo-asn", keys.GetAsn())
+	assert.Empty(t, keys.GetCity())
+	// the extension's config is shared between policies and must not be modified
+	assert.Nil(t, provider.GeoIP.GetCommonProviderConfig())
+
+	var removed []string
+	for _, m := range out.geoIP.sanitize.GetMutations().GetRequestMutations() {
+		removed = append(removed, m.GetRemove())
+	}
+	assert.Equal(t, []string{"x-geo-country", "x-geo-asn"}, removed)
+
+	t.Run("rejects headers the databases cannot provide", func(t *testing.T) {
+		policy := makePolicy(kgateway.GeoIPHeaders{
+			City:   new(gwv1.HTTPHeaderName("x-geo-city")),
+			Region: new(gwv1.HTTPHeaderName("x-geo-region")),
+		})
+		err := constructGeoIP(nil, policy, fetch, &trafficPolicySpecIr{})
+		assert.ErrorContains(t, err, "the region header requires a city database")
+		assert.ErrorContains(t, err, "the city header requires a city database")
+	})
+
+	t.Run("rejects other extension types", func(t *testing.T) {
+		provider := &TrafficPolicyGatewayExtensionIR{Name: "ext-ns/extproc"}
+		fetch := func(_ krt.HandlerContext, _ shared.NamespacedObjectReference, _ string) (*TrafficPolicyGatewayExtensionIR, error) {
+			return provider, nil
+		}
+		err := constructGeoIP(nil, policy, fetch, &trafficPolicySpecIr{})
+		assert.ErrorContains(t, err, "GeoIP")
+	})
+}
+
+func TestHttpFiltersGeoIP(t *testing.T) {
+	provider := &TrafficPolicyGatewayExtensionIR{
+		Name:  "ext-ns/geoip-ext",
+		GeoIP: &maxmindv3.MaxMindConfig{CountryDbPath: "/etc/geoip/GeoLite2-Country.mmdb"},
+	}
+	fetch := func(_ krt.HandlerContext, _ shared.NamespacedObjectReference, _ string) (*TrafficPolicyGatewayExtensionIR, error) {
+		return provider, nil
+	}
+	out := &trafficPolicySpecIr{}
+	require.NoError(t, constructGeoIP(nil, &kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec: kgateway.TrafficPolicySpec{
+			GeoIP: &kgateway.GeoIPPolicy{
+				ExtensionRef: shared.NamespacedObjectReference{Name: "geoip-ext"},
+				Headers:      kgateway.GeoIPHeaders{Country: new(gwv1.HTTPHeaderName("x-geo-country"))},
+			},
+		},
+	}, fetch, out))
+
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleGeoIP("test-filter-chain", &typedFilterConfig, out.geoIP)
+	// a second route using the same policy does not add another filter
+	plugin.handleGeoIP("test-filter-chain", &typedFilterConfig, out.geoIP)
+	require.Len(t, plugin.geoIPInChain["test-filter-chain"], 1)
+	assert.NotNil(t, typedFilterConfig.GetTypedConfig("geoip/default/policy"))
+	assert.IsType(t, &header_mutationv3.HeaderMutationPerRoute{}, typedFilterConfig.GetTypedConfig(out.geoIP.sanitizeFilterName))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 2)
+	assert.Equal(t, "envoy.extensions.filters.http.header_mutation/geoip/default/policy", httpFilters[0].Filter.GetName())
+	assert.Equal(t, geoIPSanitizeFilterStage, httpFilters[0].Stage)
+	assert.Equal(t, "geoip/default/policy", httpFilters[1].Filter.GetName())
+	assert.Equal(t, geoIPFilterStage, httpFilters[1].Stage)
+	for _, f := range httpFilters {
+		assert.True(t, f.Filter.GetDisabled())
+	}
+}
+
+func TestConstructHttpACLGeoHeaders(t *testing.T) {
+	acl := &shared.ACLPolicy{
+		DefaultAction: shared.ACLActionAllow,
+		Rules: []shared.ACLRule{
+			{Name: new("embargo"), Countries: []string{"KP"}, Action: shared.ACLActionDeny},
+			{ASNs: []int64{64512}, Action: shared.ACLActionDeny},
+		},
+	}
+	geoIP := &kgateway.GeoIPPolicy{
+		ExtensionRef: shared.NamespacedObjectReference{Name: "geoip-ext"},
+		Headers: kgateway.GeoIPHeaders{
+			Country: new(gwv1.HTTPHeaderName("x-geo-country")),
+			ASN:     new(gwv1.HTTPHeaderName("x-geo-asn")),
+		},
+	}
+
+	out := &trafficPolicySpecIr{}
+	require.NoError(t, constructHttpACL(&kgateway.TrafficPolicy{
+		Spec: kgateway.TrafficPolicySpec{ACL: acl, GeoIP: geoIP},
+	}, out))
+	cfg := &wrapperspb.StringValue{}
+	require.NoError(t, out.httpACL.config.GetFilterConfig().UnmarshalTo(cfg))
+	assert.JSONEq(t, `{
+		"defaultAction": "allow",
+		"geoHeaders": {"country": "x-geo-country", "asn": "x-geo-asn"},
+		"rules": [
+			{"name": "embargo", "countries": ["KP"], "action": "deny"},
+			{"asns": [64512], "action": "deny"}
+		]
+	}`, cfg.GetValue())
+
+	t.Run("omits geo headers when no rule uses them", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		require.NoError(t, constructHttpACL(&kgateway.TrafficPolicy{
+			Spec: kgateway.TrafficPolicySpec{
+				ACL: &shared.ACLPolicy{
+					DefaultAction: shared.ACLActionDeny,
+					Rules:         []shared.ACLRule{{CIDRs: []shared.IPOrCIDR{"10.0.0.0/8"}, Action: shared.ACLActionAllow}},
+				},
+				GeoIP: geoIP,
+			},
+		}, out))
+		cfg := &wrapperspb.StringValue{}
+		require.NoError(t, out.httpACL.config.GetFilterConfig().UnmarshalTo(cfg))
+		var m map[string]any
+		require.NoError(t, json.Unmarshal([]byte(cfg.GetValue()), &m))
+		assert.NotContains(t, m, "geoHeaders")
+	})
+
+	t.Run("requires the geo headers the rules match on", func(t *testing.T) {
+		err := constructHttpACL(&kgateway.TrafficPolicy{
+			Spec: kgateway.TrafficPolicySpec{ACL: acl},
+		}, &trafficPolicySpecIr{})
+		assert.ErrorContains(t, err, "geoIP.headers.country")
+
+		err = constructHttpACL(&kgateway.TrafficPolicy{
+			Spec: kgateway.TrafficPolicySpec{
+				ACL: acl,
+				GeoIP: &kgateway.GeoIPPolicy{
+					Headers: kgateway.GeoIPHeaders{Country: new(gwv1.HTTPHeaderName("x-geo-country"))},
+				},
+			},
+		}, &trafficPolicySpecIr{})
+		assert.ErrorContains(t, err, "geoIP.headers.asn")
+	})
+}
+
+func TestMergeHttpACLKeepsGeoIPFilters(t *testing.T) {
+	provider := &TrafficPolicyGatewayExtensionIR{
+		Name:  "ext-ns/geoip-ext",
+		GeoIP: &maxmindv3.MaxMindConfig{CountryDbPath: "/etc/geoip/GeoLite2-Country.mmdb", AsnDbPath: "/etc/geoip/GeoLite2-ASN.mmdb"},
+	}
+	fetch := func(_ krt.HandlerContext, _ shared.NamespacedObjectReference, _ string) (*TrafficPolicyGatewayExtensionIR, error) {
+		return provider, nil
+	}
+	makePolicy := func(t *testing.T, name string, headers kgateway.GeoIPHeaders, acl *shared.ACLPolicy) *TrafficPolicy {
+		t.Helper()
+		k := &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: kgateway.TrafficPolicySpec{
+				GeoIP: &kgateway.GeoIPPolicy{
+					ExtensionRef: shared.NamespacedObjectReference{Name: "geoip-ext"},
+					Headers:      headers,
+				},
+				ACL: acl,
+			},
+		}
+		tp := &TrafficPolicy{ct: time.Now()}
+		require.NoError(t, constructGeoIP(nil, k, fetch, &tp.spec))
+		require.NoError(t, constructHttpACL(k, &tp.spec))
+		return tp
+	}
+
+	countries := makePolicy(t, "countries", kgateway.GeoIPHeaders{Country: new(gwv1.HTTPHeaderName("x-geo-country"))}, &shared.ACLPolicy{
+		DefaultAction: shared.ACLActionAllow,
+		Rules:         []shared.ACLRule{{Countries: []string{"KP"}, Action: shared.ACLActionDeny}},
+	})
+	asns := makePolicy(t, "asns", kgateway.GeoIPHeaders{ASN: new(gwv1.HTTPHeaderName("x-geo-asn"))}, &shared.ACLPolicy{
+		DefaultAction: shared.ACLActionAllow,
+		Rules:         []shared.ACLRule{{ASNs: []int64{64512}, Action: shared.ACLActionDeny}},
+	})
+	require.Len(t, countries.spec.httpACL.geoIP, 1)
+
+	p1 := &TrafficPolicy{ct: time.Now()}
+	mergeTrafficPolicies(p1, countries, &ir.AttachedPolicyRef{Name: "countries"}, nil, policy.MergeOptions{Strategy: policy.AugmentedShallowMerge}, ir.MergeOrigins{}, "")
+	mergeTrafficPolicies(p1, asns, &ir.AttachedPolicyRef{Name: "asns"}, nil, policy.MergeOptions{Strategy: policy.AugmentedShallowMerge, SameHierarchy: true}, ir.MergeOrigins{}, "")
+
+	// the merged policy only has the geoIP of the first policy, but the merged ACL matches on the
+	// headers of both, so the route enables the geoip and header removal filters of both
+	assert.Equal(t, countries.spec.geoIP, p1.spec.geoIP)
+	require.Len(t, p1.spec.httpACL.geoIP, 2)
+
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleHttpACL("test-filter-chain", &typedFilterConfig, p1.spec.httpACL)
+	plugin.handleGeoIP("test-filter-chain", &typedFilterConfig, p1.spec.geoIP)
+	require.Len(t, plugin.geoIPInChain["test-filter-chain"], 2)
+	for _, name := range []string{"geoip/default/countries", "geoip/default/asns"} {
+		assert.NotNil(t, typedFilterConfig.GetTypedConfig(name))
+	}
+	assert.NotNil(t, typedFilterConfig.GetTypedConfig(asns.spec.geoIP.sanitizeFilterName))
+
+	// merging does not modify the policies it was merged from
+	assert.Len(t, countries.spec.httpACL.geoIP, 1)
+}
@@ -4,18 +4,29 @@ import (
 	"errors"
 	"fmt"
 
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	ratev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	exprv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/rate_limit_descriptors/expr/v3"
+	metadatav3 "github.com/envoyproxy/go-control-plane/envoy/type/metadata/v3"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/utils/ptr"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
 )
 
+const (
+	rateLimitExprDescriptorName = "envoy.rate_limit_descriptors.expr"
+	jwtAuthnMetadataNamespace   = "envoy.filters.http.jwt_authn"
+)
+
 // globalRateLimitIR represents the intermediate representation for a global rate limit policy.
 type globalRateLimitIR struct {
 	provider         *TrafficPolicyGatewayExtensionIR
@@ -155,6 +166,77 @@ func createRateLimitActions(descriptors []kgateway.RateLimitDescriptor) ([]*envo
 						DescriptorKey: "path",
 					},
 				}
+			case kgateway.RateLimitDescriptorEntryTypeQueryParameter:
+				if entry.QueryParameter == nil {
+					return nil, fmt.Errorf("query parameter entry requires QueryParameter field to be set")
+				}
+				action.ActionSpecifier = &envoyroutev3.RateLimit_Action_QueryParameters_{
+					QueryParameters: &envoyroutev3.RateLimit_Action_QueryParameters{
+						QueryParameterName: *entry.QueryParameter,
+						DescriptorKey:      *entry.QueryParameter, // Use query parameter name as key
+					},
+				}
+			case kgateway.RateLimitDescriptorEntryTypeMaskedRemoteAddress:
+				maskedRemoteAddress := &envoyroutev3.RateLimit_Action_MaskedRemoteAddress{}
+				if entry.MaskedRemoteAddress != nil {
+					if entry.MaskedRemoteAddress.V4PrefixLength != nil {
+						maskedRemoteAddress.V4PrefixMaskLen = wrapperspb.UInt32(uint32(max(*entry.MaskedRemoteAddress.V4PrefixLength, 0))) //#nosec G115 - CRD validates Minimum=0
+					}
+					if entry.MaskedRemoteAddress.V6PrefixLength != nil {
+						maskedRemoteAddress.V6PrefixMaskLen = wrapperspb.UInt32(uint32(max(*entry.MaskedRemoteAddress.V6PrefixLength, 0))) //#nosec G115 - CRD validates Minimum=0
+					}
+				}
+				action.ActionSpecifier = &envoyroutev3.RateLimit_Action_MaskedRemoteAddress_{
+					MaskedRemoteAddress: maskedRemoteAddress,
+				}
+			case kgateway.RateLimitDescriptorEntryTypeMetadata:
+				if entry.Metadata == nil {
+					return nil, fmt.Errorf("metadata entry requires Metadata field to be set")
+				}
+				action.ActionSpecifier = &envoyroutev3.RateLimit_Action_Metadata{
+					Metadata: dynamicMetadataAction(
+						entry.Metadata.Key,
+						entry.Metadata.Namespace,
+						entry.Metadata.Path,
+						entry.Metadata.DefaultValue,
+					),
+				}
+			case kgateway.RateLimitDescriptorEntryTypeJWTClaim:
+				if entry.JWTClaim == nil {
+					return nil, fmt.Errorf("JWT claim entry requires JWTClaim field to be set")
+				}
+				// The JWT filter writes the payload of verified tokens to its dynamic metadata
+				path := append([]string{PayloadInMetadata, entry.JWTClaim.Name}, entry.JWTClaim.Path...)
+				action.ActionSpecifier = &envoyroutev3.RateLimit_Action_Metadata{
+					Metadata: dynamicMetadataAction(
+						entry.JWTClaim.Name,
+						jwtAuthnMetadataNamespace,
+						path,
+						entry.JWTClaim.DefaultValue,
+					),
+				}
+			case kgateway.RateLimitDescriptorEntryTypeMethod:
+				action.ActionSpecifier = &envoyroutev3.RateLimit_Action_RequestHeaders_{
+					RequestHeaders: &envoyroutev3.RateLimit_Action_RequestHeaders{
+						HeaderName:    ":method",
+						DescriptorKey: "method",
+					},
+				}
+			case kgateway.RateLimitDescriptorEntryTypeExpression:
+				if entry.Expression == nil {
+					return nil, fmt.Errorf("expression entry requires Expression field to be set")
+				}
+				action.ActionSpecifier = &envoyroutev3.RateLimit_Action_Extension{
+					Extension: &envoycorev3.TypedExtensionConfig{
+						Name: rateLimitExprDescriptorName,
+						TypedConfig: utils.MustMessageToAny(&exprv3.Descriptor{
+							DescriptorKey: entry.Expression.Key,
+							ExprSpecifier: &exprv3.Descriptor_Text{
+								Text: entry.Expression.Text,
+							},
+						}),
+					},
+				}
 			default:
 				return nil, fmt.Errorf("unsupported entry type: %s", entry.Type)
 			}
@@ -166,7 +248,8 @@ func createRateLimitActions(descriptors []kgateway.RateLimitDescriptor) ([]*envo
 		if len(actions) > 0 {
 			// In Envoy, a single RateLimit includes multiple Actions that together form a descriptor
 			rateLimit := &envoyroutev3.RateLimit{
-				Actions: actions,
+				Actions:    actions,
+				HitsAddend: convertHitsAddend(descriptor.HitsAddend),
 			}
 
 			// The final result is a slice of complete RateLimit objects
@@ -177,6 +260,40 @@ func createRateLimitActions(descriptors []kgateway.RateLimitDescriptor) ([]*envo
 	return result, nil
 }
 
+// dynamicMetadataAction creates a rate limit action that reads the descriptor value from the
+// dynamic metadata at the given path in the namespace.
+func dynamicMetadataAction(key, namespace string, path []string, defaultValue *string) *envoyroutev3.RateLimit_Action_MetaData {
+	segments := make([]*metadatav3.MetadataKey_PathSegment, 0, len(path))
+	for _, p := range path {
+		segments = append(segments, &metadatav3.MetadataKey_PathSegment{
+			Segment: &metadatav3.MetadataKey_PathSegment_Key{Key: p},
+		})
+	}
+	return &envoyroutev3.RateLimit_Action_MetaData{
+		DescriptorKey: key,
+		MetadataKey: &metadatav3.MetadataKey{
+			Key:  namespace,
+			Path: segments,
+		},
+		DefaultValue: ptr.Deref(defaultValue, ""),
+		Source:       envoyroutev3.RateLimit_Action_MetaData_DYNAMIC,
+	}
+}
+
+func convertHitsAddend(in *kgateway.RateLimitHitsAddend) *envoyroutev3.RateLimit_HitsAddend {
+	if in == nil {
+		return nil
+	}
+	out := &envoyroutev3.RateLimit_HitsAddend{}
+	if in.Number != nil {
+		out.Number = wrapperspb.UInt64(uint64(max(*in.Number, 0))) //#nosec G115 - CRD validates Minimum=0
+	}
+	if in.Format != nil {
+		out.Format = *in.Format
+	}
+	return out
+}
+
 func getRateLimitFilterName(name string) string {
 	if name == "" {
 		return rateLimitFilterNamePrefix
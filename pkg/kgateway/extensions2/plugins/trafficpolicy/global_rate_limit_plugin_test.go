@@ -10,6 +10,7 @@ import (
 	envoyratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	ratev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	exprv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/rate_limit_descriptors/expr/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -241,6 +242,189 @@ func TestCreateRateLimitActions(t *testing.T) {
 				assert.Equal(t, "path", requestHeaders.DescriptorKey)
 			},
 		},
+		{
+			name: "with query parameter descriptor",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type:           kgateway.RateLimitDescriptorEntryTypeQueryParameter,
+							QueryParameter: new("api_key"),
+						},
+					},
+				},
+			},
+			validateResult: func(t *testing.T, rateLimits []*envoyroutev3.RateLimit) {
+				require.Len(t, rateLimits, 1)
+				require.Len(t, rateLimits[0].GetActions(), 1)
+				queryParameters := rateLimits[0].GetActions()[0].GetQueryParameters()
+				require.NotNil(t, queryParameters)
+				assert.Equal(t, "api_key", queryParameters.QueryParameterName)
+				assert.Equal(t, "api_key", queryParameters.DescriptorKey)
+			},
+		},
+		{
+			name: "with masked remote address descriptor",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeMaskedRemoteAddress,
+							MaskedRemoteAddress: &kgateway.RateLimitDescriptorEntryMaskedRemoteAddress{
+								V4PrefixLength: new(int32(24)),
+							},
+						},
+					},
+				},
+			},
+			validateResult: func(t *testing.T, rateLimits []*envoyroutev3.RateLimit) {
+				require.Len(t, rateLimits, 1)
+				require.Len(t, rateLimits[0].GetActions(), 1)
+				masked := rateLimits[0].GetActions()[0].GetMaskedRemoteAddress()
+				require.NotNil(t, masked)
+				assert.Equal(t, uint32(24), masked.GetV4PrefixMaskLen().GetValue())
+				assert.Nil(t, masked.GetV6PrefixMaskLen())
+			},
+		},
+		{
+			name: "with metadata descriptor",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeMetadata,
+							Metadata: &kgateway.RateLimitDescriptorEntryMetadata{
+								Key:          "plan",
+								Namespace:    "envoy.filters.http.ext_authz",
+								Path:         []string{"account", "plan"},
+								DefaultValue: new("free"),
+							},
+						},
+					},
+				},
+			},
+			validateResult: func(t *testing.T, rateLimits []*envoyroutev3.RateLimit) {
+				require.Len(t, rateLimits, 1)
+				require.Len(t, rateLimits[0].GetActions(), 1)
+				metadata := rateLimits[0].GetActions()[0].GetMetadata()
+				require.NotNil(t, metadata)
+				assert.Equal(t, "plan", metadata.DescriptorKey)
+				assert.Equal(t, "free", metadata.DefaultValue)
+				assert.Equal(t, envoyroutev3.RateLimit_Action_MetaData_DYNAMIC, metadata.Source)
+				assert.Equal(t, "envoy.filters.http.ext_authz", metadata.GetMetadataKey().GetKey())
+				require.Len(t, metadata.GetMetadataKey().GetPath(), 2)
+				assert.Equal(t, "account", metadata.GetMetadataKey().GetPath()[0].GetKey())
+				assert.Equal(t, "plan", metadata.GetMetadataKey().GetPath()[1].GetKey())
+			},
+		},
+		{
+			name: "with JWT claim descriptor",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeJWTClaim,
+							JWTClaim: &kgateway.RateLimitDescriptorEntryJWTClaim{
+								Name: "org",
+								Path: []string{"tenant_id"},
+							},
+						},
+					},
+				},
+			},
+			validateResult: func(t *testing.T, rateLimits []*envoyroutev3.RateLimit) {
+				require.Len(t, rateLimits, 1)
+				require.Len(t, rateLimits[0].GetActions(), 1)
+				metadata := rateLimits[0].GetActions()[0].GetMetadata()
+				require.NotNil(t, metadata)
+				assert.Equal(t, "org", metadata.DescriptorKey)
+				assert.Empty(t, metadata.DefaultValue)
+				assert.Equal(t, "envoy.filters.http.jwt_authn", metadata.GetMetadataKey().GetKey())
+				var path []string
+				for _, segment := range metadata.GetMetadataKey().GetPath() {
+					path = append(path, segment.GetKey())
+				}
+				assert.Equal(t, []string{PayloadInMetadata, "org", "tenant_id"}, path)
+			},
+		},
+		{
+			name: "with method descriptor",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeMethod,
+						},
+					},
+				},
+			},
+			validateResult: func(t *testing.T, rateLimits []*envoyroutev3.RateLimit) {
+				require.Len(t, rateLimits, 1)
+				require.Len(t, rateLimits[0].GetActions(), 1)
+				requestHeaders := rateLimits[0].GetActions()[0].GetRequestHeaders()
+				require.NotNil(t, requestHeaders)
+				assert.Equal(t, ":method", requestHeaders.HeaderName)
+				assert.Equal(t, "method", requestHeaders.DescriptorKey)
+			},
+		},
+		{
+			name: "with expression descriptor",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeExpression,
+							Expression: &kgateway.RateLimitDescriptorEntryExpression{
+								Key:  "scheme",
+								Text: "request.scheme",
+							},
+						},
+					},
+				},
+			},
+			validateResult: func(t *testing.T, rateLimits []*envoyroutev3.RateLimit) {
+				require.Len(t, rateLimits, 1)
+				require.Len(t, rateLimits[0].GetActions(), 1)
+				extension := rateLimits[0].GetActions()[0].GetExtension()
+				require.NotNil(t, extension)
+				assert.Equal(t, "envoy.rate_limit_descriptors.expr", extension.GetName())
+				descriptor := &exprv3.Descriptor{}
+				require.NoError(t, extension.GetTypedConfig().UnmarshalTo(descriptor))
+				assert.Equal(t, "scheme", descriptor.GetDescriptorKey())
+				assert.Equal(t, "request.scheme", descriptor.GetText())
+			},
+		},
+		{
+			name: "with hits addend",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypePath,
+						},
+					},
+					HitsAddend: &kgateway.RateLimitHitsAddend{
+						Format: new("%REQ(x-request-cost)%"),
+					},
+				},
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeRemoteAddress,
+						},
+					},
+					HitsAddend: &kgateway.RateLimitHitsAddend{
+						Number: new(int64(5)),
+					},
+				},
+			},
+			validateResult: func(t *testing.T, rateLimits []*envoyroutev3.RateLimit) {
+				require.Len(t, rateLimits, 2)
+				assert.Equal(t, "%REQ(x-request-cost)%", rateLimits[0].GetHitsAddend().GetFormat())
+				assert.Nil(t, rateLimits[0].GetHitsAddend().GetNumber())
+				assert.Equal(t, uint64(5), rateLimits[1].GetHitsAddend().GetNumber().GetValue())
+			},
+		},
 		{
 			name: "with multiple descriptors",
 			descriptors: []kgateway.RateLimitDescriptor{
@@ -344,6 +528,19 @@ func TestCreateRateLimitActions(t *testing.T) {
 			},
 			expectedError: "header entry requires Header field to be set",
 		},
+		{
+			name: "with missing JWT claim",
+			descriptors: []kgateway.RateLimitDescriptor{
+				{
+					Entries: []kgateway.RateLimitDescriptorEntry{
+						{
+							Type: kgateway.RateLimitDescriptorEntryTypeJWTClaim,
+						},
+					},
+				},
+			},
+			expectedError: "JWT claim entry requires JWTClaim field to be set",
+		},
 		{
 			name: "with unsupported entry type",
 			descriptors: []kgateway.RateLimitDescriptor{
@@ -595,3 +792,19 @@ func TestToRateLimitFilterConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRateLimitFilterShadowMode(t *testing.T) {
+	grpcService := &envoycorev3.GrpcService{
+		TargetSpecifier: &envoycorev3.GrpcService_EnvoyGrpc_{
+			EnvoyGrpc: &envoycorev3.GrpcService_EnvoyGrpc{ClusterName: "ratelimit"},
+		},
+	}
+
+	rl := buildRateLimitFilter(grpcService, &kgateway.RateLimitProvider{Domain: "test-domain"})
+	assert.Nil(t, rl.GetFilterEnforced())
+
+	rl = buildRateLimitFilter(grpcService, &kgateway.RateLimitProvider{Domain: "test-domain", ShadowMode: new(true)})
+	require.NotNil(t, rl.GetFilterEnforced())
+	assert.Equal(t, uint32(0), rl.GetFilterEnforced().GetDefaultValue().GetNumerator())
+	assert.NoError(t, rl.ValidateAll())
+}
@@ -0,0 +1,214 @@
+package trafficpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	transcoderv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_json_transcoder/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const grpcJSONTranscoderFilterNamePrefix = "grpc_json_transcoder"
+
+// grpcJSONTranscoderFilterStage runs the transcoder before authentication, so that the auth filters
+// and the filters after them apply the policies of the gRPC route the request is routed to after
+// transcoding.
+var grpcJSONTranscoderFilterStage = filters.BeforeStage(filters.AuthNStage)
+
+// grpcJSONTranscoderIR holds the gRPC-JSON transcoder filter of a single policy. Each policy gets its own
+// filter in the chain, so that its descriptor set is sent once in the listener rather than with every
+// route, and routes enable the filter of the policy that applies to them.
+type grpcJSONTranscoderIR struct {
+	filterName string
+	filter     *transcoderv3.GrpcJsonTranscoder
+}
+
+var _ PolicySubIR = &grpcJSONTranscoderIR{}
+
+func (g *grpcJSONTranscoderIR) Equals(other PolicySubIR) bool {
+	otherTranscoder, ok := other.(*grpcJSONTranscoderIR)
+	if !ok {
+		return false
+	}
+	if g == nil || otherTranscoder == nil {
+		return g == nil && otherTranscoder == nil
+	}
+	return g.filterName == otherTranscoder.filterName &&
+		proto.Equal(g.filter, otherTranscoder.filter)
+}
+
+func (g *grpcJSONTranscoderIR) Validate() error {
+	if g == nil || g.filter == nil {
+		return nil
+	}
+	return g.filter.ValidateAll()
+}
+
+// constructGRPCJSONTranscoder constructs the gRPC-JSON transcoder policy IR from the policy specification.
+func constructGRPCJSONTranscoder(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	configMaps krt.Collection[*corev1.ConfigMap],
+	secrets *krtcollections.SecretIndex,
+	out *trafficPolicySpecIr,
+) error {
+	spec := in.Spec.GRPCJSONTranscoder
+	if spec == nil {
+		return nil
+	}
+
+	descriptorSet, err := fetchDescriptorSet(krtctx, spec.DescriptorSet, in.GetNamespace(), configMaps, secrets)
+	if err != nil {
+		return fmt.Errorf("grpc json transcoder: %w", err)
+	}
+	if err := validateDescriptorSet(descriptorSet, spec.Services); err != nil {
+		return fmt.Errorf("grpc json transcoder: %w", err)
+	}
+
+	filter := &transcoderv3.GrpcJsonTranscoder{
+		DescriptorSet: &transcoderv3.GrpcJsonTranscoder_ProtoDescriptorBin{
+			ProtoDescriptorBin: descriptorSet,
+		},
+		Services:               spec.Services,
+		IgnoredQueryParameters: spec.IgnoredQueryParameters,
+		AutoMapping:            ptr.Deref(spec.AutoMapping, false),
+		ConvertGrpcStatus:      ptr.Deref(spec.ConvertGRPCStatus, false),
+	}
+	if opts := spec.PrintOptions; opts != nil {
+		filter.PrintOptions = &transcoderv3.GrpcJsonTranscoder_PrintOptions{
+			AddWhitespace:              ptr.Deref(opts.AddWhitespace, false),
+			AlwaysPrintPrimitiveFields: ptr.Deref(opts.AlwaysPrintPrimitiveFields, false),
+			AlwaysPrintEnumsAsInts:     ptr.Deref(opts.AlwaysPrintEnumsAsInts, false),
+			PreserveProtoFieldNames:    ptr.Deref(opts.PreserveProtoFieldNames, false),
+		}
+	}
+	if spec.UnknownQueryParameters != nil {
+		switch *spec.UnknownQueryParameters {
+		case kgateway.GRPCJSONUnknownQueryParametersIgnore:
+			filter.IgnoreUnknownQueryParameters = true
+		case kgateway.GRPCJSONUnknownQueryParametersReject:
+			filter.RequestValidationOptions = &transcoderv3.GrpcJsonTranscoder_RequestValidationOptions{
+				RejectUnknownQueryParameters: true,
+			}
+		}
+	}
+
+	out.grpcJSONTranscoder = &grpcJSONTranscoderIR{
+		filterName: fmt.Sprintf("%s/%s/%s", grpcJSONTranscoderFilterNamePrefix, in.GetNamespace(), in.GetName()),
+		filter:     filter,
+	}
+	return nil
+}
+
+func fetchDescriptorSet(
+	krtctx krt.HandlerContext,
+	source kgateway.GRPCDescriptorSetSource,
+	namespace string,
+	configMaps krt.Collection[*corev1.ConfigMap],
+	secrets *krtcollections.SecretIndex,
+) ([]byte, error) {
+	switch {
+	case source.ConfigMap != nil:
+		ref := source.ConfigMap
+		cm, err := GetConfigMap(krtctx, configMaps, string(ref.Name), namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find configmap %s: %w", ref.Name, err)
+		}
+		data, ok := cm.BinaryData[ref.Key]
+		if !ok || len(data) == 0 {
+			return nil, fmt.Errorf("configmap %s binaryData does not contain key '%s'", ref.Name, ref.Key)
+		}
+		return data, nil
+
+	case source.Secret != nil:
+		ref := source.Secret
+		if secrets == nil {
+			return nil, errors.New("secrets collection not available")
+		}
+		secret, err := secrets.GetSecret(krtctx, krtcollections.From{
+			GroupKind: wellknown.TrafficPolicyGVK.GroupKind(),
+			Namespace: namespace,
+		}, gwv1.SecretObjectReference{Name: ref.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find secret %s: %w", ref.Name, err)
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok || len(data) == 0 {
+			return nil, fmt.Errorf("secret %s does not contain key '%s'", ref.Name, ref.Key)
+		}
+		return data, nil
+	}
+	return nil, errors.New("one of configMap or secret must be set for the descriptor set")
+}
+
+// validateDescriptorSet checks that the descriptor set is complete and defines all the given services,
+// which Envoy would otherwise reject when loading the filter.
+func validateDescriptorSet(data []byte, services []string) error {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return fmt.Errorf("invalid descriptor set: %w", err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return fmt.Errorf("invalid descriptor set: %w", err)
+	}
+
+	var missing []string
+	for _, service := range services {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+		if err != nil {
+			missing = append(missing, service)
+			continue
+		}
+		if _, ok := desc.(protoreflect.ServiceDescriptor); !ok {
+			missing = append(missing, service)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("services not found in the descriptor set: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func (p *trafficPolicyPluginGwPass) handleGRPCJSONTranscoder(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *grpcJSONTranscoderIR) {
+	if in == nil {
+		return
+	}
+
+	pCtxTypedFilterConfig.AddTypedConfig(in.filterName, EnableFilterPerRoute())
+
+	if p.grpcJSONTranscoderInChain == nil {
+		p.grpcJSONTranscoderInChain = make(map[string][]*grpcJSONTranscoderIR)
+	}
+	for _, existing := range p.grpcJSONTranscoderInChain[fcn] {
+		if existing.filterName == in.filterName {
+			return
+		}
+	}
+	p.grpcJSONTranscoderInChain[fcn] = append(p.grpcJSONTranscoderInChain[fcn], in)
+}
+
+// addGRPCJSONTranscoderFiltersIfNeeded adds a disabled-by-default filter for every gRPC-JSON transcoder
+// policy used in the filter chain.
+func addGRPCJSONTranscoderFiltersIfNeeded(staged []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcn string) []filters.StagedHttpFilter {
+	for _, g := range p.grpcJSONTranscoderInChain[fcn] {
+		filter := filters.MustNewStagedFilter(g.filterName, g.filter, grpcJSONTranscoderFilterStage)
+		filter.Filter.Disabled = true
+		staged = append(staged, filter)
+	}
+	return staged
+}
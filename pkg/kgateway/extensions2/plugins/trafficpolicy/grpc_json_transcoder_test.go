@@ -0,0 +1,182 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func echoDescriptorSet(t *testing.T) []byte {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{
+			Name:    new("echo.proto"),
+			Package: new("echo"),
+			Syntax:  new("proto3"),
+			MessageType: []*descriptorpb.DescriptorProto{{
+				Name: new("EchoMessage"),
+				Field: []*descriptorpb.FieldDescriptorProto{{
+					Name:     new("text"),
+					JsonName: new("text"),
+					Number:   new(int32(1)),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				}},
+			}},
+			Service: []*descriptorpb.ServiceDescriptorProto{{
+				Name: new("Echo"),
+				Method: []*descriptorpb.MethodDescriptorProto{{
+					Name:       new("Echo"),
+					InputType:  new(".echo.EchoMessage"),
+					OutputType: new(".echo.EchoMessage"),
+				}},
+			}},
+		}},
+	}
+	data, err := proto.Marshal(set)
+	require.NoError(t, err)
+	return data
+}
+
+func TestConstructGRPCJSONTranscoder(t *testing.T) {
+	configMaps := krt.NewStaticCollection(nil, []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "descriptors", Namespace: "default"},
+			BinaryData: map[string][]byte{
+				"echo.pb":    echoDescriptorSet(t),
+				"invalid.pb": []byte("not a descriptor set"),
+			},
+		},
+	})
+	policy := func(spec *kgateway.GRPCJSONTranscoderPolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec:       kgateway.TrafficPolicySpec{GRPCJSONTranscoder: spec},
+		}
+	}
+	fromConfigMap := func(key string) kgateway.GRPCDescriptorSetSource {
+		return kgateway.GRPCDescriptorSetSource{
+			ConfigMap: &kgateway.GRPCDescriptorSetRef{Name: "descriptors", Key: key},
+		}
+	}
+
+	t.Run("full config", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructGRPCJSONTranscoder(krt.TestingDummyContext{}, policy(&kgateway.GRPCJSONTranscoderPolicy{
+			DescriptorSet: fromConfigMap("echo.pb"),
+			Services:      []string{"echo.Echo"},
+			PrintOptions: &kgateway.GRPCJSONPrintOptions{
+				AddWhitespace:           new(true),
+				PreserveProtoFieldNames: new(true),
+			},
+			UnknownQueryParameters: new(kgateway.GRPCJSONUnknownQueryParametersReject),
+			IgnoredQueryParameters: []string{"api_key"},
+			AutoMapping:            new(true),
+			ConvertGRPCStatus:      new(true),
+		}), configMaps, nil, out)
+		require.NoError(t, err)
+		require.NotNil(t, out.grpcJSONTranscoder)
+		require.NoError(t, out.grpcJSONTranscoder.Validate())
+		assert.Equal(t, "grpc_json_transcoder/default/policy", out.grpcJSONTranscoder.filterName)
+
+		filter := out.grpcJSONTranscoder.filter
+		assert.Equal(t, echoDescriptorSet(t), filter.GetProtoDescriptorBin())
+		assert.Equal(t, []string{"echo.Echo"}, filter.GetServices())
+		assert.True(t, filter.GetPrintOptions().GetAddWhitespace())
+		assert.True(t, filter.GetPrintOptions().GetPreserveProtoFieldNames())
+		assert.False(t, filter.GetPrintOptions().GetAlwaysPrintEnumsAsInts())
+		assert.True(t, filter.GetRequestValidationOptions().GetRejectUnknownQueryParameters())
+		assert.False(t, filter.GetIgnoreUnknownQueryParameters())
+		assert.Equal(t, []string{"api_key"}, filter.GetIgnoredQueryParameters())
+		assert.True(t, filter.GetAutoMapping())
+		assert.True(t, filter.GetConvertGrpcStatus())
+	})
+
+	t.Run("ignore unknown query parameters", func(t *testing.T) {
+		out := &trafficPolicySpecIr{}
+		err := constructGRPCJSONTranscoder(krt.TestingDummyContext{}, policy(&kgateway.GRPCJSONTranscoderPolicy{
+			DescriptorSet:          fromConfigMap("echo.pb"),
+			Services:               []string{"echo.Echo"},
+			UnknownQueryParameters: new(kgateway.GRPCJSONUnknownQueryParametersIgnore),
+		}), configMaps, nil, out)
+		require.NoError(t, err)
+		assert.True(t, out.grpcJSONTranscoder.filter.GetIgnoreUnknownQueryParameters())
+		assert.Nil(t, out.grpcJSONTranscoder.filter.GetRequestValidationOptions())
+	})
+
+	tests := []struct {
+		name     string
+		spec     *kgateway.GRPCJSONTranscoderPolicy
+		expected string
+	}{
+		{
+			name: "missing services",
+			spec: &kgateway.GRPCJSONTranscoderPolicy{
+				DescriptorSet: fromConfigMap("echo.pb"),
+				Services:      []string{"echo.Echo", "echo.Missing", "echo.EchoMessage"},
+			},
+			expected: "grpc json transcoder: services not found in the descriptor set: echo.Missing, echo.EchoMessage",
+		},
+		{
+			name: "invalid descriptor set",
+			spec: &kgateway.GRPCJSONTranscoderPolicy{
+				DescriptorSet: fromConfigMap("invalid.pb"),
+				Services:      []string{"echo.Echo"},
+			},
+			expected: "grpc json transcoder: invalid descriptor set",
+		},
+		{
+			name: "missing key",
+			spec: &kgateway.GRPCJSONTranscoderPolicy{
+				DescriptorSet: fromConfigMap("other.pb"),
+				Services:      []string{"echo.Echo"},
+			},
+			expected: "grpc json transcoder: configmap descriptors binaryData does not contain key 'other.pb'",
+		},
+		{
+			name: "missing configmap",
+			spec: &kgateway.GRPCJSONTranscoderPolicy{
+				DescriptorSet: kgateway.GRPCDescriptorSetSource{
+					ConfigMap: &kgateway.GRPCDescriptorSetRef{Name: "other", Key: "echo.pb"},
+				},
+				Services: []string{"echo.Echo"},
+			},
+			expected: "grpc json transcoder: failed to find configmap other",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructGRPCJSONTranscoder(krt.TestingDummyContext{}, policy(tc.spec), configMaps, nil, out)
+			require.ErrorContains(t, err, tc.expected)
+			assert.Nil(t, out.grpcJSONTranscoder)
+		})
+	}
+}
+
+func TestHttpFiltersGRPCJSONTranscoder(t *testing.T) {
+	transcoder := &grpcJSONTranscoderIR{filterName: "grpc_json_transcoder/default/policy"}
+
+	plugin := &trafficPolicyPluginGwPass{}
+	for _, route := range []string{"route-a", "route-b"} {
+		typedFilterConfig := ir.TypedFilterConfigMap{}
+		plugin.handleGRPCJSONTranscoder("test-filter-chain", &typedFilterConfig, transcoder)
+		assert.Equal(t, EnableFilterPerRoute(), typedFilterConfig.GetTypedConfig(transcoder.filterName), route)
+	}
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, transcoder.filterName, httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+	assert.Equal(t, grpcJSONTranscoderFilterStage, httpFilters[0].Stage)
+}
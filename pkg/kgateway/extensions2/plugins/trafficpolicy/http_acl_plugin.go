@@ -2,8 +2,10 @@ package trafficpolicy
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 
 	extensiondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
@@ -24,8 +26,24 @@ const (
 	httpACLDefaultListenerJSON = `{"defaultAction":"allow"}`
 )
 
+// httpACLConfig is the configuration of the http-acl dynamic module: the ACL policy, plus the names of
+// the request headers the geoip filter adds the client country and ASN to.
+type httpACLConfig struct {
+	shared.ACLPolicy
+	GeoHeaders *httpACLGeoHeaders `json:"geoHeaders,omitempty"`
+}
+
+type httpACLGeoHeaders struct {
+	Country string `json:"country,omitempty"`
+	ASN     string `json:"asn,omitempty"`
+}
+
 type httpACLIR struct {
 	config *dynamicmodulesv3.DynamicModuleFilterPerRoute
+	// geoIP holds the geoip filters of the policies whose country and ASN rules the ACL contains.
+	// A merged policy may take its geoIP from another policy than its ACL rules, so the ACL keeps
+	// the filters that add, and remove spoofed values of, the headers its rules match on.
+	geoIP []*geoIPIR
 }
 
 var _ PolicySubIR = &httpACLIR{}
@@ -41,7 +59,8 @@ func (h *httpACLIR) Equals(other PolicySubIR) bool {
 	if h == nil || otherACL == nil {
 		return false
 	}
-	return proto.Equal(h.config, otherACL.config)
+	return proto.Equal(h.config, otherACL.config) &&
+		slices.EqualFunc(h.geoIP, otherACL.geoIP, func(a, b *geoIPIR) bool { return a.Equals(b) })
 }
 
 func (h *httpACLIR) Validate() error {
@@ -79,6 +98,37 @@ func validateACLCIDRs(acl *shared.ACLPolicy) error {
 	return nil
 }
 
+// aclGeoHeaders returns the geo headers the ACL rules of the policy match on, taken from the geoIP
+// field of the same policy. It fails if a rule matches on a country or ASN that is not looked up.
+func aclGeoHeaders(acl *shared.ACLPolicy, geoIP *kgateway.GeoIPPolicy) (*httpACLGeoHeaders, error) {
+	var usesCountries, usesASNs bool
+	for _, rule := range acl.Rules {
+		usesCountries = usesCountries || len(rule.Countries) > 0
+		usesASNs = usesASNs || len(rule.ASNs) > 0
+	}
+	if !usesCountries && !usesASNs {
+		return nil, nil
+	}
+	var headers kgateway.GeoIPHeaders
+	if geoIP != nil {
+		headers = geoIP.Headers
+	}
+	out := &httpACLGeoHeaders{}
+	if usesCountries {
+		if headers.Country == nil {
+			return nil, errors.New("acl: rules matching countries require geoIP.headers.country to be set")
+		}
+		out.Country = string(*headers.Country)
+	}
+	if usesASNs {
+		if headers.ASN == nil {
+			return nil, errors.New("acl: rules matching ASNs require geoIP.headers.asn to be set")
+		}
+		out.ASN = string(*headers.ASN)
+	}
+	return out, nil
+}
+
 // constructHttpACL constructs the HTTP ACL policy IR from the traffic policy spec.
 func constructHttpACL(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) error {
 	if in.Spec.ACL == nil {
@@ -87,7 +137,14 @@ func constructHttpACL(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) erro
 	if err := validateACLCIDRs(in.Spec.ACL); err != nil {
 		return err
 	}
-	aclJSON, err := json.Marshal(in.Spec.ACL)
+	geoHeaders, err := aclGeoHeaders(in.Spec.ACL, in.Spec.GeoIP)
+	if err != nil {
+		return err
+	}
+	aclJSON, err := json.Marshal(httpACLConfig{
+		ACLPolicy:  *in.Spec.ACL,
+		GeoHeaders: geoHeaders,
+	})
 	if err != nil {
 		return err
 	}
@@ -97,7 +154,12 @@ func constructHttpACL(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) erro
 	if err != nil {
 		return err
 	}
+	var geoIP []*geoIPIR
+	if geoHeaders != nil && out.geoIP != nil {
+		geoIP = []*geoIPIR{out.geoIP}
+	}
 	out.httpACL = &httpACLIR{
+		geoIP: geoIP,
 		config: &dynamicmodulesv3.DynamicModuleFilterPerRoute{
 			DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
 				Name: httpACLModuleName,
@@ -115,6 +177,9 @@ func (p *trafficPolicyPluginGwPass) handleHttpACL(fcn string, typedFilterConfig
 		return
 	}
 	typedFilterConfig.AddTypedConfig(httpACLFilterNamePrefix, httpACL.config)
+	for _, geoIP := range httpACL.geoIP {
+		p.handleGeoIP(fcn, typedFilterConfig, geoIP)
+	}
 	if p.httpACLInChain == nil {
 		p.httpACLInChain = make(map[string]bool)
 	}
@@ -30,6 +30,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/cmputils"
 )
 
@@ -523,7 +524,7 @@ func GetConfigMap(krtctx krt.HandlerContext, configMaps krt.Collection[*corev1.C
 	if configMaps == nil {
 		return nil, errors.New("configmaps collection not available")
 	}
-	obj := krt.FetchOne(krtctx, configMaps, krt.FilterObjectName(types.NamespacedName{Namespace: ns, Name: cmName}))
+	obj := krtutil.FetchOne(krtctx, configMaps, krt.FilterObjectName(types.NamespacedName{Namespace: ns, Name: cmName}))
 	if obj == nil {
 		return nil, &krtcollections.NotFoundError{NotFoundObj: ir.ObjectSource{Group: "", Kind: "ConfigMap", Namespace: ns, Name: cmName}}
 	}
@@ -0,0 +1,112 @@
+package trafficpolicy
+
+import (
+	"fmt"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_wellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// luaMaxScriptSize matches the MaxLength of inline scripts, and is enforced for scripts from ConfigMaps.
+const luaMaxScriptSize = 64 * 1024
+
+type luaIR struct {
+	perRoute *luav3.LuaPerRoute
+}
+
+var _ PolicySubIR = &luaIR{}
+
+func (l *luaIR) Equals(other PolicySubIR) bool {
+	otherLua, ok := other.(*luaIR)
+	if !ok {
+		return false
+	}
+	if l == nil || otherLua == nil {
+		return l == nil && otherLua == nil
+	}
+	return proto.Equal(l.perRoute, otherLua.perRoute)
+}
+
+func (l *luaIR) Validate() error {
+	if l == nil || l.perRoute == nil {
+		return nil
+	}
+	return l.perRoute.ValidateAll()
+}
+
+// constructLua constructs the Lua policy IR from the policy specification.
+// Lua syntax errors are only detected by Envoy, so they are reported when the strict validator is enabled.
+func constructLua(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	configMaps krt.Collection[*corev1.ConfigMap],
+	out *trafficPolicySpecIr,
+) error {
+	spec := in.Spec.Lua
+	if spec == nil {
+		return nil
+	}
+
+	if spec.Disable != nil {
+		out.lua = &luaIR{
+			perRoute: &luav3.LuaPerRoute{
+				Override: &luav3.LuaPerRoute_Disabled{Disabled: true},
+			},
+		}
+		return nil
+	}
+
+	var script string
+	switch {
+	case spec.Inline != nil:
+		script = *spec.Inline
+	case spec.ConfigMapRef != nil:
+		cm, err := GetConfigMap(krtctx, configMaps, spec.ConfigMapRef.Name, in.GetNamespace())
+		if err != nil {
+			return fmt.Errorf("lua: failed to find configmap %s: %w", spec.ConfigMapRef.Name, err)
+		}
+		data, ok := cm.Data[spec.ConfigMapRef.Key]
+		if !ok || data == "" {
+			return fmt.Errorf("lua: configmap %s key '%s' not found", spec.ConfigMapRef.Name, spec.ConfigMapRef.Key)
+		}
+		script = data
+	}
+	if len(script) > luaMaxScriptSize {
+		return fmt.Errorf("lua: script is %d bytes, larger than the maximum of %d bytes", len(script), luaMaxScriptSize)
+	}
+
+	out.lua = &luaIR{
+		perRoute: &luav3.LuaPerRoute{
+			Override: &luav3.LuaPerRoute_SourceCode{
+				SourceCode: &envoycorev3.DataSource{
+					Specifier: &envoycorev3.DataSource_InlineString{InlineString: script},
+				},
+			},
+		},
+	}
+	return nil
+}
+
+func (p *trafficPolicyPluginGwPass) handleLua(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, lua *luaIR) {
+	if lua == nil || lua.perRoute == nil {
+		return
+	}
+
+	// The script is set per route, so the filter in the chain has no default script and is
+	// disabled for routes without a Lua policy.
+	pCtxTypedFilterConfig.AddTypedConfig(envoy_wellknown.Lua, lua.perRoute)
+
+	if p.luaInChain == nil {
+		p.luaInChain = make(map[string]*luav3.Lua)
+	}
+	if _, ok := p.luaInChain[fcn]; !ok {
+		p.luaInChain[fcn] = &luav3.Lua{}
+	}
+}
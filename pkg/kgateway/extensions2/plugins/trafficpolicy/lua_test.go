@@ -0,0 +1,106 @@
+package trafficpolicy
+
+import (
+	"strings"
+	"testing"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	envoy_wellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestConstructLua(t *testing.T) {
+	const script = `function envoy_on_request(handle) handle:headers():add("x-lua", "true") end`
+	configMaps := krt.NewStaticCollection(nil, []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "default"},
+			Data: map[string]string{
+				"add-header.lua": script,
+				"too-large.lua":  strings.Repeat("-", luaMaxScriptSize+1),
+			},
+		},
+	})
+	inlineScript := &luav3.LuaPerRoute{
+		Override: &luav3.LuaPerRoute_SourceCode{
+			SourceCode: &envoycorev3.DataSource{Specifier: &envoycorev3.DataSource_InlineString{InlineString: script}},
+		},
+	}
+	policy := func(lua *kgateway.LuaPolicy) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+			Spec:       kgateway.TrafficPolicySpec{Lua: lua},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		lua     *kgateway.LuaPolicy
+		want    *luav3.LuaPerRoute
+		wantErr string
+	}{
+		{
+			name: "inline script",
+			lua:  &kgateway.LuaPolicy{Inline: new(script)},
+			want: inlineScript,
+		},
+		{
+			name: "configmap script",
+			lua:  &kgateway.LuaPolicy{ConfigMapRef: &kgateway.LuaConfigMapRef{Name: "scripts", Key: "add-header.lua"}},
+			want: inlineScript,
+		},
+		{
+			name: "disable",
+			lua:  &kgateway.LuaPolicy{Disable: &shared.PolicyDisable{}},
+			want: &luav3.LuaPerRoute{Override: &luav3.LuaPerRoute_Disabled{Disabled: true}},
+		},
+		{
+			name:    "missing configmap key",
+			lua:     &kgateway.LuaPolicy{ConfigMapRef: &kgateway.LuaConfigMapRef{Name: "scripts", Key: "missing.lua"}},
+			wantErr: "lua: configmap scripts key 'missing.lua' not found",
+		},
+		{
+			name:    "script too large",
+			lua:     &kgateway.LuaPolicy{ConfigMapRef: &kgateway.LuaConfigMapRef{Name: "scripts", Key: "too-large.lua"}},
+			wantErr: "lua: script is 65537 bytes, larger than the maximum of 65536 bytes",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructLua(krt.TestingDummyContext{}, policy(tc.lua), configMaps, out)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, out.lua)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, out.lua.Equals(&luaIR{perRoute: tc.want}))
+			assert.NoError(t, out.lua.Validate())
+		})
+	}
+}
+
+func TestHttpFiltersLua(t *testing.T) {
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	lua := &luaIR{perRoute: &luav3.LuaPerRoute{Override: &luav3.LuaPerRoute_Disabled{Disabled: true}}}
+	plugin.handleLua("test-filter-chain", &typedFilterConfig, lua)
+	assert.Equal(t, lua.perRoute, typedFilterConfig.GetTypedConfig(envoy_wellknown.Lua))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, envoy_wellknown.Lua, httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+	assert.Equal(t, filters.DuringStage(filters.RouteStage), httpFilters[0].Stage)
+}
@@ -7,6 +7,7 @@ import (
 
 	extensiondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
 	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -88,7 +89,17 @@ func MergeTrafficPolicies(
 		mergeRouteTracing,
 		mergeFaultInjection,
 		mergeHttpACL,
+		mergeGeoIP,
 		mergeStatPrefix,
+		mergeWasm,
+		mergeLua,
+		mergeAdaptiveConcurrency,
+		mergeAdmissionControl,
+		mergeCache,
+		mergeGRPCJSONTranscoder,
+		mergeCustomResponse,
+		mergeBandwidthLimit,
+		mergeRequestLimits,
 	}
 
 	for _, mergeFunc := range mergeFuncs {
@@ -140,9 +151,7 @@ func mergeExtProc(
 
 	switch opts.Strategy {
 	case policy.AugmentedDeepMerge:
-		if p1.spec.extProc == nil {
-			p1.spec.extProc = &extprocIR{}
-		}
+		p1.spec.extProc = shallowCopy(p1.spec.extProc)
 		// p2 will always have just 1 item in its providerNames, and if p1 contains that then
 		// it implies that this provider was already considered from a higher priority policy,
 		// so ignore it
@@ -162,9 +171,7 @@ func mergeExtProc(
 		}
 
 	case policy.OverridableDeepMerge:
-		if p1.spec.extProc == nil {
-			p1.spec.extProc = &extprocIR{}
-		}
+		p1.spec.extProc = shallowCopy(p1.spec.extProc)
 		// p2 will always have just 1 item in its providerNames, and if p1 contains that then
 		// it implies that this provider was already considered from a higher priority policy,
 		// so ignore it
@@ -315,7 +322,11 @@ func mergeRustformation(
 			return
 		}
 
-		p1.spec.rustformation.config.FilterConfig = anyMsg
+		// p1 may share its config with a policy IR it was shallow merged from, so replace it
+		// rather than modifying it in place
+		config := proto.CloneOf(p1.spec.rustformation.config)
+		config.FilterConfig = anyMsg
+		p1.spec.rustformation = &rustformationIR{config: config}
 		mergeOrigins.Append("transformation", p2Ref, p2MergeOrigins)
 
 	default:
@@ -347,9 +358,7 @@ func mergeExtAuth(
 
 	switch opts.Strategy {
 	case policy.AugmentedDeepMerge:
-		if p1.spec.extAuth == nil {
-			p1.spec.extAuth = &extAuthIR{}
-		}
+		p1.spec.extAuth = shallowCopy(p1.spec.extAuth)
 		// as p2 is not a merged policy, it will always have just 1 item in its providerNames
 		// as each extauth policy can only reference a single provider.
 		// If p1 contains the singular provider in p2 then it implies that this provider
@@ -370,9 +379,7 @@ func mergeExtAuth(
 		}
 
 	case policy.OverridableDeepMerge:
-		if p1.spec.extAuth == nil {
-			p1.spec.extAuth = &extAuthIR{}
-		}
+		p1.spec.extAuth = shallowCopy(p1.spec.extAuth)
 		// p2 will always have just 1 item in its providerNames, and if p1 contains that then
 		// it implies that this provider was already considered from a higher priority policy,
 		// so ignore it
@@ -815,7 +822,14 @@ func mergeHttpACL(
 			return
 		}
 
-		p1.spec.httpACL.config.FilterConfig = anyMsg
+		// p1 may share its config with a policy IR it was shallow merged from, so replace it
+		// rather than modifying it in place
+		config := proto.CloneOf(p1.spec.httpACL.config)
+		config.FilterConfig = anyMsg
+		p1.spec.httpACL = &httpACLIR{
+			config: config,
+			geoIP:  mergeACLGeoIP(p1.spec.httpACL.geoIP, p2.spec.httpACL.geoIP),
+		}
 		mergeOrigins.Append("httpACL", p2Ref, p2MergeOrigins)
 
 	default:
@@ -823,6 +837,18 @@ func mergeHttpACL(
 	}
 }
 
+// mergeACLGeoIP returns the geoip filters of both ACLs, as the merged rules match on the headers
+// of both.
+func mergeACLGeoIP(g1, g2 []*geoIPIR) []*geoIPIR {
+	out := slices.Clip(g1)
+	for _, g := range g2 {
+		if !slices.ContainsFunc(out, func(existing *geoIPIR) bool { return existing.filterName == g.filterName }) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
 func detectHttpACLMergeConflict(m1, m2 map[string]any) []error {
 	var conflicts []error
 
@@ -835,6 +861,19 @@ func detectHttpACLMergeConflict(m1, m2 map[string]any) []error {
 		conflicts = append(conflicts, fmt.Errorf("defaultAction conflict: %q vs %q", da1, da2))
 	}
 
+	// geoHeaders: the rules of both policies match on the headers they name, so they must agree
+	gh1, hasGH1 := m1["geoHeaders"].(map[string]any)
+	gh2, hasGH2 := m2["geoHeaders"].(map[string]any)
+	if hasGH1 && hasGH2 {
+		for _, key := range []string{"country", "asn"} {
+			v1, ok1 := gh1[key]
+			v2, ok2 := gh2[key]
+			if ok1 && ok2 && v1 != v2 {
+				conflicts = append(conflicts, fmt.Errorf("geoHeaders.%s conflict: %q vs %q", key, v1, v2))
+			}
+		}
+	}
+
 	dr1, hasDR1 := m1["denyResponse"].(map[string]any)
 	dr2, hasDR2 := m2["denyResponse"].(map[string]any)
 	if !hasDR1 || !hasDR2 {
@@ -870,6 +909,20 @@ func mergeHttpACLJsonInPlace(m1, m2 map[string]any) {
 		m1["rules"] = append(rules1, rules2...)
 	}
 
+	// geoHeaders: union, conflicts were already detected in detectHttpACLConflicts()
+	if gh2, ok := m2["geoHeaders"].(map[string]any); ok {
+		gh1, ok := m1["geoHeaders"].(map[string]any)
+		if !ok {
+			gh1 = map[string]any{}
+			m1["geoHeaders"] = gh1
+		}
+		for k, v := range gh2 {
+			if _, exists := gh1[k]; !exists {
+				gh1[k] = v
+			}
+		}
+	}
+
 	// denyResponse:
 	// we already detected status conflict in detectHttpACLConflicts()
 	// so we are always using the status from m1. headers are unions
@@ -936,3 +989,163 @@ func defaultMerge[T any](
 		logger.Warn("unsupported merge strategy for policy", "strategy", opts.Strategy, "policy", p2Ref, "field", fieldName)
 	}
 }
+
+// shallowCopy returns a copy of v, or a new T if v is nil, so that a merge can modify the
+// fields of p1 without modifying a policy IR that p1 was shallow merged from.
+func shallowCopy[T any](v *T) *T {
+	out := new(T)
+	if v != nil {
+		*out = *v
+	}
+	return out
+}
+
+func mergeGeoIP(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[geoIPIR]{
+		Get: func(spec *trafficPolicySpecIr) *geoIPIR { return spec.geoIP },
+		Set: func(spec *trafficPolicySpecIr, val *geoIPIR) { spec.geoIP = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "geoIP")
+}
+
+func mergeWasm(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[wasmIR]{
+		Get: func(spec *trafficPolicySpecIr) *wasmIR { return spec.wasm },
+		Set: func(spec *trafficPolicySpecIr, val *wasmIR) { spec.wasm = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "wasm")
+}
+
+func mergeLua(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[luaIR]{
+		Get: func(spec *trafficPolicySpecIr) *luaIR { return spec.lua },
+		Set: func(spec *trafficPolicySpecIr, val *luaIR) { spec.lua = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "lua")
+}
+
+func mergeAdaptiveConcurrency(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[adaptiveConcurrencyIR]{
+		Get: func(spec *trafficPolicySpecIr) *adaptiveConcurrencyIR { return spec.adaptiveConcurrency },
+		Set: func(spec *trafficPolicySpecIr, val *adaptiveConcurrencyIR) { spec.adaptiveConcurrency = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "adaptiveConcurrency")
+}
+
+func mergeAdmissionControl(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[admissionControlIR]{
+		Get: func(spec *trafficPolicySpecIr) *admissionControlIR { return spec.admissionControl },
+		Set: func(spec *trafficPolicySpecIr, val *admissionControlIR) { spec.admissionControl = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "admissionControl")
+}
+
+func mergeCache(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[cacheIR]{
+		Get: func(spec *trafficPolicySpecIr) *cacheIR { return spec.cache },
+		Set: func(spec *trafficPolicySpecIr, val *cacheIR) { spec.cache = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "cache")
+}
+
+func mergeGRPCJSONTranscoder(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[grpcJSONTranscoderIR]{
+		Get: func(spec *trafficPolicySpecIr) *grpcJSONTranscoderIR { return spec.grpcJSONTranscoder },
+		Set: func(spec *trafficPolicySpecIr, val *grpcJSONTranscoderIR) { spec.grpcJSONTranscoder = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "grpcJsonTranscoder")
+}
+
+func mergeCustomResponse(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[customResponseIR]{
+		Get: func(spec *trafficPolicySpecIr) *customResponseIR { return spec.customResponse },
+		Set: func(spec *trafficPolicySpecIr, val *customResponseIR) { spec.customResponse = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "customResponse")
+}
+
+func mergeBandwidthLimit(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[bandwidthLimitIR]{
+		Get: func(spec *trafficPolicySpecIr) *bandwidthLimitIR { return spec.bandwidthLimit },
+		Set: func(spec *trafficPolicySpecIr, val *bandwidthLimitIR) { spec.bandwidthLimit = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "bandwidthLimit")
+}
+
+func mergeRequestLimits(
+	p1, p2 *TrafficPolicy,
+	p2Ref *ir.AttachedPolicyRef,
+	p2MergeOrigins ir.MergeOrigins,
+	opts policy.MergeOptions,
+	mergeOrigins ir.MergeOrigins,
+	_ TrafficPolicyMergeOpts,
+) {
+	accessor := fieldAccessor[requestLimitsIR]{
+		Get: func(spec *trafficPolicySpecIr) *requestLimitsIR { return spec.requestLimits },
+		Set: func(spec *trafficPolicySpecIr, val *requestLimitsIR) { spec.requestLimits = val },
+	}
+	defaultMerge(p1, p2, p2Ref, p2MergeOrigins, opts, mergeOrigins, accessor, "requestLimits")
+}
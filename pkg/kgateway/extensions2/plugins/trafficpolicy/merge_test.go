@@ -7,8 +7,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 
+	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
 	kgateway "github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
@@ -53,6 +56,76 @@ func TestMergePoliciesPreservesErrors(t *testing.T) {
 	assert.True(t, errors.Is(byName["p2"], err2))
 }
 
+func TestValidateMergedRetryDeadline(t *testing.T) {
+	gk := schema.GroupKind{Group: "test", Kind: "TrafficPolicy"}
+	att := func(name string, spec kgateway.TrafficPolicySpec) ir.PolicyAtt {
+		out := &TrafficPolicy{ct: time.Now()}
+		require.NoError(t, constructTimeoutRetry(spec, &out.spec))
+		return ir.PolicyAtt{
+			GroupKind: gk,
+			PolicyRef: &ir.AttachedPolicyRef{Name: name},
+			PolicyIr:  out,
+		}
+	}
+	retry := att("retry", kgateway.TrafficPolicySpec{Retry: &kgateway.Retry{
+		RetryOn:       []kgateway.RetryOnCondition{"5xx"},
+		Attempts:      3,
+		PerTryTimeout: &metav1.Duration{Duration: time.Second},
+	}})
+	timeouts := att("timeouts", kgateway.TrafficPolicySpec{Timeouts: &shared.Timeouts{
+		Request: &metav1.Duration{Duration: 2 * time.Second},
+	}})
+	other := att("other", kgateway.TrafficPolicySpec{Timeouts: &shared.Timeouts{
+		Request: &metav1.Duration{Duration: 10 * time.Second},
+	}})
+
+	merge := func(pols ...ir.PolicyAtt) ir.PolicyAtt {
+		return validateMergedRetryDeadline(pols, policy.MergePolicies(pols, mergeTrafficPolicies, ""))
+	}
+
+	// the retry takes up to 4s, past the 2s request timeout it is merged with
+	merged := merge(retry, timeouts)
+	require.Len(t, merged.Errors, 2)
+	var refs []string
+	for _, e := range merged.Errors {
+		var pe *ir.PolicyError
+		require.True(t, errors.As(e, &pe))
+		refs = append(refs, pe.Ref.Name)
+		assert.ErrorContains(t, pe, "exceeds the request timeout of 2s")
+	}
+	assert.ElementsMatch(t, []string{"retry", "timeouts"}, refs)
+
+	// the timeout of the higher priority policy is the one that applies
+	assert.Empty(t, merge(retry, other, timeouts).Errors)
+}
+
+func TestMergeDoesNotModifyShallowMergedPolicies(t *testing.T) {
+	gk := schema.GroupKind{Group: "test", Kind: "TrafficPolicy"}
+	childExtAuth := &extAuthIR{
+		perProviderConfig: []*perProviderExtAuthConfig{{}},
+		providerNames:     sets.New("child"),
+	}
+	// the child is shallow merged, so the merged policy takes its extAuth as is, and the
+	// parent is then deep merged into it
+	child := ir.PolicyAtt{
+		GroupKind:               gk,
+		PolicyRef:               &ir.AttachedPolicyRef{Name: "child"},
+		PolicyIr:                &TrafficPolicy{ct: time.Now(), spec: trafficPolicySpecIr{extAuth: childExtAuth}},
+		HierarchicalPriority:    1,
+		InheritedPolicyPriority: apiannotations.ShallowMergePreferChild,
+	}
+	parent := ir.PolicyAtt{
+		GroupKind:               gk,
+		PolicyRef:               &ir.AttachedPolicyRef{Name: "parent"},
+		PolicyIr:                &TrafficPolicy{ct: time.Now(), spec: trafficPolicySpecIr{extAuth: &extAuthIR{disableAllProviders: true}}},
+		InheritedPolicyPriority: apiannotations.DeepMergePreferChild,
+	}
+
+	merged := policy.MergePolicies([]ir.PolicyAtt{child, parent}, mergeTrafficPolicies, "")
+	assert.True(t, merged.PolicyIr.(*TrafficPolicy).spec.extAuth.disableAllProviders)
+	assert.False(t, childExtAuth.disableAllProviders, "merging modified the child policy IR")
+}
+
 func TestMergeHttpACL(t *testing.T) {
 	p2Ref := &ir.AttachedPolicyRef{Name: "p2", Namespace: "default"}
 
@@ -374,4 +447,19 @@ func TestMergeHttpACL(t *testing.T) {
 		assert.Len(t, conflicts, 1)
 		assert.Contains(t, conflicts[0].Error(), "defaultAction conflict")
 	})
+
+	t.Run("detectHttpACLMergeConflict: conflict returned when geo headers differ", func(t *testing.T) {
+		m1 := map[string]any{"defaultAction": "deny", "geoHeaders": map[string]any{"country": "x-geo-country"}}
+		m2 := map[string]any{"defaultAction": "deny", "geoHeaders": map[string]any{"country": "x-country", "asn": "x-asn"}}
+		conflicts := detectHttpACLMergeConflict(m1, m2)
+		assert.Len(t, conflicts, 1)
+		assert.Contains(t, conflicts[0].Error(), "geoHeaders.country conflict")
+	})
+
+	t.Run("mergeHttpACLJsonInPlace: geo headers unioned", func(t *testing.T) {
+		m1 := map[string]any{"defaultAction": "deny", "geoHeaders": map[string]any{"country": "x-geo-country"}}
+		m2 := map[string]any{"defaultAction": "deny", "geoHeaders": map[string]any{"country": "x-geo-country", "asn": "x-geo-asn"}}
+		mergeHttpACLJsonInPlace(m1, m2)
+		assert.Equal(t, map[string]any{"country": "x-geo-country", "asn": "x-geo-asn"}, m1["geoHeaders"])
+	})
 }
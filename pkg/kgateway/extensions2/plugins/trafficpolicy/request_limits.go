@@ -0,0 +1,226 @@
+package trafficpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extensiondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
+	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	requestLimitsModuleName       = "rust_module"
+	requestLimitsFilterName       = "request-limits"
+	requestLimitsFilterNamePrefix = "dynamic_modules/" + requestLimitsFilterName
+)
+
+// requestLimitsFormatOperator matches the Envoy format string operators in a rejection body.
+// The request-limits module only renders the ones in requestLimitsSupportedOperators.
+var (
+	requestLimitsFormatOperator     = regexp.MustCompile(`%[A-Z_]+(\([^%]*\))?(:[0-9]+)?%`)
+	requestLimitsSupportedOperators = map[string]bool{
+		"%RESPONSE_CODE%":    true,
+		"%LOCAL_REPLY_BODY%": true,
+	}
+)
+
+// requestLimitsConfig is the per-route config of the request-limits dynamic module filter.
+type requestLimitsConfig struct {
+	// Route is the value of the route label of the rejection counter.
+	Route               string                  `json:"route,omitempty"`
+	MaxBodyBytes        *uint64                 `json:"maxBodyBytes,omitempty"`
+	MaxURLLength        *int32                  `json:"maxUrlLength,omitempty"`
+	MaxHeaderValueBytes *int32                  `json:"maxHeaderValueBytes,omitempty"`
+	Rejection           *requestLimitsRejection `json:"rejection,omitempty"`
+}
+
+type requestLimitsRejection struct {
+	StatusCode  *int32          `json:"statusCode,omitempty"`
+	ContentType *string         `json:"contentType,omitempty"`
+	Text        *string         `json:"text,omitempty"`
+	JSON        json.RawMessage `json:"json,omitempty"`
+}
+
+type requestLimitsIR struct {
+	// config is nil when the policy disables the request limits.
+	config *requestLimitsConfig
+	// perRoute is the filter config for config, labelled with the policy name.
+	perRoute *dynamicmodulesv3.DynamicModuleFilterPerRoute
+}
+
+var _ PolicySubIR = &requestLimitsIR{}
+
+func (r *requestLimitsIR) Equals(other PolicySubIR) bool {
+	otherRequestLimits, ok := other.(*requestLimitsIR)
+	if !ok {
+		return false
+	}
+	if r == nil || otherRequestLimits == nil {
+		return r == nil && otherRequestLimits == nil
+	}
+	return proto.Equal(r.perRoute, otherRequestLimits.perRoute)
+}
+
+func (r *requestLimitsIR) Validate() error {
+	if r == nil || r.perRoute == nil {
+		return nil
+	}
+	return r.perRoute.ValidateAll()
+}
+
+// constructRequestLimits constructs the request limits policy IR from the traffic policy spec.
+func constructRequestLimits(in *kgateway.TrafficPolicy, out *trafficPolicySpecIr) error {
+	spec := in.Spec.RequestLimits
+	if spec == nil {
+		return nil
+	}
+	if spec.Disable != nil {
+		out.requestLimits = &requestLimitsIR{}
+		return nil
+	}
+
+	config := &requestLimitsConfig{
+		Route:               in.Namespace + "/" + in.Name,
+		MaxURLLength:        spec.MaxURLLength,
+		MaxHeaderValueBytes: spec.MaxHeaderValueSize,
+	}
+	if spec.MaxBodySize != nil {
+		size := spec.MaxBodySize.Value()
+		if size <= 0 {
+			return fmt.Errorf("request limits: maxBodySize must be greater than 0")
+		}
+		config.MaxBodyBytes = new(uint64(size))
+	}
+	if spec.RejectionResponse != nil {
+		rejection, err := toRequestLimitsRejection(spec.RejectionResponse)
+		if err != nil {
+			return fmt.Errorf("request limits: %w", err)
+		}
+		config.Rejection = rejection
+	}
+
+	perRoute, err := requestLimitsPerRoute(config)
+	if err != nil {
+		return fmt.Errorf("request limits: %w", err)
+	}
+	out.requestLimits = &requestLimitsIR{
+		config:   config,
+		perRoute: perRoute,
+	}
+	return nil
+}
+
+func toRequestLimitsRejection(in *kgateway.RequestLimitsRejection) (*requestLimitsRejection, error) {
+	out := &requestLimitsRejection{
+		StatusCode: in.StatusCode,
+	}
+	if in.BodyFormat == nil {
+		return out, nil
+	}
+	if err := validateRequestLimitsBodyFormat(in.BodyFormat); err != nil {
+		return nil, err
+	}
+	out.ContentType = in.BodyFormat.ContentType
+	out.Text = in.BodyFormat.Text
+	if in.BodyFormat.JSON != nil {
+		out.JSON = in.BodyFormat.JSON.Raw
+	}
+	return out, nil
+}
+
+// validateRequestLimitsBodyFormat rejects body formats using operators that the request-limits
+// module cannot render, since it sends the response itself instead of going through Envoy's
+// local reply formatter.
+func validateRequestLimitsBodyFormat(format *shared.BodyFormat) error {
+	var body string
+	switch {
+	case format.Text != nil:
+		body = *format.Text
+	case format.JSON != nil:
+		if !json.Valid(format.JSON.Raw) {
+			return fmt.Errorf("rejectionResponse.bodyFormat.json is invalid")
+		}
+		body = string(format.JSON.Raw)
+	default:
+		return fmt.Errorf("rejectionResponse.bodyFormat must specify either text or json")
+	}
+	for _, op := range requestLimitsFormatOperator.FindAllString(body, -1) {
+		if !requestLimitsSupportedOperators[op] {
+			return fmt.Errorf("rejectionResponse.bodyFormat uses unsupported operator %s, only %%RESPONSE_CODE%% and %%LOCAL_REPLY_BODY%% are supported", op)
+		}
+	}
+	return nil
+}
+
+func requestLimitsPerRoute(config *requestLimitsConfig) (*dynamicmodulesv3.DynamicModuleFilterPerRoute, error) {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	filterCfg, err := utils.MessageToAny(&wrapperspb.StringValue{
+		Value: string(configJSON),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dynamicmodulesv3.DynamicModuleFilterPerRoute{
+		DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
+			Name: requestLimitsModuleName,
+		},
+		FilterName:         requestLimitsFilterName,
+		PerRouteConfigName: requestLimitsFilterName,
+		FilterConfig:       filterCfg,
+	}, nil
+}
+
+func (p *trafficPolicyPluginGwPass) handleRequestLimits(fcn string, typedFilterConfig *ir.TypedFilterConfigMap, requestLimits *requestLimitsIR) {
+	if requestLimits == nil {
+		return
+	}
+
+	if requestLimits.perRoute != nil {
+		typedFilterConfig.AddTypedConfig(requestLimitsFilterNamePrefix, requestLimits.perRoute)
+	} else {
+		// The policy has disable set. Disable the filter to override any request
+		// limits configured at a higher level (e.g. Gateway-attached policy).
+		typedFilterConfig.AddTypedConfig(requestLimitsFilterNamePrefix, DisableFilterPerRoute())
+	}
+
+	if p.requestLimitsInChain == nil {
+		p.requestLimitsInChain = make(map[string]bool)
+	}
+	p.requestLimitsInChain[fcn] = true
+}
+
+// applyRequestLimitsRouteLabel labels the rejections of a route-level policy with the stat prefix
+// of the route or, when it has none, the name of the route, so they can be counted per route.
+// It must run after the route stat prefix has been resolved.
+func applyRequestLimitsRouteLabel(requestLimits *requestLimitsIR, outputRoute *envoyroutev3.Route, typedFilterConfig *ir.TypedFilterConfigMap) error {
+	if requestLimits == nil || requestLimits.config == nil {
+		return nil
+	}
+	label := outputRoute.GetStatPrefix()
+	if label == "" {
+		label = outputRoute.GetName()
+	}
+	if label == "" {
+		return nil
+	}
+	config := *requestLimits.config
+	config.Route = label
+	perRoute, err := requestLimitsPerRoute(&config)
+	if err != nil {
+		return fmt.Errorf("request limits: %w", err)
+	}
+	typedFilterConfig.AddTypedConfig(requestLimitsFilterNamePrefix, perRoute)
+	return nil
+}
@@ -0,0 +1,156 @@
+package trafficpolicy
+
+import (
+	"testing"
+
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func requestLimitsPolicy(spec *kgateway.RequestLimitsPolicy) *kgateway.TrafficPolicy {
+	return &kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "default"},
+		Spec:       kgateway.TrafficPolicySpec{RequestLimits: spec},
+	}
+}
+
+func requestLimitsJSON(t *testing.T, cfg any) string {
+	t.Helper()
+	var perRoute *dynamicmodulesv3.DynamicModuleFilterPerRoute
+	switch c := cfg.(type) {
+	case *dynamicmodulesv3.DynamicModuleFilterPerRoute:
+		perRoute = c
+	case *requestLimitsIR:
+		perRoute = c.perRoute
+	}
+	require.NotNil(t, perRoute)
+	value := &wrapperspb.StringValue{}
+	require.NoError(t, perRoute.GetFilterConfig().UnmarshalTo(value))
+	return value.GetValue()
+}
+
+func TestConstructRequestLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *kgateway.RequestLimitsPolicy
+		want    string
+		wantErr string
+	}{
+		{
+			name: "limits with default rejection",
+			spec: &kgateway.RequestLimitsPolicy{
+				MaxBodySize:        new(resource.MustParse("1Mi")),
+				MaxURLLength:       new(int32(2048)),
+				MaxHeaderValueSize: new(int32(4096)),
+			},
+			want: `{"route":"default/limits","maxBodyBytes":1048576,"maxUrlLength":2048,"maxHeaderValueBytes":4096}`,
+		},
+		{
+			name: "json rejection body",
+			spec: &kgateway.RequestLimitsPolicy{
+				MaxURLLength: new(int32(1024)),
+				RejectionResponse: &kgateway.RequestLimitsRejection{
+					StatusCode: new(int32(400)),
+					BodyFormat: &shared.BodyFormat{
+						ContentType: new("application/problem+json"),
+						JSON:        &apiextensionsv1.JSON{Raw: []byte(`{"status":"%RESPONSE_CODE%","detail":"%LOCAL_REPLY_BODY%"}`)},
+					},
+				},
+			},
+			want: `{"route":"default/limits","maxUrlLength":1024,"rejection":{"statusCode":400,"contentType":"application/problem+json","json":{"status":"%RESPONSE_CODE%","detail":"%LOCAL_REPLY_BODY%"}}}`,
+		},
+		{
+			name: "text rejection body",
+			spec: &kgateway.RequestLimitsPolicy{
+				MaxHeaderValueSize: new(int32(1024)),
+				RejectionResponse: &kgateway.RequestLimitsRejection{
+					BodyFormat: &shared.BodyFormat{Text: new("rejected: %LOCAL_REPLY_BODY%")},
+				},
+			},
+			want: `{"route":"default/limits","maxHeaderValueBytes":1024,"rejection":{"text":"rejected: %LOCAL_REPLY_BODY%"}}`,
+		},
+		{
+			name: "unsupported operator",
+			spec: &kgateway.RequestLimitsPolicy{
+				MaxHeaderValueSize: new(int32(1024)),
+				RejectionResponse: &kgateway.RequestLimitsRejection{
+					BodyFormat: &shared.BodyFormat{Text: new("%REQ(:path)% is too large")},
+				},
+			},
+			wantErr: "unsupported operator %REQ(:path)%",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &trafficPolicySpecIr{}
+			err := constructRequestLimits(requestLimitsPolicy(tt.spec), out)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, out.requestLimits.Validate())
+			assert.JSONEq(t, tt.want, requestLimitsJSON(t, out.requestLimits))
+		})
+	}
+}
+
+func TestHandleRequestLimits(t *testing.T) {
+	out := &trafficPolicySpecIr{}
+	require.NoError(t, constructRequestLimits(requestLimitsPolicy(&kgateway.RequestLimitsPolicy{
+		MaxURLLength: new(int32(2048)),
+	}), out))
+
+	plugin := &trafficPolicyPluginGwPass{}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleRequestLimits("test-filter-chain", &typedFilterConfig, out.requestLimits)
+	assert.JSONEq(t, `{"route":"default/limits","maxUrlLength":2048}`,
+		requestLimitsJSON(t, typedFilterConfig.GetTypedConfig(requestLimitsFilterNamePrefix)))
+
+	t.Run("labels rejections with the route stat prefix", func(t *testing.T) {
+		typedFilterConfig := ir.TypedFilterConfigMap{}
+		route := &envoyroutev3.Route{Name: "my-route-rule0", StatPrefix: "my-ns.my-route"}
+		require.NoError(t, applyRequestLimitsRouteLabel(out.requestLimits, route, &typedFilterConfig))
+		assert.JSONEq(t, `{"route":"my-ns.my-route","maxUrlLength":2048}`,
+			requestLimitsJSON(t, typedFilterConfig.GetTypedConfig(requestLimitsFilterNamePrefix)))
+		assert.Equal(t, "default/limits", out.requestLimits.config.Route, "the IR must not be mutated")
+	})
+
+	t.Run("labels rejections with the route name without a stat prefix", func(t *testing.T) {
+		typedFilterConfig := ir.TypedFilterConfigMap{}
+		route := &envoyroutev3.Route{Name: "my-route-rule0"}
+		require.NoError(t, applyRequestLimitsRouteLabel(out.requestLimits, route, &typedFilterConfig))
+		assert.JSONEq(t, `{"route":"my-route-rule0","maxUrlLength":2048}`,
+			requestLimitsJSON(t, typedFilterConfig.GetTypedConfig(requestLimitsFilterNamePrefix)))
+	})
+
+	t.Run("disable overrides higher level policies", func(t *testing.T) {
+		disabled := &trafficPolicySpecIr{}
+		require.NoError(t, constructRequestLimits(requestLimitsPolicy(&kgateway.RequestLimitsPolicy{
+			Disable: &shared.PolicyDisable{},
+		}), disabled))
+		typedFilterConfig := ir.TypedFilterConfigMap{}
+		plugin.handleRequestLimits("test-filter-chain", &typedFilterConfig, disabled.requestLimits)
+		require.NoError(t, applyRequestLimitsRouteLabel(disabled.requestLimits, &envoyroutev3.Route{Name: "r"}, &typedFilterConfig))
+		cfg, ok := typedFilterConfig.GetTypedConfig(requestLimitsFilterNamePrefix).(*envoyroutev3.FilterConfig)
+		require.True(t, ok)
+		assert.True(t, cfg.GetDisabled())
+	})
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, requestLimitsFilterNamePrefix, httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+}
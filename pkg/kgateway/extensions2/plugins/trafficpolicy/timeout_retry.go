@@ -1,11 +1,17 @@
 package trafficpolicy
 
 import (
+	"fmt"
+	"time"
+
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/policy"
 )
 
@@ -16,6 +22,8 @@ var (
 
 type retryIR struct {
 	policy *envoyroutev3.RetryPolicy
+	// hedgePolicy is set when a retry is hedged on per-try timeout.
+	hedgePolicy *envoyroutev3.HedgePolicy
 }
 
 func (a *retryIR) Equals(other PolicySubIR) bool {
@@ -26,14 +34,17 @@ func (a *retryIR) Equals(other PolicySubIR) bool {
 	if a == nil || b == nil {
 		return a == nil && b == nil
 	}
-	return proto.Equal(a.policy, b.policy)
+	return proto.Equal(a.policy, b.policy) && proto.Equal(a.hedgePolicy, b.hedgePolicy)
 }
 
 func (a *retryIR) Validate() error {
 	if a == nil || a.policy == nil {
 		return nil
 	}
-	return a.policy.Validate()
+	if err := a.policy.Validate(); err != nil {
+		return err
+	}
+	return a.hedgePolicy.Validate()
 }
 
 type timeoutsIR struct {
@@ -60,7 +71,7 @@ func (a *timeoutsIR) Validate() error {
 func constructTimeoutRetry(
 	spec kgateway.TrafficPolicySpec,
 	out *trafficPolicySpecIr,
-) {
+) error {
 	if spec.Timeouts != nil {
 		out.timeouts = &timeoutsIR{}
 		if spec.Timeouts.Request != nil {
@@ -72,8 +83,71 @@ func constructTimeoutRetry(
 	}
 
 	if spec.Retry != nil {
+		if err := validateRetryDeadline(spec.Retry, spec.Timeouts); err != nil {
+			return err
+		}
 		out.retry = &retryIR{
-			policy: policy.BuildRetryPolicy(spec.Retry),
+			policy:      policy.BuildRetryPolicy(spec.Retry),
+			hedgePolicy: policy.BuildHedgePolicy(spec.Retry),
 		}
 	}
+	return nil
+}
+
+// validateRetryDeadline checks that the per-try timeouts of the initial attempt and all the retries
+// fit in the request timeout, so that the last retries are not cut short by the overall deadline.
+func validateRetryDeadline(retry *kgateway.Retry, timeouts *shared.Timeouts) error {
+	if retry.PerTryTimeout == nil || timeouts == nil || timeouts.Request == nil {
+		return nil
+	}
+	return checkRetryDeadline(retry.Attempts+1, retry.PerTryTimeout.Duration, timeouts.Request.Duration)
+}
+
+// validateMergedRetryDeadline adds the errors of retryDeadlineErrors to a merged policy.
+func validateMergedRetryDeadline(pols []ir.PolicyAtt, merged ir.PolicyAtt) ir.PolicyAtt {
+	merged.Errors = append(merged.Errors, retryDeadlineErrors(pols, merged)...)
+	return merged
+}
+
+// retryDeadlineErrors runs the retry deadline check on a merged policy, whose retry and timeouts
+// may come from different policies, e.g. a retry on an HTTPRoute and a request timeout on its
+// Gateway. The error is reported against every policy that contributes one of the two settings.
+func retryDeadlineErrors(pols []ir.PolicyAtt, merged ir.PolicyAtt) []error {
+	tp, ok := merged.PolicyIr.(*TrafficPolicy)
+	if !ok || tp.spec.retry == nil || tp.spec.timeouts == nil {
+		return nil
+	}
+	retry := tp.spec.retry.policy
+	if retry.GetPerTryTimeout() == nil || tp.spec.timeouts.routeTimeout == nil {
+		return nil
+	}
+	err := checkRetryDeadline(int32(retry.GetNumRetries().GetValue())+1, //nolint:gosec // G115: built from an int32 attempts count
+		retry.GetPerTryTimeout().AsDuration(), tp.spec.timeouts.routeTimeout.AsDuration())
+	if err == nil {
+		return nil
+	}
+
+	var errs []error
+	contributors := sets.New(merged.MergeOrigins.Get("retry")...).Insert(merged.MergeOrigins.Get("timeouts")...)
+	for _, pol := range pols {
+		if pol.PolicyRef == nil || !contributors.Has(pol.PolicyRef.ID()) {
+			continue
+		}
+		// a policy attached through several sections is reported once
+		contributors.Delete(pol.PolicyRef.ID())
+		errs = append(errs, &ir.PolicyError{Ref: pol.PolicyRef, Err: err})
+	}
+	return errs
+}
+
+func checkRetryDeadline(attempts int32, perTryTimeout, requestTimeout time.Duration) error {
+	if requestTimeout == 0 {
+		return nil
+	}
+	total := perTryTimeout * time.Duration(attempts)
+	if total > requestTimeout {
+		return fmt.Errorf("retry: %d attempt(s) with a per-try timeout of %s take up to %s, which exceeds the request timeout of %s",
+			attempts, perTryTimeout, total, requestTimeout)
+	}
+	return nil
 }
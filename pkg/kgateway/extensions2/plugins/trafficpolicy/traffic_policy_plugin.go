@@ -2,19 +2,23 @@ package trafficpolicy
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	extensiondynamicmodulev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/dynamic_modules/v3"
 	envoy_api_key_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/api_key_auth/v3"
+	bandwidthlimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/bandwidth_limit/v3"
 	envoy_basic_auth_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/basic_auth/v3"
 	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
 	corsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
 	envoy_csrf_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/csrf/v3"
+	customresponsev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/custom_response/v3"
 	dynamicmodulesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_modules/v3"
 	faulthttpv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/fault/v3"
 	header_mutationv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_mutation/v3"
 	localratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	envoyrbacv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoy_wellknown "github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -26,6 +30,7 @@ import (
 
 	apiannotations "github.com/kgateway-dev/kgateway/v2/api/annotations"
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
@@ -84,6 +89,8 @@ type PolicySubIR interface {
 type TrafficPolicy struct {
 	ct   time.Time
 	spec trafficPolicySpecIr
+	// warnings about settings of the policy that do not take effect, see ir.PolicyWarningsIR
+	warnings []string
 }
 
 type trafficPolicySpecIr struct {
@@ -111,13 +118,28 @@ type trafficPolicySpecIr struct {
 	tracing          *routeTracingIR
 	faultInjection   *faultInjectionIR
 	httpACL          *httpACLIR
+	geoIP            *geoIPIR
 	statPrefix       *statPrefixIR
+	wasm             *wasmIR
+	lua              *luaIR
+
+	adaptiveConcurrency *adaptiveConcurrencyIR
+	admissionControl    *admissionControlIR
+	cache               *cacheIR
+	grpcJSONTranscoder  *grpcJSONTranscoderIR
+	customResponse      *customResponseIR
+	bandwidthLimit      *bandwidthLimitIR
+	requestLimits       *requestLimitsIR
 }
 
 func (d *TrafficPolicy) CreationTime() time.Time {
 	return d.ct
 }
 
+func (d *TrafficPolicy) Warnings() []string {
+	return d.warnings
+}
+
 func (d *TrafficPolicy) Equals(in any) bool {
 	d2, ok := in.(*TrafficPolicy)
 	if !ok {
@@ -199,9 +221,42 @@ func (d *TrafficPolicy) Equals(in any) bool {
 	if !d.spec.httpACL.Equals(d2.spec.httpACL) {
 		return false
 	}
+	if !d.spec.geoIP.Equals(d2.spec.geoIP) {
+		return false
+	}
 	if !d.spec.statPrefix.Equals(d2.spec.statPrefix) {
 		return false
 	}
+	if !d.spec.wasm.Equals(d2.spec.wasm) {
+		return false
+	}
+	if !d.spec.lua.Equals(d2.spec.lua) {
+		return false
+	}
+	if !d.spec.adaptiveConcurrency.Equals(d2.spec.adaptiveConcurrency) {
+		return false
+	}
+	if !d.spec.admissionControl.Equals(d2.spec.admissionControl) {
+		return false
+	}
+	if !d.spec.bandwidthLimit.Equals(d2.spec.bandwidthLimit) {
+		return false
+	}
+	if !d.spec.requestLimits.Equals(d2.spec.requestLimits) {
+		return false
+	}
+	if !d.spec.cache.Equals(d2.spec.cache) {
+		return false
+	}
+	if !d.spec.grpcJSONTranscoder.Equals(d2.spec.grpcJSONTranscoder) {
+		return false
+	}
+	if !d.spec.customResponse.Equals(d2.spec.customResponse) {
+		return false
+	}
+	if !slices.Equal(d.warnings, d2.warnings) {
+		return false
+	}
 	return true
 }
 
@@ -233,8 +288,18 @@ func (p *TrafficPolicy) Validate() error {
 	validators = append(validators, p.spec.tracing.Validate)
 	validators = append(validators, p.spec.faultInjection.Validate)
 	validators = append(validators, p.spec.httpACL.Validate)
+	validators = append(validators, p.spec.geoIP.Validate)
 	validators = append(validators, p.spec.internalRedirect.Validate)
 	validators = append(validators, p.spec.statPrefix.Validate)
+	validators = append(validators, p.spec.wasm.Validate)
+	validators = append(validators, p.spec.lua.Validate)
+	validators = append(validators, p.spec.adaptiveConcurrency.Validate)
+	validators = append(validators, p.spec.admissionControl.Validate)
+	validators = append(validators, p.spec.bandwidthLimit.Validate)
+	validators = append(validators, p.spec.requestLimits.Validate)
+	validators = append(validators, p.spec.cache.Validate)
+	validators = append(validators, p.spec.grpcJSONTranscoder.Validate)
+	validators = append(validators, p.spec.customResponse.Validate)
 	for _, validator := range validators {
 		if err := validator(); err != nil {
 			return err
@@ -251,24 +316,36 @@ type trafficPolicyPluginGwPass struct {
 	// should be set on routes that have been successfully authenticated
 	enableAuthMetadata bool
 
-	setTransformationInChain map[string]bool // TODO(nfuden): make this multi stage
-	localRateLimitInChain    map[string]*localratelimitv3.LocalRateLimit
-	extAuthPerProvider       ProviderNeededMap
-	extProcPerProvider       ProviderNeededMap
-	jwtPerProvider           ProviderNeededMap
-	rateLimitPerProvider     ProviderNeededMap
-	oauth2PerProvider        ProviderNeededMap
-	rbacInChain              map[string]*envoyrbacv3.RBAC
-	corsInChain              map[string]*corsv3.Cors
-	csrfInChain              map[string]*envoy_csrf_v3.CsrfPolicy
-	headerMutationInChain    map[string]*header_mutationv3.HeaderMutationPerRoute
-	bufferInChain            map[string]*bufferv3.Buffer
-	compressorInChain        map[string][]compressorEntry
-	decompressorInChain      map[string][]decompressorEntry
-	basicAuthInChain         map[string]*envoy_basic_auth_v3.BasicAuth
-	apiKeyAuthInChain        map[string]*envoy_api_key_auth_v3.ApiKeyAuth
-	faultInChain             map[string]*faulthttpv3.HTTPFault
-	httpACLInChain           map[string]bool
+	setTransformationInChain   map[string]bool // TODO(nfuden): make this multi stage
+	localRateLimitInChain      map[string]*localratelimitv3.LocalRateLimit
+	extAuthPerProvider         ProviderNeededMap
+	extProcPerProvider         ProviderNeededMap
+	jwtPerProvider             ProviderNeededMap
+	rateLimitPerProvider       ProviderNeededMap
+	oauth2PerProvider          ProviderNeededMap
+	rbacInChain                map[string]*envoyrbacv3.RBAC
+	corsInChain                map[string]*corsv3.Cors
+	csrfInChain                map[string]*envoy_csrf_v3.CsrfPolicy
+	headerMutationInChain      map[string]*header_mutationv3.HeaderMutationPerRoute
+	bufferInChain              map[string]*bufferv3.Buffer
+	compressorInChain          map[string][]compressorEntry
+	decompressorInChain        map[string][]decompressorEntry
+	basicAuthInChain           map[string]*envoy_basic_auth_v3.BasicAuth
+	apiKeyAuthInChain          map[string]*envoy_api_key_auth_v3.ApiKeyAuth
+	hashedAPIKeyAuthInChain    map[string]bool
+	moduleBasicAuthInChain     map[string]bool
+	faultInChain               map[string]*faulthttpv3.HTTPFault
+	httpACLInChain             map[string]bool
+	geoIPInChain               map[string][]*geoIPIR
+	wasmInChain                map[string][]*wasmIR
+	luaInChain                 map[string]*luav3.Lua
+	adaptiveConcurrencyInChain map[string][]*adaptiveConcurrencyIR
+	admissionControlInChain    map[string][]*admissionControlIR
+	cacheInChain               map[string][]*cacheIR
+	grpcJSONTranscoderInChain  map[string][]*grpcJSONTranscoderIR
+	customResponseInChain      map[string]bool
+	bandwidthLimitInChain      map[string]*bandwidthlimitv3.BandwidthLimit
+	requestLimitsInChain       map[string]bool
 	// maps secret name to secret in case the same secret is referenced in multiple attachment points (e.g., vhost and route)
 	secrets map[string]*envoytlsv3.Secret
 }
@@ -286,15 +363,7 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, me
 
 	constructor := NewTrafficPolicyConstructor(ctx, commoncol)
 
-	// TrafficPolicy IR will have TypedConfig -> implement backendroute method to add prompt guard, etc.
-	statusCol, policyCol := krt.NewStatusCollection(col, func(krtctx krt.HandlerContext, policyCR *kgateway.TrafficPolicy) (*krtcollections.StatusMarker, *ir.PolicyWrapper) {
-		objSrc := ir.ObjectSource{
-			Group:     gk.Group,
-			Kind:      gk.Kind,
-			Namespace: policyCR.Namespace,
-			Name:      policyCR.Name,
-		}
-
+	buildIR := func(krtctx krt.HandlerContext, policyCR *kgateway.TrafficPolicy) (*TrafficPolicy, int32, []error) {
 		policyIR, errors := constructor.ConstructIR(krtctx, policyCR)
 		if err := validateWithValidationLevel(ctx, policyIR, v, commoncol.Settings.ValidationMode, commoncol.Settings.EnableAuthMetadata); err != nil {
 			logger.Error("validation failed", "policy", policyCR.Name, "error", err)
@@ -304,6 +373,19 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, me
 		if err != nil {
 			errors = append(errors, err)
 		}
+		return policyIR, precedenceWeight, errors
+	}
+
+	// TrafficPolicy IR will have TypedConfig -> implement backendroute method to add prompt guard, etc.
+	statusCol, policyCol := krt.NewStatusCollection(col, func(krtctx krt.HandlerContext, policyCR *kgateway.TrafficPolicy) (*krtcollections.StatusMarker, *ir.PolicyWrapper) {
+		objSrc := ir.ObjectSource{
+			Group:     gk.Group,
+			Kind:      gk.Kind,
+			Namespace: policyCR.Namespace,
+			Name:      policyCR.Name,
+		}
+
+		policyIR, precedenceWeight, errors := buildIR(krtctx, policyCR)
 
 		var statusMarker *krtcollections.StatusMarker
 		for _, ancestor := range policyCR.Status.Ancestors {
@@ -353,10 +435,15 @@ func NewPlugin(ctx context.Context, commoncol *collections.CommonCollections, me
 				Policies:                        policyCol,
 				ProcessPolicyStaleStatusMarkers: processMarkers,
 				MergePolicies: func(pols []ir.PolicyAtt) ir.PolicyAtt {
-					return policy.MergePolicies(pols, mergeTrafficPolicies, mergeSettings)
+					return validateMergedRetryDeadline(pols, policy.MergePolicies(pols, mergeTrafficPolicies, mergeSettings))
 				},
-				GetPolicyStatus:   getPolicyStatusFn(cli),
-				PatchPolicyStatus: patchPolicyStatusFn(cli),
+				ValidateMergedPolicy: retryDeadlineErrors,
+				GetPolicyStatus:      getPolicyStatusFn(cli),
+				PatchPolicyStatus:    patchPolicyStatusFn(cli),
+				ValidatePolicy: pluginutils.ValidatePolicyFn(func(krtctx krt.HandlerContext, policyCR *kgateway.TrafficPolicy) []error {
+					_, _, errors := buildIR(krtctx, policyCR)
+					return errors
+				}),
 			},
 		},
 		ExtraHasSynced: constructor.HasSynced,
@@ -444,8 +531,9 @@ func (p *trafficPolicyPluginGwPass) ApplyForRoute(pCtx *ir.RouteContext, outputR
 	// rather than in handlePerRoutePolicies.
 	applyStatPrefix(policy.spec.statPrefix, pCtx, outputRoute)
 	p.handlePolicies(pCtx.FilterChainName, &pCtx.TypedFilterConfig, policy.spec)
+	applyBandwidthLimitStatPrefix(policy.spec.bandwidthLimit, outputRoute.GetStatPrefix(), &pCtx.TypedFilterConfig)
 
-	return nil
+	return applyRequestLimitsRouteLabel(policy.spec.requestLimits, outputRoute, &pCtx.TypedFilterConfig)
 }
 
 func (p *trafficPolicyPluginGwPass) ApplyForRouteBackend(
@@ -481,6 +569,9 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = append(stagedFilters, filter)
 	}
 
+	// Add Geo-IP filters before FaultStage, so the ACL filter can match on their headers.
+	stagedFilters = addGeoIPFiltersIfNeeded(stagedFilters, p, fcc.FilterChainName)
+
 	// Add HTTP ACL filter immediately after FaultStage, before all other filters.
 	if p.httpACLInChain[fcc.FilterChainName] {
 		cfg := utils.MustMessageToAny(&wrapperspb.StringValue{
@@ -498,6 +589,24 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = append(stagedFilters, filter)
 	}
 
+	// Add request limits filter after the ACL, so oversized requests are rejected
+	// before CORS, authentication and any filter that reads the body.
+	if p.requestLimitsInChain[fcc.FilterChainName] {
+		cfg := utils.MustMessageToAny(&wrapperspb.StringValue{
+			Value: "{}",
+		})
+		requestLimitsListenerCfg := &dynamicmodulesv3.DynamicModuleFilter{
+			DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
+				Name: requestLimitsModuleName,
+			},
+			FilterName:   requestLimitsFilterName,
+			FilterConfig: cfg,
+		}
+		filter := filters.MustNewStagedFilter(requestLimitsFilterNamePrefix, requestLimitsListenerCfg, filters.BeforeStage(filters.CorsStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+
 	// Add global ExtProc disable filter when there are providers
 	if len(p.extProcPerProvider.Providers[fcc.FilterChainName]) > 0 {
 		// register the filter that sets metadata so that it can have overrides on the route level
@@ -678,6 +787,13 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = append(stagedFilters, filter)
 	}
 
+	// Add Lua filter. The script is set per route.
+	if f := p.luaInChain[fcc.FilterChainName]; f != nil {
+		filter := filters.MustNewStagedFilter(envoy_wellknown.Lua, f, filters.DuringStage(filters.RouteStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+
 	// Add Buffer filter to enable buffer for the listener.
 	// Requires the buffer policy to be set as typed_per_filter_config.
 	if f := p.bufferInChain[fcc.FilterChainName]; f != nil {
@@ -686,6 +802,15 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = append(stagedFilters, filter)
 	}
 
+	// Add Bandwidth limit filter right before the router, so requests rejected by
+	// earlier filters do not count against the limit.
+	// Requires the bandwidth limit policy to be set as typed_per_filter_config.
+	if f := p.bandwidthLimitInChain[fcc.FilterChainName]; f != nil {
+		filter := filters.MustNewStagedFilter(bandwidthLimitFilterName, f, filters.BeforeStage(filters.RouteStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+
 	if f := p.rbacInChain[fcc.FilterChainName]; f != nil {
 		filter := filters.MustNewStagedFilter(rbacFilterNamePrefix, f, filters.DuringStage(filters.AuthZStage))
 		stagedFilters = append(stagedFilters, filter)
@@ -701,6 +826,21 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = AddAuthEnabledFilterIfNeeded(stagedFilters, BasicAuthEnabledFilterName, p.enableAuthMetadata)
 	}
 
+	// Add the basic auth filter of the dynamic module, which verifies the bcrypt and argon2 hashes set per route
+	if p.moduleBasicAuthInChain[fcc.FilterChainName] {
+		cfg := utils.MustMessageToAny(&wrapperspb.StringValue{Value: "{}"})
+		filter := filters.MustNewStagedFilter(moduleBasicAuthFilterNamePrefix, &dynamicmodulesv3.DynamicModuleFilter{
+			DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
+				Name: moduleBasicAuthModuleName,
+			},
+			FilterName:   moduleBasicAuthFilterName,
+			FilterConfig: cfg,
+		}, filters.DuringStage(filters.AuthNStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+		stagedFilters = AddAuthEnabledFilterIfNeeded(stagedFilters, BasicAuthEnabledFilterName, p.enableAuthMetadata)
+	}
+
 	// Add API key auth filter to the chain
 	if f := p.apiKeyAuthInChain[fcc.FilterChainName]; f != nil {
 		filter := filters.MustNewStagedFilter(apiKeyAuthFilterNamePrefix, f, filters.DuringStage(filters.AuthNStage))
@@ -709,6 +849,41 @@ func (p *trafficPolicyPluginGwPass) HttpFilters(_ ir.HttpFiltersContext, fcc ir.
 		stagedFilters = AddAuthEnabledFilterIfNeeded(stagedFilters, APIKeyAuthEnabledFilterName, p.enableAuthMetadata)
 	}
 
+	// Add the API key auth filter of the dynamic module, which verifies the hashed API keys set per route
+	if p.hashedAPIKeyAuthInChain[fcc.FilterChainName] {
+		cfg := utils.MustMessageToAny(&wrapperspb.StringValue{Value: "{}"})
+		filter := filters.MustNewStagedFilter(hashedAPIKeyAuthFilterNamePrefix, &dynamicmodulesv3.DynamicModuleFilter{
+			DynamicModuleConfig: &extensiondynamicmodulev3.DynamicModuleConfig{
+				Name: hashedAPIKeyAuthModuleName,
+			},
+			FilterName:   hashedAPIKeyAuthFilterName,
+			FilterConfig: cfg,
+		}, filters.DuringStage(filters.AuthNStage))
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+		stagedFilters = AddAuthEnabledFilterIfNeeded(stagedFilters, APIKeyAuthEnabledFilterName, p.enableAuthMetadata)
+	}
+
+	// Add Wasm filters
+	stagedFilters = addWasmFiltersIfNeeded(stagedFilters, p, fcc.FilterChainName)
+
+	// Add adaptive concurrency and admission control filters
+	stagedFilters = addAdmissionControlFiltersIfNeeded(stagedFilters, p, fcc.FilterChainName)
+	stagedFilters = addAdaptiveConcurrencyFiltersIfNeeded(stagedFilters, p, fcc.FilterChainName)
+
+	// Add cache filters
+	stagedFilters = addCacheFiltersIfNeeded(stagedFilters, p, fcc.FilterChainName)
+
+	// Add gRPC-JSON transcoder filters
+	stagedFilters = addGRPCJSONTranscoderFiltersIfNeeded(stagedFilters, p, fcc.FilterChainName)
+
+	// Add custom response filter. The responses are set per route.
+	if p.customResponseInChain[fcc.FilterChainName] {
+		filter := filters.MustNewStagedFilter(customResponseFilterName, &customresponsev3.CustomResponse{}, customResponseFilterStage)
+		filter.Filter.Disabled = true
+		stagedFilters = append(stagedFilters, filter)
+	}
+
 	if len(stagedFilters) == 0 {
 		return nil, nil
 	}
@@ -791,6 +966,16 @@ func (p *trafficPolicyPluginGwPass) handlePolicies(
 	p.handleOauth2(fcn, typedFilterConfig, spec.oauth2)
 	p.handleFaultInjection(fcn, typedFilterConfig, spec.faultInjection)
 	p.handleHttpACL(fcn, typedFilterConfig, spec.httpACL)
+	p.handleGeoIP(fcn, typedFilterConfig, spec.geoIP)
+	p.handleWasm(fcn, typedFilterConfig, spec.wasm)
+	p.handleLua(fcn, typedFilterConfig, spec.lua)
+	p.handleAdaptiveConcurrency(fcn, typedFilterConfig, spec.adaptiveConcurrency)
+	p.handleAdmissionControl(fcn, typedFilterConfig, spec.admissionControl)
+	p.handleBandwidthLimit(fcn, typedFilterConfig, spec.bandwidthLimit)
+	p.handleRequestLimits(fcn, typedFilterConfig, spec.requestLimits)
+	p.handleCache(fcn, typedFilterConfig, spec.cache)
+	p.handleGRPCJSONTranscoder(fcn, typedFilterConfig, spec.grpcJSONTranscoder)
+	p.handleCustomResponse(fcn, typedFilterConfig, spec.customResponse)
 }
 
 // handlePerRoutePolicies handles policies that are meant to be processed at the route level
@@ -854,6 +1039,10 @@ func applyRetryPolicy(retry *retryIR, out *envoyroutev3.Route) {
 	// set by the builtin HTTPRouteRetry policy or a more specific TrafficPolicy.
 	if action.GetRetryPolicy() == nil {
 		action.RetryPolicy = retry.policy
+		// The hedge policy goes with the retry policy, whose per-try timeout triggers the hedging.
+		if retry.hedgePolicy != nil {
+			action.HedgePolicy = retry.hedgePolicy
+		}
 	}
 }
 
@@ -0,0 +1,237 @@
+package trafficpolicy
+
+import (
+	"errors"
+	"fmt"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoywasmfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	envoywasmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/wasm/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	wasmFilterNamePrefix = "wasm"
+	wasmRuntimeV8        = "envoy.wasm.runtime.v8"
+)
+
+var defaultWasmFilterStage = filters.AfterStage(filters.WellKnownFilterStage(filters.AuthZStage))
+
+// wasmIR holds the Wasm filter of a single policy. The Wasm filter has no per-route configuration, so
+// each policy gets its own filter in the chain carrying the policy's plugin configuration, and routes
+// enable the filter of the policy that applies to them.
+type wasmIR struct {
+	filterName  string
+	filter      *envoywasmfilterv3.Wasm
+	filterStage filters.FilterStage[filters.WellKnownFilterStage]
+	weight      int32
+}
+
+var _ PolicySubIR = &wasmIR{}
+
+func (w *wasmIR) Equals(other PolicySubIR) bool {
+	otherWasm, ok := other.(*wasmIR)
+	if !ok {
+		return false
+	}
+	if w == nil || otherWasm == nil {
+		return w == nil && otherWasm == nil
+	}
+	return w.filterName == otherWasm.filterName &&
+		proto.Equal(w.filter, otherWasm.filter) &&
+		w.filterStage == otherWasm.filterStage &&
+		w.weight == otherWasm.weight
+}
+
+func (w *wasmIR) Validate() error {
+	if w == nil || w.filter == nil {
+		return nil
+	}
+	return w.filter.ValidateAll()
+}
+
+// constructWasm constructs the Wasm policy IR from the policy specification.
+func constructWasm(
+	krtctx krt.HandlerContext,
+	in *kgateway.TrafficPolicy,
+	fetchGatewayExtension FetchGatewayExtensionFunc,
+	out *trafficPolicySpecIr,
+) error {
+	spec := in.Spec.Wasm
+	if spec == nil {
+		return nil
+	}
+
+	gatewayExtension, err := fetchGatewayExtension(krtctx, spec.ExtensionRef, in.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("wasm: %w", err)
+	}
+	if gatewayExtension.Wasm == nil {
+		return pluginutils.ErrInvalidExtensionType(kgateway.GatewayExtensionTypeWasm)
+	}
+
+	pluginConfig := proto.Clone(gatewayExtension.Wasm).(*envoywasmv3.PluginConfig)
+	if spec.Config != nil && len(spec.Config.Raw) > 0 {
+		pluginConfig.Configuration = utils.MustMessageToAny(wrapperspb.String(string(spec.Config.Raw)))
+	}
+
+	var weight int32
+	if spec.FilterStage != nil {
+		weight = spec.FilterStage.Weight
+	}
+	out.wasm = &wasmIR{
+		filterName:  wasmFilterName(in.GetNamespace(), in.GetName()),
+		filter:      &envoywasmfilterv3.Wasm{Config: pluginConfig},
+		filterStage: convertFilterStageSpec(spec.FilterStage, defaultWasmFilterStage),
+		weight:      weight,
+	}
+	return nil
+}
+
+// buildWasmPluginConfig translates a Wasm GatewayExtension into the plugin configuration shared by the
+// filters of every policy that references it. The plugin configuration itself is set per policy.
+func buildWasmPluginConfig(
+	krtctx krt.HandlerContext,
+	ext *ir.GatewayExtension,
+	configMaps krt.Collection[*corev1.ConfigMap],
+	backends backendResolver,
+) (*envoywasmv3.PluginConfig, error) {
+	in := ext.Wasm
+
+	code, err := translateWasmModuleSource(krtctx, ext, configMaps, backends)
+	if err != nil {
+		return nil, err
+	}
+
+	failurePolicy := envoywasmv3.FailurePolicy_FAIL_CLOSED
+	if in.FailOpen {
+		failurePolicy = envoywasmv3.FailurePolicy_FAIL_OPEN
+	}
+	name := ext.ResourceName()
+	return &envoywasmv3.PluginConfig{
+		Name:   name,
+		RootId: ptr.Deref(in.RootID, ""),
+		Vm: &envoywasmv3.PluginConfig_VmConfig{
+			VmConfig: &envoywasmv3.VmConfig{
+				VmId:    name,
+				Runtime: wasmRuntimeV8,
+				Code:    code,
+			},
+		},
+		FailurePolicy: failurePolicy,
+	}, nil
+}
+
+func translateWasmModuleSource(
+	krtctx krt.HandlerContext,
+	ext *ir.GatewayExtension,
+	configMaps krt.Collection[*corev1.ConfigMap],
+	backends backendResolver,
+) (*envoycorev3.AsyncDataSource, error) {
+	module := ext.Wasm.Module
+	switch {
+	case module.ConfigMap != nil:
+		cm, err := GetConfigMap(krtctx, configMaps, module.ConfigMap.Name, ext.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find configmap %s: %w", module.ConfigMap.Name, err)
+		}
+		code, ok := cm.BinaryData[module.ConfigMap.Key]
+		if !ok {
+			data, found := cm.Data[module.ConfigMap.Key]
+			if !found {
+				return nil, fmt.Errorf("configmap %s does not contain key '%s'", module.ConfigMap.Name, module.ConfigMap.Key)
+			}
+			code = []byte(data)
+		}
+		if len(code) == 0 {
+			return nil, fmt.Errorf("configmap %s key '%s' is empty", module.ConfigMap.Name, module.ConfigMap.Key)
+		}
+		return &envoycorev3.AsyncDataSource{
+			Specifier: &envoycorev3.AsyncDataSource_Local{
+				Local: &envoycorev3.DataSource{
+					Specifier: &envoycorev3.DataSource_InlineBytes{InlineBytes: code},
+				},
+			},
+		}, nil
+
+	case module.File != nil:
+		return &envoycorev3.AsyncDataSource{
+			Specifier: &envoycorev3.AsyncDataSource_Local{
+				Local: &envoycorev3.DataSource{
+					Specifier: &envoycorev3.DataSource_Filename{Filename: module.File.Path},
+				},
+			},
+		}, nil
+
+	case module.HTTP != nil:
+		backend, err := backends.GetBackendFromRef(krtctx, ext.ObjectSource, module.HTTP.BackendRef.BackendObjectReference)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving wasm module backend %v: %w", module.HTTP.BackendRef.BackendObjectReference, err)
+		}
+		if backend == nil {
+			return nil, fmt.Errorf("wasm module backend not found: %v", module.HTTP.BackendRef.BackendObjectReference)
+		}
+		timeout := durationpb.New(kgateway.HTTPDefaultTimeout)
+		if module.HTTP.Timeout != nil {
+			timeout = durationpb.New(module.HTTP.Timeout.Duration)
+		}
+		return &envoycorev3.AsyncDataSource{
+			Specifier: &envoycorev3.AsyncDataSource_Remote{
+				Remote: &envoycorev3.RemoteDataSource{
+					HttpUri: &envoycorev3.HttpUri{
+						Uri: module.HTTP.URL,
+						HttpUpstreamType: &envoycorev3.HttpUri_Cluster{
+							Cluster: backend.ClusterName(),
+						},
+						Timeout: timeout,
+					},
+					Sha256: module.HTTP.SHA256,
+				},
+			},
+		}, nil
+	}
+	return nil, errors.New("one of configMap, file or http must be set for the wasm module")
+}
+
+func wasmFilterName(namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", wasmFilterNamePrefix, namespace, name)
+}
+
+func (p *trafficPolicyPluginGwPass) handleWasm(fcn string, pCtxTypedFilterConfig *ir.TypedFilterConfigMap, in *wasmIR) {
+	if in == nil {
+		return
+	}
+
+	pCtxTypedFilterConfig.AddTypedConfig(in.filterName, EnableFilterPerRoute())
+
+	if p.wasmInChain == nil {
+		p.wasmInChain = make(map[string][]*wasmIR)
+	}
+	for _, existing := range p.wasmInChain[fcn] {
+		if existing.filterName == in.filterName {
+			return
+		}
+	}
+	p.wasmInChain[fcn] = append(p.wasmInChain[fcn], in)
+}
+
+// addWasmFiltersIfNeeded adds a disabled-by-default filter for every Wasm policy used in the filter chain.
+func addWasmFiltersIfNeeded(staged []filters.StagedHttpFilter, p *trafficPolicyPluginGwPass, fcn string) []filters.StagedHttpFilter {
+	for _, w := range p.wasmInChain[fcn] {
+		filter := filters.MustNewStagedFilterWithWeight(w.filterName, w.filter, w.filterStage, w.weight)
+		filter.Filter.Disabled = true
+		staged = append(staged, filter)
+	}
+	return staged
+}
@@ -0,0 +1,179 @@
+package trafficpolicy
+
+import (
+	"testing"
+	"time"
+
+	envoywasmfilterv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	envoywasmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/wasm/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/shared"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestBuildWasmPluginConfig(t *testing.T) {
+	extSource := ir.ObjectSource{Kind: "GatewayExtension", Namespace: "ext-ns", Name: "wasm-ext"}
+	configMaps := krt.NewStaticCollection(nil, []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "module", Namespace: "ext-ns"},
+			BinaryData: map[string][]byte{"plugin.wasm": []byte("\x00asm")},
+		},
+	})
+
+	t.Run("configmap module", func(t *testing.T) {
+		ext := &ir.GatewayExtension{
+			ObjectSource: extSource,
+			Wasm: &kgateway.WasmProvider{
+				Module: kgateway.WasmModuleSource{
+					ConfigMap: &kgateway.WasmConfigMapSource{Name: "module", Key: "plugin.wasm"},
+				},
+				RootID: new("my_root"),
+			},
+		}
+
+		out, err := buildWasmPluginConfig(krt.TestingDummyContext{}, ext, configMaps, nil)
+		require.NoError(t, err)
+		assert.Equal(t, extSource.ResourceName(), out.GetName())
+		assert.Equal(t, "my_root", out.GetRootId())
+		assert.Equal(t, envoywasmv3.FailurePolicy_FAIL_CLOSED, out.GetFailurePolicy())
+		assert.Equal(t, wasmRuntimeV8, out.GetVmConfig().GetRuntime())
+		assert.Equal(t, []byte("\x00asm"), out.GetVmConfig().GetCode().GetLocal().GetInlineBytes())
+	})
+
+	t.Run("missing configmap key", func(t *testing.T) {
+		ext := &ir.GatewayExtension{
+			ObjectSource: extSource,
+			Wasm: &kgateway.WasmProvider{
+				Module: kgateway.WasmModuleSource{
+					ConfigMap: &kgateway.WasmConfigMapSource{Name: "module", Key: "other.wasm"},
+				},
+			},
+		}
+
+		_, err := buildWasmPluginConfig(krt.TestingDummyContext{}, ext, configMaps, nil)
+		assert.EqualError(t, err, "configmap module does not contain key 'other.wasm'")
+	})
+
+	t.Run("file module", func(t *testing.T) {
+		ext := &ir.GatewayExtension{
+			ObjectSource: extSource,
+			Wasm: &kgateway.WasmProvider{
+				Module:   kgateway.WasmModuleSource{File: &kgateway.WasmFileSource{Path: "/etc/wasm/plugin.wasm"}},
+				FailOpen: true,
+			},
+		}
+
+		out, err := buildWasmPluginConfig(krt.TestingDummyContext{}, ext, configMaps, nil)
+		require.NoError(t, err)
+		assert.Equal(t, envoywasmv3.FailurePolicy_FAIL_OPEN, out.GetFailurePolicy())
+		assert.Equal(t, "/etc/wasm/plugin.wasm", out.GetVmConfig().GetCode().GetLocal().GetFilename())
+	})
+
+	t.Run("http module", func(t *testing.T) {
+		backendVal := ir.NewBackendObjectIR(ir.ObjectSource{
+			Kind:      "Service",
+			Namespace: "ext-ns",
+			Name:      "modules",
+		}, 80, "", "svc")
+		sha := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+		ext := &ir.GatewayExtension{
+			ObjectSource: extSource,
+			Wasm: &kgateway.WasmProvider{
+				Module: kgateway.WasmModuleSource{
+					HTTP: &kgateway.WasmHTTPSource{
+						URL:        "http://modules.ext-ns/plugin.wasm",
+						SHA256:     sha,
+						BackendRef: gwv1.BackendRef{BackendObjectReference: gwv1.BackendObjectReference{Name: "modules"}},
+						Timeout:    &metav1.Duration{Duration: 5 * time.Second},
+					},
+				},
+			},
+		}
+
+		out, err := buildWasmPluginConfig(krt.TestingDummyContext{}, ext, configMaps, &fakeBackendResolver{backend: &backendVal})
+		require.NoError(t, err)
+		remote := out.GetVmConfig().GetCode().GetRemote()
+		assert.Equal(t, sha, remote.GetSha256())
+		assert.Equal(t, "http://modules.ext-ns/plugin.wasm", remote.GetHttpUri().GetUri())
+		assert.Equal(t, backendVal.ClusterName(), remote.GetHttpUri().GetCluster())
+		assert.Equal(t, 5*time.Second, remote.GetHttpUri().GetTimeout().AsDuration())
+	})
+}
+
+func TestConstructWasm(t *testing.T) {
+	provider := &TrafficPolicyGatewayExtensionIR{
+		Name: "ext-ns/wasm-ext",
+		Wasm: &envoywasmv3.PluginConfig{Name: "ext-ns/wasm-ext"},
+	}
+	fetch := func(_ krt.HandlerContext, _ shared.NamespacedObjectReference, _ string) (*TrafficPolicyGatewayExtensionIR, error) {
+		return provider, nil
+	}
+	policy := &kgateway.TrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Spec: kgateway.TrafficPolicySpec{
+			Wasm: &kgateway.WasmPolicy{
+				ExtensionRef: shared.NamespacedObjectReference{Name: "wasm-ext"},
+				Config:       &apiextensionsv1.JSON{Raw: []byte(`{"header":"x-team"}`)},
+				FilterStage: &kgateway.FilterStageSpec{
+					Stage:     kgateway.FilterStageAuthN,
+					Predicate: kgateway.FilterStagePredicateBefore,
+					Weight:    3,
+				},
+			},
+		},
+	}
+
+	out := &trafficPolicySpecIr{}
+	require.NoError(t, constructWasm(nil, policy, fetch, out))
+	require.NotNil(t, out.wasm)
+	assert.Equal(t, "wasm/default/policy", out.wasm.filterName)
+	assert.Equal(t, filters.BeforeStage(filters.AuthNStage), out.wasm.filterStage)
+	assert.Equal(t, int32(3), out.wasm.weight)
+
+	cfg := &wrapperspb.StringValue{}
+	require.NoError(t, out.wasm.filter.GetConfig().GetConfiguration().UnmarshalTo(cfg))
+	assert.JSONEq(t, `{"header":"x-team"}`, cfg.GetValue())
+	// the extension's plugin config is shared between policies and must not be modified
+	assert.Nil(t, provider.Wasm.GetConfiguration())
+
+	t.Run("rejects other extension types", func(t *testing.T) {
+		provider := &TrafficPolicyGatewayExtensionIR{Name: "ext-ns/extproc"}
+		fetch := func(_ krt.HandlerContext, _ shared.NamespacedObjectReference, _ string) (*TrafficPolicyGatewayExtensionIR, error) {
+			return provider, nil
+		}
+		err := constructWasm(nil, policy, fetch, &trafficPolicySpecIr{})
+		assert.ErrorContains(t, err, "Wasm")
+	})
+}
+
+func TestHttpFiltersWasm(t *testing.T) {
+	plugin := &trafficPolicyPluginGwPass{}
+	w := &wasmIR{
+		filterName:  "wasm/default/policy",
+		filter:      &envoywasmfilterv3.Wasm{Config: &envoywasmv3.PluginConfig{Name: "ext-ns/wasm-ext"}},
+		filterStage: defaultWasmFilterStage,
+	}
+	typedFilterConfig := ir.TypedFilterConfigMap{}
+	plugin.handleWasm("test-filter-chain", &typedFilterConfig, w)
+	// a second route using the same policy does not add another filter
+	plugin.handleWasm("test-filter-chain", &typedFilterConfig, w)
+	require.Len(t, plugin.wasmInChain["test-filter-chain"], 1)
+	assert.NotNil(t, typedFilterConfig.GetTypedConfig("wasm/default/policy"))
+
+	httpFilters, err := plugin.HttpFilters(ir.HttpFiltersContext{}, ir.FilterChainCommon{FilterChainName: "test-filter-chain"})
+	require.NoError(t, err)
+	require.Len(t, httpFilters, 1)
+	assert.Equal(t, "wasm/default/policy", httpFilters[0].Filter.GetName())
+	assert.True(t, httpFilters[0].Filter.GetDisabled())
+	assert.Equal(t, defaultWasmFilterStage, httpFilters[0].Stage)
+}
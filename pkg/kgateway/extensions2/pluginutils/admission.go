@@ -0,0 +1,29 @@
+package pluginutils
+
+import (
+	"context"
+	"fmt"
+
+	"istio.io/istio/pkg/kube/krt"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+)
+
+// ValidatePolicyFn adapts the function a plugin uses to build a policy's IR and errors into a
+// sdk.ValidatePolicyFn for the admission webhook, so that admission rejects exactly the errors
+// that would be reported on the policy's status.
+//
+// Admission requests are not part of a krt collection, so validate is called with a nil
+// HandlerContext: lookups done through krtutil.Fetch and krtutil.FetchOne then read the current
+// state of the collections without registering a dependency. Lookups reached from validate must
+// use these rather than krt.Fetch and krt.FetchOne, which panic on a nil HandlerContext.
+func ValidatePolicyFn[T runtime.Object](validate func(krtctx krt.HandlerContext, obj T) []error) sdk.ValidatePolicyFn {
+	return func(_ context.Context, obj runtime.Object) []error {
+		typed, ok := obj.(T)
+		if !ok {
+			return []error{fmt.Errorf("unexpected object type %T", obj)}
+		}
+		return validate(nil, typed)
+	}
+}
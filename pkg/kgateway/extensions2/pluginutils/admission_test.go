@@ -0,0 +1,41 @@
+package pluginutils_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/pluginutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestValidatePolicyFnFetchesReferencedObjects(t *testing.T) {
+	gwExts := krt.NewStaticCollection(nil, []ir.GatewayExtension{{
+		ObjectSource: ir.ObjectSource{
+			Group:     wellknown.GatewayExtensionGVK.Group,
+			Kind:      wellknown.GatewayExtensionGVK.Kind,
+			Namespace: "default",
+			Name:      "ext-auth",
+		},
+	}})
+	validate := pluginutils.ValidatePolicyFn(func(krtctx krt.HandlerContext, tp *kgateway.TrafficPolicy) []error {
+		if _, err := pluginutils.GetGatewayExtension(gwExts, krtctx, tp.Name, tp.Namespace); err != nil {
+			return []error{err}
+		}
+		return nil
+	})
+
+	policy := func(name string) *kgateway.TrafficPolicy {
+		return &kgateway.TrafficPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+	}
+	assert.Empty(t, validate(context.Background(), policy("ext-auth")))
+	errs := validate(context.Background(), policy("missing"))
+	if assert.Len(t, errs, 1) {
+		assert.ErrorIs(t, errs[0], pluginutils.ErrGatewayExtensionNotFound)
+	}
+}
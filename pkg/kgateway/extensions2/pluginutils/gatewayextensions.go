@@ -9,6 +9,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
 // ErrGatewayExtensionNotFound is returned when a referenced GatewayExtension cannot be resolved.
@@ -45,7 +46,7 @@ func GetGatewayExtension(
 		Name:      extensionName,
 		Namespace: ns,
 	}
-	gwExt := krt.FetchOne(kctx, gwExts, krt.FilterKey(gwExtKey.ResourceName()))
+	gwExt := krtutil.FetchOne(kctx, gwExts, krt.FilterKey(gwExtKey.ResourceName()))
 	if gwExt == nil {
 		return nil, fmt.Errorf("%s/%s: %w", ns, extensionName, ErrGatewayExtensionNotFound)
 	}
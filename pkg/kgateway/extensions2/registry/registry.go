@@ -11,6 +11,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/backend"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/backendconfigpolicy"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/backendtlspolicy"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/backendtrafficpolicy"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/destrule"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/directresponse"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/httplistenerpolicy"
@@ -92,5 +93,6 @@ func Plugins(
 		serviceentry.NewPlugin(ctx, commoncol),
 		sandwich.NewPlugin(),
 		backendconfigpolicy.NewPlugin(ctx, commoncol, validator),
+		backendtrafficpolicy.NewPlugin(ctx, commoncol),
 	}
 }
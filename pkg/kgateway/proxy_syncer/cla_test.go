@@ -142,3 +142,50 @@ func TestTranslatesDestrulesFailover(t *testing.T) {
 	g.Expect(localLocality.Priority).To(gomega.Equal(uint32(0)))
 	g.Expect(remoteLocality.Priority).To(gomega.Equal(uint32(1)))
 }
+
+func TestTranslatesEndpointPriorities(t *testing.T) {
+	g := gomega.NewWithT(t)
+	us := ir.NewBackendObjectIR(ir.ObjectSource{
+		Namespace: "ns",
+		Name:      "name",
+	}, 0, "", "")
+	efu := ir.NewEndpointsForBackend(us)
+	for _, ep := range []struct {
+		path     string
+		priority uint32
+	}{{"a", 0}, {"b", 1}, {"c", 0}} {
+		efu.Add(ir.PodLocality{}, ir.EndpointWithMd{
+			LbEndpoint: &envoyendpointv3.LbEndpoint{
+				HostIdentifier: &envoyendpointv3.LbEndpoint_Endpoint{
+					Endpoint: &envoyendpointv3.Endpoint{
+						Address: &envoycorev3.Address{
+							Address: &envoycorev3.Address_Pipe{Pipe: &envoycorev3.Pipe{Path: ep.path}},
+						},
+					},
+				},
+			},
+			EndpointMd: ir.EndpointMetadata{Priority: ep.priority},
+		})
+	}
+
+	cla := endpoints.PrioritizeEndpoints(nil, ir.UniquelyConnectedClient{}, endpoints.EndpointsInputs{
+		EndpointsForBackend: *efu,
+	})
+	g.Expect(cla.Endpoints).To(gomega.HaveLen(2))
+	g.Expect(cla.Endpoints[0].Priority).To(gomega.Equal(uint32(0)))
+	g.Expect(cla.Endpoints[0].LbEndpoints).To(gomega.HaveLen(2))
+	g.Expect(cla.Endpoints[1].Priority).To(gomega.Equal(uint32(1)))
+	g.Expect(cla.Endpoints[1].LbEndpoints).To(gomega.HaveLen(1))
+	g.Expect(cla.Endpoints[1].LbEndpoints[0].GetEndpoint().GetAddress().GetPipe().GetPath()).To(gomega.Equal("b"))
+
+	// The endpoint priority is more significant than the failover priority.
+	cla = endpoints.PrioritizeEndpoints(nil, ir.UniquelyConnectedClient{}, endpoints.EndpointsInputs{
+		EndpointsForBackend: *efu,
+		PriorityInfo: &endpoints.PriorityInfo{
+			FailoverPriority: endpoints.NewPriorities([]string{corev1.LabelTopologyRegion}),
+		},
+	})
+	g.Expect(cla.Endpoints).To(gomega.HaveLen(2))
+	g.Expect(cla.Endpoints[0].Priority).To(gomega.Equal(uint32(0)))
+	g.Expect(cla.Endpoints[1].Priority).To(gomega.Equal(uint32(2)))
+}
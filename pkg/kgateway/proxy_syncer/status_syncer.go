@@ -195,6 +195,10 @@ func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger,
 					for _, parentRef := range r.Spec.ParentRefs {
 						gatewayNames = append(gatewayNames, string(parentRef.Name))
 					}
+				case *gwv1a2.UDPRoute:
+					for _, parentRef := range r.Spec.ParentRefs {
+						gatewayNames = append(gatewayNames, string(parentRef.Name))
+					}
 				case *unstructured.Unstructured:
 					if unstructuredTLSRoute := collections.ConvertUnstructuredTLSRouteToV1Alpha2ForStatus(r); unstructuredTLSRoute != nil {
 						for _, parentRef := range unstructuredTLSRoute.Spec.ParentRefs {
@@ -328,6 +332,12 @@ func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger,
 				return nil, nil
 			}
 			r.Status.RouteStatus = *status
+		case *gwv1a2.UDPRoute:
+			status = rm.BuildRouteStatus(ctx, r, s.controllerName)
+			if status == nil || isRouteStatusEqual(&r.Status.RouteStatus, status) {
+				return nil, nil
+			}
+			r.Status.RouteStatus = *status
 		case *unstructured.Unstructured:
 			unstructuredTLSRoute := collections.ConvertUnstructuredTLSRouteToV1Alpha2ForStatus(r)
 			if unstructuredTLSRoute == nil {
@@ -399,6 +409,21 @@ func (s *StatusSyncer) syncRouteStatus(ctx context.Context, logger *slog.Logger,
 		}
 	}
 
+	// Sync UDPRoute statuses
+	for rnn := range rm.UDPRoutes {
+		err := syncStatusWithRetry(wellknown.UDPRouteKind, rnn,
+			func(ctx context.Context, routeKey client.ObjectKey) (client.Object, error) {
+				route := new(gwv1a2.UDPRoute)
+				return route, s.mgr.GetClient().Get(ctx, routeKey, route)
+			},
+			func(route client.Object) (*gwv1.RouteStatus, error) {
+				return buildAndUpdateStatus(route, wellknown.UDPRouteKind)
+			})
+		if err != nil {
+			logger.Error("all attempts failed at updating UDPRoute status", "error", err, "route", rnn)
+		}
+	}
+
 	// Sync GRPCRoute statuses
 	for rnn := range rm.GRPCRoutes {
 		err := syncStatusWithRetry(wellknown.GRPCRouteKind, rnn,
@@ -787,6 +812,7 @@ func (s *StatusSyncer) syncPolicyStatus(ctx context.Context, rm reports.ReportMa
 					}
 
 					if cond.Reason != string(shared.PolicyReasonValid) &&
+						cond.Reason != string(shared.PolicyReasonPartiallyValid) &&
 						cond.Reason != string(shared.PolicyReasonPending) {
 						statusErr = fmt.Errorf("invalid policy condition")
 
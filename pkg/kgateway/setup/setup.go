@@ -28,6 +28,7 @@ import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/admin"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/controller"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/proxy_syncer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/webhook"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
 	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
@@ -443,6 +444,13 @@ func (s *setup) buildKgatewayWithConfig(
 		return err
 	}
 
+	if s.globalSettings.EnableValidationWebhook {
+		slog.Info("adding validation webhook server", "port", s.globalSettings.ValidationWebhookPort)
+		if err := mgr.Add(webhook.NewServer(s.globalSettings.ValidationWebhookPort, mgr.GetScheme(), c.Plugins())); err != nil {
+			return fmt.Errorf("error adding validation webhook server to manager: %w", err)
+		}
+	}
+
 	// RunAndWait must be called AFTER all Informers clients have been created
 	s.apiClient.RunAndWait(ctx.Done())
 
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -25,8 +26,10 @@ import (
 
 	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
 	backendplugin "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/plugins/backend"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/fsutils"
 	"github.com/kgateway-dev/kgateway/v2/pkg/version"
+	"github.com/kgateway-dev/kgateway/v2/test/testutils"
 	translatortest "github.com/kgateway-dev/kgateway/v2/test/translator"
 )
 
@@ -456,6 +459,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy API Key Authentication with hashed keys", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/api-key-auth-hashed.yaml"},
+			outputFile: "traffic-policy/api-key-auth-hashed.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy API Key Authentication with SecretRef and ReferenceGrant", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"traffic-policy/api-key-auth-secretref-with-refgrant.yaml"},
@@ -544,6 +558,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("Basic auth with bcrypt and argon2 users in realms", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"basic-auth/bcrypt-argon2-realms.yaml"},
+			outputFile: "basic-auth/bcrypt-argon2-realms.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("Basic auth with {SHA} users is PartiallyValid", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"basic-auth/weak-hash-status.yaml"},
+			outputFile: "basic-auth/weak-hash-status.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("Basic auth at gateway level with route override", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"basic-auth/gateway-with-route-override.yaml"},
@@ -643,6 +679,94 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy Wasm different attachment points", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/wasm.yaml"},
+			outputFile: "traffic-policy/wasm.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy GeoIP headers and country and ASN ACL rules", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/geoip-acl.yaml"},
+			outputFile: "traffic-policy/geoip-acl.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy GeoIP of a merged route ACL", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/geoip-acl-merge.yaml"},
+			outputFile: "traffic-policy/geoip-acl-merge.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy AdaptiveConcurrency and AdmissionControl", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/adaptive-concurrency-admission-control.yaml"},
+			outputFile: "traffic-policy/adaptive-concurrency-admission-control.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy Cache different attachment points", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/cache.yaml"},
+			outputFile: "traffic-policy/cache.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy gRPC-JSON transcoder", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/grpc-json-transcoder.yaml"},
+			outputFile: "traffic-policy/grpc-json-transcoder.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy CustomResponse different attachment points", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/custom-response.yaml"},
+			outputFile: "traffic-policy/custom-response.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy Lua different attachment points", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/lua.yaml"},
+			outputFile: "traffic-policy/lua.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy ExtProc Full Config", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"traffic-policy/extproc-full-config.yaml"},
@@ -982,6 +1106,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy with bandwidth limit per gateway and per route", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/bandwidth-limit.yaml"},
+			outputFile: "traffic-policy/bandwidth-limit.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy with request limits per gateway and per route", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/request-limits.yaml"},
+			outputFile: "traffic-policy/request-limits.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy with header modifiers attached to gateway", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"traffic-policy/header-modifiers-gateway.yaml"},
@@ -1266,6 +1412,50 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("udp gateway with udproute and session idle timeout", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"udp-routing/basic.yaml"},
+			outputFile: "udp-routing/basic.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("udproute with multiple weighted backends is rejected", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"udp-routing/multiple-weighted-backends.yaml"},
+			outputFile: "udp-routing/multiple-weighted-backends.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("udproute with multiple rules is rejected", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"udp-routing/multiple-rules.yaml"},
+			outputFile: "udp-routing/multiple-rules.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("udproute with missing backend reports correctly", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"udp-routing/missing-backend.yaml"},
+			outputFile: "udp-routing/missing-backend.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("tls gateway with tcproute", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"tcp-routing/tls.yaml"},
@@ -1420,6 +1610,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("AWS Lambda backend with response streaming and async response", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"backends/aws_lambda_stream.yaml"},
+			outputFile: "backends/aws_lambda_stream.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("AWS Lambda backend with AssumeRole auth", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"backends/aws_lambda_assume_role.yaml"},
@@ -1467,6 +1668,55 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("Consul backend", func(t *testing.T) {
+		restore := backendplugin.SetConsulInstancesForTest([]backendplugin.TestConsulInstance{
+			{Address: "10.1.0.10", Port: 8080, Datacenter: "dc1"},
+			{Address: "10.1.0.11", Port: 8080, Datacenter: "dc1"},
+		})
+		defer restore()
+
+		test(t, translatorTestCase{
+			inputFiles: []string{"backends/consul.yaml"},
+			outputFile: "backends/consul.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		}, func(s *apisettings.Settings) {
+			s.EnableConsulDiscovery = true
+		})
+	})
+
+	t.Run("DNS SRV backend", func(t *testing.T) {
+		restore := backendplugin.SetDnsSrvRecordsForTest([]backendplugin.TestDnsSrvRecord{
+			{Addresses: []string{"10.1.0.10", "10.1.0.11"}, Port: 8080, Priority: 10, Weight: 60},
+			{Addresses: []string{"10.2.0.10"}, Port: 8080, Priority: 20, Weight: 40},
+		})
+		defer restore()
+
+		test(t, translatorTestCase{
+			inputFiles: []string{"backends/dns_srv.yaml"},
+			outputFile: "backends/dns_srv.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		}, func(s *apisettings.Settings) {
+			s.EnableDnsSrvDiscovery = true
+		})
+	})
+
+	t.Run("File backend", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"backends/file.yaml"},
+			outputFile: "backends/file.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("GCP backend", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"backends/gcp_backend.yaml"},
@@ -1741,6 +1991,28 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("TrafficPolicy retry with hedging and host selection", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/retry-hedging.yaml"},
+			outputFile: "traffic-policy/retry-hedging.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
+	t.Run("TrafficPolicy route retry past the Gateway request timeout", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"traffic-policy/retry-deadline-gateway.yaml"},
+			outputFile: "traffic-policy/retry-deadline-gateway.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("TrafficPolicy HTTPS retry", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"traffic-policy/https-retry.yaml"},
@@ -1851,6 +2123,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("XBackendTrafficPolicy with retry budget and session persistence", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"backend-traffic-policy/retry-budget-session-persistence.yaml"},
+			outputFile: "backend-traffic-policy/retry-budget-session-persistence.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("HTTPListenerPolicy with upgrades", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"https-listener-pol/upgrades.yaml"},
@@ -2766,6 +3049,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("Backend Config Policy with credential injection", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"backendconfigpolicy/credential-injection.yaml"},
+			outputFile: "backendconfigpolicy/credential-injection.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("Backend Config Policy with upstream proxy protocol V1", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"backendconfigpolicy/upstream-proxy-protocol-v1.yaml"},
@@ -3382,6 +3676,17 @@ func TestBasic(t *testing.T) {
 		})
 	})
 
+	t.Run("ListenerPolicy with HTTP/3 on HTTPS listener", func(t *testing.T) {
+		test(t, translatorTestCase{
+			inputFiles: []string{"listener-policy/http3.yaml"},
+			outputFile: "listener-policy/http3.yaml",
+			gwNN: types.NamespacedName{
+				Namespace: "default",
+				Name:      "example-gateway",
+			},
+		})
+	})
+
 	t.Run("ListenerPolicy with per-listener mTLS override", func(t *testing.T) {
 		test(t, translatorTestCase{
 			inputFiles: []string{"listener-policy/per-listener-mtls.yaml"},
@@ -3743,6 +4048,59 @@ func requireGatewayClientCertificateCommonName(t *testing.T, cluster *envoyclust
 	return certificate.Subject.CommonName
 }
 
+// TestValidatePolicyResolvesReferences runs the ValidatePolicy of every policy plugin the way the
+// admission webhook does, outside of any krt collection, against policies that reference other
+// objects, so that a lookup that needs a krt HandlerContext fails here instead of on an admission
+// request.
+func TestValidatePolicyResolvesReferences(t *testing.T) {
+	dir := fsutils.MustGetThisDir()
+	scheme := translatortest.NewScheme(runtime.SchemeBuilder{})
+	gvkToStructuralSchema, err := testutils.GetStructuralSchemasForAllCharts()
+	require.NoError(t, err)
+
+	var validators map[schema.GroupKind]pluginsdk.PolicyPlugin
+	validated := map[schema.GroupKind]bool{}
+	for _, input := range []string{
+		// A GatewayExtension with a JWKS ConfigMap.
+		"jwt/gateway-configmap.yaml",
+		// A basic auth Secret.
+		"basic-auth/secret-ref.yaml",
+		// A TLS Secret.
+		"backendconfigpolicy/simple-tls.yaml",
+		// A backendRef to the OpenTelemetry collector.
+		"listener-policy-http/opentelemetry.yaml",
+	} {
+		t.Run(input, func(t *testing.T) {
+			inputFile := filepath.Join(dir, "testutils/inputs", input)
+			results, err := translatortest.TestCase{InputFiles: []string{inputFile}}.Run(t, t.Context(), scheme, translatortest.ExtraConfig{})
+			require.NoError(t, err)
+			for _, result := range results {
+				validators = result.PolicyPlugins
+			}
+			require.NotNil(t, validators, "expected a translated gateway")
+
+			objs, err := testutils.LoadFromFiles(inputFile, scheme, gvkToStructuralSchema)
+			require.NoError(t, err)
+			for _, obj := range objs {
+				gvks, _, err := scheme.ObjectKinds(obj)
+				require.NoError(t, err)
+				validate := validators[gvks[0].GroupKind()].ValidatePolicy
+				if validate == nil {
+					continue
+				}
+				assert.Empty(t, validate(t.Context(), obj), "%s %s/%s", gvks[0].Kind, obj.GetNamespace(), obj.GetName())
+				validated[gvks[0].GroupKind()] = true
+			}
+		})
+	}
+
+	for gk, plugin := range validators {
+		if plugin.ValidatePolicy != nil {
+			assert.True(t, validated[gk], "no policy of kind %s was validated", gk.Kind)
+		}
+	}
+}
+
 func TestValidation(t *testing.T) {
 	type validationTest struct {
 		name      string
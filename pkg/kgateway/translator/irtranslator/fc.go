@@ -3,6 +3,8 @@ package irtranslator
 import (
 	"fmt"
 
+	cncfcorev3 "github.com/cncf/xds/go/xds/core/v3"
+	cncfmatcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
@@ -10,6 +12,7 @@ import (
 	envoy_tls_inspector "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
 	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	envoytcp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	envoyudp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/udp_proxy/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	envoymatcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -20,6 +23,7 @@ import (
 
 	"github.com/kgateway-dev/kgateway/v2/api/annotations"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	kgwwellknown "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/filters"
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
 	sdkreporter "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/reporter"
@@ -466,6 +470,44 @@ func (h *filterChainTranslator) computeTcpFilters(l ir.TcpIR, listenerReporter s
 	return append(networkFilters, tcpFilter)
 }
 
+// computeUdpListenerFilter returns the UDP proxy listener filter that forwards the
+// sessions of a UDP listener to its backend.
+func (h *filterChainTranslator) computeUdpListenerFilter(l ir.UdpIR) (*envoylistenerv3.ListenerFilter, error) {
+	if h.reporter != nil {
+		reportBackendObjectPolicyStatus(h.reporter, h.listener.PolicyAncestorRef, h.pluginPass, l.BackendRef.BackendObject)
+	}
+
+	route, err := utils.MessageToAny(&envoyudp.Route{Cluster: l.BackendRef.ClusterName})
+	if err != nil {
+		return nil, err
+	}
+	cfg := &envoyudp.UdpProxyConfig{
+		StatPrefix: l.Name,
+		RouteSpecifier: &envoyudp.UdpProxyConfig_Matcher{
+			Matcher: &cncfmatcherv3.Matcher{
+				OnNoMatch: &cncfmatcherv3.Matcher_OnMatch{
+					OnMatch: &cncfmatcherv3.Matcher_OnMatch_Action{
+						Action: &cncfcorev3.TypedExtensionConfig{
+							Name:        "route",
+							TypedConfig: route,
+						},
+					},
+				},
+			},
+		},
+	}
+	typedConfig, err := utils.MessageToAny(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &envoylistenerv3.ListenerFilter{
+		Name: kgwwellknown.UdpProxyFilterName,
+		ConfigType: &envoylistenerv3.ListenerFilter_TypedConfig{
+			TypedConfig: typedConfig,
+		},
+	}, nil
+}
+
 func NewFilterWithTypedConfig(name string, config proto.Message) (*envoylistenerv3.Filter, error) {
 	s := &envoylistenerv3.Filter{
 		Name: name,
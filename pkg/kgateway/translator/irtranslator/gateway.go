@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
@@ -51,13 +52,23 @@ func (t *Translator) Translate(ctx context.Context, gw ir.GatewayIR, reporter sd
 	for _, l := range gw.Listeners {
 		outListener, routes := t.ComputeListener(ctx, pass, gw, l, reporter)
 		// Envoy rejects listeners with no filter chains; skip adding such listeners.
-		if outListener == nil || len(outListener.GetFilterChains()) == 0 {
+		// UDP listeners have no filter chains, and are handled by their listener filter instead.
+		if outListener == nil || (len(outListener.GetFilterChains()) == 0 && l.UdpListener == nil) {
 			originalListenerName := findOriginalListenerName(gw, l)
 			logger.Warn("invalid listener due to no filter chains generated", "listener", originalListenerName)
 			continue
 		}
 		res.Listeners = append(res.Listeners, outListener)
 		res.Routes = append(res.Routes, routes...)
+
+		if l.Quic != nil {
+			quicListener, err := computeQuicListener(outListener, l.Quic)
+			if err != nil {
+				logger.Error("failed to compute HTTP/3 listener", "listener", l.Name, "error", err)
+			} else if quicListener != nil {
+				res.Listeners = append(res.Listeners, quicListener)
+			}
+		}
 	}
 
 	for _, c := range pass {
@@ -110,7 +121,23 @@ func (t *Translator) ComputeListener(
 		Name:    lis.Name,
 		Address: listenerAddress,
 	}
-	if gw.PerConnectionBufferLimitBytes != nil {
+	if lis.UdpListener != nil {
+		// The UDP proxy is built before the listener plugins run so that they can
+		// configure it, e.g. to set the session idle timeout.
+		listenerAddress.GetSocketAddress().Protocol = envoycorev3.SocketAddress_UDP
+		fct := filterChainTranslator{
+			listener:   lis,
+			gateway:    gw,
+			reporter:   reporter,
+			pluginPass: pass,
+		}
+		udpFilter, err := fct.computeUdpListenerFilter(*lis.UdpListener)
+		if err != nil {
+			logger.Error("failed to compute UDP proxy listener filter", "listener", lis.Name, "error", err)
+			return nil, nil
+		}
+		ret.ListenerFilters = append(ret.GetListenerFilters(), udpFilter)
+	} else if gw.PerConnectionBufferLimitBytes != nil {
 		ret.PerConnectionBufferLimitBytes = &wrapperspb.UInt32Value{Value: *gw.PerConnectionBufferLimitBytes}
 	}
 	t.runListenerPlugins(pass, gw, lis, reporter, ret)
@@ -145,6 +172,9 @@ func (t *Translator) ComputeListener(
 		}
 		rc := hr.ComputeRouteConfiguration(ctx, hfc.Vhosts)
 		if rc != nil {
+			if lis.Quic != nil && hfc.FilterChainCommon.TLS != nil {
+				rc.ResponseHeadersToAdd = append(rc.GetResponseHeadersToAdd(), altSvcHeaderValue(lis.BindPort, lis.Quic))
+			}
 			routes = append(routes, rc)
 
 			// Record metrics for the number of domains per listener.
@@ -316,6 +346,7 @@ func (t *Translator) newPass(reporter sdkreporter.Reporter) TranslationPassPlugi
 			ProxyTranslationPass: tp,
 			Name:                 v.Name,
 			MergePolicies:        v.MergePolicies,
+			ValidateMergedPolicy: v.ValidateMergedPolicy,
 		}
 	}
 	return ret
@@ -328,4 +359,6 @@ type TranslationPass struct {
 	// such that policies ordered from high to low priority, both hierarchically
 	// and within the same hierarchy, are Merged into a single Policy
 	MergePolicies func(policies []ir.PolicyAtt) ir.PolicyAtt
+	// ValidateMergedPolicy, when set, validates the policies of a route merged with the ones it inherits
+	ValidateMergedPolicy func(policies []ir.PolicyAtt, merged ir.PolicyAtt) []error
 }
@@ -1,6 +1,8 @@
 package irtranslator
 
 import (
+	"strings"
+
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	"google.golang.org/protobuf/types/known/structpb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,6 +49,17 @@ func reportPolicyAcceptanceStatus(
 			continue
 		}
 
+		if w, ok := policy.PolicyIr.(ir.PolicyWarningsIR); ok && len(w.Warnings()) > 0 {
+			r.SetCondition(reporter.PolicyCondition{
+				Type:               string(shared.PolicyConditionAccepted),
+				Status:             metav1.ConditionTrue,
+				Reason:             string(shared.PolicyReasonPartiallyValid),
+				Message:            strings.Join(w.Warnings(), "; "),
+				ObservedGeneration: policy.Generation,
+			})
+			continue
+		}
+
 		r.SetCondition(reporter.PolicyCondition{
 			Type:               string(shared.PolicyConditionAccepted),
 			Status:             metav1.ConditionTrue,
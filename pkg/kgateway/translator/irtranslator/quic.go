@@ -0,0 +1,122 @@
+package irtranslator
+
+import (
+	"fmt"
+
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyhttp "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	envoyquicv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/quic/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+const (
+	// QuicListenerSuffix is appended to the name of an HTTPS listener to name its HTTP/3 companion listener.
+	QuicListenerSuffix = "~quic"
+
+	altSvcHeader = "alt-svc"
+)
+
+var quicAlpnProtocols = []string{"h3"}
+
+// altSvcHeaderValue returns the header that advertises HTTP/3 on the given port to clients
+// connecting over TCP, as defined in RFC 7838.
+func altSvcHeaderValue(port uint32, quic *ir.QuicIR) *envoycorev3.HeaderValueOption {
+	return &envoycorev3.HeaderValueOption{
+		Header: &envoycorev3.HeaderValue{
+			Key:   altSvcHeader,
+			Value: fmt.Sprintf(`h3=":%d"; ma=%d`, port, int64(quic.AltSvcMaxAge.Seconds())),
+		},
+		AppendAction: envoycorev3.HeaderValueOption_ADD_IF_ABSENT,
+	}
+}
+
+// computeQuicListener derives the HTTP/3 listener from the translated TCP listener, so that both serve
+// the same filter chains with the same certificates. Only the TLS filter chains are served over QUIC.
+// Returns nil if the TCP listener has no TLS filter chains.
+func computeQuicListener(tcpListener *envoylistenerv3.Listener, quic *ir.QuicIR) (*envoylistenerv3.Listener, error) {
+	out := proto.Clone(tcpListener).(*envoylistenerv3.Listener)
+	out.Name = tcpListener.GetName() + QuicListenerSuffix
+	out.GetAddress().GetSocketAddress().Protocol = envoycorev3.SocketAddress_UDP
+	out.UdpListenerConfig = &envoylistenerv3.UdpListenerConfig{
+		QuicOptions: quic.Options,
+		DownstreamSocketConfig: &envoycorev3.UdpSocketConfig{
+			PreferGro: wrapperspb.Bool(true),
+		},
+	}
+	// Listener filters and socket options such as TLS inspection, proxy protocol and TCP keepalive
+	// only apply to TCP.
+	out.ListenerFilters = nil
+	out.ListenerFiltersTimeout = nil
+	out.SocketOptions = nil
+	out.TcpKeepalive = nil
+	out.TcpFastOpenQueueLength = nil
+	out.TcpBacklogSize = nil
+	out.ConnectionBalanceConfig = nil
+	out.EnableMptcp = false
+	out.FilterChains = nil
+
+	for _, fc := range tcpListener.GetFilterChains() {
+		if fc.GetTransportSocket().GetName() != wellknown.TransportSocketTls {
+			continue
+		}
+		quicFc := proto.Clone(fc).(*envoylistenerv3.FilterChain)
+		transportSocket, err := toQuicTransportSocket(fc.GetTransportSocket())
+		if err != nil {
+			return nil, err
+		}
+		quicFc.TransportSocket = transportSocket
+		for _, f := range quicFc.GetFilters() {
+			if f.GetName() != wellknown.HTTPConnectionManager {
+				continue
+			}
+			if err := toHttp3ConnectionManager(f); err != nil {
+				return nil, err
+			}
+		}
+		out.FilterChains = append(out.GetFilterChains(), quicFc)
+	}
+	if len(out.GetFilterChains()) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+func toQuicTransportSocket(ts *envoycorev3.TransportSocket) (*envoycorev3.TransportSocket, error) {
+	tlsContext := &envoytlsv3.DownstreamTlsContext{}
+	if err := ts.GetTypedConfig().UnmarshalTo(tlsContext); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal downstream TLS context: %w", err)
+	}
+	tlsContext.GetCommonTlsContext().AlpnProtocols = quicAlpnProtocols
+	typedConfig, err := utils.MessageToAny(&envoyquicv3.QuicDownstreamTransport{
+		DownstreamTlsContext: tlsContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &envoycorev3.TransportSocket{
+		Name:       wellknown.TransportSocketQuic,
+		ConfigType: &envoycorev3.TransportSocket_TypedConfig{TypedConfig: typedConfig},
+	}, nil
+}
+
+func toHttp3ConnectionManager(f *envoylistenerv3.Filter) error {
+	hcm := &envoyhttp.HttpConnectionManager{}
+	if err := f.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+		return fmt.Errorf("failed to unmarshal http connection manager: %w", err)
+	}
+	hcm.CodecType = envoyhttp.HttpConnectionManager_HTTP3
+	hcm.Http3ProtocolOptions = &envoycorev3.Http3ProtocolOptions{}
+	typedConfig, err := utils.MessageToAny(hcm)
+	if err != nil {
+		return err
+	}
+	f.ConfigType = &envoylistenerv3.Filter_TypedConfig{TypedConfig: typedConfig}
+	return nil
+}
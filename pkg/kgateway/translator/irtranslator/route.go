@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"regexp"
 	"slices"
@@ -16,6 +17,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/conditions"
@@ -140,6 +142,10 @@ func (h *httpRouteConfigurationTranslator) computeVirtualHost(
 ) *envoyroutev3.VirtualHost {
 	sanitizedName := utils.SanitizeForEnvoy(ctx, virtualHost.Name, "virtual host")
 
+	// Policies the routes inherit from their HTTP listener, and then from their HTTPS listener
+	// and Gateway, in order of precedence.
+	inheritedPolicies := []ir.AttachedPolicies{virtualHost.AttachedPolicies, h.attachedPolicies, h.gw.AttachedHttpPolicies}
+
 	var envoyRoutes []*envoyroutev3.Route
 	var computedRoutes []computedHTTPRoute
 	for i, route := range virtualHost.Rules {
@@ -151,7 +157,7 @@ func (h *httpRouteConfigurationTranslator) computeVirtualHost(
 			routeReport = h.reporter.Route(route.Parent.SourceObject).ParentRef(&route.ParentRef)
 		}
 		generatedName := fmt.Sprintf("%s-route-%d", virtualHost.Name, i)
-		computedRoute := h.envoyRoutes(routeReport, route, generatedName)
+		computedRoute := h.envoyRoutes(routeReport, route, generatedName, inheritedPolicies)
 		if computedRoute != nil {
 			envoyRoutes = append(envoyRoutes, computedRoute)
 			computedRoutes = append(computedRoutes, computedHTTPRoute{
@@ -247,6 +253,7 @@ func (h *httpRouteConfigurationTranslator) envoyRoutes(
 	routeReport reportssdk.ParentRefReporter,
 	in ir.HttpRouteRuleMatchIR,
 	generatedName string,
+	inheritedPolicies []ir.AttachedPolicies,
 ) *envoyroutev3.Route {
 	out := h.initRoutes(in, generatedName)
 
@@ -265,7 +272,7 @@ func (h *httpRouteConfigurationTranslator) envoyRoutes(
 	}
 
 	// Run plugins here that may set action. Handle the routeProcessingErr error later.
-	routeProcessingErr := h.runRoutePlugins(in, out, backendConfigCtx.typedPerFilterConfigRoute)
+	routeProcessingErr := h.runRoutePlugins(in, out, backendConfigCtx.typedPerFilterConfigRoute, inheritedPolicies)
 
 	// Apply typed per filter config from translating route action and route plugins
 	typedPerFilterConfig := backendConfigCtx.typedPerFilterConfigRoute.ToAnyMap()
@@ -477,6 +484,7 @@ func (h *httpRouteConfigurationTranslator) runRoutePlugins(
 	in ir.HttpRouteRuleMatchIR,
 	out *envoyroutev3.Route,
 	typedPerFilterConfig ir.TypedFilterConfigMap,
+	inheritedPolicies []ir.AttachedPolicies,
 ) error {
 	// all policies up to listener have been applied as vhost polices; we need to apply the httproute policies and below
 	//
@@ -543,10 +551,70 @@ func (h *httpRouteConfigurationTranslator) runRoutePlugins(
 		out.Metadata = addMergeOriginsToFilterMetadata(gk, mergeOrigins, out.GetMetadata())
 		reportPolicyAttachmentStatus(h.reporter, ancestorRef, mergeOrigins, pols...)
 	}
+	// inherited policies only fill in the route action, so there is nothing to combine on
+	// routes that do not forward
+	if len(errs) == 0 && out.GetRoute() != nil {
+		errs = h.inheritedPolicyErrors(append([]ir.AttachedPolicies{attachedPolicies}, inheritedPolicies...))
+	}
 
 	return errors.Join(errs...)
 }
 
+// inheritedPolicyErrors returns the errors that only arise once the policies of a route are
+// merged with the ones it inherits, given as levels in order of precedence. The policies of
+// each level are applied separately, with a less specific level only filling in what a more
+// specific one leaves unset, which is what merging the levels computes. The merged policy can
+// then be validated as a whole, e.g. a retry on the route against a request timeout on its Gateway.
+// Only the plugins that set ValidateMergedPolicy are merged, and only the errors it reports count.
+func (h *httpRouteConfigurationTranslator) inheritedPolicyErrors(levels []ir.AttachedPolicies) []error {
+	var all ir.AttachedPolicies
+	all.Append(levels...)
+
+	var errs []error
+	for _, gk := range all.ApplyOrderedGroupKinds() {
+		pass := h.pluginPass[gk]
+		if pass == nil || pass.MergePolicies == nil || pass.ValidateMergedPolicy == nil {
+			continue
+		}
+
+		var populated int
+		for _, level := range levels {
+			if len(level.Policies[gk]) > 0 {
+				populated++
+			}
+		}
+		if populated < 2 {
+			continue
+		}
+
+		// errors reported while validating the inherited levels on their own; the route
+		// level is known to be valid
+		levelErrs := sets.New[string]()
+		combined := slices.Clip(levels[0].Policies[gk])
+		for i, level := range levels[1:] {
+			pols := level.Policies[gk]
+			if len(pols) == 0 {
+				continue
+			}
+			for _, err := range pass.ValidateMergedPolicy(pols, pass.MergePolicies(pols)) {
+				levelErrs.Insert(err.Error())
+			}
+			// inherited levels rank below any delegating parent of the route
+			for _, pol := range pols {
+				pol.HierarchicalPriority = math.MinInt32 + len(levels) - i
+				combined = append(combined, pol)
+			}
+		}
+
+		for _, err := range pass.ValidateMergedPolicy(combined, pass.MergePolicies(combined)) {
+			if !levelErrs.Has(err.Error()) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
 // routeAncestorRef returns the ancestor to report route-attached policy status
 // against: the route's own ListenerParentRef (the Gateway/ListenerSet it actually
 // attaches to, taken from its own spec.parentRefs and already defaulted at the
@@ -597,13 +665,25 @@ func (h *httpRouteConfigurationTranslator) runBackendPolicies(in ir.HttpBackend,
 func (h *httpRouteConfigurationTranslator) runBackend(in ir.HttpBackend, pCtx *ir.RouteBackendContext, outRoute *envoyroutev3.Route) error {
 	var errs []error
 	if in.Backend.BackendObject != nil {
-		backendPass := h.pluginPass[in.Backend.BackendObject.GetGroupKind()]
+		backendGK := in.Backend.BackendObject.GetGroupKind()
+		backendPass := h.pluginPass[backendGK]
 		if backendPass != nil {
 			err := backendPass.ApplyForBackend(pCtx, in, outRoute)
 			if err != nil {
 				errs = append(errs, err)
 			}
 		}
+		// Policies attached to the backend object are applied to its cluster, but may also
+		// need to add filters to the filter chains that route to it.
+		for _, gk := range in.Backend.BackendObject.AttachedPolicies.ApplyOrderedGroupKinds() {
+			pass := h.pluginPass[gk]
+			if gk == backendGK || pass == nil {
+				continue
+			}
+			if err := pass.ApplyForBackend(pCtx, in, outRoute); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 	// TODO: check return value, if error returned, log error and report condition
 	return errors.Join(errs...)
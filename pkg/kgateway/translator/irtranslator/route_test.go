@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
@@ -433,6 +434,36 @@ func routesFromValidationBootstrap(t *testing.T, config *envoybootstrapv3.Bootst
 	return vhosts[0].GetRoutes()
 }
 
+func TestInheritedPolicyErrorsOnlyValidatesMergedPolicies(t *testing.T) {
+	validatedGK := schema.GroupKind{Group: "test.kgateway.dev", Kind: "Validated"}
+	mergedGK := schema.GroupKind{Group: "test.kgateway.dev", Kind: "Merged"}
+	mergeErr := errors.New("policies conflict")
+	// both plugins report an error when policies of several levels are merged
+	mergeLevels := func(pols []ir.PolicyAtt) ir.PolicyAtt {
+		if len(pols) > 1 {
+			return ir.PolicyAtt{Errors: []error{mergeErr}}
+		}
+		return ir.PolicyAtt{}
+	}
+	h := testHTTPRouteTranslator(nil, apisettings.ValidationStandard)
+	h.pluginPass = TranslationPassPlugins{
+		validatedGK: {
+			MergePolicies: mergeLevels,
+			ValidateMergedPolicy: func(_ []ir.PolicyAtt, merged ir.PolicyAtt) []error {
+				return merged.Errors
+			},
+		},
+		mergedGK: {MergePolicies: mergeLevels},
+	}
+
+	level := ir.AttachedPolicies{Policies: map[schema.GroupKind][]ir.PolicyAtt{
+		validatedGK: {{}},
+		mergedGK:    {{}},
+	}}
+	errs := h.inheritedPolicyErrors([]ir.AttachedPolicies{level, level})
+	assert.Equal(t, []error{mergeErr}, errs)
+}
+
 func TestSummarizeRuleErrors_NilReturnsEmpty(t *testing.T) {
 	assert.Equal(t, "", summarizeRuleErrors(nil))
 }
@@ -35,6 +35,7 @@ var logger = logging.New("translator/listener")
 
 const (
 	TcpTlsListenerNoBackendsMessage = "TCP/TLS listener has no valid backends or routes"
+	UdpListenerNoBackendsMessage    = "UDP listener has no valid backends or routes"
 	ResourceNotFoundMessageTemplate = "%s %s/%s not found."
 )
 
@@ -121,6 +122,8 @@ func (ml *MergedListeners) AppendListener(
 		ml.AppendTcpListener(listener, routes, reporter)
 	case gwv1.TLSProtocolType:
 		ml.AppendTlsListener(listener, routes, reporter)
+	case gwv1.UDPProtocolType:
+		ml.AppendUdpListener(listener, routes, reporter)
 	default:
 		return fmt.Errorf("unsupported protocol: %v", listener.Protocol)
 	}
@@ -247,6 +250,29 @@ func (ml *MergedListeners) AppendTcpListener(
 	})
 }
 
+func (ml *MergedListeners) AppendUdpListener(
+	listener ir.Listener,
+	routeInfos []*query.RouteInfo,
+	reporter reports.ListenerReporter,
+) {
+	ul := &udpListener{
+		listener:         listener,
+		listenerReporter: reporter,
+		routesWithHosts:  routeInfos,
+	}
+
+	// UDP listeners on a port conflict with any other listener on it, and are
+	// rejected during validation, so the port is never shared here.
+	ml.Listeners = append(ml.Listeners, &MergedListener{
+		name:        GenerateListenerName(listener),
+		port:        getListenerPortNumber(listener),
+		udpListener: ul,
+		listener:    listener,
+		gateway:     ml.parentGw,
+		settings:    ml.settings,
+	})
+}
+
 func (ml *MergedListeners) AppendTlsListener(
 	listener ir.Listener,
 	routeInfos []*query.RouteInfo,
@@ -337,6 +363,7 @@ type MergedListener struct {
 	httpFilterChain   *httpFilterChain
 	httpsFilterChains []httpsFilterChain
 	TcpFilterChains   []tcpFilterChain
+	udpListener       *udpListener
 	listener          ir.Listener
 	gateway           ir.Gateway
 	settings          ListenerTranslatorConfig
@@ -360,6 +387,7 @@ func (ml *MergedListener) TranslateListener(
 	}
 
 	// Translate HTTPS filter chains
+	httpsFilterChainCount := 0
 	for _, mfc := range ml.httpsFilterChains {
 		httpsFilterChain, err := mfc.translateHttpsFilterChain(
 			kctx,
@@ -376,6 +404,13 @@ func (ml *MergedListener) TranslateListener(
 		}
 
 		httpFilterChains = append(httpFilterChains, *httpsFilterChain)
+		httpsFilterChainCount++
+	}
+
+	// HTTP/3 is served alongside the HTTPS filter chains, so it is only enabled when at least one was translated
+	var quic *ir.QuicIR
+	if httpsFilterChainCount > 0 {
+		quic = getHTTP3FromAttached(ml.httpsFilterChains, ml.gateway, uint32(ml.port)) //nolint:gosec // G115: Gateway listener port is int32, always positive, safe to convert to uint32
 	}
 
 	// Translate TCP listeners (if any exist)
@@ -401,6 +436,19 @@ func (ml *MergedListener) TranslateListener(
 		}
 	}
 
+	var udpListenerIR *ir.UdpIR
+	if ml.udpListener != nil {
+		udpListenerIR = ml.udpListener.translateUdpListener(ml.name, reporter)
+		if udpListenerIR == nil {
+			ml.udpListener.listenerReporter.SetCondition(reports.ListenerCondition{
+				Type:    gwv1.ListenerConditionProgrammed,
+				Status:  metav1.ConditionFalse,
+				Reason:  gwv1.ListenerReasonInvalid,
+				Message: UdpListenerNoBackendsMessage,
+			})
+		}
+	}
+
 	// Get bind address based on ListenerBindIpv6 setting
 	bindAddress := "0.0.0.0"
 	if ml.settings.ListenerBindIpv6 {
@@ -415,6 +463,8 @@ func (ml *MergedListener) TranslateListener(
 		AttachedPolicies:  ir.AttachedPolicies{}, // TODO: find policies attached to listener and attach them <- this might not be possible due to listener merging. also a gw listener ~= envoy filter chain; and i don't believe we need policies there
 		HttpFilterChain:   httpFilterChains,
 		TcpFilterChain:    matchedTcpListeners,
+		UdpListener:       udpListenerIR,
+		Quic:              quic,
 		PolicyAncestorRef: ml.listener.PolicyAncestorRef,
 	}
 }
@@ -643,6 +693,105 @@ func (tc *tcpFilterChain) translateTcpFilterChain(
 	}
 }
 
+// udpListener represents a Gateway UDP listener. Unlike TCP listeners, UDP listeners
+// are never merged with other listeners on the same port.
+type udpListener struct {
+	listener         ir.Listener
+	listenerReporter reports.ListenerReporter
+	routesWithHosts  []*query.RouteInfo
+}
+
+func (ul *udpListener) translateUdpListener(parentName string, reporter reports.Reporter) *ir.UdpIR {
+	if len(ul.routesWithHosts) == 0 {
+		return nil
+	}
+
+	// As with TCP, a UDP listener has nothing to match routes on, so only the
+	// oldest route is honored and any others are rejected.
+	r := slices.MinFunc(ul.routesWithHosts, func(a, b *query.RouteInfo) int {
+		return a.Object.GetSourceObject().GetCreationTimestamp().Compare(b.Object.GetSourceObject().GetCreationTimestamp().Time)
+	})
+	for _, other := range ul.routesWithHosts {
+		if other != r {
+			rejectConflictingRoute(other, reporter)
+		}
+	}
+
+	uRoute, ok := r.Object.(*ir.UdpRouteIR)
+	if !ok {
+		return nil
+	}
+
+	// Envoy's UDP proxy forwards each session to a single cluster and cannot split
+	// traffic by weight, so at most one backendRef may carry weight. Backends with a
+	// zero weight are ignored, which still allows shifting traffic between backends.
+	var backend *ir.BackendRefIR
+	weighted := 0
+	for i := range uRoute.Backends {
+		if uRoute.Backends[i].Weight > 0 {
+			backend = &uRoute.Backends[i]
+			weighted++
+		}
+	}
+
+	condition := reports.RouteCondition{
+		Type:   gwv1.RouteConditionAccepted,
+		Status: metav1.ConditionTrue,
+		Reason: gwv1.RouteReasonAccepted,
+	}
+	switch {
+	case len(uRoute.SourceObject.Spec.Rules) != 1:
+		condition = reports.RouteCondition{
+			Type:    gwv1.RouteConditionAccepted,
+			Status:  metav1.ConditionFalse,
+			Reason:  gwv1.RouteReasonUnsupportedValue,
+			Message: "UDPRoute must have exactly one rule, as UDPRoute rules have nothing to match on",
+		}
+	case weighted > 1:
+		condition = reports.RouteCondition{
+			Type:    gwv1.RouteConditionAccepted,
+			Status:  metav1.ConditionFalse,
+			Reason:  gwv1.RouteReasonUnsupportedValue,
+			Message: "UDPRoute supports at most one backendRef with a non-zero weight, as Envoy's UDP proxy forwards each session to a single cluster and cannot split traffic by weight",
+		}
+	}
+	parentRefReporters := make([]reports.ParentRefReporter, 0, len(uRoute.ParentRefs))
+	for _, parentRef := range uRoute.ParentRefs {
+		parentRefReporter := reporter.Route(uRoute.SourceObject).ParentRef(&parentRef)
+		parentRefReporter.SetCondition(condition)
+		if condition.Status != metav1.ConditionTrue {
+			// A rejected route is not translated, so it must not be reported as programmed.
+			parentRefReporter.SetCondition(reports.RouteCondition{
+				Type:    conditions.KgatewayConditionProgrammed,
+				Status:  metav1.ConditionFalse,
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			})
+		}
+		parentRefReporters = append(parentRefReporters, parentRefReporter)
+	}
+	if condition.Status != metav1.ConditionTrue || backend == nil {
+		return nil
+	}
+
+	// A backend with an error resolves to the blackhole cluster, so datagrams are
+	// dropped rather than the listener being removed.
+	if backend.Err != nil || backend.BackendObject == nil {
+		err := backend.Err
+		if err == nil {
+			err = errors.New("not found")
+		}
+		for _, parentRefReporter := range parentRefReporters {
+			query.ProcessBackendError(err, parentRefReporter)
+		}
+	}
+
+	return &ir.UdpIR{
+		Name:       fmt.Sprintf("%s-%s.%s-rule-%d", parentName, uRoute.Namespace, uRoute.Name, 0),
+		BackendRef: *backend,
+	}
+}
+
 // rejectConflictingRoute sets Accepted=False on the specific listener
 // attachment of a route that lost the oldest-wins selection.
 func rejectConflictingRoute(ri *query.RouteInfo, reporter reports.Reporter) {
@@ -656,6 +805,8 @@ func rejectConflictingRoute(ri *query.RouteInfo, reporter reports.Reporter) {
 		reporter.Route(o.SourceObject).ParentRef(&ri.ParentRef).SetCondition(condition)
 	case *ir.TlsRouteIR:
 		reporter.Route(o.SourceObject).ParentRef(&ri.ParentRef).SetCondition(condition)
+	case *ir.UdpRouteIR:
+		reporter.Route(o.SourceObject).ParentRef(&ri.ParentRef).SetCondition(condition)
 	}
 }
 
@@ -1289,6 +1440,29 @@ func getCertValidationFromAttached(listener ir.Listener) *ir.ClientCertificateVa
 	}
 }
 
+// getHTTP3FromAttached returns the HTTP/3 configuration for the port from the ListenerPolicies attached
+// to its HTTPS listeners or their Gateway, or nil if none enables HTTP/3. Policies attached to a listener
+// take precedence over policies attached to the Gateway.
+func getHTTP3FromAttached(httpsFilterChains []httpsFilterChain, gw ir.Gateway, port uint32) *ir.QuicIR {
+	gk := wellknown.ListenerPolicyGVK.GroupKind()
+	var polAttachments []ir.PolicyAtt
+	for _, hfc := range httpsFilterChains {
+		polAttachments = append(polAttachments, hfc.listener.AttachedPolicies.Policies[gk]...)
+	}
+	polAttachments = append(polAttachments, gw.AttachedListenerPolicies.Policies[gk]...)
+
+	for _, polAtt := range polAttachments {
+		pol, ok := polAtt.PolicyIr.(ir.HTTP3PolicyIR)
+		if !ok {
+			continue
+		}
+		if quic := pol.HTTP3(port); quic != nil {
+			return quic
+		}
+	}
+	return nil
+}
+
 // applyClientCertificateValidation applies the resolved client certificate validation configuration
 // to the TLS config by fetching CA certificates and setting validation parameters.
 // Returns a boolean indicating if any client certificate validation was applied successfully and an error if any errors were encountered.
@@ -0,0 +1,475 @@
+package offline
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ChangeType describes how a resource differs between two outputs.
+type ChangeType string
+
+const (
+	Added   ChangeType = "Added"
+	Removed ChangeType = "Removed"
+	Changed ChangeType = "Changed"
+)
+
+// Diff is the semantic difference between two translation outputs. Only Gateways with
+// at least one difference are included.
+type Diff struct {
+	Gateways map[string]*GatewayDiff `json:"gateways,omitempty"`
+}
+
+// GatewayDiff lists the resources that differ for a single Gateway. Resources are matched
+// by name, so reordering alone is never reported as a difference. Virtual hosts are
+// diffed on their own rather than as part of their route configuration.
+type GatewayDiff struct {
+	Listeners    []ResourceDiff `json:"listeners,omitempty"`
+	Routes       []ResourceDiff `json:"routes,omitempty"`
+	VirtualHosts []ResourceDiff `json:"virtualHosts,omitempty"`
+	Clusters     []ResourceDiff `json:"clusters,omitempty"`
+}
+
+// ResourceDiff is a single added, removed or changed resource.
+type ResourceDiff struct {
+	Name   string     `json:"name"`
+	Change ChangeType `json:"change"`
+	// Fields holds the field level differences of a changed resource.
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff is a difference in a single proto field. Path uses the protojson field names;
+// elements of repeated fields are addressed by name when every element has a unique one,
+// and by index otherwise. An empty Before or After means the field is unset on that side.
+type FieldDiff struct {
+	Path   string `json:"path"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Empty returns true when the two outputs are semantically identical.
+func (d *Diff) Empty() bool {
+	return len(d.Gateways) == 0
+}
+
+// Compare returns the semantic diff between the before and after outputs. Differences that
+// only come from hashes are ignored: names are compared without a trailing hash, and
+// metadata keys ending in "hash" are skipped.
+func Compare(before, after *Output) *Diff {
+	diff := &Diff{Gateways: map[string]*GatewayDiff{}}
+	names := slices.Sorted(maps.Keys(before.Gateways))
+	for name := range after.Gateways {
+		if _, ok := before.Gateways[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		gwDiff := compareGateways(before.Gateways[name], after.Gateways[name])
+		if !gwDiff.empty() {
+			diff.Gateways[name] = gwDiff
+		}
+	}
+	return diff
+}
+
+// Write renders the diff in a human-readable form, one resource per line followed by its
+// changed fields.
+func (d *Diff) Write(w io.Writer) error {
+	var sb strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(d.Gateways)) {
+		gwDiff := d.Gateways[name]
+		fmt.Fprintf(&sb, "Gateway %s\n", name)
+		for _, group := range []struct {
+			kind  string
+			diffs []ResourceDiff
+		}{
+			{"Listener", gwDiff.Listeners},
+			{"RouteConfiguration", gwDiff.Routes},
+			{"VirtualHost", gwDiff.VirtualHosts},
+			{"Cluster", gwDiff.Clusters},
+		} {
+			for _, rd := range group.diffs {
+				fmt.Fprintf(&sb, "  %s %s %s\n", changeSymbol(rd.Change), group.kind, rd.Name)
+				for _, fd := range rd.Fields {
+					fmt.Fprintf(&sb, "      %s: %s -> %s\n", fd.Path, orUnset(fd.Before), orUnset(fd.After))
+				}
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func changeSymbol(c ChangeType) string {
+	switch c {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func orUnset(v string) string {
+	if v == "" {
+		return "<unset>"
+	}
+	return v
+}
+
+func (d *GatewayDiff) empty() bool {
+	return len(d.Listeners) == 0 && len(d.Routes) == 0 && len(d.VirtualHosts) == 0 && len(d.Clusters) == 0
+}
+
+func compareGateways(before, after *GatewayResult) *GatewayDiff {
+	if before == nil {
+		before = &GatewayResult{}
+	}
+	if after == nil {
+		after = &GatewayResult{}
+	}
+	return &GatewayDiff{
+		Listeners:    compareResources(before.Listeners, after.Listeners, (*envoylistenerv3.Listener).GetName),
+		Routes:       compareResources(withoutVirtualHosts(before.Routes), withoutVirtualHosts(after.Routes), (*envoyroutev3.RouteConfiguration).GetName),
+		VirtualHosts: compareResources(virtualHosts(before.Routes), virtualHosts(after.Routes), func(vh namedVirtualHost) string { return vh.name }),
+		Clusters:     compareResources(before.Clusters, after.Clusters, (*envoyclusterv3.Cluster).GetName),
+	}
+}
+
+// withoutVirtualHosts strips the virtual hosts from the route configurations, as they are
+// compared separately.
+func withoutVirtualHosts(routes []*envoyroutev3.RouteConfiguration) []*envoyroutev3.RouteConfiguration {
+	out := make([]*envoyroutev3.RouteConfiguration, 0, len(routes))
+	for _, rc := range routes {
+		rc = proto.CloneOf(rc)
+		rc.VirtualHosts = nil
+		out = append(out, rc)
+	}
+	return out
+}
+
+// namedVirtualHost is a virtual host along with its route configuration, as virtual host
+// names are only unique within a single route configuration.
+type namedVirtualHost struct {
+	name string
+	*envoyroutev3.VirtualHost
+}
+
+func virtualHosts(routes []*envoyroutev3.RouteConfiguration) []namedVirtualHost {
+	var out []namedVirtualHost
+	for _, rc := range routes {
+		for _, vh := range rc.GetVirtualHosts() {
+			out = append(out, namedVirtualHost{name: rc.GetName() + "/" + vh.GetName(), VirtualHost: vh})
+		}
+	}
+	return out
+}
+
+// resourceMessage is implemented by the xDS protos, and by namedVirtualHost through its
+// embedded message.
+type resourceMessage interface {
+	ProtoReflect() protoreflect.Message
+}
+
+func compareResources[T resourceMessage](before, after []T, name func(T) string) []ResourceDiff {
+	// Resources are matched by their name without a hash, unless that makes the names of
+	// several resources on either side the same, in which case those are matched by their
+	// full names so that none of them is dropped.
+	collides := map[string]bool{}
+	for _, side := range [][]T{before, after} {
+		seen := map[string]bool{}
+		for _, r := range side {
+			key := withoutHash(name(r))
+			collides[key] = collides[key] || seen[key]
+			seen[key] = true
+		}
+	}
+	matchKey := func(r T) string {
+		if key := withoutHash(name(r)); !collides[key] {
+			return key
+		}
+		return name(r)
+	}
+	beforeByName := make(map[string]T, len(before))
+	for _, r := range before {
+		beforeByName[matchKey(r)] = r
+	}
+	afterByName := make(map[string]T, len(after))
+	for _, r := range after {
+		afterByName[matchKey(r)] = r
+	}
+
+	var diffs []ResourceDiff
+	for n, b := range beforeByName {
+		a, ok := afterByName[n]
+		if !ok {
+			diffs = append(diffs, ResourceDiff{Name: name(b), Change: Removed})
+			continue
+		}
+		var fields []FieldDiff
+		compareMessages("", b.ProtoReflect(), a.ProtoReflect(), &fields)
+		if len(fields) > 0 {
+			diffs = append(diffs, ResourceDiff{Name: name(a), Change: Changed, Fields: fields})
+		}
+	}
+	for n, a := range afterByName {
+		if _, ok := beforeByName[n]; !ok {
+			diffs = append(diffs, ResourceDiff{Name: name(a), Change: Added})
+		}
+	}
+	slices.SortFunc(diffs, func(a, b ResourceDiff) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return diffs
+}
+
+// compareMessages appends the differences between two messages of the same type. Any
+// fields are unpacked when both sides hold the same type so that differences inside typed
+// configs are reported field by field.
+func compareMessages(path string, before, after protoreflect.Message, out *[]FieldDiff) {
+	if before.Descriptor().FullName() == "google.protobuf.Any" {
+		beforeAny, _ := before.Interface().(*anypb.Any)
+		afterAny, _ := after.Interface().(*anypb.Any)
+		if beforeAny != nil && afterAny != nil && beforeAny.GetTypeUrl() == afterAny.GetTypeUrl() {
+			beforeMsg, errBefore := beforeAny.UnmarshalNew()
+			afterMsg, errAfter := afterAny.UnmarshalNew()
+			if errBefore == nil && errAfter == nil {
+				compareMessages(path, beforeMsg.ProtoReflect(), afterMsg.ProtoReflect(), out)
+				return
+			}
+		}
+	}
+
+	fields := before.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		fieldPath := joinPath(path, fd.JSONName())
+		hasBefore, hasAfter := before.Has(fd), after.Has(fd)
+		switch {
+		case !hasBefore && !hasAfter:
+			continue
+		case fd.IsList():
+			compareLists(fieldPath, fd, before.Get(fd).List(), after.Get(fd).List(), out)
+		case fd.IsMap():
+			compareMaps(fieldPath, fd, before.Get(fd).Map(), after.Get(fd).Map(), out)
+		case !hasBefore || !hasAfter:
+			*out = append(*out, FieldDiff{
+				Path:   fieldPath,
+				Before: formatField(fd, before, hasBefore),
+				After:  formatField(fd, after, hasAfter),
+			})
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			compareMessages(fieldPath, before.Get(fd).Message(), after.Get(fd).Message(), out)
+		case isNameField(fd) && withoutHash(before.Get(fd).String()) == withoutHash(after.Get(fd).String()):
+			continue
+		case !before.Get(fd).Equal(after.Get(fd)):
+			*out = append(*out, FieldDiff{
+				Path:   fieldPath,
+				Before: formatValue(fd, before.Get(fd)),
+				After:  formatValue(fd, after.Get(fd)),
+			})
+		}
+	}
+}
+
+func compareLists(path string, fd protoreflect.FieldDescriptor, before, after protoreflect.List, out *[]FieldDiff) {
+	if fd.Kind() == protoreflect.MessageKind {
+		beforeKeys, okBefore := listElementNames(before)
+		afterKeys, okAfter := listElementNames(after)
+		if okBefore && okAfter {
+			compareNamedLists(path, fd, before, after, beforeKeys, afterKeys, out)
+			return
+		}
+	}
+
+	for i := range max(before.Len(), after.Len()) {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= after.Len():
+			*out = append(*out, FieldDiff{Path: elemPath, Before: formatValue(fd, before.Get(i))})
+		case i >= before.Len():
+			*out = append(*out, FieldDiff{Path: elemPath, After: formatValue(fd, after.Get(i))})
+		case fd.Kind() == protoreflect.MessageKind:
+			compareMessages(elemPath, before.Get(i).Message(), after.Get(i).Message(), out)
+		case !before.Get(i).Equal(after.Get(i)):
+			*out = append(*out, FieldDiff{Path: elemPath, Before: formatValue(fd, before.Get(i)), After: formatValue(fd, after.Get(i))})
+		}
+	}
+}
+
+// compareNamedLists matches the elements of two lists by name. As the order of named
+// elements such as routes and HTTP filters is significant to Envoy, a change in the
+// relative order of the elements present on both sides is reported on the list itself.
+func compareNamedLists(
+	path string,
+	fd protoreflect.FieldDescriptor,
+	before, after protoreflect.List,
+	beforeKeys, afterKeys map[string]int,
+	out *[]FieldDiff,
+) {
+	var beforeOrder, afterOrder []string
+	for i := range before.Len() {
+		name := elementName(before.Get(i).Message())
+		elemPath := fmt.Sprintf("%s[name=%s]", path, name)
+		j, ok := afterKeys[name]
+		if !ok {
+			*out = append(*out, FieldDiff{Path: elemPath, Before: formatValue(fd, before.Get(i))})
+			continue
+		}
+		beforeOrder = append(beforeOrder, name)
+		compareMessages(elemPath, before.Get(i).Message(), after.Get(j).Message(), out)
+	}
+	for i := range after.Len() {
+		name := elementName(after.Get(i).Message())
+		if _, ok := beforeKeys[name]; !ok {
+			*out = append(*out, FieldDiff{Path: fmt.Sprintf("%s[name=%s]", path, name), After: formatValue(fd, after.Get(i))})
+			continue
+		}
+		afterOrder = append(afterOrder, name)
+	}
+	if !slices.Equal(beforeOrder, afterOrder) {
+		*out = append(*out, FieldDiff{
+			Path:   path + "[order]",
+			Before: strings.Join(beforeOrder, ", "),
+			After:  strings.Join(afterOrder, ", "),
+		})
+	}
+}
+
+// listElementNames returns the index of each element by name, or false if any element
+// has no name or shares it with another element.
+func listElementNames(list protoreflect.List) (map[string]int, bool) {
+	names := make(map[string]int, list.Len())
+	for i := range list.Len() {
+		name := elementName(list.Get(i).Message())
+		if name == "" {
+			return nil, false
+		}
+		if _, dup := names[name]; dup {
+			return nil, false
+		}
+		names[name] = i
+	}
+	return names, true
+}
+
+func elementName(msg protoreflect.Message) string {
+	fd := msg.Descriptor().Fields().ByName("name")
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return withoutHash(msg.Get(fd).String())
+}
+
+// hashSuffix matches the hash kgateway appends to generated names, the unpadded hex of an
+// FNV-64a hash such as the one of the OAuth2 cookie names.
+var hashSuffix = regexp.MustCompile(`-[0-9a-f]{13,16}$`)
+
+// withoutHash strips a trailing hash from a name, so that resources whose name only differs
+// by that hash are matched with each other.
+func withoutHash(name string) string {
+	loc := hashSuffix.FindStringIndex(name)
+	if loc == nil {
+		return name
+	}
+	// A hex hash is told apart from a number, such as a timestamp, by its letters.
+	if strings.Trim(name[loc[0]+1:], "0123456789") == "" {
+		return name
+	}
+	return name[:loc[0]]
+}
+
+// isNameField returns true for the string fields holding a resource name, e.g. name,
+// cluster_name or route_config_name.
+func isNameField(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.StringKind && !fd.IsList() && strings.HasSuffix(string(fd.Name()), "name")
+}
+
+// isHashKey returns true for map keys holding a hash, such as the hash entries of filter
+// metadata. Their values change along with the config they were computed from, which is
+// diffed on its own.
+func isHashKey(key string) bool {
+	return strings.HasSuffix(strings.ToLower(key), "hash")
+}
+
+func compareMaps(path string, fd protoreflect.FieldDescriptor, before, after protoreflect.Map, out *[]FieldDiff) {
+	keys := map[string]protoreflect.MapKey{}
+	before.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[k.String()] = k
+		return true
+	})
+	after.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[k.String()] = k
+		return true
+	})
+
+	valueFd := fd.MapValue()
+	for _, name := range slices.Sorted(maps.Keys(keys)) {
+		if isHashKey(name) {
+			continue
+		}
+		k := keys[name]
+		elemPath := fmt.Sprintf("%s[%s]", path, name)
+		hasBefore, hasAfter := before.Has(k), after.Has(k)
+		switch {
+		case !hasAfter:
+			*out = append(*out, FieldDiff{Path: elemPath, Before: formatValue(valueFd, before.Get(k))})
+		case !hasBefore:
+			*out = append(*out, FieldDiff{Path: elemPath, After: formatValue(valueFd, after.Get(k))})
+		case valueFd.Kind() == protoreflect.MessageKind:
+			compareMessages(elemPath, before.Get(k).Message(), after.Get(k).Message(), out)
+		case !before.Get(k).Equal(after.Get(k)):
+			*out = append(*out, FieldDiff{Path: elemPath, Before: formatValue(valueFd, before.Get(k)), After: formatValue(valueFd, after.Get(k))})
+		}
+	}
+}
+
+func formatField(fd protoreflect.FieldDescriptor, msg protoreflect.Message, has bool) string {
+	if !has {
+		return ""
+	}
+	return formatValue(fd, msg.Get(fd))
+}
+
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		b, err := protojson.Marshal(v.Message().Interface())
+		if err != nil {
+			return fmt.Sprintf("<%v>", err)
+		}
+		return string(b)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", v.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("%x", v.Bytes())
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
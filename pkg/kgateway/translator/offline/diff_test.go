@@ -0,0 +1,312 @@
+package offline_test
+
+import (
+	"bytes"
+	"testing"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/offline"
+)
+
+func routeConfig(vhosts ...*envoyroutev3.VirtualHost) *envoyroutev3.RouteConfiguration {
+	return &envoyroutev3.RouteConfiguration{Name: "listener~8080", VirtualHosts: vhosts}
+}
+
+func virtualHost(name string, routes ...*envoyroutev3.Route) *envoyroutev3.VirtualHost {
+	return &envoyroutev3.VirtualHost{Name: name, Domains: []string{name}, Routes: routes}
+}
+
+func route(name, cluster string) *envoyroutev3.Route {
+	return &envoyroutev3.Route{
+		Name: name,
+		Action: &envoyroutev3.Route_Route{Route: &envoyroutev3.RouteAction{
+			ClusterSpecifier: &envoyroutev3.RouteAction_Cluster{Cluster: cluster},
+		}},
+	}
+}
+
+// hashedListener returns a listener whose filter chain name and metadata carry the given
+// hashes.
+func hashedListener(fcHash, metadataHash string) *envoylistenerv3.Listener {
+	return &envoylistenerv3.Listener{
+		Name: "listener~8443",
+		FilterChains: []*envoylistenerv3.FilterChain{{
+			Name: "https-" + fcHash,
+			Metadata: &envoycorev3.Metadata{FilterMetadata: map[string]*structpb.Struct{
+				"merge.TrafficPolicy.gateway.kgateway.dev": {Fields: map[string]*structpb.Value{
+					"policyHash": structpb.NewStringValue(metadataHash),
+					"source":     structpb.NewStringValue("default/policy"),
+				}},
+			}},
+		}},
+	}
+}
+
+func bufferConfig(t *testing.T, maxBytes uint32) *anypb.Any {
+	a, err := anypb.New(&bufferv3.BufferPerRoute{Override: &bufferv3.BufferPerRoute_Buffer{
+		Buffer: &bufferv3.Buffer{MaxRequestBytes: wrapperspb.UInt32(maxBytes)},
+	}})
+	require.NoError(t, err)
+	return a
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		before *offline.GatewayResult
+		after  *offline.GatewayResult
+		want   *offline.GatewayDiff
+	}{
+		{
+			name: "identical resources in a different order",
+			before: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{{Name: "a"}, {Name: "b"}},
+				Routes:   []*envoyroutev3.RouteConfiguration{routeConfig(virtualHost("x"), virtualHost("y"))},
+			},
+			after: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{{Name: "b"}, {Name: "a"}},
+				Routes:   []*envoyroutev3.RouteConfiguration{routeConfig(virtualHost("y"), virtualHost("x"))},
+			},
+		},
+		{
+			name: "added and removed resources",
+			before: &offline.GatewayResult{
+				Listeners: []*envoylistenerv3.Listener{{Name: "listener~8080"}},
+				Clusters:  []*envoyclusterv3.Cluster{{Name: "a"}},
+			},
+			after: &offline.GatewayResult{
+				Listeners: []*envoylistenerv3.Listener{{Name: "listener~8080"}, {Name: "listener~8443"}},
+				Clusters:  []*envoyclusterv3.Cluster{{Name: "b"}},
+			},
+			want: &offline.GatewayDiff{
+				Listeners: []offline.ResourceDiff{{Name: "listener~8443", Change: offline.Added}},
+				Clusters: []offline.ResourceDiff{
+					{Name: "a", Change: offline.Removed},
+					{Name: "b", Change: offline.Added},
+				},
+			},
+		},
+		{
+			name: "changed scalar and message fields",
+			before: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{{Name: "a", ConnectTimeout: durationpb.New(5e9)}},
+			},
+			after: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{{
+					Name:           "a",
+					ConnectTimeout: durationpb.New(1e9),
+					LbPolicy:       envoyclusterv3.Cluster_RANDOM,
+				}},
+			},
+			want: &offline.GatewayDiff{
+				Clusters: []offline.ResourceDiff{{
+					Name:   "a",
+					Change: offline.Changed,
+					Fields: []offline.FieldDiff{
+						{Path: "connectTimeout.seconds", Before: "5", After: "1"},
+						{Path: "lbPolicy", After: "RANDOM"},
+					},
+				}},
+			},
+		},
+		{
+			name: "virtual hosts are diffed separately from their route configuration",
+			before: &offline.GatewayResult{
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(
+					virtualHost("x", route("r1", "a"), route("r2", "b")),
+					virtualHost("y"),
+				)},
+			},
+			after: &offline.GatewayResult{
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(
+					virtualHost("x", route("r2", "b"), route("r1", "c")),
+					virtualHost("z"),
+				)},
+			},
+			want: &offline.GatewayDiff{
+				VirtualHosts: []offline.ResourceDiff{
+					{
+						Name:   "listener~8080/x",
+						Change: offline.Changed,
+						Fields: []offline.FieldDiff{
+							{Path: "routes[name=r1].route.cluster", Before: `"a"`, After: `"c"`},
+							{Path: "routes[order]", Before: "r1, r2", After: "r2, r1"},
+						},
+					},
+					{Name: "listener~8080/y", Change: offline.Removed},
+					{Name: "listener~8080/z", Change: offline.Added},
+				},
+			},
+		},
+		{
+			name: "typed configs are unpacked",
+			before: &offline.GatewayResult{
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(&envoyroutev3.VirtualHost{
+					Name:                 "x",
+					TypedPerFilterConfig: map[string]*anypb.Any{"envoy.filters.http.buffer": bufferConfig(t, 1024)},
+				})},
+			},
+			after: &offline.GatewayResult{
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(&envoyroutev3.VirtualHost{
+					Name:                 "x",
+					TypedPerFilterConfig: map[string]*anypb.Any{"envoy.filters.http.buffer": bufferConfig(t, 2048)},
+				})},
+			},
+			want: &offline.GatewayDiff{
+				VirtualHosts: []offline.ResourceDiff{{
+					Name:   "listener~8080/x",
+					Change: offline.Changed,
+					Fields: []offline.FieldDiff{{
+						Path:   "typedPerFilterConfig[envoy.filters.http.buffer].buffer.maxRequestBytes.value",
+						Before: "1024",
+						After:  "2048",
+					}},
+				}},
+			},
+		},
+		{
+			name: "names and metadata that only differ by hash",
+			before: &offline.GatewayResult{
+				Listeners: []*envoylistenerv3.Listener{hashedListener("1a2b3c4d5e6f7081", "11111111")},
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(
+					virtualHost("x", route("route-1a2b3c4d5e6f7a8b", "a")),
+				)},
+			},
+			after: &offline.GatewayResult{
+				Listeners: []*envoylistenerv3.Listener{hashedListener("90abcdef01234567", "22222222")},
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(
+					virtualHost("x", route("route-9f8e7d6c5b4a3928", "a")),
+				)},
+			},
+		},
+		{
+			name: "hashed names are still diffed on their other fields",
+			before: &offline.GatewayResult{
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(
+					virtualHost("x", route("route-1a2b3c4d5e6f7a8b", "a")),
+				)},
+			},
+			after: &offline.GatewayResult{
+				Routes: []*envoyroutev3.RouteConfiguration{routeConfig(
+					virtualHost("x", route("route-9f8e7d6c5b4a3928", "b")),
+				)},
+			},
+			want: &offline.GatewayDiff{
+				VirtualHosts: []offline.ResourceDiff{{
+					Name:   "listener~8080/x",
+					Change: offline.Changed,
+					Fields: []offline.FieldDiff{
+						{Path: "routes[name=route].route.cluster", Before: `"a"`, After: `"b"`},
+					},
+				}},
+			},
+		},
+		{
+			name: "numeric suffixes are not hashes",
+			before: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{{Name: "backend-8080-1700000000000"}},
+			},
+			after: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{{Name: "backend-8080-1700000000001"}},
+			},
+			want: &offline.GatewayDiff{
+				Clusters: []offline.ResourceDiff{
+					{Name: "backend-8080-1700000000000", Change: offline.Removed},
+					{Name: "backend-8080-1700000000001", Change: offline.Added},
+				},
+			},
+		},
+		{
+			name: "names that only differ by hash on the same side are all compared",
+			before: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{
+					{Name: "backend-1a2b3c4d5e6f7a8b", AltStatName: "a"},
+					{Name: "backend-9f8e7d6c5b4a3928", AltStatName: "b"},
+				},
+			},
+			after: &offline.GatewayResult{
+				Clusters: []*envoyclusterv3.Cluster{
+					{Name: "backend-1a2b3c4d5e6f7a8b", AltStatName: "c"},
+					{Name: "backend-9f8e7d6c5b4a3928", AltStatName: "d"},
+				},
+			},
+			want: &offline.GatewayDiff{
+				Clusters: []offline.ResourceDiff{
+					{
+						Name:   "backend-1a2b3c4d5e6f7a8b",
+						Change: offline.Changed,
+						Fields: []offline.FieldDiff{{Path: "altStatName", Before: `"a"`, After: `"c"`}},
+					},
+					{
+						Name:   "backend-9f8e7d6c5b4a3928",
+						Change: offline.Changed,
+						Fields: []offline.FieldDiff{{Path: "altStatName", Before: `"b"`, After: `"d"`}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := offline.Compare(
+				&offline.Output{Gateways: map[string]*offline.GatewayResult{"default/gw": tc.before}},
+				&offline.Output{Gateways: map[string]*offline.GatewayResult{"default/gw": tc.after}},
+			)
+			if tc.want == nil {
+				assert.True(t, diff.Empty())
+				return
+			}
+			assert.Equal(t, map[string]*offline.GatewayDiff{"default/gw": tc.want}, diff.Gateways)
+		})
+	}
+}
+
+func TestCompareAddedGateway(t *testing.T) {
+	diff := offline.Compare(
+		&offline.Output{},
+		&offline.Output{Gateways: map[string]*offline.GatewayResult{
+			"default/gw": {Clusters: []*envoyclusterv3.Cluster{{Name: "a"}}},
+		}},
+	)
+	require.Contains(t, diff.Gateways, "default/gw")
+	assert.Equal(t, []offline.ResourceDiff{{Name: "a", Change: offline.Added}}, diff.Gateways["default/gw"].Clusters)
+
+	var buf bytes.Buffer
+	require.NoError(t, diff.Write(&buf))
+	assert.Equal(t, "Gateway default/gw\n  + Cluster a\n", buf.String())
+}
+
+func TestParseSnapshot(t *testing.T) {
+	r := require.New(t)
+
+	out, err := offline.ParseSnapshot([]byte(`{
+  "data": {
+    "kgateway-kube-gateway-api~default~gw": {"clusters": [{"name": "b"}, {"name": "a"}]},
+    "kgateway-kube-gateway-api~default~gw~zone-a~default": {"clusters": [{"name": "c"}]},
+    "kgateway-kube-gateway-api~default~other~zone-a~default": {"listeners": [{"name": "listener~80"}]},
+    "misconfigured-node": {}
+  },
+  "error": ""
+}`))
+	r.NoError(err)
+	r.Len(out.Gateways, 2)
+
+	r.Len(out.Gateways["default/gw"].Clusters, 2)
+	r.Equal("a", out.Gateways["default/gw"].Clusters[0].GetName())
+	r.Len(out.Gateways["default/other"].Listeners, 1)
+
+	_, err = offline.ParseSnapshot([]byte(`{"data": {"kgateway-kube-gateway-api~default~gw": "panic occurred"}, "error": ""}`))
+	r.ErrorContains(err, "panic occurred")
+}
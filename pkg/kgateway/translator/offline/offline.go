@@ -0,0 +1,401 @@
+// Package offline drives the kgateway translation pipeline against a static set of
+// Kubernetes manifests, without a running cluster. It is used by the `kgateway translate`
+// subcommand to render the xDS configuration that a set of Gateway API and kgateway
+// resources would produce.
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"google.golang.org/protobuf/proto"
+	kubeclient "istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/krt"
+	corev1 "k8s.io/api/core/v1"
+	apiserverschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/inmemory"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/extensions2/registry"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/gatewaytls"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/proxy_syncer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/query"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/irtranslator"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/manifestutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/validator"
+)
+
+// ErrGatewayNotFound is returned when a Gateway requested via Options.Gateways is not
+// present in the input manifests.
+var ErrGatewayNotFound = errors.New("gateway not found in input manifests")
+
+// ExtraPluginsFn returns additional plugins to register alongside the built-in ones,
+// mirroring setup.WithExtraPlugins for downstream distributions.
+type ExtraPluginsFn func(ctx context.Context, commoncol *collections.CommonCollections, mergeSettingsJSON string) []pluginsdk.Plugin
+
+// Options configures an offline translation run.
+type Options struct {
+	// InputFiles are YAML files or directories containing the manifests to translate.
+	// Directories are walked recursively for *.yaml and *.yml files.
+	InputFiles []string
+
+	// Gateways restricts the output to the given Gateways. When empty, every Gateway
+	// found in the inputs is translated.
+	Gateways []types.NamespacedName
+
+	// Settings are the controller settings to translate with. When nil, the settings
+	// are built from the KGW_* environment variables exactly as the controller does.
+	Settings *apisettings.Settings
+
+	// Validator is used when Settings.ValidationMode is strict. When nil, the validator
+	// is built from Settings the same way as the controller.
+	Validator validator.Validator
+
+	// GVKToStructuralSchema, when set, is used to apply CRD defaults and run the OpenAPI
+	// and CEL validations on every input object before translation.
+	GVKToStructuralSchema map[schema.GroupVersionKind]*apiserverschema.Structural
+
+	// ControllerName is the GatewayClass controller name to translate for. Defaults to
+	// wellknown.DefaultGatewayControllerName.
+	ControllerName string
+
+	// ExtraPlugins are registered in addition to the built-in plugin set.
+	ExtraPlugins ExtraPluginsFn
+
+	// ExtraSchemes are added to the scheme used to decode the input manifests.
+	ExtraSchemes runtime.SchemeBuilder
+}
+
+// Run loads the input manifests into an in-memory client, runs the full translator with
+// the production plugin set and returns the xDS resources and status reports produced
+// for each Gateway.
+func Run(ctx context.Context, opts Options) (*Output, error) {
+	settings := opts.Settings
+	if settings == nil {
+		var err error
+		settings, err = apisettings.BuildSettings()
+		if err != nil {
+			return nil, fmt.Errorf("error building settings: %w", err)
+		}
+	}
+	controllerName := opts.ControllerName
+	if controllerName == "" {
+		controllerName = wellknown.DefaultGatewayControllerName
+	}
+
+	objs, err := LoadObjects(opts.InputFiles, opts.ExtraSchemes, opts.GVKToStructuralSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := inmemory.NewClient(nil, objs...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating in-memory client: %w", err)
+	}
+	defer cli.Shutdown()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Gateways commonly reference the default classes without shipping the GatewayClass
+	// itself, so make sure those exist and point at our controller. Classes present in
+	// the inputs are left untouched.
+	for _, className := range []string{wellknown.DefaultGatewayClassName, wellknown.DefaultWaypointClassName} {
+		_, _ = cli.GatewayAPI().GatewayV1().GatewayClasses().Create(ctx, &gwv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: className},
+			Spec:       gwv1.GatewayClassSpec{ControllerName: gwv1.GatewayController(controllerName)},
+		}, metav1.CreateOptions{})
+	}
+
+	krtopts := krtutil.KrtOptions{Stop: ctx.Done()}
+	commoncol, err := collections.NewCommonCollections(
+		ctx,
+		krtopts,
+		cli,
+		controllerName,
+		*settings,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	v := opts.Validator
+	if v == nil {
+		v = validator.New(*settings)
+	}
+	plugins := registry.Plugins(ctx, commoncol, *settings, v)
+	plugins = append(plugins, krtcollections.NewBuiltinPlugin(ctx))
+	var extraPlugins []pluginsdk.Plugin
+	if opts.ExtraPlugins != nil {
+		extraPlugins = opts.ExtraPlugins(ctx, commoncol, settings.PolicyMerge)
+		plugins = append(plugins, extraPlugins...)
+	}
+	extensions := registry.MergePlugins(plugins...)
+	commoncol.InitPlugins(ctx, extensions, *settings)
+
+	combined := translator.NewCombinedTranslator(ctx, extensions, commoncol, v)
+	combined.Init(ctx)
+
+	cli.RunAndWait(ctx.Done())
+	commoncol.GatewayIndex.Gateways.WaitUntilSynced(ctx.Done())
+	kubeclient.WaitForCacheSync("routes", ctx.Done(), commoncol.Routes.HasSynced)
+	kubeclient.WaitForCacheSync("extensions", ctx.Done(), extensions.HasSynced)
+	kubeclient.WaitForCacheSync("commoncol", ctx.Done(), commoncol.HasSynced)
+	kubeclient.WaitForCacheSync("translator", ctx.Done(), combined.HasSynced)
+	kubeclient.WaitForCacheSync("backends", ctx.Done(), commoncol.BackendIndex.HasSynced)
+	kubeclient.WaitForCacheSync("endpoints", ctx.Done(), commoncol.Endpoints.HasSynced)
+	for i, plug := range extraPlugins {
+		kubeclient.WaitForCacheSync(fmt.Sprintf("extra-%d", i), ctx.Done(), plug.HasSynced)
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	listenerSets := map[types.NamespacedName]*gwv1.ListenerSet{}
+	for _, obj := range objs {
+		if ls, ok := obj.(*gwv1.ListenerSet); ok {
+			listenerSets[client.ObjectKeyFromObject(ls)] = ls
+		}
+	}
+
+	gateways := map[types.NamespacedName]*gwv1.Gateway{}
+	for _, gw := range commoncol.GatewayIndex.Gateways.List() {
+		gateways[types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}] = gw.Obj
+	}
+	for _, nn := range opts.Gateways {
+		if _, ok := gateways[nn]; !ok {
+			return nil, fmt.Errorf("%s: %w", nn, ErrGatewayNotFound)
+		}
+	}
+
+	// Translation runs inside krt collections, as it does in the proxy syncer, so that
+	// it evaluates the synced collections with a real handler context.
+	clusterCol := translateBackends(ctx, krtopts, combined, commoncol)
+	translations := translateGateways(ctx, krtopts, combined, commoncol, opts.Gateways)
+	if !clusterCol.WaitUntilSynced(ctx.Done()) || !translations.WaitUntilSynced(ctx.Done()) {
+		return nil, ctx.Err()
+	}
+	var clusters []*envoyclusterv3.Cluster
+	for _, c := range clusterCol.List() {
+		clusters = append(clusters, c.cluster)
+	}
+
+	out := &Output{Gateways: map[string]*GatewayResult{}}
+	for _, translation := range translations.List() {
+		gwNN := types.NamespacedName{Namespace: translation.gateway.Namespace, Name: translation.gateway.Name}
+		xdsSnap, reportsMap := translation.xdsSnap, translation.reports
+
+		// Backend policy and Backend status reports are produced outside of gateway
+		// translation by the proxy syncer, so reproduce them here to report on those too.
+		var backendIRs []*ir.BackendObjectIR
+		for _, col := range commoncol.BackendIndex.BackendsWithPolicyRequiringStatus() {
+			backendIRs = append(backendIRs, col.List()...)
+		}
+		backendPolicyReports := proxy_syncer.GenerateBackendPolicyReport(backendIRs, map[schema.GroupKind]struct{}{
+			wellknown.BackendTLSPolicyGVK.GroupKind(): {},
+		})
+		maps.Copy(reportsMap.Policies, backendPolicyReports.Policies)
+
+		if kgwBackendPlugin, ok := extensions.ContributesBackends[wellknown.BackendGVK.GroupKind()]; ok {
+			var kgwBackends []ir.BackendObjectIR
+			var kgwExtraConditions []ir.BackendObjectStatus
+			if kgwBackendPlugin.Backends != nil {
+				kgwBackends = kgwBackendPlugin.Backends.List()
+			}
+			if kgwBackendPlugin.ExtraConditions != nil {
+				kgwExtraConditions = kgwBackendPlugin.ExtraConditions.List()
+			}
+			backendStatusReports := proxy_syncer.GenerateBackendStatusReport(kgwBackends, nil, kgwExtraConditions)
+			maps.Copy(reportsMap.Backends, backendStatusReports.Backends)
+		}
+
+		result := &GatewayResult{
+			Listeners: xdsSnap.Listeners,
+			Routes:    xdsSnap.Routes,
+			Clusters:  slices.Concat(clusters, translation.clientCertificateClusters, xdsSnap.ExtraClusters),
+			Secrets:   redactSecrets(xdsSnap.Secrets),
+			Statuses:  buildStatuses(ctx, reportsMap, gateways, listenerSets, extensions.ContributesPolicies, controllerName),
+		}
+		result.sort()
+		out.Gateways[gwNN.String()] = result
+	}
+
+	return out, nil
+}
+
+// LoadObjects decodes the Kubernetes objects from the given YAML files or directories.
+// When gvkToStructuralSchema is set, CRD defaults are applied and the objects are
+// validated against the CRD schemas and CEL rules, as the API server would.
+func LoadObjects(
+	inputFiles []string,
+	extraSchemes runtime.SchemeBuilder,
+	gvkToStructuralSchema map[schema.GroupVersionKind]*apiserverschema.Structural,
+) ([]client.Object, error) {
+	scheme := schemes.GatewayScheme()
+	extraSchemes = append(extraSchemes, kgateway.Install)
+	if err := extraSchemes.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error building scheme: %w", err)
+	}
+
+	var objs []client.Object
+	for _, file := range inputFiles {
+		fileObjs, err := manifestutils.LoadFromFiles(file, scheme, gvkToStructuralSchema)
+		if err != nil {
+			return nil, fmt.Errorf("error loading %s: %w", file, err)
+		}
+		objs = append(objs, fileObjs...)
+	}
+
+	var creationTime time.Time
+	for _, obj := range objs {
+		// Policy precedence falls back to creation time, so give objects that do not carry
+		// one a timestamp that follows their order in the inputs to keep output stable.
+		if obj.GetCreationTimestamp().Time.IsZero() {
+			creationTime = creationTime.Add(time.Second)
+			obj.SetCreationTimestamp(metav1.NewTime(creationTime))
+		}
+		// The API server folds stringData into data on write; do the same since the
+		// in-memory client stores objects as-is.
+		if secret, ok := obj.(*corev1.Secret); ok && len(secret.StringData) > 0 {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte, len(secret.StringData))
+			}
+			for key, value := range secret.StringData {
+				secret.Data[key] = []byte(value)
+			}
+		}
+	}
+	return objs, nil
+}
+
+// backendCluster is the Envoy cluster translated for a Backend.
+type backendCluster struct {
+	name    string
+	cluster *envoyclusterv3.Cluster
+}
+
+func (c backendCluster) ResourceName() string {
+	return c.name
+}
+
+func (c backendCluster) Equals(other backendCluster) bool {
+	return c.name == other.name && proto.Equal(c.cluster, other.cluster)
+}
+
+// translateBackends translates every Backend known to the translator into its Envoy
+// cluster. In a live controller clusters are shared by all Gateways served by the same
+// client, so the same set is reported for every Gateway.
+func translateBackends(
+	ctx context.Context,
+	krtopts krtutil.KrtOptions,
+	combined *translator.CombinedTranslator,
+	commoncol *collections.CommonCollections,
+) krt.Collection[backendCluster] {
+	bt := combined.GetBackendTranslator()
+	ucc := ir.NewUniquelyConnectedClient("offline", "offline", nil, ir.PodLocality{})
+	backends := krt.JoinCollection(commoncol.BackendIndex.BackendsWithPolicy(), krtopts.ToOptions("OfflineBackends")...)
+	return krt.NewCollection(backends, func(kctx krt.HandlerContext, backend *ir.BackendObjectIR) *backendCluster {
+		// Errors are already surfaced as Backend status; an invalid backend yields
+		// a nil or blackhole cluster which is still worth showing.
+		cluster, _ := bt.TranslateBackend(ctx, kctx, ucc, backend)
+		if cluster == nil {
+			return nil
+		}
+		return &backendCluster{name: backend.ResourceName(), cluster: cluster}
+	}, krtopts.ToOptions("OfflineClusters")...)
+}
+
+// gatewayTranslation is the translation of a Gateway.
+type gatewayTranslation struct {
+	gateway ir.Gateway
+	xdsSnap *irtranslator.TranslationResult
+	reports reports.ReportMap
+	// clientCertificateClusters are the clusters of the Gateway-scoped clones of the
+	// backends its routes reference, when the Gateway supplies a backend client
+	// certificate. The proxy syncer translates them alongside the shared clusters.
+	clientCertificateClusters []*envoyclusterv3.Cluster
+}
+
+func (t gatewayTranslation) ResourceName() string {
+	return t.gateway.ResourceName()
+}
+
+// Equals compares the translation results by identity. The inputs are static, so a
+// Gateway is only ever translated once.
+func (t gatewayTranslation) Equals(other gatewayTranslation) bool {
+	return t.gateway.Equals(other.gateway) && t.xdsSnap == other.xdsSnap
+}
+
+// translateGateways translates the Gateways, restricted to the given ones when set.
+func translateGateways(
+	ctx context.Context,
+	krtopts krtutil.KrtOptions,
+	combined *translator.CombinedTranslator,
+	commoncol *collections.CommonCollections,
+	gateways []types.NamespacedName,
+) krt.Collection[gatewayTranslation] {
+	queries := query.NewData(commoncol)
+	bt := combined.GetBackendTranslator()
+	ucc := ir.NewUniquelyConnectedClient("offline", "offline", nil, ir.PodLocality{})
+	return krt.NewCollection(commoncol.GatewayIndex.Gateways, func(kctx krt.HandlerContext, gw ir.Gateway) *gatewayTranslation {
+		if len(gateways) > 0 && !slices.Contains(gateways, types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}) {
+			return nil
+		}
+		xdsSnap, reportsMap := combined.TranslateGateway(kctx, ctx, gw)
+		if xdsSnap == nil {
+			return nil
+		}
+		translation := &gatewayTranslation{gateway: gw, xdsSnap: xdsSnap, reports: reportsMap}
+
+		// Certificate errors are reported on the Gateway status by TranslateGateway.
+		clientCertificate, err := gatewaytls.ResolveForGateway(kctx, ctx, queries, &gw)
+		if err != nil || clientCertificate == nil {
+			return translation
+		}
+		routes, err := queries.GetRoutesForGateway(kctx, ctx, &gw)
+		if err != nil {
+			return translation
+		}
+		for _, backend := range query.BuildGatewayBackendClientCertificateVariants(routes, &gw, clientCertificate) {
+			if cluster, _ := bt.TranslateBackend(ctx, kctx, ucc, backend); cluster != nil {
+				translation.clientCertificateClusters = append(translation.clientCertificateClusters, cluster)
+			}
+		}
+		return translation
+	}, krtopts.ToOptions("OfflineGatewayTranslations")...)
+}
+
+// redactSecrets strips the key material from secrets so that the output is safe to
+// share, keeping only the names that listeners reference.
+func redactSecrets(secrets []*envoytlsv3.Secret) []*envoytlsv3.Secret {
+	if len(secrets) == 0 {
+		return nil
+	}
+	redacted := make([]*envoytlsv3.Secret, 0, len(secrets))
+	for _, s := range secrets {
+		redacted = append(redacted, &envoytlsv3.Secret{Name: s.GetName()})
+	}
+	return redacted
+}
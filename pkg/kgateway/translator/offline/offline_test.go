@@ -0,0 +1,107 @@
+package offline_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+	"k8s.io/apimachinery/pkg/types"
+
+	apisettings "github.com/kgateway-dev/kgateway/v2/api/settings"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/translator/offline"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/fsutils"
+)
+
+func testOptions(t *testing.T) offline.Options {
+	t.Helper()
+	settings, err := apisettings.BuildSettings()
+	require.NoError(t, err)
+	return offline.Options{
+		InputFiles: []string{filepath.Join(fsutils.MustGetThisDir(), "testdata", "gateway.yaml")},
+		Settings:   settings,
+	}
+}
+
+func TestRun(t *testing.T) {
+	r := require.New(t)
+
+	out, err := offline.Run(context.Background(), testOptions(t))
+	r.NoError(err)
+	r.Len(out.Gateways, 2)
+
+	result := out.Gateways["default/example-gateway"]
+	r.NotNil(result)
+	r.Len(result.Listeners, 1)
+	r.Len(result.Routes, 1)
+	r.Len(result.Routes[0].GetVirtualHosts(), 1)
+	r.Contains(result.Routes[0].GetVirtualHosts()[0].GetRoutes()[0].GetTypedPerFilterConfig(), "envoy.filters.http.buffer")
+
+	var clusterNames []string
+	for _, c := range result.Clusters {
+		clusterNames = append(clusterNames, c.GetName())
+	}
+	assert.Contains(t, clusterNames, "kube_default_example-svc_80")
+
+	r.NotNil(result.Statuses)
+	r.Contains(result.Statuses.Gateways, "default/example-gateway")
+	r.Contains(result.Statuses.HTTPRoutes, "default/example-route")
+	r.Contains(result.Statuses.Policies, "TrafficPolicy/default/buffer-policy")
+}
+
+func TestRunSelectsGateways(t *testing.T) {
+	opts := testOptions(t)
+	opts.Gateways = []types.NamespacedName{{Namespace: "default", Name: "other-gateway"}}
+
+	out, err := offline.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, out.Gateways, 1)
+	assert.Contains(t, out.Gateways, "default/other-gateway")
+
+	opts.Gateways = []types.NamespacedName{{Namespace: "default", Name: "missing"}}
+	_, err = offline.Run(context.Background(), opts)
+	assert.True(t, errors.Is(err, offline.ErrGatewayNotFound))
+}
+
+func TestRunTranslatesGatewayClientCertificateClusters(t *testing.T) {
+	opts := testOptions(t)
+	opts.InputFiles = []string{filepath.Join(fsutils.MustGetThisDir(), "testdata", "client-certificates.yaml")}
+
+	out, err := offline.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, out.Gateways, 2)
+
+	clusterNames := func(gw string) []string {
+		var names []string
+		for _, c := range out.Gateways[gw].Clusters {
+			names = append(names, c.GetName())
+		}
+		return names
+	}
+	// Each Gateway gets the clone of the backend that presents its own client certificate.
+	one, two := clusterNames("default/example-gateway-one"), clusterNames("default/example-gateway-two")
+	assert.Contains(t, one, "kube_default_backend-service_gw_backend_client_cert_default_example-gateway-one_443")
+	assert.NotContains(t, one, "kube_default_backend-service_gw_backend_client_cert_default_example-gateway-two_443")
+	assert.Contains(t, two, "kube_default_backend-service_gw_backend_client_cert_default_example-gateway-two_443")
+	assert.NotContains(t, two, "kube_default_backend-service_gw_backend_client_cert_default_example-gateway-one_443")
+}
+
+func TestOutputRoundTrip(t *testing.T) {
+	r := require.New(t)
+
+	out, err := offline.Run(context.Background(), testOptions(t))
+	r.NoError(err)
+
+	b, err := out.JSON()
+	r.NoError(err)
+
+	var got offline.Output
+	r.NoError(json.Unmarshal(b, &got))
+	r.Empty(cmp.Diff(out, &got, protocmp.Transform(), cmpopts.EquateEmpty()))
+}
@@ -0,0 +1,140 @@
+package offline
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+
+	envoyclusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// Output is the result of an offline translation run.
+type Output struct {
+	// Gateways maps the Gateway namespace/name to its translation result.
+	Gateways map[string]*GatewayResult `json:"gateways"`
+}
+
+// GatewayResult holds the xDS resources produced for a single Gateway along with the
+// status that the controller would write back to the translated resources.
+type GatewayResult struct {
+	Listeners []*envoylistenerv3.Listener
+	Routes    []*envoyroutev3.RouteConfiguration
+	Clusters  []*envoyclusterv3.Cluster
+	// Secrets only carry the secret names; key material is never included in the output.
+	Secrets  []*envoytlsv3.Secret
+	Statuses *Statuses
+}
+
+// JSON returns the output as indented JSON.
+func (o *Output) JSON() ([]byte, error) {
+	return json.MarshalIndent(o, "", "  ")
+}
+
+// YAML returns the output as YAML.
+func (o *Output) YAML() ([]byte, error) {
+	jsn, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(jsn)
+}
+
+// sort orders every resource list by name so that the output is stable across runs.
+func (r *GatewayResult) sort() {
+	slices.SortFunc(r.Listeners, func(a, b *envoylistenerv3.Listener) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+	slices.SortFunc(r.Routes, func(a, b *envoyroutev3.RouteConfiguration) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+	slices.SortFunc(r.Clusters, func(a, b *envoyclusterv3.Cluster) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+	slices.SortFunc(r.Secrets, func(a, b *envoytlsv3.Secret) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+}
+
+type gatewayResultJSON struct {
+	Listeners []json.RawMessage `json:"listeners,omitempty"`
+	Routes    []json.RawMessage `json:"routes,omitempty"`
+	Clusters  []json.RawMessage `json:"clusters,omitempty"`
+	Secrets   []json.RawMessage `json:"secrets,omitempty"`
+	Statuses  *Statuses         `json:"statuses,omitempty"`
+}
+
+func (r *GatewayResult) MarshalJSON() ([]byte, error) {
+	var (
+		out gatewayResultJSON
+		err error
+	)
+	if out.Listeners, err = marshalProtoMessages(r.Listeners); err != nil {
+		return nil, err
+	}
+	if out.Routes, err = marshalProtoMessages(r.Routes); err != nil {
+		return nil, err
+	}
+	if out.Clusters, err = marshalProtoMessages(r.Clusters); err != nil {
+		return nil, err
+	}
+	if out.Secrets, err = marshalProtoMessages(r.Secrets); err != nil {
+		return nil, err
+	}
+	out.Statuses = r.Statuses
+	return json.Marshal(out)
+}
+
+func (r *GatewayResult) UnmarshalJSON(data []byte) error {
+	var in gatewayResultJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	var err error
+	if r.Listeners, err = unmarshalProtoMessages[envoylistenerv3.Listener](in.Listeners); err != nil {
+		return err
+	}
+	if r.Routes, err = unmarshalProtoMessages[envoyroutev3.RouteConfiguration](in.Routes); err != nil {
+		return err
+	}
+	if r.Clusters, err = unmarshalProtoMessages[envoyclusterv3.Cluster](in.Clusters); err != nil {
+		return err
+	}
+	if r.Secrets, err = unmarshalProtoMessages[envoytlsv3.Secret](in.Secrets); err != nil {
+		return err
+	}
+	r.Statuses = in.Statuses
+	return nil
+}
+
+func marshalProtoMessages[T proto.Message](messages []T) ([]json.RawMessage, error) {
+	var result []json.RawMessage
+	for _, msg := range messages {
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func unmarshalProtoMessages[T any, PT interface {
+	*T
+	proto.Message
+}](raw []json.RawMessage) ([]PT, error) {
+	var result []PT
+	for _, data := range raw {
+		msg := PT(new(T))
+		if err := protojson.Unmarshal(data, msg); err != nil {
+			return nil, err
+		}
+		result = append(result, msg)
+	}
+	return result, nil
+}
@@ -0,0 +1,71 @@
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/xds"
+)
+
+// snapshotResponse is the response of the admin server's /snapshots/xds?format=protojson
+// endpoint, keyed by xDS cache key.
+type snapshotResponse struct {
+	Data  map[string]json.RawMessage `json:"data"`
+	Error string                     `json:"error"`
+}
+
+// ParseSnapshot reads the response of a running controller's /snapshots/xds?format=protojson
+// admin endpoint into an Output, so that the live configuration can be compared with an
+// offline translation. Only the Gateway snapshots are kept; when a Gateway has several
+// per-locality snapshots, the one without a locality is preferred.
+func ParseSnapshot(data []byte) (*Output, error) {
+	var resp snapshotResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("error parsing xDS snapshot: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	out := &Output{Gateways: map[string]*GatewayResult{}}
+	for _, key := range slices.Sorted(maps.Keys(resp.Data)) {
+		if !xds.IsKubeGatewayCacheKey(key) {
+			continue
+		}
+		// Keys are sorted, so a Gateway's snapshot without a locality comes before its
+		// per-locality ones.
+		gwNN, ok := parseCacheKey(key)
+		if !ok || out.Gateways[gwNN.String()] != nil {
+			continue
+		}
+		var result GatewayResult
+		if err := json.Unmarshal(resp.Data[key], &result); err != nil {
+			// Entries that failed to render on the server are strings holding the error.
+			var msg string
+			if json.Unmarshal(resp.Data[key], &msg) == nil {
+				return nil, fmt.Errorf("error in xDS snapshot %s: %s", key, msg)
+			}
+			return nil, fmt.Errorf("error parsing xDS snapshot %s: %w", key, err)
+		}
+		result.sort()
+		out.Gateways[gwNN.String()] = &result
+	}
+	return out, nil
+}
+
+// parseCacheKey parses an OWNER~NAMESPACE~NAME cache key, where the name may be followed
+// by a ~-separated locality suffix.
+func parseCacheKey(key string) (types.NamespacedName, bool) {
+	parts := strings.SplitN(key, xds.KeyDelimiter, 3)
+	if len(parts) != 3 {
+		return types.NamespacedName{}, false
+	}
+	name, _, _ := strings.Cut(parts[2], xds.KeyDelimiter)
+	return types.NamespacedName{Namespace: parts[1], Name: name}, true
+}
@@ -0,0 +1,147 @@
+package offline
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+)
+
+// Statuses are the statuses the controller would write for the resources involved in
+// translating a Gateway, keyed by namespace/name (and kind for policies).
+type Statuses struct {
+	Gateways     map[string]*gwv1.GatewayStatus     `json:"gateways,omitempty"`
+	ListenerSets map[string]*gwv1.ListenerSetStatus `json:"listenerSets,omitempty"`
+	HTTPRoutes   map[string]*gwv1.RouteStatus       `json:"httpRoutes,omitempty"`
+	TCPRoutes    map[string]*gwv1.RouteStatus       `json:"tcpRoutes,omitempty"`
+	TLSRoutes    map[string]*gwv1.RouteStatus       `json:"tlsRoutes,omitempty"`
+	UDPRoutes    map[string]*gwv1.RouteStatus       `json:"udpRoutes,omitempty"`
+	GRPCRoutes   map[string]*gwv1.RouteStatus       `json:"grpcRoutes,omitempty"`
+	Policies     map[string]*gwv1.PolicyStatus      `json:"policies,omitempty"`
+	Backends     map[string]*kgateway.BackendStatus `json:"backends,omitempty"`
+}
+
+// buildStatuses renders the report map into resource statuses. Transition times are
+// zeroed so that two runs over the same inputs produce identical output.
+func buildStatuses(
+	ctx context.Context,
+	reportsMap reports.ReportMap,
+	gateways map[types.NamespacedName]*gwv1.Gateway,
+	listenerSets map[types.NamespacedName]*gwv1.ListenerSet,
+	policyPlugins map[schema.GroupKind]pluginsdk.PolicyPlugin,
+	controllerName string,
+) *Statuses {
+	statuses := &Statuses{
+		Gateways:     map[string]*gwv1.GatewayStatus{},
+		ListenerSets: map[string]*gwv1.ListenerSetStatus{},
+		HTTPRoutes:   map[string]*gwv1.RouteStatus{},
+		TCPRoutes:    map[string]*gwv1.RouteStatus{},
+		TLSRoutes:    map[string]*gwv1.RouteStatus{},
+		UDPRoutes:    map[string]*gwv1.RouteStatus{},
+		GRPCRoutes:   map[string]*gwv1.RouteStatus{},
+		Policies:     map[string]*gwv1.PolicyStatus{},
+		Backends:     map[string]*kgateway.BackendStatus{},
+	}
+
+	for gwNN := range reportsMap.Gateways {
+		// The Gateway object is needed so that status.listeners are populated.
+		gw := gwv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: gwNN.Name, Namespace: gwNN.Namespace}}
+		if actual := gateways[gwNN]; actual != nil {
+			gw = *actual
+		}
+		if status := reportsMap.BuildGWStatus(ctx, gw, nil); status != nil {
+			zeroConditionTimes(status.Conditions)
+			for _, l := range status.Listeners {
+				zeroConditionTimes(l.Conditions)
+			}
+			statuses.Gateways[gwNN.String()] = status
+		}
+	}
+
+	for gvk, listenerSetsForGVK := range reportsMap.ListenerSets {
+		for lsNN := range listenerSetsForGVK {
+			ls := gwv1.ListenerSet{ObjectMeta: metav1.ObjectMeta{Name: lsNN.Name, Namespace: lsNN.Namespace}}
+			if actual := listenerSets[lsNN]; actual != nil {
+				ls = *actual
+			}
+			if ls.GroupVersionKind().Empty() {
+				ls.SetGroupVersionKind(gvk)
+			}
+			if status := reportsMap.BuildListenerSetStatus(ctx, ls); status != nil {
+				zeroConditionTimes(status.Conditions)
+				for _, l := range status.Listeners {
+					zeroConditionTimes(l.Conditions)
+				}
+				statuses.ListenerSets[lsNN.String()] = status
+			}
+		}
+	}
+
+	buildRouteStatuses(ctx, reportsMap, reportsMap.HTTPRoutes, func() client.Object { return &gwv1.HTTPRoute{} }, controllerName, statuses.HTTPRoutes)
+	buildRouteStatuses(ctx, reportsMap, reportsMap.TCPRoutes, func() client.Object { return &gwv1a2.TCPRoute{} }, controllerName, statuses.TCPRoutes)
+	buildRouteStatuses(ctx, reportsMap, reportsMap.TLSRoutes, func() client.Object { return &gwv1.TLSRoute{} }, controllerName, statuses.TLSRoutes)
+	buildRouteStatuses(ctx, reportsMap, reportsMap.UDPRoutes, func() client.Object { return &gwv1a2.UDPRoute{} }, controllerName, statuses.UDPRoutes)
+	buildRouteStatuses(ctx, reportsMap, reportsMap.GRPCRoutes, func() client.Object { return &gwv1.GRPCRoute{} }, controllerName, statuses.GRPCRoutes)
+
+	for policyKey := range reportsMap.Policies {
+		var status *gwv1.PolicyStatus
+		if plugin, ok := policyPlugins[schema.GroupKind{Group: policyKey.Group, Kind: policyKey.Kind}]; ok && plugin.BuildPolicyStatus != nil {
+			status = plugin.BuildPolicyStatus(ctx, reportsMap, policyKey, controllerName, gwv1.PolicyStatus{})
+		} else {
+			status = reportsMap.BuildPolicyStatus(ctx, policyKey, controllerName, gwv1.PolicyStatus{})
+		}
+		if status == nil {
+			continue
+		}
+		for i := range status.Ancestors {
+			zeroConditionTimes(status.Ancestors[i].Conditions)
+		}
+		statuses.Policies[fmt.Sprintf("%s/%s/%s", policyKey.Kind, policyKey.Namespace, policyKey.Name)] = status
+	}
+
+	for backendNN := range reportsMap.Backends {
+		backend := kgateway.Backend{ObjectMeta: metav1.ObjectMeta{Name: backendNN.Name, Namespace: backendNN.Namespace}}
+		if status := reportsMap.BuildBackendStatus(ctx, &backend, kgateway.BackendStatus{}); status != nil {
+			zeroConditionTimes(status.Conditions)
+			statuses.Backends[backendNN.String()] = status
+		}
+	}
+
+	return statuses
+}
+
+func buildRouteStatuses(
+	ctx context.Context,
+	reportsMap reports.ReportMap,
+	routes map[types.NamespacedName]*reports.RouteReport,
+	newRoute func() client.Object,
+	controllerName string,
+	out map[string]*gwv1.RouteStatus,
+) {
+	for routeNN := range routes {
+		route := newRoute()
+		route.SetName(routeNN.Name)
+		route.SetNamespace(routeNN.Namespace)
+		if status := reportsMap.BuildRouteStatus(ctx, route, controllerName); status != nil {
+			for i := range status.Parents {
+				zeroConditionTimes(status.Parents[i].Conditions)
+			}
+			out[routeNN.String()] = status
+		}
+	}
+}
+
+func zeroConditionTimes(conditions []metav1.Condition) {
+	for i := range conditions {
+		conditions[i].LastTransitionTime = metav1.Time{}
+	}
+}
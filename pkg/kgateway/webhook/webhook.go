@@ -0,0 +1,101 @@
+// Package webhook implements the validating admission webhook for kgateway policies.
+//
+// The webhook runs the same validation that translation runs, as contributed by each policy plugin
+// through sdk.PolicyPlugin.ValidatePolicy, so that an invalid policy is rejected when it is applied
+// rather than only being reported on its status afterwards.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+)
+
+const (
+	// ValidatePath is the path the validating webhook is served on.
+	ValidatePath = "/validate"
+
+	// TLSCertDir is the directory the webhook serving certificate and key are mounted in.
+	TLSCertDir = "/etc/webhook-tls"
+
+	// TLSSecretName is the name of the Kubernetes Secret containing the webhook serving certificate.
+	// This secret must exist in the kgateway installation namespace when the webhook is enabled.
+	TLSSecretName = "kgateway-webhook-cert" //nolint:gosec // G101: This is a well-known webhook TLS secret name, not a credential
+)
+
+var logger = logging.New("webhook")
+
+// NewServer returns a webhook server that serves the policy validating webhook on the given port.
+func NewServer(port int, scheme *runtime.Scheme, plugins sdk.Plugin) webhook.Server {
+	srv := webhook.NewServer(webhook.Options{
+		Port:    port,
+		CertDir: TLSCertDir,
+	})
+	srv.Register(ValidatePath, &webhook.Admission{Handler: NewPolicyValidator(scheme, plugins)})
+	return srv
+}
+
+// NewPolicyValidator returns an admission handler that validates the policies of every plugin
+// that implements ValidatePolicy. Objects of other kinds are allowed.
+//
+// Policies are rejected for the errors their IR construction reports, which include the errors
+// of the Envoy validator when the ValidationMode setting is STRICT.
+func NewPolicyValidator(scheme *runtime.Scheme, plugins sdk.Plugin) admission.Handler {
+	validators := map[schema.GroupKind]sdk.ValidatePolicyFn{}
+	for gk, p := range plugins.ContributesPolicies {
+		if p.ValidatePolicy != nil {
+			validators[gk] = p.ValidatePolicy
+		}
+	}
+	return &policyValidator{
+		scheme:     scheme,
+		decoder:    admission.NewDecoder(scheme),
+		validators: validators,
+		hasSynced:  plugins.HasSynced,
+	}
+}
+
+type policyValidator struct {
+	scheme     *runtime.Scheme
+	decoder    admission.Decoder
+	validators map[schema.GroupKind]sdk.ValidatePolicyFn
+	hasSynced  func() bool
+}
+
+func (v *policyValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	validate, ok := v.validators[gvk.GroupKind()]
+	if !ok || req.Operation == admissionv1.Delete {
+		return admission.Allowed("")
+	}
+	// Policies are validated against the objects they reference, so validating before the
+	// collections have synced could reject valid policies.
+	if !v.hasSynced() {
+		return admission.Errored(http.StatusServiceUnavailable, errors.New("kgateway is not ready to validate policies"))
+	}
+
+	obj, err := v.scheme.New(gvk)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if err := v.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if errs := validate(ctx, obj); len(errs) > 0 {
+		err := errors.Join(errs...)
+		logger.Debug("rejecting invalid policy", "kind", gvk.Kind, "namespace", req.Namespace, "name", req.Name, "error", err)
+		return admission.Denied(fmt.Sprintf("%s %s/%s is invalid: %v", gvk.Kind, req.Namespace, req.Name, err))
+	}
+	return admission.Allowed("")
+}
@@ -0,0 +1,131 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/webhook"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	sdk "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+)
+
+func request(t *testing.T, op admissionv1.Operation, gvk schema.GroupVersionKind, obj runtime.Object) admission.Request {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	require.NoError(t, err)
+	return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: op,
+		Kind:      metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+		Namespace: "default",
+		Name:      "policy",
+		Object:    runtime.RawExtension{Raw: raw},
+	}}
+}
+
+func TestPolicyValidator(t *testing.T) {
+	validatePolicy := func(_ context.Context, obj runtime.Object) []error {
+		tp, ok := obj.(*kgateway.TrafficPolicy)
+		if !ok {
+			return []error{errors.New("not a TrafficPolicy")}
+		}
+		if tp.Annotations["invalid"] == "true" {
+			return []error{errors.New("bad config")}
+		}
+		return nil
+	}
+	plugins := func(synced bool) sdk.Plugin {
+		return sdk.Plugin{
+			ContributesPolicies: map[schema.GroupKind]sdk.PolicyPlugin{
+				wellknown.TrafficPolicyGVK.GroupKind(): {ValidatePolicy: validatePolicy},
+				// BackendConfigPolicies are not validated by this plugin set.
+				wellknown.BackendConfigPolicyGVK.GroupKind(): {},
+			},
+			ExtraHasSynced: func() bool { return synced },
+		}
+	}
+	policy := func(invalid bool) *kgateway.TrafficPolicy {
+		tp := &kgateway.TrafficPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+		if invalid {
+			tp.Annotations = map[string]string{"invalid": "true"}
+		}
+		return tp
+	}
+
+	tests := []struct {
+		name        string
+		synced      bool
+		req         func(t *testing.T) admission.Request
+		wantAllow   bool
+		wantCode    int32
+		wantMessage string
+	}{
+		{
+			name:   "valid policy is allowed",
+			synced: true,
+			req: func(t *testing.T) admission.Request {
+				return request(t, admissionv1.Create, wellknown.TrafficPolicyGVK, policy(false))
+			},
+			wantAllow: true,
+		},
+		{
+			name:   "invalid policy is denied",
+			synced: true,
+			req: func(t *testing.T) admission.Request {
+				return request(t, admissionv1.Update, wellknown.TrafficPolicyGVK, policy(true))
+			},
+			wantCode:    http.StatusForbidden,
+			wantMessage: "TrafficPolicy default/policy is invalid: bad config",
+		},
+		{
+			name:   "policy without a validator is allowed",
+			synced: true,
+			req: func(t *testing.T) admission.Request {
+				return request(t, admissionv1.Create, wellknown.BackendConfigPolicyGVK, &kgateway.BackendConfigPolicy{})
+			},
+			wantAllow: true,
+		},
+		{
+			name:   "deletion is allowed",
+			synced: true,
+			req: func(t *testing.T) admission.Request {
+				return request(t, admissionv1.Delete, wellknown.TrafficPolicyGVK, policy(true))
+			},
+			wantAllow: true,
+		},
+		{
+			name:   "errors until synced",
+			synced: false,
+			req: func(t *testing.T) admission.Request {
+				return request(t, admissionv1.Create, wellknown.TrafficPolicyGVK, policy(false))
+			},
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			v := webhook.NewPolicyValidator(schemes.DefaultScheme(), plugins(tc.synced))
+			resp := v.Handle(context.Background(), tc.req(t))
+			assert.Equal(t, tc.wantAllow, resp.Allowed)
+			if !tc.wantAllow {
+				assert.Equal(t, tc.wantCode, resp.Result.Code)
+			}
+			if tc.wantMessage != "" {
+				assert.Equal(t, tc.wantMessage, resp.Result.Message)
+			}
+		})
+	}
+}
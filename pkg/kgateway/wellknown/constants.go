@@ -49,6 +49,9 @@ const (
 	ExtprocFilterName     = "envoy.filters.http.ext_proc"
 )
 
+// UdpProxyFilterName is the name of the listener filter that proxies the sessions of UDP listeners.
+const UdpProxyFilterName = "envoy.filters.udp_listener.udp_proxy"
+
 const (
 	EnvoyConfigNameMaxLen = 253
 )
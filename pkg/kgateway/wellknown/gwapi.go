@@ -27,6 +27,7 @@ const (
 	HTTPRouteKind        = "HTTPRoute"
 	TCPRouteKind         = "TCPRoute"
 	TLSRouteKind         = "TLSRoute"
+	UDPRouteKind         = "UDPRoute"
 	GRPCRouteKind        = "GRPCRoute"
 	GatewayKind          = "Gateway"
 	GatewayClassKind     = "GatewayClass"
@@ -39,6 +40,8 @@ const (
 	// TODO: Remove legacy XListenerSet support once the nightly matrix no longer targets
 	// Gateway API releases that only serve gateway.networking.x-k8s.io XListenerSets.
 	XListenerSetKind = "XListenerSet"
+	// XBackendTrafficPolicyKind is the experimental BackendTrafficPolicy kind.
+	XBackendTrafficPolicyKind = "XBackendTrafficPolicy"
 
 	// List Kind strings
 	HTTPRouteListKind      = "HTTPRouteList"
@@ -136,6 +139,16 @@ var (
 		Version:  gwv1a2.GroupVersion.Version,
 		Resource: "tcproutes",
 	}
+	UDPRouteGVK = schema.GroupVersionKind{
+		Group:   GatewayGroup,
+		Version: gwv1a2.GroupVersion.Version,
+		Kind:    UDPRouteKind,
+	}
+	UDPRouteGVR = schema.GroupVersionResource{
+		Group:    GatewayGroup,
+		Version:  gwv1a2.GroupVersion.Version,
+		Resource: "udproutes",
+	}
 	GRPCRouteGVK = schema.GroupVersionKind{
 		Group:   GatewayGroup,
 		Version: gwv1.GroupVersion.Version,
@@ -200,6 +213,17 @@ var (
 		Version:  gwxv1a1.GroupVersion.Version,
 		Resource: "xlistenersets",
 	}
+
+	XBackendTrafficPolicyGVK = schema.GroupVersionKind{
+		Group:   gwxv1a1.GroupName,
+		Version: gwxv1a1.GroupVersion.Version,
+		Kind:    XBackendTrafficPolicyKind,
+	}
+	XBackendTrafficPolicyGVR = schema.GroupVersionResource{
+		Group:    gwxv1a1.GroupName,
+		Version:  gwxv1a1.GroupVersion.Version,
+		Resource: "xbackendtrafficpolicies",
+	}
 )
 
 func IsListenerSetGVK(gvk schema.GroupVersionKind) bool {
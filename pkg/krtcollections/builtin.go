@@ -178,7 +178,7 @@ func (h *RoutesIndex) buildHTTPRouteRulePolicy(rule gwv1.HTTPRouteRule) ruleIR {
 	// Ref: https://github.com/kgateway-dev/kgateway/issues/12825
 	if rule.SessionPersistence != nil {
 		if h.enableExperimentalGatewayAPIFeatures {
-			ir.sessionPersistence = convertSessionPersistence(rule.SessionPersistence)
+			ir.sessionPersistence = ConvertSessionPersistence(rule.SessionPersistence)
 		} else {
 			logger.Warn("experimental gateway api features are disabled but SessionPersistence is configured. Skipping")
 		}
@@ -334,7 +334,9 @@ func (r ruleIR) applyRetry(
 	action.RetryPolicy = r.retry
 }
 
-func convertSessionPersistence(sessionPersistence *gwv1.SessionPersistence) *stateful_sessionv3.StatefulSessionPerRoute {
+// ConvertSessionPersistence translates a Gateway API session persistence config to the per-route
+// config of the Envoy stateful session filter.
+func ConvertSessionPersistence(sessionPersistence *gwv1.SessionPersistence) *stateful_sessionv3.StatefulSessionPerRoute {
 	if sessionPersistence == nil {
 		return nil
 	}
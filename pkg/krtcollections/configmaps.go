@@ -7,6 +7,7 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
 type ConfigMapIndex struct {
@@ -53,7 +54,7 @@ func (c *ConfigMapIndex) GetConfigMap(kctx krt.HandlerContext, from From, config
 		Namespace: toNs,
 		Name:      string(configMapRef.Name),
 	}
-	cmPtr := krt.FetchOne(kctx, c.configmaps, krt.FilterObjectName(nn))
+	cmPtr := krtutil.FetchOne(kctx, c.configmaps, krt.FilterObjectName(nn))
 	if cmPtr == nil {
 		return nil, &NotFoundError{NotFoundObj: to}
 	}
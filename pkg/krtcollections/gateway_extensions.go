@@ -43,6 +43,8 @@ func NewGatewayExtensionsCollection(
 			RateLimit:        cr.Spec.RateLimit,
 			JWT:              cr.Spec.JWT,
 			OAuth2:           cr.Spec.OAuth2,
+			Wasm:             cr.Spec.Wasm,
+			GeoIP:            cr.Spec.GeoIP,
 			PrecedenceWeight: weight,
 		}
 		return gwExt
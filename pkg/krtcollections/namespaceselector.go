@@ -3,6 +3,8 @@ package krtcollections
 import (
 	"istio.io/istio/pkg/kube/krt"
 	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
 func SameNamespace(ns string) func(kctx krt.HandlerContext, namespace string) bool {
@@ -19,7 +21,7 @@ func AllNamespace() func(kctx krt.HandlerContext, namespace string) bool {
 
 func NamespaceSelector(namespaces krt.Collection[NamespaceMetadata], sel labels.Selector) func(kctx krt.HandlerContext, namespace string) bool {
 	return func(kctx krt.HandlerContext, namespace string) bool {
-		ns := krt.FetchOne(kctx, namespaces, krt.FilterKey(namespace))
+		ns := krtutil.FetchOne(kctx, namespaces, krt.FilterKey(namespace))
 		if ns == nil {
 			return sel.Empty()
 		}
@@ -296,7 +296,7 @@ func (i *BackendIndex) getBackend(kctx krt.HandlerContext, gk schema.GroupKind,
 		return i.getBackendFromAlias(kctx, gk, n, port)
 	}
 
-	up := krt.FetchOne(kctx, col, krt.FilterKey(ir.BackendResourceName(key, port, "")))
+	up := krtutil.FetchOne(kctx, col, krt.FilterKey(ir.BackendResourceName(key, port, "")))
 	if up == nil {
 		var (
 			err     error
@@ -334,7 +334,7 @@ func (i *BackendIndex) getBackendFromAlias(kctx krt.HandlerContext, gk schema.Gr
 			continue
 		}
 
-		results = append(results, krt.Fetch(kctx, col, krt.FilterIndex(i.aliasIndexWithPolicy[actualGk], key))...)
+		results = append(results, krtutil.Fetch(kctx, col, krt.FilterIndex(i.aliasIndexWithPolicy[actualGk], key))...)
 
 		didFetch = true
 	}
@@ -425,16 +425,36 @@ func NewGatewayIndex(config GatewayIndexConfig, opts ...GatewayIndexConfigOption
 func GatewaysForDeployerTransformationFunc(config *GatewayIndexConfig) func(kctx krt.HandlerContext, gw *gwv1.Gateway) *ir.GatewayForDeployer {
 	return func(kctx krt.HandlerContext, gw *gwv1.Gateway) *ir.GatewayForDeployer {
 		// only care about gateways that use a class controlled by us
-		gwClass := ptr.Flatten(krt.FetchOne(kctx, config.GatewayClasses, krt.FilterKey(string(gw.Spec.GatewayClassName))))
+		gwClass := ptr.Flatten(krtutil.FetchOne(kctx, config.GatewayClasses, krt.FilterKey(string(gw.Spec.GatewayClassName))))
 		if gwClass == nil || !config.ControllerNames.Contains(string(gwClass.Spec.ControllerName)) {
 			return nil
 		}
+		gwSource := ir.ObjectSource{
+			Group:     gwv1.GroupVersion.Group,
+			Kind:      wellknown.GatewayKind,
+			Namespace: gw.Namespace,
+			Name:      gw.Name,
+		}
+		gwPolicies := config.PolicyIndex.GetTargetingPolicies(kctx, gwSource, "", gw.GetLabels())
+
 		ports := sets.New[int32]()
+		udpPorts := sets.New[int32]()
 		for _, l := range gw.Spec.Listeners {
+			if l.Protocol == gwv1.UDPProtocolType {
+				udpPorts.Insert(l.Port)
+				continue
+			}
 			ports.Insert(l.Port)
+			// HTTP/3 is served on a UDP port alongside the HTTPS listener
+			if l.Protocol == gwv1.HTTPSProtocolType {
+				sectionPolicies := config.PolicyIndex.GetTargetingPolicies(kctx, gwSource, string(l.Name), gw.GetLabels())
+				if http3Enabled(l.Port, sectionPolicies, gwPolicies) {
+					udpPorts.Insert(l.Port)
+				}
+			}
 		}
 
-		listenerSets := krt.Fetch(kctx, config.ListenerSets, krt.FilterIndex(config.byParentRefIndex, TargetRefIndexKey{
+		listenerSets := krtutil.Fetch(kctx, config.ListenerSets, krt.FilterIndex(config.byParentRefIndex, TargetRefIndexKey{
 			Group:     wellknown.GatewayGroup,
 			Kind:      wellknown.GatewayKind,
 			Name:      gw.GetName(),
@@ -448,27 +468,56 @@ func GatewaysForDeployerTransformationFunc(config *GatewayIndexConfig) func(kctx
 				if portErr != nil {
 					continue
 				}
+				if l.Protocol == gwv1.UDPProtocolType {
+					udpPorts.Insert(port)
+					continue
+				}
 				ports.Insert(port)
+				if l.Protocol == gwv1.HTTPSProtocolType {
+					lsGVK := ls.GroupVersionKind()
+					if lsGVK.Empty() {
+						lsGVK = wellknown.ListenerSetGVK
+					}
+					lsSource := ir.ObjectSource{
+						Group:     lsGVK.Group,
+						Kind:      lsGVK.Kind,
+						Namespace: ls.Namespace,
+						Name:      ls.Name,
+					}
+					sectionPolicies := config.PolicyIndex.GetTargetingPolicies(kctx, lsSource, string(l.Name), ls.GetLabels())
+					listenerSetPolicies := config.PolicyIndex.GetTargetingPolicies(kctx, lsSource, "", ls.GetLabels())
+					if http3Enabled(port, sectionPolicies, listenerSetPolicies, gwPolicies) {
+						udpPorts.Insert(port)
+					}
+				}
 			}
 		}
 		ir := &ir.GatewayForDeployer{
-			ObjectSource: ir.ObjectSource{
-				Group:     gwv1.GroupVersion.Group,
-				Kind:      wellknown.GatewayKind,
-				Namespace: gw.Namespace,
-				Name:      gw.Name,
-			},
+			ObjectSource:   gwSource,
 			ControllerName: string(gwClass.Spec.ControllerName),
 			Ports:          smallset.New(ports.UnsortedList()...),
+			UDPPorts:       smallset.New(udpPorts.UnsortedList()...),
 		}
 		return ir
 	}
 }
 
+// http3Enabled returns whether any of the given policies serves the port over HTTP/3.
+func http3Enabled(port int32, policies ...[]ir.PolicyAtt) bool {
+	for _, pols := range policies {
+		for _, pol := range pols {
+			if h3, ok := pol.PolicyIr.(ir.HTTP3PolicyIR); ok && h3.HTTP3(uint32(port)) != nil { //nolint:gosec // G115: Gateway listener port is int32, always positive, safe to convert to uint32
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func GatewaysForEnvoyTransformationFunc(config *GatewayIndexConfig) func(kctx krt.HandlerContext, gw *gwv1.Gateway) *ir.Gateway {
 	return func(kctx krt.HandlerContext, gw *gwv1.Gateway) *ir.Gateway {
 		// only care about gateways use a class controlled by envoy
-		gwClass := ptr.Flatten(krt.FetchOne(kctx, config.GatewayClasses, krt.FilterKey(string(gw.Spec.GatewayClassName))))
+		gwClass := ptr.Flatten(krtutil.FetchOne(kctx, config.GatewayClasses, krt.FilterKey(string(gw.Spec.GatewayClassName))))
 		if gwClass == nil || string(gwClass.Spec.ControllerName) != config.EnvoyControllerName {
 			return nil
 		}
@@ -510,7 +559,7 @@ func GatewaysForEnvoyTransformationFunc(config *GatewayIndexConfig) func(kctx kr
 			logger.Error("unable to parse allowedListeners", "error", err)
 		}
 
-		listenerSets := krt.Fetch(kctx, config.ListenerSets, krt.FilterIndex(config.byParentRefIndex, TargetRefIndexKey{
+		listenerSets := krtutil.Fetch(kctx, config.ListenerSets, krt.FilterIndex(config.byParentRefIndex, TargetRefIndexKey{
 			Group:     wellknown.GatewayGroup,
 			Kind:      wellknown.GatewayKind,
 			Name:      gw.GetName(),
@@ -789,7 +838,7 @@ func (p *PolicyIndex) fetchByTargetRef(
 		if onlyBackends && !policyCol.forBackends {
 			continue
 		}
-		policies := krt.Fetch(kctx, policyCol.policiesByTargetRef, krt.FilterIndex(policyCol.index, targetRef))
+		policies := krtutil.Fetch(kctx, policyCol.policiesByTargetRef, krt.FilterIndex(policyCol.index, targetRef))
 		ret = append(ret, policies...)
 	}
 	return ret
@@ -806,7 +855,7 @@ func (p *PolicyIndex) fetchByTargetRefLabels(
 		if onlyBackends && !policyCol.forBackends {
 			continue
 		}
-		policies := krt.Fetch(kctx, policyCol.policiesByTargetRef, krt.FilterIndex(policyCol.index, targetRef),
+		policies := krtutil.Fetch(kctx, policyCol.policiesByTargetRef, krt.FilterIndex(policyCol.index, targetRef),
 			krt.FilterGeneric(func(a any) bool {
 				p := a.(ir.PolicyWrapper)
 				for _, ref := range p.TargetRefs {
@@ -959,7 +1008,7 @@ func (p *PolicyIndex) fetchPolicy(kctx krt.HandlerContext, policyRef ir.ObjectSo
 		}
 	}
 	if pi, ok := p.availablePolicies[gk]; ok {
-		return krt.FetchOne(kctx, pi.policies, krt.FilterKey(policyRef.ResourceName()))
+		return krtutil.FetchOne(kctx, pi.policies, krt.FilterKey(policyRef.ResourceName()))
 	}
 	return nil
 }
@@ -1043,13 +1092,13 @@ func (r *RefGrantIndex) ReferenceAllowed(kctx krt.HandlerContext, fromgk schema.
 		FromGK:     fromgk,
 		FromNs:     fromns,
 	}
-	matchingGrants := krt.Fetch(kctx, r.refgrants, krt.FilterIndex(r.refGrantIndex, key))
+	matchingGrants := krtutil.Fetch(kctx, r.refgrants, krt.FilterIndex(r.refGrantIndex, key))
 	if len(matchingGrants) != 0 {
 		return true
 	}
 	// try with name:
 	key.ToName = to.Name
-	return len(krt.Fetch(kctx, r.refgrants, krt.FilterIndex(r.refGrantIndex, key))) != 0
+	return len(krtutil.Fetch(kctx, r.refgrants, krt.FilterIndex(r.refGrantIndex, key))) != 0
 }
 
 type RouteWrapper struct {
@@ -1086,6 +1135,12 @@ func (c RouteWrapper) Equals(in RouteWrapper) bool {
 		} else {
 			return a.Equals(*bhttp)
 		}
+	case *ir.UdpRouteIR:
+		if budp, ok := in.Route.(*ir.UdpRouteIR); !ok {
+			return false
+		} else {
+			return a.Equals(*budp)
+		}
 	}
 	panic("unknown route type")
 }
@@ -1099,6 +1154,7 @@ type RoutesIndex struct {
 	grpcRouteStatusMarkers               krt.StatusCollection[*gwv1.GRPCRoute, StatusMarker]
 	tcpRouteStatusMarkers                krt.StatusCollection[*gwv1a2.TCPRoute, StatusMarker]
 	tlsRouteStatusMarkers                krt.StatusCollection[*gwv1a2.TLSRoute, StatusMarker]
+	udpRouteStatusMarkers                krt.StatusCollection[*gwv1a2.UDPRoute, StatusMarker]
 	httpBySelector                       krt.Index[HTTPRouteSelector, ir.HttpRouteIR]
 	byParentRef                          krt.Index[TargetRefIndexKey, RouteWrapper]
 	weightedRoutePrecedence              bool
@@ -1126,12 +1182,13 @@ func (r *RoutesIndex) HTTPRoutes() krt.Collection[ir.HttpRouteIR] {
 }
 
 // ProcessRouteStatusMarkers adds empty status in the report map for any marked route (HTTP, TCP,
-// TLS, GRPC) that has no status reported. Used for clearing stale status for orphaned routes.
+// TLS, UDP, GRPC) that has no status reported. Used for clearing stale status for orphaned routes.
 func (r *RoutesIndex) ProcessRouteStatusMarkers(kctx krt.HandlerContext, reportMap reports.ReportMap) {
 	rp := reports.NewReporter(&reportMap)
 	processRouteStatusMarkers(kctx, r.httpRouteStatusMarkers, reportMap.HTTPRoutes, rp)
 	processRouteStatusMarkers(kctx, r.tcpRouteStatusMarkers, reportMap.TCPRoutes, rp)
 	processRouteStatusMarkers(kctx, r.tlsRouteStatusMarkers, reportMap.TLSRoutes, rp)
+	processRouteStatusMarkers(kctx, r.udpRouteStatusMarkers, reportMap.UDPRoutes, rp)
 	processRouteStatusMarkers(kctx, r.grpcRouteStatusMarkers, reportMap.GRPCRoutes, rp)
 }
 
@@ -1143,7 +1200,7 @@ func processRouteStatusMarkers[T controllers.Object](
 	reportMap map[types.NamespacedName]*reports.RouteReport,
 	rp reporter.Reporter,
 ) {
-	objStatus := krt.Fetch(kctx, statusCol)
+	objStatus := krtutil.Fetch(kctx, statusCol)
 	for _, status := range objStatus {
 		routeKey := types.NamespacedName{
 			Namespace: status.Obj.GetNamespace(),
@@ -1164,6 +1221,7 @@ func NewRoutesIndex(
 	grpcroutes krt.Collection[*gwv1.GRPCRoute],
 	tcproutes krt.Collection[*gwv1a2.TCPRoute],
 	tlsroutes krt.Collection[*gwv1a2.TLSRoute],
+	udproutes krt.Collection[*gwv1a2.UDPRoute],
 	policies *PolicyIndex,
 	backends *BackendIndex,
 	refgrants *RefGrantIndex,
@@ -1176,7 +1234,7 @@ func NewRoutesIndex(
 		weightedRoutePrecedence:              globalSettings.WeightedRoutePrecedence,
 		enableExperimentalGatewayAPIFeatures: globalSettings.EnableExperimentalGatewayAPIFeatures,
 	}
-	h.hasSyncedFuncs = append(h.hasSyncedFuncs, httproutes.HasSynced, grpcroutes.HasSynced, tcproutes.HasSynced, tlsroutes.HasSynced)
+	h.hasSyncedFuncs = append(h.hasSyncedFuncs, httproutes.HasSynced, grpcroutes.HasSynced, tcproutes.HasSynced, tlsroutes.HasSynced, udproutes.HasSynced)
 
 	h.httpRouteStatusMarkers, h.httpRoutes = krt.NewStatusCollection(httproutes, func(kctx krt.HandlerContext, i *gwv1.HTTPRoute) (*StatusMarker, *ir.HttpRouteIR) {
 		return h.transformHttpRoute(kctx, i, controllerName)
@@ -1186,7 +1244,7 @@ func NewRoutesIndex(
 		return &RouteWrapper{Route: &i}
 	}, krtopts.ToOptions("routes-http-routes-with-policy")...)
 
-	var tcpRoutesCollection, tlsRoutesCollection, grpcRoutesCollection krt.Collection[RouteWrapper]
+	var tcpRoutesCollection, tlsRoutesCollection, udpRoutesCollection, grpcRoutesCollection krt.Collection[RouteWrapper]
 
 	h.grpcRouteStatusMarkers, grpcRoutesCollection = krt.NewStatusCollection(grpcroutes, func(kctx krt.HandlerContext, i *gwv1.GRPCRoute) (*StatusMarker, *RouteWrapper) {
 		status, route := h.transformGRPCRoute(kctx, i, controllerName)
@@ -1203,7 +1261,12 @@ func NewRoutesIndex(
 		return status, &RouteWrapper{Route: route}
 	}, krtopts.ToOptions("routes-tls-routes-with-policy")...)
 
-	h.routes = krt.JoinCollection([]krt.Collection[RouteWrapper]{httpRouteCollection, grpcRoutesCollection, tcpRoutesCollection, tlsRoutesCollection}, krtopts.ToOptions("all-routes-with-policy")...)
+	h.udpRouteStatusMarkers, udpRoutesCollection = krt.NewStatusCollection(udproutes, func(kctx krt.HandlerContext, i *gwv1a2.UDPRoute) (*StatusMarker, *RouteWrapper) {
+		status, route := h.transformUdpRoute(kctx, i, controllerName)
+		return status, &RouteWrapper{Route: route}
+	}, krtopts.ToOptions("routes-udp-routes-with-policy")...)
+
+	h.routes = krt.JoinCollection([]krt.Collection[RouteWrapper]{httpRouteCollection, grpcRoutesCollection, tcpRoutesCollection, tlsRoutesCollection, udpRoutesCollection}, krtopts.ToOptions("all-routes-with-policy")...)
 
 	httpBySelector := krtpkg.UnnamedIndex(h.httpRoutes, func(i ir.HttpRouteIR) []HTTPRouteSelector {
 		value, ok := i.SourceObject.GetLabels()[apilabels.DelegationLabelSelector]
@@ -1258,11 +1321,11 @@ func NewRoutesIndex(
 }
 
 func (h *RoutesIndex) FetchHTTPRoutesBySelector(kctx krt.HandlerContext, selector HTTPRouteSelector) []ir.HttpRouteIR {
-	return krt.Fetch(kctx, h.httpRoutes, krt.FilterIndex(h.httpBySelector, selector))
+	return krtutil.Fetch(kctx, h.httpRoutes, krt.FilterIndex(h.httpBySelector, selector))
 }
 
 func (h *RoutesIndex) RoutesFor(kctx krt.HandlerContext, nns types.NamespacedName, group, kind string) []ir.Route {
-	rts := krt.Fetch(kctx, h.routes, krt.FilterIndex(h.byParentRef, TargetRefIndexKey{
+	rts := krtutil.Fetch(kctx, h.routes, krt.FilterIndex(h.byParentRef, TargetRefIndexKey{
 		Name:      nns.Name,
 		Group:     group,
 		Kind:      kind,
@@ -1282,7 +1345,7 @@ func (h *RoutesIndex) FetchHttp(kctx krt.HandlerContext, ns, n string) *ir.HttpR
 		Namespace: ns,
 		Name:      n,
 	}
-	route := krt.FetchOne(kctx, h.httpRoutes, krt.FilterKey(src.ResourceName()))
+	route := krtutil.FetchOne(kctx, h.httpRoutes, krt.FilterKey(src.ResourceName()))
 	return route
 }
 
@@ -1304,7 +1367,7 @@ func (h *RoutesIndex) Fetch(kctx krt.HandlerContext, gk schema.GroupKind, ns, n
 		Namespace: ns,
 		Name:      n,
 	}
-	return krt.FetchOne(kctx, h.routes, krt.FilterKey(src.ResourceName()))
+	return krtutil.FetchOne(kctx, h.routes, krt.FilterKey(src.ResourceName()))
 }
 
 func (h *RoutesIndex) transformTcpRoute(kctx krt.HandlerContext, i *gwv1a2.TCPRoute, controllerName string) (*StatusMarker, *ir.TcpRouteIR) {
@@ -1366,6 +1429,35 @@ func (h *RoutesIndex) transformTlsRoute(kctx krt.HandlerContext, i *gwv1a2.TLSRo
 	}
 }
 
+func (h *RoutesIndex) transformUdpRoute(kctx krt.HandlerContext, i *gwv1a2.UDPRoute, controllerName string) (*StatusMarker, *ir.UdpRouteIR) {
+	src := ir.ObjectSource{
+		Group:     gwv1a2.GroupVersion.Group,
+		Kind:      wellknown.UDPRouteKind,
+		Namespace: i.Namespace,
+		Name:      i.Name,
+	}
+	var backends []gwv1.BackendRef
+	if len(i.Spec.Rules) > 0 {
+		backends = i.Spec.Rules[0].BackendRefs
+	}
+
+	var statusMarker *StatusMarker
+	for _, parentStatus := range i.Status.Parents {
+		if string(parentStatus.ControllerName) == controllerName {
+			statusMarker = &StatusMarker{}
+			break
+		}
+	}
+
+	return statusMarker, &ir.UdpRouteIR{
+		ObjectSource:     src,
+		SourceObject:     i,
+		ParentRefs:       i.Spec.ParentRefs,
+		Backends:         h.getTcpBackends(kctx, src, backends),
+		AttachedPolicies: ToAttachedPolicies(h.policies.GetTargetingPolicies(kctx, src, "", i.GetLabels())),
+	}
+}
+
 func (h *RoutesIndex) transformHttpRoute(kctx krt.HandlerContext, i *gwv1.HTTPRoute, controllerName string) (*StatusMarker, *ir.HttpRouteIR) {
 	src := ir.ObjectSource{
 		Group:     gwv1.GroupVersion.Group,
@@ -8,6 +8,7 @@ import (
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
 )
 
 type From struct {
@@ -65,7 +66,7 @@ func (s *SecretIndex) GetSecret(kctx krt.HandlerContext, from From, secretRef gw
 	if !s.refgrants.ReferenceAllowed(kctx, from.GroupKind, from.Namespace, to) {
 		return nil, fmt.Errorf("cannot reference secret %s : %w", to.NamespacedName(), ErrMissingReferenceGrant)
 	}
-	secret := krt.FetchOne(kctx, col, krt.FilterKey(to.ResourceName()))
+	secret := krtutil.FetchOne(kctx, col, krt.FilterKey(to.ResourceName()))
 	if secret == nil {
 		return nil, &NotFoundError{NotFoundObj: to}
 	}
@@ -105,7 +106,7 @@ func (s *SecretIndex) GetSecretsBySelector(
 	}
 
 	// First, fetch all secrets matching the label selector
-	labelMatchedSecrets := krt.Fetch(kctx, col,
+	labelMatchedSecrets := krtutil.Fetch(kctx, col,
 		krt.FilterGeneric(func(obj any) bool {
 			secret := obj.(ir.Secret)
 
@@ -111,6 +111,19 @@ func newDelayedTypedInformer[T controllers.ComparableObject](
 	return delayed
 }
 
+// NewOptionalInformer returns a typed informer for a CRD that may not be installed, such as an
+// experimental Gateway API resource. When the cluster does not serve gvr, the informer reports
+// synced right away and starts watching once the CRD is installed.
+func NewOptionalInformer[T controllers.ComparableObject](
+	c kube.Client,
+	gvr schema.GroupVersionResource,
+	filter kclient.Filter,
+) kclient.Informer[T] {
+	return newDelayedTypedInformer(c, gvr, func() kclient.Informer[T] {
+		return kclient.NewFiltered[T](c, filter)
+	})
+}
+
 func newDelayedDynamicUnstructuredInformer(
 	c kube.Client,
 	gvr schema.GroupVersionResource,
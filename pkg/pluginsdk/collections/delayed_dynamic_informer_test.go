@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	gwv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwxv1a1 "sigs.k8s.io/gateway-api/apisx/v1alpha1"
 	"sigs.k8s.io/gateway-api/pkg/consts"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
@@ -136,6 +137,18 @@ func TestDelayedDynamicUnstructuredInformerReportsSyncedWithoutCRD(t *testing.T)
 	require.Empty(t, inf.List(metav1.NamespaceAll, labels.Everything()))
 }
 
+func TestOptionalInformerReportsSyncedWithoutCRD(t *testing.T) {
+	stop := test.NewStop(t)
+	_ = apiextensionsv1.AddToScheme(kube.FakeIstioScheme)
+	client := kube.NewFakeClient()
+
+	inf := NewOptionalInformer[*gwxv1a1.XBackendTrafficPolicy](client, wellknown.XBackendTrafficPolicyGVR, kclient.Filter{})
+	inf.Start(stop)
+
+	require.True(t, inf.HasSynced(), "missing CRDs should not block startup")
+	require.Empty(t, inf.List(metav1.NamespaceAll, labels.Everything()))
+}
+
 func TestCrdServesVersionWithNilClientIsNonAuthoritative(t *testing.T) {
 	served, err := crdServesVersion(nil, wellknown.TLSRouteV1Alpha3GVR)
 	require.Error(t, err)
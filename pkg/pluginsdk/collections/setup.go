@@ -188,8 +188,16 @@ func (c *CommonCollections) InitCollections(
 	default:
 		tlsRoutes = krt.JoinCollection(tlsRouteCollections, c.KrtOpts.ToOptions("TLSRoute")...)
 	}
+	// UDPRoute is only served by the experimental Gateway API channel.
+	var udpRoutes krt.Collection[*gwv1a2.UDPRoute]
+	if globalSettings.EnableExperimentalGatewayAPIFeatures {
+		udpRoutes = krt.WrapClient(kclient.NewFilteredDelayed[*gwv1a2.UDPRoute](c.Client, wellknown.UDPRouteGVR, filter), c.KrtOpts.ToOptions("UDPRoute")...)
+	} else {
+		udpRoutes = krt.NewStaticCollection[*gwv1a2.UDPRoute](nil, nil, c.KrtOpts.ToOptions("disable/UDPRoute")...)
+	}
 	metrics.RegisterEvents(tcproutes, kmetrics.GetResourceMetricEventHandler[*gwv1a2.TCPRoute]())
 	metrics.RegisterEvents(tlsRoutes, kmetrics.GetResourceMetricEventHandler[*gwv1a2.TLSRoute]())
+	metrics.RegisterEvents(udpRoutes, kmetrics.GetResourceMetricEventHandler[*gwv1a2.UDPRoute]())
 
 	grpcRoutes := krt.WrapClient(kclient.NewFilteredDelayed[*gwv1.GRPCRoute](c.Client, wellknown.GRPCRouteGVR, filter), c.KrtOpts.ToOptions("GRPCRoute")...)
 	metrics.RegisterEvents(grpcRoutes, kmetrics.GetResourceMetricEventHandler[*gwv1.GRPCRoute]())
@@ -198,7 +206,7 @@ func (c *CommonCollections) InitCollections(
 	initBackends(plugins, backendIndex)
 	endpointIRs := initEndpoints(plugins, c.KrtOpts)
 
-	routes := krtcollections.NewRoutesIndex(c.KrtOpts, c.ControllerName, httpRoutes, grpcRoutes, tcproutes, tlsRoutes, policies, backendIndex, c.RefGrants, globalSettings)
+	routes := krtcollections.NewRoutesIndex(c.KrtOpts, c.ControllerName, httpRoutes, grpcRoutes, tcproutes, tlsRoutes, udpRoutes, policies, backendIndex, c.RefGrants, globalSettings)
 	return gateways, routes, backendIndex, endpointIRs
 }
 
@@ -0,0 +1,686 @@
+package discovery
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/krtcollections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/logging"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/krtutil"
+)
+
+var logger = logging.New("pluginsdk/discovery")
+
+const (
+	defaultRefreshInterval = 30 * time.Second
+	// defaultPollTimeout bounds a single poll independently of the refresh
+	// interval, so a hung API call stalls discovery for at most this long.
+	defaultPollTimeout = 30 * time.Second
+	defaultMaxBackoff  = 5 * time.Minute
+)
+
+// Options configures a Collection.
+type Options struct {
+	// Enabled starts discovery. A disabled Collection has empty, synced
+	// collections and never calls the provider.
+	Enabled bool
+	// RefreshInterval is the interval between polls. Defaults to 30s. The scopes
+	// of a BlockingLister are listed at most once per interval instead.
+	RefreshInterval time.Duration
+	// PollTimeout bounds a single poll. Defaults to 30s. It does not bound the
+	// listings of a BlockingLister, only how long HasSynced waits for the initial
+	// ones.
+	PollTimeout time.Duration
+	// MaxBackoff caps the interval between the polls of a scope whose listings
+	// keep failing, which doubles with every consecutive failure. Defaults to
+	// 5m, and is never lower than RefreshInterval.
+	MaxBackoff time.Duration
+	// Metrics records the outcome of polls. Defaults to NewMetricsRecorder.
+	Metrics MetricsRecorder
+	KrtOpts krtutil.KrtOptions
+}
+
+// Collection discovers the endpoints of the backends of a Provider.
+type Collection[C Config[C], I any] struct {
+	provider        Provider[C, I]
+	lister          BlockingLister[C, I] // the provider, or nil when it is not a BlockingLister
+	backends        krt.Collection[ir.BackendObjectIR]
+	trigger         *krt.RecomputeTrigger
+	refreshInterval time.Duration
+	pollTimeout     time.Duration
+	maxBackoff      time.Duration
+	metrics         MetricsRecorder
+	// refreshCh requests an immediate poll (buffered, size 1, so concurrent
+	// requests coalesce). Used when a backend has no state yet or its state was
+	// resolved under an outdated config, so reconciliation doesn't have to wait
+	// out the refresh interval.
+	refreshCh chan struct{}
+
+	// pollMu serializes polls, and guards scopes, watchCtx and watches.
+	pollMu sync.Mutex
+	// scopes tracks the scopes whose listing failed on their last poll.
+	scopes map[string]scopeBackoff
+	// watchCtx is the context of the watches of a BlockingLister, set once the
+	// collection starts running.
+	watchCtx context.Context
+	// watches holds the watch of every scope of a BlockingLister.
+	watches map[string]*scopeWatch[I]
+
+	stateMu sync.RWMutex
+	state   map[string]backendState[C]
+
+	Endpoints krt.Collection[ir.EndpointsForBackend]
+	// DiscoveryStatus contributes the EndpointsDiscovered condition of every
+	// backend of the provider, derived from its latest poll.
+	DiscoveryStatus krt.Collection[ir.BackendObjectStatus]
+}
+
+type backendState[C Config[C]] struct {
+	config    C
+	endpoints []Endpoint
+	status    Status
+}
+
+func (s backendState[C]) equals(other backendState[C]) bool {
+	return s.config.Equals(other.config) &&
+		slices.Equal(s.endpoints, other.endpoints) &&
+		s.status == other.status
+}
+
+type scopeBackoff struct {
+	failures int
+	// skip is the number of scheduled polls left before the scope is listed again.
+	skip int
+}
+
+// scopeWatch is the watch of a scope of a BlockingLister.
+type scopeWatch[I any] struct {
+	cancel context.CancelFunc
+	// listed is closed once the first listing of the scope completed.
+	listed chan struct{}
+
+	mu sync.Mutex
+	// latest is the latest listing of the scope, and applied whether it was
+	// applied to the state of the backends of the scope since.
+	latest  *scopeListing[I]
+	applied bool
+}
+
+type scopeListing[I any] struct {
+	instances []I
+	err       error
+	duration  time.Duration
+}
+
+// set records the latest listing of the scope. Only the watch calls it.
+func (w *scopeWatch[I]) set(listing scopeListing[I]) {
+	w.mu.Lock()
+	w.latest, w.applied = &listing, false
+	w.mu.Unlock()
+	select {
+	case <-w.listed:
+	default:
+		close(w.listed)
+	}
+}
+
+// take returns the latest listing of the scope, and whether it was not applied
+// yet, and marks it applied.
+func (w *scopeWatch[I]) take() (*scopeListing[I], bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fresh := w.latest != nil && !w.applied
+	w.applied = true
+	return w.latest, fresh
+}
+
+type backendConfig[C Config[C]] struct {
+	resourceName string
+	namespace    string
+	name         string
+	config       C
+}
+
+// NewCollection builds the collections of discovered endpoints and statuses for
+// the backends that provider discovers, and polls provider until ctx is
+// cancelled. A nil ctx builds the collections without polling, for tests that
+// drive polls with Refresh; the scopes of a BlockingLister are then never listed.
+func NewCollection[C Config[C], I any](
+	ctx context.Context,
+	backends krt.Collection[ir.BackendObjectIR],
+	provider Provider[C, I],
+	opts Options,
+) *Collection[C, I] {
+	c := &Collection[C, I]{
+		provider: provider,
+		backends: backends,
+		// Start the trigger unsynced so that Endpoints.HasSynced blocks until the
+		// initial poll has populated c.state, and Envoy never observes the empty
+		// pre-poll EDS view as a synced state.
+		trigger:         krt.NewRecomputeTrigger(false),
+		refreshInterval: cmp.Or(opts.RefreshInterval, defaultRefreshInterval),
+		pollTimeout:     cmp.Or(opts.PollTimeout, defaultPollTimeout),
+		metrics:         opts.Metrics,
+		refreshCh:       make(chan struct{}, 1),
+		scopes:          map[string]scopeBackoff{},
+		watches:         map[string]*scopeWatch[I]{},
+		state:           map[string]backendState[C]{},
+	}
+	c.lister, _ = provider.(BlockingLister[C, I])
+	c.maxBackoff = max(cmp.Or(opts.MaxBackoff, defaultMaxBackoff), c.refreshInterval)
+	if c.metrics == nil {
+		c.metrics = NewMetricsRecorder(provider.Name())
+	}
+
+	name := provider.Name()
+	if !opts.Enabled {
+		c.trigger.MarkSynced()
+		c.Endpoints = krt.NewStaticCollection[ir.EndpointsForBackend](nil, nil, opts.KrtOpts.ToOptions("disable/"+name+"/DiscoveredEndpoints")...)
+		c.DiscoveryStatus = krt.NewStaticCollection[ir.BackendObjectStatus](nil, nil, opts.KrtOpts.ToOptions("disable/"+name+"/DiscoveryStatus")...)
+		return c
+	}
+
+	c.Endpoints = krt.NewCollection(backends, func(kctx krt.HandlerContext, backend ir.BackendObjectIR) *ir.EndpointsForBackend {
+		cfg, ok := provider.Config(backend)
+		if !ok {
+			return nil
+		}
+		c.trigger.MarkDependant(kctx)
+		return c.endpointsForBackend(backend, cfg)
+	}, opts.KrtOpts.ToOptions(name+"/DiscoveredEndpoints")...)
+
+	c.DiscoveryStatus = krt.NewCollection(backends, func(kctx krt.HandlerContext, backend ir.BackendObjectIR) *ir.BackendObjectStatus {
+		return c.discoveryStatusForBackend(kctx, backend)
+	}, opts.KrtOpts.ToOptions(name+"/DiscoveryStatus")...)
+
+	// Drop the metric series of deleted backends so stale gauges don't remain
+	// visible. Registered whether or not metrics are active, as the recorder
+	// checks metrics.Active() on every call; deleting absent series is a no-op.
+	backends.Register(func(o krt.Event[ir.BackendObjectIR]) {
+		if o.Event != controllers.EventDelete {
+			return
+		}
+		src := o.Latest().GetObjectSource()
+		c.metrics.Delete(src.Namespace, src.Name)
+	})
+
+	if ctx != nil {
+		go c.run(ctx)
+	}
+
+	return c
+}
+
+// HasSynced reports whether the initial poll has propagated to Endpoints.
+func (c *Collection[C, I]) HasSynced() bool {
+	return c.Endpoints.HasSynced()
+}
+
+// Discovered returns the endpoints and status of a backend after the latest
+// poll, and false when no poll covered the backend yet.
+func (c *Collection[C, I]) Discovered(resourceName string) ([]Endpoint, Status, bool) {
+	c.stateMu.RLock()
+	defer c.stateMu.RUnlock()
+	state, ok := c.state[resourceName]
+	return slices.Clone(state.endpoints), state.status, ok
+}
+
+// run polls until ctx is cancelled, which also cancels the in-flight poll and
+// the watches of a BlockingLister.
+func (c *Collection[C, I]) run(ctx context.Context) {
+	name := c.provider.Name()
+	logger.Debug("starting endpoint discovery", "provider", name, "refresh_interval", c.refreshInterval)
+	if !kube.WaitForCacheSync(name+" backends", ctx.Done(), c.backends.HasSynced) {
+		logger.Debug("endpoint discovery stopped before backend cache sync completed", "provider", name)
+		return
+	}
+
+	c.pollMu.Lock()
+	c.watchCtx = ctx
+	c.pollMu.Unlock()
+
+	// The initial poll covers every backend already in the synced collection,
+	// so drop the requests queued before it.
+	c.drainRefreshRequest()
+	c.Refresh(ctx, true)
+	var tick <-chan time.Time
+	if c.lister == nil {
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	} else {
+		// The refresh above started the watches, whose first listings the
+		// initial state is made of.
+		c.waitForInitialListings(ctx)
+		c.drainRefreshRequest()
+		c.Refresh(ctx, false)
+	}
+	// Mark the trigger synced only after the initial poll has populated c.state.
+	c.trigger.MarkSynced()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Debug("stopping endpoint discovery", "provider", name)
+			return
+		case <-tick:
+			c.Refresh(ctx, true)
+		case <-c.refreshCh:
+			c.Refresh(ctx, false)
+		}
+	}
+}
+
+// requestRefresh asks the run loop for an immediate poll. The send is
+// non-blocking, so pending requests coalesce in the single-slot buffer.
+func (c *Collection[C, I]) requestRefresh() {
+	select {
+	case c.refreshCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Collection[C, I]) drainRefreshRequest() {
+	select {
+	case <-c.refreshCh:
+	default:
+	}
+}
+
+// waitForInitialListings waits until every watch completed its first listing,
+// for at most the poll timeout.
+func (c *Collection[C, I]) waitForInitialListings(ctx context.Context) {
+	c.pollMu.Lock()
+	watches := slices.Collect(maps.Values(c.watches))
+	c.pollMu.Unlock()
+
+	timeout := time.NewTimer(c.pollTimeout)
+	defer timeout.Stop()
+	for _, w := range watches {
+		select {
+		case <-w.listed:
+		case <-timeout.C:
+			logger.Warn("initial endpoint discovery did not complete within the poll timeout", "provider", c.provider.Name())
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Refresh polls the backends of the provider and updates the discovered
+// endpoints. A scheduled poll lists every scope that is not backing off, while
+// an unscheduled one only lists the scopes of backends that have no state yet
+// or whose config changed since their state was resolved. For a BlockingLister,
+// Refresh lists nothing but applies the latest listings of the watches instead.
+func (c *Collection[C, I]) Refresh(ctx context.Context, scheduled bool) {
+	c.pollMu.Lock()
+	defer c.pollMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.pollTimeout)
+	defer cancel()
+
+	nextState, err := c.computeState(ctx, scheduled)
+	if err != nil {
+		logger.Error("failed to discover backend endpoints", "provider", c.provider.Name(), "error", err)
+	}
+
+	c.stateMu.Lock()
+	changed := !maps.EqualFunc(c.state, nextState, backendState[C].equals)
+	c.state = nextState
+	c.stateMu.Unlock()
+
+	logger.Debug("completed endpoint discovery poll", "provider", c.provider.Name(), "backends", len(nextState), "changed", changed)
+	if changed {
+		c.trigger.TriggerRecomputation()
+	}
+}
+
+// computeState polls the scopes due for a poll, and carries the state of the
+// other backends. c.pollMu must be held.
+func (c *Collection[C, I]) computeState(ctx context.Context, scheduled bool) (map[string]backendState[C], error) {
+	byScope := map[string][]backendConfig[C]{}
+	for _, backend := range c.backends.List() {
+		cfg, ok := c.provider.Config(backend)
+		if !ok {
+			continue
+		}
+		src := backend.GetObjectSource()
+		scope := cfg.ListScope()
+		byScope[scope] = append(byScope[scope], backendConfig[C]{
+			resourceName: backend.ResourceName(),
+			namespace:    src.Namespace,
+			name:         src.Name,
+			config:       cfg,
+		})
+	}
+
+	// Carry the prior state of every backend, so a failure in one scope, or a
+	// scope that is not polled, doesn't wipe healthy endpoints. The endpoints are
+	// only carried while the config selects the same endpoints. Backends without
+	// state, or whose config changed since their state was resolved, are changed.
+	nextState := map[string]backendState[C]{}
+	changed := map[string]bool{}
+	c.stateMu.RLock()
+	for _, cfgs := range byScope {
+		for _, cfg := range cfgs {
+			prior, ok := c.state[cfg.resourceName]
+			next := backendState[C]{config: cfg.config}
+			if ok && prior.config.EndpointsEqual(cfg.config) {
+				next.endpoints = prior.endpoints
+			}
+			if ok && prior.config.Equals(cfg.config) {
+				next.status = prior.status
+			} else {
+				changed[cfg.resourceName] = true
+			}
+			nextState[cfg.resourceName] = next
+		}
+	}
+	c.stateMu.RUnlock()
+
+	if c.lister != nil {
+		return nextState, c.applyWatches(byScope, changed, nextState)
+	}
+
+	due := map[string]bool{}
+	for scope, cfgs := range byScope {
+		backoff, failing := c.scopes[scope]
+		due[scope] = scheduled && (!failing || backoff.skip == 0) ||
+			slices.ContainsFunc(cfgs, func(cfg backendConfig[C]) bool { return changed[cfg.resourceName] })
+	}
+
+	for scope, backoff := range c.scopes {
+		switch {
+		case byScope[scope] == nil:
+			delete(c.scopes, scope)
+		case scheduled && !due[scope]:
+			backoff.skip--
+			c.scopes[scope] = backoff
+		}
+	}
+
+	var (
+		wg          sync.WaitGroup
+		nextStateMu sync.Mutex
+		errs        []error
+	)
+	for scope, cfgs := range byScope {
+		if !due[scope] {
+			continue
+		}
+		wg.Go(func() {
+			err := c.pollScope(ctx, cfgs, nextState, &nextStateMu)
+			nextStateMu.Lock()
+			defer nextStateMu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("list instances for scope %q: %w", scope, err))
+				backoff := c.scopes[scope]
+				backoff.failures++
+				backoff.skip = c.backoffPolls(backoff.failures)
+				c.scopes[scope] = backoff
+				return
+			}
+			delete(c.scopes, scope)
+		})
+	}
+	wg.Wait()
+
+	return nextState, errors.Join(errs...)
+}
+
+// backoffPolls returns the number of scheduled polls that a scope skips after
+// failures consecutive failed polls, doubling the interval between its polls
+// with every failure up to the max backoff.
+func (c *Collection[C, I]) backoffPolls(failures int) int {
+	maxSkip := int(c.maxBackoff/c.refreshInterval) - 1
+	return min(1<<min(failures-1, 16)-1, maxSkip)
+}
+
+func (c *Collection[C, I]) pollScope(
+	ctx context.Context,
+	cfgs []backendConfig[C],
+	nextState map[string]backendState[C],
+	nextStateMu *sync.Mutex,
+) error {
+	start := time.Now()
+	instances, err := c.provider.List(ctx, cfgs[0].config)
+	duration := time.Since(start)
+
+	nextStateMu.Lock()
+	defer nextStateMu.Unlock()
+	c.applyListing(cfgs, instances, err, duration, nextState)
+	return err
+}
+
+// applyListing updates the state of the backends of a scope from a listing of
+// the scope, which failed when err is set.
+func (c *Collection[C, I]) applyListing(
+	cfgs []backendConfig[C],
+	instances []I,
+	err error,
+	duration time.Duration,
+	nextState map[string]backendState[C],
+) {
+	if err != nil {
+		reason, message := c.provider.ClassifyError(err)
+		for _, cfg := range cfgs {
+			state := nextState[cfg.resourceName]
+			carried := len(state.endpoints)
+			// A failed poll that carries endpoints leaves the backend degraded but
+			// serving, which operators must be able to tell from a backend without
+			// endpoints. The cause stays in the message.
+			statusReason := reason
+			if carried > 0 {
+				statusReason = string(kgateway.BackendReasonDegraded)
+			}
+			state.status = Status{
+				Status:  metav1.ConditionFalse,
+				Reason:  statusReason,
+				Message: FailureMessage(message, carried),
+			}
+			nextState[cfg.resourceName] = state
+			c.metrics.PollFailed(cfg.namespace, cfg.name, reason, duration)
+		}
+		return
+	}
+
+	for _, cfg := range cfgs {
+		endpoints, noMatchMessage := c.provider.Select(cfg.config, instances)
+		slices.SortFunc(endpoints, compareEndpoints)
+		state := backendState[C]{config: cfg.config, endpoints: endpoints}
+		if len(endpoints) > 0 {
+			state.status = Status{
+				Status:  metav1.ConditionTrue,
+				Reason:  string(kgateway.BackendReasonDiscovered),
+				Message: fmt.Sprintf("%d endpoints active", len(endpoints)),
+			}
+		} else {
+			state.status = Status{
+				Status:  metav1.ConditionFalse,
+				Reason:  string(kgateway.BackendReasonNoMatchingInstances),
+				Message: noMatchMessage,
+			}
+		}
+		nextState[cfg.resourceName] = state
+		c.metrics.PollSucceeded(cfg.namespace, cfg.name, len(endpoints), duration)
+		logger.Debug("resolved backend endpoints", "provider", c.provider.Name(), "backend", cfg.resourceName, "endpoints", len(endpoints))
+	}
+}
+
+// applyWatches starts a watch for every scope of a BlockingLister that has
+// none, stops the watches of scopes without backends, and applies the latest
+// listing of a scope to its backends when the scope was listed since the last
+// refresh, or to its changed backends otherwise. c.pollMu must be held.
+func (c *Collection[C, I]) applyWatches(
+	byScope map[string][]backendConfig[C],
+	changed map[string]bool,
+	nextState map[string]backendState[C],
+) error {
+	for scope, w := range c.watches {
+		if byScope[scope] == nil {
+			w.cancel()
+			delete(c.watches, scope)
+		}
+	}
+
+	var errs []error
+	for scope, cfgs := range byScope {
+		w, ok := c.watches[scope]
+		if !ok {
+			if c.watchCtx != nil {
+				c.startWatch(scope, cfgs[0].config)
+			}
+			continue
+		}
+		listing, fresh := w.take()
+		if listing == nil {
+			continue
+		}
+		if !fresh {
+			cfgs = slices.DeleteFunc(slices.Clone(cfgs), func(cfg backendConfig[C]) bool { return !changed[cfg.resourceName] })
+			if len(cfgs) == 0 {
+				continue
+			}
+		}
+		c.applyListing(cfgs, listing.instances, listing.err, listing.duration, nextState)
+		if fresh && listing.err != nil {
+			errs = append(errs, fmt.Errorf("list instances for scope %q: %w", scope, listing.err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// startWatch starts the watch of a scope of a BlockingLister. c.pollMu must be held.
+func (c *Collection[C, I]) startWatch(scope string, cfg C) {
+	ctx, cancel := context.WithCancel(c.watchCtx)
+	w := &scopeWatch[I]{cancel: cancel, listed: make(chan struct{})}
+	c.watches[scope] = w
+	go c.watch(ctx, w, cfg)
+}
+
+// watch lists a scope of a BlockingLister until ctx is cancelled, and requests
+// a refresh to apply every listing. Listings start at most once per refresh
+// interval, and the interval doubles after every consecutive failed listing up
+// to the max backoff, like the polls of a failing scope.
+func (c *Collection[C, I]) watch(ctx context.Context, w *scopeWatch[I], cfg C) {
+	var (
+		index    uint64
+		failures int
+	)
+	for {
+		start := time.Now()
+		instances, next, err := c.lister.ListBlocking(ctx, cfg, index)
+		if ctx.Err() != nil {
+			return
+		}
+		w.set(scopeListing[I]{instances: instances, err: err, duration: time.Since(start)})
+		c.requestRefresh()
+
+		if err != nil {
+			failures++
+		} else {
+			failures = 0
+			index = next
+		}
+		wait := c.refreshInterval*time.Duration(c.backoffPolls(max(failures, 1))+1) - time.Since(start)
+		if wait <= 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func compareEndpoints(a, b Endpoint) int {
+	return cmp.Or(
+		cmp.Compare(a.Priority, b.Priority),
+		strings.Compare(a.Locality.Region, b.Locality.Region),
+		strings.Compare(a.Locality.Zone, b.Locality.Zone),
+		strings.Compare(a.Locality.Subzone, b.Locality.Subzone),
+		strings.Compare(a.Address, b.Address),
+		cmp.Compare(a.Port, b.Port),
+		strings.Compare(a.ID, b.ID),
+	)
+}
+
+func (c *Collection[C, I]) endpointsForBackend(backend ir.BackendObjectIR, cfg C) *ir.EndpointsForBackend {
+	eps := ir.NewEndpointsForBackend(backend)
+
+	c.stateMu.RLock()
+	state, ok := c.state[backend.ResourceName()]
+	c.stateMu.RUnlock()
+	if !ok {
+		// A new backend that no poll covered yet.
+		c.requestRefresh()
+		return eps
+	}
+	if !state.config.Equals(cfg) {
+		c.requestRefresh()
+		if !state.config.EndpointsEqual(cfg) {
+			// Serving endpoints selected under another config would route traffic
+			// to the wrong targets, so serve none until the poll lands.
+			return eps
+		}
+	}
+
+	for _, endpoint := range state.endpoints {
+		lbEndpoint := krtcollections.CreateLBEndpoint(endpoint.Address, endpoint.Port, nil, false)
+		if endpoint.Weight > 0 {
+			lbEndpoint.LoadBalancingWeight = wrapperspb.UInt32(endpoint.Weight)
+		}
+		eps.Add(endpoint.Locality, ir.EndpointWithMd{
+			LbEndpoint: lbEndpoint,
+			EndpointMd: ir.EndpointMetadata{Priority: endpoint.Priority},
+		})
+	}
+	return eps
+}
+
+// discoveryStatusForBackend builds the EndpointsDiscovered condition of a
+// backend from its latest poll, or from the provider for unpollable backends.
+func (c *Collection[C, I]) discoveryStatusForBackend(kctx krt.HandlerContext, backend ir.BackendObjectIR) *ir.BackendObjectStatus {
+	if _, ok := c.provider.Config(backend); !ok {
+		reporter, ok := c.provider.(UnpollableReporter)
+		if !ok {
+			return nil
+		}
+		status, unpollable := reporter.UnpollableStatus(backend)
+		if !unpollable {
+			return nil
+		}
+		src := backend.GetObjectSource()
+		c.metrics.Unpollable(src.Namespace, src.Name)
+		return StatusUpdate(backend, status)
+	}
+
+	// Depend on the trigger so the status is recomputed after every poll, even
+	// when a failed poll leaves the carried endpoints unchanged.
+	c.trigger.MarkDependant(kctx)
+
+	c.stateMu.RLock()
+	state, ok := c.state[backend.ResourceName()]
+	c.stateMu.RUnlock()
+	if !ok || state.status.Reason == "" {
+		return nil
+	}
+	return StatusUpdate(backend, state.status)
+}
@@ -0,0 +1,421 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/krt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+func TestRefreshListsEachScopeOnce(t *testing.T) {
+	provider := &fakeProvider{instances: []fakeInstance{
+		{address: "10.0.0.1", zone: "a"},
+		{address: "10.0.0.2", zone: "b"},
+	}}
+	c := newTestCollection(provider,
+		fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080}),
+		fakeBackend("backend-b", fakeConfig{scope: "shared", port: 9090, zone: "b"}),
+		fakeBackend("backend-c", fakeConfig{scope: "other", port: 8080}),
+	)
+
+	c.Refresh(context.Background(), true)
+
+	assert.ElementsMatch(t, []string{"shared", "other"}, provider.listedScopes())
+	endpoints, status, ok := c.Discovered(resourceName("backend-a"))
+	require.True(t, ok)
+	assert.Equal(t, []Endpoint{
+		{Address: "10.0.0.1", Port: 8080, Locality: ir.PodLocality{Zone: "a"}},
+		{Address: "10.0.0.2", Port: 8080, Locality: ir.PodLocality{Zone: "b"}},
+	}, endpoints)
+	assert.Equal(t, Status{Status: metav1.ConditionTrue, Reason: "Discovered", Message: "2 endpoints active"}, status)
+
+	endpoints, _, _ = c.Discovered(resourceName("backend-b"))
+	assert.Equal(t, []Endpoint{{Address: "10.0.0.2", Port: 9090, Locality: ir.PodLocality{Zone: "b"}}}, endpoints)
+}
+
+func TestRefreshReportsNoMatchingInstances(t *testing.T) {
+	c := newTestCollection(&fakeProvider{}, fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080}))
+
+	c.Refresh(context.Background(), true)
+
+	_, status, ok := c.Discovered(resourceName("backend-a"))
+	require.True(t, ok)
+	assert.Equal(t, Status{Status: metav1.ConditionFalse, Reason: "NoMatchingInstances", Message: "nothing in shared"}, status)
+}
+
+func TestRefreshCarriesEndpointsAcrossFailures(t *testing.T) {
+	provider := &fakeProvider{instances: []fakeInstance{{address: "10.0.0.1"}}}
+	c := newTestCollection(provider, fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080}))
+	c.Refresh(context.Background(), true)
+
+	provider.setErr(errors.New("throttled"))
+	c.Refresh(context.Background(), true)
+
+	endpoints, status, _ := c.Discovered(resourceName("backend-a"))
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, Status{
+		Status:  metav1.ConditionFalse,
+		Reason:  string(kgateway.BackendReasonDegraded),
+		Message: "throttled; serving 1 endpoints from the last successful poll",
+	}, status)
+}
+
+func TestRefreshCarriesEndpointsOnlyWhileTheyAreTheSame(t *testing.T) {
+	provider := &fakeProvider{instances: []fakeInstance{{address: "10.0.0.1"}}}
+	c := newTestCollection(provider, fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080}))
+	c.Refresh(context.Background(), true)
+	provider.setErr(errors.New("throttled"))
+
+	// A credential change selects the same endpoints, which keep serving.
+	c.backends.(krt.StaticCollection[ir.BackendObjectIR]).UpdateObject(fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080, credential: "v2"}))
+	c.Refresh(context.Background(), false)
+	endpoints, status, _ := c.Discovered(resourceName("backend-a"))
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, string(kgateway.BackendReasonDegraded), status.Reason)
+
+	// A port change does not.
+	c.backends.(krt.StaticCollection[ir.BackendObjectIR]).UpdateObject(fakeBackend("backend-a", fakeConfig{scope: "shared", port: 9090, credential: "v2"}))
+	c.Refresh(context.Background(), false)
+	endpoints, status, _ = c.Discovered(resourceName("backend-a"))
+	assert.Empty(t, endpoints)
+	assert.Equal(t, Status{
+		Status:  metav1.ConditionFalse,
+		Reason:  "DiscoveryError",
+		Message: "throttled; no endpoints available from a previous poll",
+	}, status)
+}
+
+func TestRefreshBacksOffFailingScopes(t *testing.T) {
+	provider := &fakeProvider{err: errors.New("throttled")}
+	c := newTestCollection(provider,
+		fakeBackend("backend-a", fakeConfig{scope: "failing", port: 8080}),
+	)
+	c.maxBackoff = 4 * c.refreshInterval
+
+	var polled []bool
+	for range 10 {
+		before := len(provider.listedScopes())
+		c.Refresh(context.Background(), true)
+		polled = append(polled, len(provider.listedScopes()) > before)
+	}
+	// The intervals between polls double up to the max backoff of 4 intervals.
+	assert.Equal(t, []bool{true, true, false, true, false, false, false, true, false, false}, polled)
+
+	// A successful poll resets the backoff.
+	provider.setErr(nil)
+	for range 3 {
+		c.Refresh(context.Background(), true)
+	}
+	before := len(provider.listedScopes())
+	c.Refresh(context.Background(), true)
+	c.Refresh(context.Background(), true)
+	assert.Equal(t, before+2, len(provider.listedScopes()))
+}
+
+func TestUnscheduledRefreshOnlyListsScopesOfChangedBackends(t *testing.T) {
+	provider := &fakeProvider{}
+	c := newTestCollection(provider,
+		fakeBackend("backend-a", fakeConfig{scope: "a", port: 8080}),
+		fakeBackend("backend-b", fakeConfig{scope: "b", port: 8080}),
+	)
+	c.Refresh(context.Background(), true)
+	require.Len(t, provider.listedScopes(), 2)
+
+	c.backends.(krt.StaticCollection[ir.BackendObjectIR]).UpdateObject(fakeBackend("backend-c", fakeConfig{scope: "c", port: 8080}))
+	c.Refresh(context.Background(), false)
+
+	assert.Equal(t, "c", provider.listedScopes()[2])
+	assert.Len(t, provider.listedScopes(), 3)
+}
+
+func TestEndpointsForBackendRequestsRefresh(t *testing.T) {
+	provider := &fakeProvider{instances: []fakeInstance{{address: "10.0.0.1"}}}
+	c := newTestCollection(provider)
+	backend := fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080})
+
+	eps := c.endpointsForBackend(backend, fakeConfig{scope: "shared", port: 8080})
+	assert.Empty(t, eps.LbEps, "a backend without state serves no endpoints")
+	requireRefreshRequested(t, c)
+
+	c.backends.(krt.StaticCollection[ir.BackendObjectIR]).UpdateObject(backend)
+	c.Refresh(context.Background(), true)
+
+	eps = c.endpointsForBackend(backend, fakeConfig{scope: "shared", port: 8080, credential: "v2"})
+	assert.Equal(t, 1, countEndpoints(eps), "a credential change keeps serving endpoints")
+	requireRefreshRequested(t, c)
+
+	eps = c.endpointsForBackend(backend, fakeConfig{scope: "shared", port: 9090})
+	assert.Zero(t, countEndpoints(eps), "a port change discards endpoints")
+	requireRefreshRequested(t, c)
+}
+
+func TestEndpointsForBackendSetsWeightAndPriority(t *testing.T) {
+	provider := &fakeProvider{instances: []fakeInstance{{address: "10.0.0.1", weight: 5, priority: 1}}}
+	backend := fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080})
+	c := newTestCollection(provider, backend)
+	c.Refresh(context.Background(), true)
+
+	eps := c.endpointsForBackend(backend, fakeConfig{scope: "shared", port: 8080})
+	for _, lbEps := range eps.LbEps {
+		require.Len(t, lbEps, 1)
+		assert.Equal(t, uint32(5), lbEps[0].LbEndpoint.GetLoadBalancingWeight().GetValue())
+		assert.Equal(t, uint32(1), lbEps[0].EndpointMd.Priority)
+	}
+}
+
+func TestDiscoveryStatusForUnpollableBackend(t *testing.T) {
+	c := newTestCollection(&fakeProvider{})
+	backend := fakeBackend("backend-a", fakeConfig{unpollable: true})
+
+	got := c.discoveryStatusForBackend(krt.TestingDummyContext{}, backend)
+	require.NotNil(t, got)
+	assert.Equal(t, string(kgateway.BackendReasonCredentialError), got.Conditions[0].Reason)
+	assert.Equal(t, string(kgateway.BackendConditionEndpointsDiscovered), got.Conditions[0].Type)
+}
+
+func TestHasSyncedWaitsForInitialPoll(t *testing.T) {
+	backend := fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080})
+	c := NewCollection(context.Background(), krt.NewStaticCollection(nil, []ir.BackendObjectIR{backend}),
+		Provider[fakeConfig, fakeInstance](&fakeProvider{}), Options{Enabled: true})
+
+	require.Eventually(t, c.HasSynced, 5*time.Second, 10*time.Millisecond)
+	_, status, ok := c.Discovered(resourceName("backend-a"))
+	require.True(t, ok, "HasSynced must imply the initial poll completed")
+	assert.Equal(t, "NoMatchingInstances", status.Reason)
+}
+
+func TestDisabledCollectionIsSynced(t *testing.T) {
+	provider := &fakeProvider{}
+	backend := fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080})
+	c := NewCollection(context.Background(), krt.NewStaticCollection(nil, []ir.BackendObjectIR{backend}),
+		Provider[fakeConfig, fakeInstance](provider), Options{})
+
+	assert.True(t, c.HasSynced())
+	assert.Empty(t, c.Endpoints.List())
+	assert.Empty(t, provider.listedScopes())
+}
+
+func TestBlockingListerWatchesScopes(t *testing.T) {
+	provider := newFakeBlockingProvider(fakeInstance{address: "10.0.0.1"})
+	backend := fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080})
+	c := NewCollection(t.Context(), krt.NewStaticCollection(nil, []ir.BackendObjectIR{backend}),
+		Provider[fakeConfig, fakeInstance](provider), Options{Enabled: true, RefreshInterval: 10 * time.Millisecond})
+
+	require.Eventually(t, c.HasSynced, 5*time.Second, 10*time.Millisecond)
+	endpoints, _, ok := c.Discovered(resourceName("backend-a"))
+	require.True(t, ok, "HasSynced must imply the initial listing was applied")
+	assert.Len(t, endpoints, 1)
+
+	// The watch blocks on the index of the initial listing and applies the next one.
+	provider.updates <- fakeListing{instances: []fakeInstance{{address: "10.0.0.1"}, {address: "10.0.0.2"}}}
+	require.Eventually(t, func() bool {
+		endpoints, _, _ := c.Discovered(resourceName("backend-a"))
+		return len(endpoints) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	provider.updates <- fakeListing{err: errors.New("throttled")}
+	require.Eventually(t, func() bool {
+		_, status, _ := c.Discovered(resourceName("backend-a"))
+		return status.Reason == string(kgateway.BackendReasonDegraded)
+	}, 5*time.Second, 10*time.Millisecond)
+	endpoints, status, _ := c.Discovered(resourceName("backend-a"))
+	assert.Len(t, endpoints, 2)
+	assert.Equal(t, "throttled; serving 2 endpoints from the last successful poll", status.Message)
+	assert.Empty(t, provider.listedScopes(), "the scopes of a BlockingLister are never polled")
+}
+
+func TestBlockingListerAppliesListingsToChangedBackends(t *testing.T) {
+	provider := newFakeBlockingProvider(fakeInstance{address: "10.0.0.1", zone: "a"}, fakeInstance{address: "10.0.0.2", zone: "b"})
+	backends := krt.NewStaticCollection(nil, []ir.BackendObjectIR{fakeBackend("backend-a", fakeConfig{scope: "shared", port: 8080})})
+	c := NewCollection(t.Context(), backends, Provider[fakeConfig, fakeInstance](provider), Options{
+		Enabled:         true,
+		RefreshInterval: 10 * time.Millisecond,
+	})
+	require.Eventually(t, c.HasSynced, 5*time.Second, 10*time.Millisecond)
+
+	// A backend of the scope that was already listed is resolved from the latest
+	// listing, without waiting for the scope to change.
+	backends.UpdateObject(fakeBackend("backend-b", fakeConfig{scope: "shared", port: 9090, zone: "b"}))
+	c.Refresh(t.Context(), false)
+	endpoints, _, ok := c.Discovered(resourceName("backend-b"))
+	require.True(t, ok)
+	assert.Equal(t, []Endpoint{{Address: "10.0.0.2", Port: 9090, Locality: ir.PodLocality{Zone: "b"}}}, endpoints)
+}
+
+func newTestCollection(provider *fakeProvider, backends ...ir.BackendObjectIR) *Collection[fakeConfig, fakeInstance] {
+	return NewCollection(nil, krt.NewStaticCollection(nil, backends), Provider[fakeConfig, fakeInstance](provider), Options{
+		Enabled:         true,
+		RefreshInterval: time.Second,
+	})
+}
+
+func resourceName(name string) string {
+	return fakeBackend(name, fakeConfig{}).ResourceName()
+}
+
+func countEndpoints(eps *ir.EndpointsForBackend) int {
+	total := 0
+	for _, lbEps := range eps.LbEps {
+		total += len(lbEps)
+	}
+	return total
+}
+
+func requireRefreshRequested(t *testing.T, c *Collection[fakeConfig, fakeInstance]) {
+	t.Helper()
+	select {
+	case <-c.refreshCh:
+	default:
+		t.Fatal("expected an on-demand refresh request")
+	}
+}
+
+type fakeConfig struct {
+	scope      string
+	port       uint32
+	zone       string
+	credential string
+	unpollable bool
+}
+
+func (c fakeConfig) ListScope() string { return c.scope }
+
+func (c fakeConfig) Equals(other fakeConfig) bool { return c == other }
+
+func (c fakeConfig) EndpointsEqual(other fakeConfig) bool {
+	return c.scope == other.scope && c.port == other.port && c.zone == other.zone
+}
+
+// fakeConfigIR carries the fakeConfig of a backend in its ObjIr.
+type fakeConfigIR struct {
+	config fakeConfig
+}
+
+func (f fakeConfigIR) Equals(other any) bool {
+	o, ok := other.(fakeConfigIR)
+	return ok && f == o
+}
+
+func fakeBackend(name string, cfg fakeConfig) ir.BackendObjectIR {
+	backend := ir.NewBackendObjectIR(ir.ObjectSource{Kind: "Fake", Namespace: "default", Name: name}, 0, "", "fake")
+	backend.ObjIr = fakeConfigIR{config: cfg}
+	return backend
+}
+
+type fakeInstance struct {
+	address  string
+	zone     string
+	weight   uint32
+	priority uint32
+}
+
+type fakeProvider struct {
+	mu        sync.Mutex
+	instances []fakeInstance
+	err       error
+	listed    []string
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) Config(backend ir.BackendObjectIR) (fakeConfig, bool) {
+	cfg, ok := backend.ObjIr.(fakeConfigIR)
+	if !ok || cfg.config.unpollable {
+		return fakeConfig{}, false
+	}
+	return cfg.config, true
+}
+
+func (p *fakeProvider) List(_ context.Context, cfg fakeConfig) ([]fakeInstance, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listed = append(p.listed, cfg.scope)
+	return p.instances, p.err
+}
+
+func (p *fakeProvider) Select(cfg fakeConfig, instances []fakeInstance) ([]Endpoint, string) {
+	var endpoints []Endpoint
+	for _, instance := range instances {
+		if cfg.zone != "" && instance.zone != cfg.zone {
+			continue
+		}
+		endpoints = append(endpoints, Endpoint{
+			Address:  instance.address,
+			Port:     cfg.port,
+			Locality: ir.PodLocality{Zone: instance.zone},
+			Weight:   instance.weight,
+			Priority: instance.priority,
+		})
+	}
+	return endpoints, "nothing in " + cfg.scope
+}
+
+func (p *fakeProvider) ClassifyError(err error) (string, string) {
+	return string(kgateway.BackendReasonDiscoveryError), err.Error()
+}
+
+func (p *fakeProvider) UnpollableStatus(backend ir.BackendObjectIR) (Status, bool) {
+	cfg, ok := backend.ObjIr.(fakeConfigIR)
+	if !ok || !cfg.config.unpollable {
+		return Status{}, false
+	}
+	return Status{
+		Status:  metav1.ConditionFalse,
+		Reason:  string(kgateway.BackendReasonCredentialError),
+		Message: "credentials could not be resolved",
+	}, true
+}
+
+func (p *fakeProvider) setErr(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+func (p *fakeProvider) listedScopes() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.listed...)
+}
+
+type fakeListing struct {
+	instances []fakeInstance
+	err       error
+}
+
+// fakeBlockingProvider answers the first listing of a scope with its instances,
+// and blocks the next ones until a listing is sent on updates.
+type fakeBlockingProvider struct {
+	*fakeProvider
+	updates chan fakeListing
+}
+
+func newFakeBlockingProvider(instances ...fakeInstance) *fakeBlockingProvider {
+	return &fakeBlockingProvider{
+		fakeProvider: &fakeProvider{instances: instances},
+		updates:      make(chan fakeListing),
+	}
+}
+
+func (p *fakeBlockingProvider) ListBlocking(ctx context.Context, _ fakeConfig, index uint64) ([]fakeInstance, uint64, error) {
+	if index == 0 {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.instances, 1, nil
+	}
+	select {
+	case listing := <-p.updates:
+		return listing.instances, index + 1, listing.err
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	}
+}
@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+)
+
+// MetricsRecorder records the outcome of the polls of a provider per backend.
+// Implementations check metrics.Active() themselves.
+type MetricsRecorder interface {
+	// PollSucceeded records a successful poll that resolved endpointCount endpoints.
+	PollSucceeded(namespace, name string, endpointCount int, duration time.Duration)
+	// PollFailed records a failed poll with the reason ClassifyError returned for
+	// it, rather than the Degraded reason of the status when endpoints are carried.
+	PollFailed(namespace, name, reason string, duration time.Duration)
+	// Unpollable records a backend reported by an UnpollableReporter, which
+	// serves no endpoints and never enters the poll loop.
+	Unpollable(namespace, name string)
+	// Delete removes the series of a deleted backend.
+	Delete(namespace, name string)
+}
+
+// Backend discovery metrics of the providers that use the default recorder. The
+// exposed names are kgateway_backend_discovery_poll_total,
+// kgateway_backend_discovery_endpoints_active,
+// kgateway_backend_discovery_error_state and
+// kgateway_backend_discovery_poll_duration_seconds.
+const (
+	backendDiscoverySubsystem = "backend_discovery"
+
+	metricProviderLabel  = "provider"
+	metricNamespaceLabel = "namespace"
+	metricNameLabel      = "name"
+	metricResultLabel    = "result"
+	metricReasonLabel    = "reason"
+
+	pollResultSuccess = "success"
+	pollResultError   = "error"
+)
+
+var (
+	pollTotal = metrics.NewCounter(
+		metrics.CounterOpts{
+			Subsystem: backendDiscoverySubsystem,
+			Name:      "poll_total",
+			Help:      "Total number of endpoint discovery polls per Backend",
+		},
+		[]string{metricProviderLabel, metricNamespaceLabel, metricNameLabel, metricResultLabel, metricReasonLabel},
+	)
+
+	// endpointsActive retains its value across failed polls, like the endpoints
+	// carried across them.
+	endpointsActive = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: backendDiscoverySubsystem,
+			Name:      "endpoints_active",
+			Help:      "Current number of active Envoy endpoints discovered for a Backend",
+		},
+		[]string{metricProviderLabel, metricNamespaceLabel, metricNameLabel},
+	)
+
+	errorState = metrics.NewGauge(
+		metrics.GaugeOpts{
+			Subsystem: backendDiscoverySubsystem,
+			Name:      "error_state",
+			Help:      "Whether the most recent endpoint discovery poll for a Backend failed (1) or succeeded (0)",
+		},
+		[]string{metricProviderLabel, metricNamespaceLabel, metricNameLabel},
+	)
+
+	pollDuration = metrics.NewHistogram(
+		metrics.HistogramOpts{
+			Subsystem:                       backendDiscoverySubsystem,
+			Name:                            "poll_duration_seconds",
+			Help:                            "Duration of endpoint discovery polls per Backend",
+			Buckets:                         []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30},
+			NativeHistogramBucketFactor:     1.1,
+			NativeHistogramMaxBucketNumber:  100,
+			NativeHistogramMinResetDuration: time.Hour,
+		},
+		[]string{metricProviderLabel, metricNamespaceLabel, metricNameLabel, metricResultLabel},
+	)
+)
+
+type defaultMetricsRecorder struct {
+	provider string
+}
+
+// NewMetricsRecorder returns the recorder of the kgateway_backend_discovery_*
+// metrics for a provider, which is the default recorder of a Collection.
+func NewMetricsRecorder(provider string) MetricsRecorder {
+	return defaultMetricsRecorder{provider: provider}
+}
+
+func (r defaultMetricsRecorder) identity(namespace, name string) []metrics.Label {
+	return []metrics.Label{
+		{Name: metricProviderLabel, Value: r.provider},
+		{Name: metricNamespaceLabel, Value: namespace},
+		{Name: metricNameLabel, Value: name},
+	}
+}
+
+func (r defaultMetricsRecorder) PollSucceeded(namespace, name string, endpointCount int, duration time.Duration) {
+	if !metrics.Active() {
+		return
+	}
+	reason := string(kgateway.BackendReasonDiscovered)
+	if endpointCount == 0 {
+		reason = string(kgateway.BackendReasonNoMatchingInstances)
+	}
+	identity := r.identity(namespace, name)
+	pollTotal.Inc(append(identity,
+		metrics.Label{Name: metricResultLabel, Value: pollResultSuccess},
+		metrics.Label{Name: metricReasonLabel, Value: reason},
+	)...)
+	pollDuration.Observe(duration.Seconds(), append(identity,
+		metrics.Label{Name: metricResultLabel, Value: pollResultSuccess},
+	)...)
+	endpointsActive.Set(float64(endpointCount), identity...)
+	errorState.Set(0, identity...)
+}
+
+func (r defaultMetricsRecorder) PollFailed(namespace, name, reason string, duration time.Duration) {
+	if !metrics.Active() {
+		return
+	}
+	identity := r.identity(namespace, name)
+	pollTotal.Inc(append(identity,
+		metrics.Label{Name: metricResultLabel, Value: pollResultError},
+		metrics.Label{Name: metricReasonLabel, Value: reason},
+	)...)
+	pollDuration.Observe(duration.Seconds(), append(identity,
+		metrics.Label{Name: metricResultLabel, Value: pollResultError},
+	)...)
+	errorState.Set(1, identity...)
+}
+
+func (r defaultMetricsRecorder) Unpollable(namespace, name string) {
+	if !metrics.Active() {
+		return
+	}
+	identity := r.identity(namespace, name)
+	errorState.Set(1, identity...)
+	endpointsActive.Set(0, identity...)
+}
+
+func (r defaultMetricsRecorder) Delete(namespace, name string) {
+	identity := r.identity(namespace, name)
+	pollTotal.DeletePartialMatch(identity...)
+	endpointsActive.DeletePartialMatch(identity...)
+	errorState.DeletePartialMatch(identity...)
+	pollDuration.DeletePartialMatch(identity...)
+}
+
+// ResetMetrics resets the kgateway_backend_discovery_* metrics.
+// This is provided for testing purposes only.
+func ResetMetrics() {
+	pollTotal.Reset()
+	endpointsActive.Reset()
+	errorState.Reset()
+	pollDuration.Reset()
+}
@@ -0,0 +1,73 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics"
+	"github.com/kgateway-dev/kgateway/v2/pkg/metrics/metricstest"
+)
+
+func backendLabels(name string) []metrics.Label {
+	return []metrics.Label{
+		{Name: "name", Value: name},
+		{Name: "namespace", Value: "default"},
+		{Name: "provider", Value: "fake"},
+	}
+}
+
+func TestRefreshRecordsDefaultMetrics(t *testing.T) {
+	ResetMetrics()
+
+	provider := &fakeProvider{instances: []fakeInstance{{address: "10.0.0.1"}, {address: "10.0.0.2"}}}
+	c := newTestCollection(provider,
+		fakeBackend("backend-a", fakeConfig{scope: "ok", port: 8080}),
+	)
+	c.Refresh(context.Background(), true)
+	provider.setErr(errors.New("throttled"))
+	c.Refresh(context.Background(), true)
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricsInclude("kgateway_backend_discovery_poll_total", []metricstest.ExpectMetric{
+		&metricstest.ExpectedMetric{
+			Labels: append(backendLabels("backend-a"),
+				metrics.Label{Name: "reason", Value: "Discovered"},
+				metrics.Label{Name: "result", Value: "success"},
+			),
+			Value: 1,
+		},
+		&metricstest.ExpectedMetric{
+			// The raw reason of the failure, not the Degraded reason of the status.
+			Labels: append(backendLabels("backend-a"),
+				metrics.Label{Name: "reason", Value: "DiscoveryError"},
+				metrics.Label{Name: "result", Value: "error"},
+			),
+			Value: 1,
+		},
+	})
+	// The endpoints gauge keeps the count of the carried endpoints.
+	gathered.AssertMetric("kgateway_backend_discovery_endpoints_active", &metricstest.ExpectedMetric{
+		Labels: backendLabels("backend-a"),
+		Value:  2,
+	})
+	gathered.AssertMetric("kgateway_backend_discovery_error_state", &metricstest.ExpectedMetric{
+		Labels: backendLabels("backend-a"),
+		Value:  1,
+	})
+	gathered.AssertHistogramPopulated("kgateway_backend_discovery_poll_duration_seconds")
+}
+
+func TestDeleteRemovesBackendSeries(t *testing.T) {
+	ResetMetrics()
+
+	recorder := NewMetricsRecorder("fake")
+	recorder.PollSucceeded("default", "backend-a", 3, 0)
+	recorder.PollSucceeded("default", "backend-b", 5, 0)
+	recorder.Delete("default", "backend-a")
+
+	gathered := metricstest.MustGatherMetrics(t)
+	gathered.AssertMetricsLabels("kgateway_backend_discovery_endpoints_active", [][]metrics.Label{
+		backendLabels("backend-b"),
+	})
+}
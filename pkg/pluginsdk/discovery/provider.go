@@ -0,0 +1,145 @@
+// Package discovery implements runtime endpoint discovery for backends whose
+// endpoints live in an external system, such as a cloud provider API or a
+// service catalog, that is polled periodically.
+//
+// A Provider only lists instances from the external system and maps them to
+// endpoints; NewCollection implements the rest once for all providers: the
+// refresh loop, batching of backends that share a listing, backoff after failed
+// polls, carrying endpoints across failed polls, the EndpointsDiscovered status
+// condition and metrics. A provider whose external system can tell when a
+// listing may have changed, such as a service catalog with blocking queries,
+// implements BlockingLister to be watched instead of polled. Plugins registered
+// with setup.WithExtraPlugins wire a provider into a BackendPlugin through the
+// Endpoints and DiscoveryStatus collections of the returned Collection:
+//
+//	col := discovery.NewCollection(ctx, backends, myProvider{}, discovery.Options{
+//		Enabled:         true,
+//		RefreshInterval: 30 * time.Second,
+//		KrtOpts:         commoncol.KrtOpts,
+//	})
+//	return sdk.Plugin{
+//		ContributesBackends: map[schema.GroupKind]sdk.BackendPlugin{
+//			gk: {
+//				BackendInit:     ir.BackendInit{InitEnvoyBackend: initEdsCluster},
+//				Backends:        backends,
+//				Endpoints:       col.Endpoints,
+//				ExtraConditions: col.DiscoveryStatus,
+//			},
+//		},
+//		ExtraHasSynced: col.HasSynced,
+//	}
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/ir"
+)
+
+// Provider discovers the endpoints of backends by listing instances of type I
+// from an external system. C is the discovery config of a single backend.
+type Provider[C Config[C], I any] interface {
+	// Name identifies the provider in logs, metrics and collection names, e.g. "ec2".
+	Name() string
+	// Config returns the discovery config of a backend, or false when the
+	// provider does not discover the endpoints of the backend.
+	Config(backend ir.BackendObjectIR) (C, bool)
+	// List lists the instances visible to cfg. Backends whose configs share a
+	// ListScope are listed once per poll, with the config of any one of them.
+	List(ctx context.Context, cfg C) ([]I, error)
+	// Select maps listed instances to the endpoints of a backend. When no
+	// instance maps to an endpoint, noMatchMessage explains why in the status of
+	// the backend, and it should only depend on cfg so the status does not churn.
+	Select(cfg C, instances []I) (endpoints []Endpoint, noMatchMessage string)
+	// ClassifyError returns the EndpointsDiscovered reason and message of a
+	// failed List. The message must not include secret values.
+	ClassifyError(err error) (reason string, message string)
+}
+
+// Config is the discovery config of a backend.
+type Config[C any] interface {
+	// ListScope returns the key of the listing the backend shares with other
+	// backends, e.g. its region and credentials.
+	ListScope() string
+	// Equals reports whether two configs are identical. A backend whose config
+	// changed is polled again without waiting for the refresh interval.
+	Equals(other C) bool
+	// EndpointsEqual reports whether two configs select the same endpoints from
+	// the same instances. Endpoints discovered under a config are served and
+	// carried across failed polls only while this holds, so that changing only
+	// the credentials of a backend, say, keeps serving its endpoints.
+	EndpointsEqual(other C) bool
+}
+
+// BlockingLister can be implemented by a Provider whose external system can
+// tell when the instances of a scope may have changed, such as the index of a
+// Consul blocking query or the TTL of DNS records. The Collection then watches
+// every scope instead of listing it every refresh interval: a watch lists its
+// scope again as soon as ListBlocking returns, but never more often than once
+// per refresh interval, and backs off after failed listings like a poll does.
+type BlockingLister[C Config[C], I any] interface {
+	// ListBlocking lists the instances visible to cfg once they may differ from
+	// the listing identified by index, and returns the index of the new listing.
+	// An index of 0 lists the instances right away. The poll timeout does not
+	// apply, so ListBlocking must bound how long it blocks itself.
+	ListBlocking(ctx context.Context, cfg C, index uint64) ([]I, uint64, error)
+}
+
+// UnpollableReporter can be implemented by a Provider to report the status of
+// backends it handles but cannot poll, for which Config returns false. A typical
+// example is a backend whose credentials cannot be resolved.
+type UnpollableReporter interface {
+	UnpollableStatus(backend ir.BackendObjectIR) (Status, bool)
+}
+
+// Endpoint is a discovered endpoint of a backend.
+type Endpoint struct {
+	Address  string
+	Port     uint32
+	Locality ir.PodLocality
+	// Weight is the load balancing weight of the endpoint. Zero uses the default weight.
+	Weight uint32
+	// Priority is the priority level of the endpoint, see ir.EndpointMetadata.
+	Priority uint32
+	// ID distinguishes endpoints that share an address, such as the ID of the
+	// instance. It only orders and compares endpoints.
+	ID string
+}
+
+// Status is the outcome of the latest discovery for a backend, reported as its
+// EndpointsDiscovered condition. The zero value means that discovery has not run
+// for the backend yet.
+type Status struct {
+	Status  metav1.ConditionStatus
+	Reason  string
+	Message string
+}
+
+// StatusUpdate wraps a discovery status as a BackendObjectStatus carrying the
+// EndpointsDiscovered condition of the backend.
+func StatusUpdate(backend ir.BackendObjectIR, status Status) *ir.BackendObjectStatus {
+	return &ir.BackendObjectStatus{
+		Source: backend.GetObjectSource(),
+		Conditions: []metav1.Condition{{
+			Type:    string(kgateway.BackendConditionEndpointsDiscovered),
+			Status:  status.Status,
+			Reason:  status.Reason,
+			Message: status.Message,
+		}},
+	}
+}
+
+// FailureMessage augments the cause of a failed poll with whether the backend is
+// still serving endpoints carried from the last successful poll, which the False
+// EndpointsDiscovered condition alone does not tell. The carried count does not
+// change across consecutive failures, so the message does not churn the status.
+func FailureMessage(cause string, carriedEndpoints int) string {
+	if carriedEndpoints > 0 {
+		return fmt.Sprintf("%s; serving %d endpoints from the last successful poll", cause, carriedEndpoints)
+	}
+	return fmt.Sprintf("%s; no endpoints available from a previous poll", cause)
+}
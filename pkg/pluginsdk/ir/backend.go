@@ -483,8 +483,10 @@ type GatewayForDeployer struct {
 	ObjectSource
 	// Controller name for the gateway
 	ControllerName string
-	// All ports from all listeners
+	// All ports from all TCP-based listeners
 	Ports smallset.Set[int32]
+	// All ports from all UDP listeners
+	UDPPorts smallset.Set[int32]
 }
 
 func (c GatewayForDeployer) ResourceName() string {
@@ -494,7 +496,8 @@ func (c GatewayForDeployer) ResourceName() string {
 func (c GatewayForDeployer) Equals(in GatewayForDeployer) bool {
 	return c.ObjectSource.Equals(in.ObjectSource) &&
 		c.ControllerName == in.ControllerName &&
-		slices.Equal(c.Ports.List(), in.Ports.List())
+		slices.Equal(c.Ports.List(), in.Ports.List()) &&
+		slices.Equal(c.UDPPorts.List(), in.UDPPorts.List())
 }
 
 type ListenerForDeployer struct {
@@ -472,6 +472,18 @@ func TestHarnessGatewayExtensionEquals(t *testing.T) {
 				e.OAuth2 = &kgateway.OAuth2Provider{}
 			},
 		},
+		{
+			Field: "Wasm",
+			Mutate: func(e *GatewayExtension) {
+				e.Wasm = &kgateway.WasmProvider{}
+			},
+		},
+		{
+			Field: "GeoIP",
+			Mutate: func(e *GatewayExtension) {
+				e.GeoIP = &kgateway.GeoIPProvider{}
+			},
+		},
 		{
 			Field:  "PrecedenceWeight",
 			Mutate: func(e *GatewayExtension) { e.PrecedenceWeight = 99 },
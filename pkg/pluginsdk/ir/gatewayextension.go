@@ -29,6 +29,12 @@ type GatewayExtension struct {
 	// OAuth2 configuration for OAuth2 extension type.
 	OAuth2 *kgateway.OAuth2Provider
 
+	// Wasm configuration for Wasm extension type.
+	Wasm *kgateway.WasmProvider
+
+	// GeoIP configuration for GeoIP extension type.
+	GeoIP *kgateway.GeoIPProvider
+
 	// PrecedenceWeight specifies the precedence weight associated with the provider.
 	// A higher weight implies higher priority.
 	// It is used to order provider filters by their weight.
@@ -64,6 +70,12 @@ func (e GatewayExtension) Equals(other GatewayExtension) bool {
 	if !reflect.DeepEqual(e.OAuth2, other.OAuth2) {
 		return false
 	}
+	if !reflect.DeepEqual(e.Wasm, other.Wasm) {
+		return false
+	}
+	if !reflect.DeepEqual(e.GeoIP, other.GeoIP) {
+		return false
+	}
 	if e.PrecedenceWeight != other.PrecedenceWeight {
 		return false
 	}
@@ -1,7 +1,10 @@
 package ir
 
 import (
+	"time"
+
 	envoycorev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoylistenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	envoytlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -63,6 +66,10 @@ type ListenerIR struct {
 
 	HttpFilterChain []HttpFilterChainIR
 	TcpFilterChain  []TcpIR
+	// UdpListener is set for UDP listeners, which have no filter chains.
+	UdpListener *UdpIR
+	// Quic is set when the HTTPS filter chains of the listener are also served over HTTP/3.
+	Quic *QuicIR
 
 	PolicyAncestorRef gwv1.ParentReference
 
@@ -148,6 +155,29 @@ type TcpIR struct {
 	BackendRefs []BackendRefIR
 }
 
+// UdpIR forwards the datagrams received by a UDP listener to a backend.
+type UdpIR struct {
+	// Name is used as the stat prefix of the UDP proxy.
+	Name string
+	// BackendRef is the backend datagrams are forwarded to. The UDP proxy cannot
+	// split sessions by weight, so a UDP listener has a single backend.
+	BackendRef BackendRefIR
+}
+
+// QuicIR configures the HTTP/3 listener that serves the HTTPS filter chains of a listener over QUIC.
+type QuicIR struct {
+	// AltSvcMaxAge is how long clients may cache the alt-svc advertisement of the HTTP/3 listener.
+	AltSvcMaxAge time.Duration
+	// Options are the QUIC transport settings of the HTTP/3 listener.
+	Options *envoylistenerv3.QuicProtocolOptions
+}
+
+// HTTP3PolicyIR is implemented by policy IRs that can enable HTTP/3 on listener ports.
+type HTTP3PolicyIR interface {
+	// HTTP3 returns the HTTP/3 configuration for the given listener port, or nil if it does not enable HTTP/3.
+	HTTP3(port uint32) *QuicIR
+}
+
 // this is 1:1 with envoy deployments
 // not in a collection so doesn't need a krt interfaces.
 type GatewayIR struct {
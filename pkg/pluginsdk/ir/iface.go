@@ -281,6 +281,14 @@ type PolicyHashIR interface {
 	PolicyHash() uint64
 }
 
+// PolicyWarningsIR can be implemented by PolicyIRs that are valid but configured in a way
+// that keeps part of the policy from taking effect. The warnings are reported on the policy
+// status, which remains accepted.
+// Warnings should be formatted for users, like policy errors.
+type PolicyWarningsIR interface {
+	Warnings() []string
+}
+
 type PolicyWrapper struct {
 	// A reference to the original policy object
 	ObjectSource `json:",inline"`
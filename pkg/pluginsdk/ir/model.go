@@ -70,6 +70,10 @@ func labeledRole(role string, labels map[string]string) string {
 
 type EndpointMetadata struct {
 	Labels map[string]string
+	// Priority is the priority level of the endpoint within its backend, 0 being the
+	// highest. Endpoints at a level only receive traffic when the levels above them
+	// are unhealthy. Levels should be contiguous, starting from 0.
+	Priority uint32
 }
 type EndpointWithMd struct {
 	*envoyendpointv3.LbEndpoint
@@ -187,6 +191,9 @@ func hashEndpoints(l PodLocality, emd EndpointWithMd) uint64 {
 	hasher.Write([]byte(l.Subzone))
 
 	utils.HashUint64(hasher, utils.HashLabels(emd.EndpointMd.Labels))
+	if emd.EndpointMd.Priority != 0 {
+		utils.HashUint64(hasher, uint64(emd.EndpointMd.Priority))
+	}
 	utils.HashProtoWithHasher(hasher, emd.LbEndpoint)
 	return hasher.Sum64()
 }
@@ -0,0 +1,24 @@
+package krtutil
+
+import "istio.io/istio/pkg/kube/krt"
+
+// Fetch is krt.Fetch, except that a nil kctx reads the current state of the collection
+// without registering a dependency. This lets lookups shared by krt transformations and the
+// admission webhook run outside of a collection.
+func Fetch[T any](kctx krt.HandlerContext, c krt.Collection[T], opts ...krt.FetchOption) []T {
+	return krt.FetchOrList(kctx, c, opts...)
+}
+
+// FetchOne is krt.FetchOne, except that a nil kctx reads the current state of the collection
+// without registering a dependency.
+func FetchOne[T any](kctx krt.HandlerContext, c krt.Collection[T], opts ...krt.FetchOption) *T {
+	res := Fetch(kctx, c, opts...)
+	switch len(res) {
+	case 0:
+		return nil
+	case 1:
+		return &res[0]
+	default:
+		panic("FetchOne found more than 1 item")
+	}
+}
@@ -4,12 +4,23 @@ import (
 	"strings"
 
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	previoushostsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/retry/host/previous_hosts/v3"
+	previousprioritiesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/retry/priority/previous_priorities/v3"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
+	sdkutils "github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/utils"
+)
+
+const (
+	previousHostsPredicateName   = "envoy.retry_host_predicates.previous_hosts"
+	previousPrioritiesName       = "envoy.retry_priorities.previous_priorities"
+	previousPrioritiesUpdateFreq = 2
 )
 
 func BuildRetryPolicy(in *kgateway.Retry) *envoyroutev3.RetryPolicy {
@@ -17,9 +28,10 @@ func BuildRetryPolicy(in *kgateway.Retry) *envoyroutev3.RetryPolicy {
 		return nil
 	}
 	policy := &envoyroutev3.RetryPolicy{
-		RetryOn:              retryOnToString(in.RetryOn, len(in.StatusCodes) > 0),
+		RetryOn:              retryOnToString(in.RetryOn, len(in.StatusCodes) > 0, len(in.RetriableHeaders) > 0),
 		NumRetries:           wrapperspb.UInt32(uint32(in.Attempts)), //nolint:gosec // G115: retry attempts are small positive integers
 		RetriableStatusCodes: retryCodesToUint32(in.StatusCodes),
+		RetriableHeaders:     retriableHeaders(in.RetriableHeaders),
 	}
 	if in.PerTryTimeout != nil {
 		policy.PerTryTimeout = durationpb.New(in.PerTryTimeout.Duration)
@@ -31,11 +43,51 @@ func BuildRetryPolicy(in *kgateway.Retry) *envoyroutev3.RetryPolicy {
 		}
 	}
 
+	applyRetryHostSelection(in.HostSelection, policy)
+
 	return policy
 }
 
+// BuildHedgePolicy builds the hedge policy of a route from the retry policy.
+// It returns nil unless hedging on per-try timeout is enabled.
+func BuildHedgePolicy(in *kgateway.Retry) *envoyroutev3.HedgePolicy {
+	if in == nil || !ptr.Deref(in.HedgeOnPerTryTimeout, false) {
+		return nil
+	}
+	return &envoyroutev3.HedgePolicy{
+		HedgeOnPerTryTimeout: true,
+	}
+}
+
+func applyRetryHostSelection(in *kgateway.RetryHostSelection, out *envoyroutev3.RetryPolicy) {
+	if in == nil {
+		return
+	}
+	if ptr.Deref(in.AvoidPreviousHosts, false) {
+		out.RetryHostPredicate = []*envoyroutev3.RetryPolicy_RetryHostPredicate{{
+			Name: previousHostsPredicateName,
+			ConfigType: &envoyroutev3.RetryPolicy_RetryHostPredicate_TypedConfig{
+				TypedConfig: utils.MustMessageToAny(&previoushostsv3.PreviousHostsPredicate{}),
+			},
+		}}
+	}
+	if ptr.Deref(in.AvoidPreviousPriorities, false) {
+		out.RetryPriority = &envoyroutev3.RetryPolicy_RetryPriority{
+			Name: previousPrioritiesName,
+			ConfigType: &envoyroutev3.RetryPolicy_RetryPriority_TypedConfig{
+				TypedConfig: utils.MustMessageToAny(&previousprioritiesv3.PreviousPrioritiesConfig{
+					UpdateFrequency: previousPrioritiesUpdateFreq,
+				}),
+			},
+		}
+	}
+	if in.MaxAttempts != nil {
+		out.HostSelectionRetryMaxAttempts = int64(*in.MaxAttempts)
+	}
+}
+
 // retryOnToString converts a slice of RetryOnCondition to a comma-separated string
-func retryOnToString(retryOn []kgateway.RetryOnCondition, forStatusCodes bool) string {
+func retryOnToString(retryOn []kgateway.RetryOnCondition, forStatusCodes, forHeaders bool) string {
 	retryOnSet := sets.NewString()
 	for _, r := range retryOn {
 		retryOnSet.Insert(string(r))
@@ -44,9 +96,30 @@ func retryOnToString(retryOn []kgateway.RetryOnCondition, forStatusCodes bool) s
 	if forStatusCodes {
 		retryOnSet.Insert("retriable-status-codes")
 	}
+	// Likewise for response headers
+	if forHeaders {
+		retryOnSet.Insert("retriable-headers")
+	}
 	return strings.Join(retryOnSet.List(), ",")
 }
 
+func retriableHeaders(headers []gwv1.HTTPHeaderMatch) []*envoyroutev3.HeaderMatcher {
+	if len(headers) == 0 {
+		return nil
+	}
+	matchers := make([]*envoyroutev3.HeaderMatcher, 0, len(headers))
+	for _, header := range headers {
+		if header.Type == nil {
+			header.Type = ptr.To(gwv1.HeaderMatchExact)
+		}
+		// the header match type is an enum of the types supported by ToEnvoyHeaderMatcher
+		if matcher, err := sdkutils.ToEnvoyHeaderMatcher(header); err == nil {
+			matchers = append(matchers, matcher)
+		}
+	}
+	return matchers
+}
+
 func retryCodesToUint32(codes []gwv1.HTTPRouteRetryStatusCode) []uint32 {
 	if len(codes) == 0 {
 		return nil
@@ -5,15 +5,20 @@ import (
 	"time"
 
 	envoyroutev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	previoushostsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/retry/host/previous_hosts/v3"
+	previousprioritiesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/retry/priority/previous_priorities/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/utils"
 )
 
 func TestBuildRetryPolicy(t *testing.T) {
@@ -138,6 +143,86 @@ func TestBuildRetryPolicy(t *testing.T) {
 				RetriableStatusCodes: nil,
 			},
 		},
+		{
+			name: "retry policy with retriable headers",
+			input: &kgateway.Retry{
+				RetryOn:  []kgateway.RetryOnCondition{"reset"},
+				Attempts: int32(2),
+				RetriableHeaders: []gwv1.HTTPHeaderMatch{
+					{Name: "x-retry"},
+					{Type: ptr.To(gwv1.HeaderMatchRegularExpression), Name: "x-upstream-status", Value: "^5.*"},
+				},
+			},
+			want: &envoyroutev3.RetryPolicy{
+				RetryOn:    "reset,retriable-headers",
+				NumRetries: wrapperspb.UInt32(2),
+				RetriableHeaders: []*envoyroutev3.HeaderMatcher{
+					{
+						Name: "x-retry",
+						HeaderMatchSpecifier: &envoyroutev3.HeaderMatcher_StringMatch{
+							StringMatch: &envoymatcherv3.StringMatcher{
+								MatchPattern: &envoymatcherv3.StringMatcher_Exact{Exact: ""},
+							},
+						},
+					},
+					{
+						Name: "x-upstream-status",
+						HeaderMatchSpecifier: &envoyroutev3.HeaderMatcher_StringMatch{
+							StringMatch: &envoymatcherv3.StringMatcher{
+								MatchPattern: &envoymatcherv3.StringMatcher_SafeRegex{
+									SafeRegex: &envoymatcherv3.RegexMatcher{Regex: "^5.*"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "retry policy with host selection",
+			input: &kgateway.Retry{
+				RetryOn:  []kgateway.RetryOnCondition{"connect-failure"},
+				Attempts: int32(3),
+				HostSelection: &kgateway.RetryHostSelection{
+					AvoidPreviousHosts:      ptr.To(true),
+					AvoidPreviousPriorities: ptr.To(true),
+					MaxAttempts:             ptr.To(int32(5)),
+				},
+			},
+			want: &envoyroutev3.RetryPolicy{
+				RetryOn:    "connect-failure",
+				NumRetries: wrapperspb.UInt32(3),
+				RetryHostPredicate: []*envoyroutev3.RetryPolicy_RetryHostPredicate{{
+					Name: "envoy.retry_host_predicates.previous_hosts",
+					ConfigType: &envoyroutev3.RetryPolicy_RetryHostPredicate_TypedConfig{
+						TypedConfig: utils.MustMessageToAny(&previoushostsv3.PreviousHostsPredicate{}),
+					},
+				}},
+				RetryPriority: &envoyroutev3.RetryPolicy_RetryPriority{
+					Name: "envoy.retry_priorities.previous_priorities",
+					ConfigType: &envoyroutev3.RetryPolicy_RetryPriority_TypedConfig{
+						TypedConfig: utils.MustMessageToAny(&previousprioritiesv3.PreviousPrioritiesConfig{UpdateFrequency: 2}),
+					},
+				},
+				HostSelectionRetryMaxAttempts: 5,
+			},
+		},
+		{
+			name: "host selection without avoided hosts only sets max attempts",
+			input: &kgateway.Retry{
+				RetryOn:  []kgateway.RetryOnCondition{"connect-failure"},
+				Attempts: int32(1),
+				HostSelection: &kgateway.RetryHostSelection{
+					AvoidPreviousHosts: ptr.To(false),
+					MaxAttempts:        ptr.To(int32(2)),
+				},
+			},
+			want: &envoyroutev3.RetryPolicy{
+				RetryOn:                       "connect-failure",
+				NumRetries:                    wrapperspb.UInt32(1),
+				HostSelectionRetryMaxAttempts: 2,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -149,3 +234,14 @@ func TestBuildRetryPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildHedgePolicy(t *testing.T) {
+	assert.Nil(t, BuildHedgePolicy(nil))
+	assert.Nil(t, BuildHedgePolicy(&kgateway.Retry{Attempts: 1}))
+	assert.Nil(t, BuildHedgePolicy(&kgateway.Retry{Attempts: 1, HedgeOnPerTryTimeout: ptr.To(false)}))
+	assert.Empty(t, cmp.Diff(&envoyroutev3.HedgePolicy{HedgeOnPerTryTimeout: true}, BuildHedgePolicy(&kgateway.Retry{
+		Attempts:             1,
+		PerTryTimeout:        &metav1.Duration{Duration: time.Second},
+		HedgeOnPerTryTimeout: ptr.To(true),
+	}), protocmp.Transform()))
+}
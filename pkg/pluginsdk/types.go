@@ -9,6 +9,7 @@ import (
 	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/krt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
@@ -53,6 +54,9 @@ type (
 	PatchPolicyStatusFn func(context.Context, types.NamespacedName, gwv1.PolicyStatus) error
 	// BuildPolicyStatusFn is a type that plugins can implement to build a PolicyStatus from a report map.
 	BuildPolicyStatusFn func(context.Context, reports.ReportMap, reporter.PolicyKey, string, gwv1.PolicyStatus) *gwv1.PolicyStatus
+	// ValidatePolicyFn is a type that plugins can implement to validate a policy object before it is
+	// persisted. It returns the errors that would otherwise be reported on the policy's status.
+	ValidatePolicyFn func(context.Context, runtime.Object) []error
 )
 
 type PolicyPlugin struct {
@@ -72,11 +76,20 @@ type PolicyPlugin struct {
 	// rather than the default behavior of fetching by name from the aggregated policy KRT collection
 	PoliciesFetch func(n, ns string) ir.PolicyIR
 	MergePolicies func(pols []ir.PolicyAtt) ir.PolicyAtt
+	// ValidateMergedPolicy can optionally be set to validate a route's policies once they are merged
+	// with the ones it inherits from its listeners and Gateway. It returns the errors of merged, the
+	// result of MergePolicies(pols), that no level reports on its own, e.g. a retry on an HTTPRoute
+	// that does not fit in a request timeout on its Gateway.
+	ValidateMergedPolicy func(pols []ir.PolicyAtt, merged ir.PolicyAtt) []error
 
 	GetPolicyStatus   GetPolicyStatusFn
 	PatchPolicyStatus PatchPolicyStatusFn
 	BuildPolicyStatus BuildPolicyStatusFn
 
+	// ValidatePolicy, when set, is used by the validating admission webhook to reject invalid
+	// policies when they are created or updated.
+	ValidatePolicy ValidatePolicyFn
+
 	// PolicyStatusFromGatewayReports indicates that policy status should be reported from the
 	// Gateway translation report path rather than the backend-only report path.
 	PolicyStatusFromGatewayReports bool
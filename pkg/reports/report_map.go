@@ -33,6 +33,7 @@ func MergeReportMaps(inputs ...ReportMap) ReportMap {
 		mergeRouteReportMap(merged.GRPCRoutes, input.GRPCRoutes)
 		mergeRouteReportMap(merged.TCPRoutes, input.TCPRoutes)
 		mergeRouteReportMap(merged.TLSRoutes, input.TLSRoutes)
+		mergeRouteReportMap(merged.UDPRoutes, input.UDPRoutes)
 		for key, report := range input.Policies {
 			existing := merged.Policies[key]
 			if existing == nil {
@@ -195,6 +196,7 @@ func EqualReportMaps(a, b ReportMap) bool {
 		maps.EqualFunc(a.GRPCRoutes, b.GRPCRoutes, routeReportEqual) &&
 		maps.EqualFunc(a.TCPRoutes, b.TCPRoutes, routeReportEqual) &&
 		maps.EqualFunc(a.TLSRoutes, b.TLSRoutes, routeReportEqual) &&
+		maps.EqualFunc(a.UDPRoutes, b.UDPRoutes, routeReportEqual) &&
 		maps.EqualFunc(a.Policies, b.Policies, policyReportEqual) &&
 		maps.EqualFunc(a.Backends, b.Backends, backendReportEqual)
 }
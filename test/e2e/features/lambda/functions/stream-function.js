@@ -0,0 +1,11 @@
+exports.handler = awslambda.streamifyResponse(async (event, responseStream, context) => {
+  responseStream = awslambda.HttpResponseStream.from(responseStream, {
+    statusCode: 200,
+    headers: { "content-type": "text/event-stream" },
+  });
+  for (let i = 1; i <= 3; i++) {
+    responseStream.write(`data: ${JSON.stringify({ chunk: i, path: event.raw_path })}\n\n`);
+    await new Promise((resolve) => setTimeout(resolve, 100));
+  }
+  responseStream.end();
+});
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -58,9 +59,12 @@ func (s *testingSuite) SetupSuite() {
 	s.ti.AssertionsT(s.T()).EventuallyPodReady(s.ctx, "lambda-test", "aws-cli", 30*time.Second)
 
 	s.manifests = map[string][]string{
-		"TestLambdaBackendRouting":      {lambdaBackendManifest},
-		"TestLambdaBackendAsyncRouting": {lambdaAsyncManifest},
-		"TestLambdaBackendQualifier":    {lambdaQualifierManifest},
+		"TestLambdaBackendRouting":         {lambdaBackendManifest},
+		"TestLambdaBackendAsyncRouting":    {lambdaAsyncManifest},
+		"TestLambdaBackendQualifier":       {lambdaQualifierManifest},
+		"TestLambdaBackendQualifierHeader": {lambdaQualifierHeaderManifest},
+		"TestLambdaBackendAsyncResponse":   {lambdaAsyncResponseManifest},
+		"TestLambdaBackendStreaming":       {lambdaStreamingManifest},
 	}
 
 	s.extractLocalstackEndpoint()
@@ -253,6 +257,86 @@ func (s *testingSuite) TestLambdaBackendQualifier() {
 	)
 }
 
+func (s *testingSuite) TestLambdaBackendQualifierHeader() {
+	// An allowed qualifier header selects the dev alias, and is not passed on to the function
+	s.ti.AssertionsT(s.T()).AssertEventualCurlResponse(
+		s.ctx,
+		testdefaults.CurlPodExecOpt,
+		[]curl.Option{
+			curl.WithHost(kubeutils.ServiceFQDN(gatewayObjectMeta)),
+			curl.WithHostHeader("www.example.com"),
+			curl.WithPort(8080),
+			curl.WithPath("/lambda"),
+			curl.WithHeader("x-lambda-qualifier", "dev"),
+		},
+		&testmatchers.HttpResponse{
+			StatusCode: http.StatusOK,
+			Body: gomega.And(
+				gomega.ContainSubstring(`"message":"Hello from Lambda dev"`),
+				gomega.Not(gomega.ContainSubstring(`x-lambda-qualifier`)),
+			),
+		},
+	)
+
+	// A qualifier that is not allowed falls back to the configured qualifier
+	s.ti.AssertionsT(s.T()).AssertEventualCurlResponse(
+		s.ctx,
+		testdefaults.CurlPodExecOpt,
+		[]curl.Option{
+			curl.WithHost(kubeutils.ServiceFQDN(gatewayObjectMeta)),
+			curl.WithHostHeader("www.example.com"),
+			curl.WithPort(8080),
+			curl.WithPath("/lambda"),
+			curl.WithHeader("x-lambda-qualifier", "$LATEST"),
+		},
+		&testmatchers.HttpResponse{
+			StatusCode: http.StatusOK,
+			Body:       gomega.ContainSubstring(`"message":"Hello from Lambda prod"`),
+		},
+	)
+}
+
+func (s *testingSuite) TestLambdaBackendAsyncResponse() {
+	s.ti.AssertionsT(s.T()).AssertEventualCurlResponse(
+		s.ctx,
+		testdefaults.CurlPodExecOpt,
+		[]curl.Option{
+			curl.WithHost(kubeutils.ServiceFQDN(gatewayObjectMeta)),
+			curl.WithHostHeader("www.example.com"),
+			curl.WithPort(8080),
+			curl.WithPath("/lambda"),
+		},
+		&testmatchers.HttpResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]any{"x-queued": "true"},
+			Body:       gomega.BeEmpty(),
+		},
+	)
+}
+
+func (s *testingSuite) TestLambdaBackendStreaming() {
+	// The status and headers come from the prelude the function writes with HttpResponseStream,
+	// and the body is the concatenation of the streamed chunks
+	s.ti.AssertionsT(s.T()).AssertEventualCurlResponse(
+		s.ctx,
+		testdefaults.CurlPodExecOpt,
+		[]curl.Option{
+			curl.WithHost(kubeutils.ServiceFQDN(gatewayObjectMeta)),
+			curl.WithHostHeader("www.example.com"),
+			curl.WithPort(8080),
+			curl.WithPath("/lambda/stream"),
+		},
+		&testmatchers.HttpResponse{
+			StatusCode: http.StatusOK,
+			Headers:    map[string]any{"content-type": "text/event-stream"},
+			Body: gomega.And(
+				gomega.ContainSubstring(`data: {"chunk":1,"path":"/lambda/stream"}`),
+				gomega.ContainSubstring(`data: {"chunk":3,"path":"/lambda/stream"}`),
+			),
+		},
+	)
+}
+
 func (s *testingSuite) extractLocalstackEndpoint() {
 	s.T().Log("extracting localstack endpoint URL from cluster")
 
@@ -267,69 +351,79 @@ func (s *testingSuite) extractLocalstackEndpoint() {
 func (s *testingSuite) createLambdaFunctions() {
 	k := s.ti.Actions.Kubectl().WithReceiver(io.Discard)
 
+	s.createLambdaFunction("hello-function", lambdaFunctionPath)
+	s.createLambdaFunction("stream-function", lambdaStreamFunctionPath)
+
+	// Create function versions
+	err := k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
+		"aws", "lambda", "publish-version",
+		"--endpoint-url", s.endpointURL,
+		"--function-name", "hello-function")
+	s.Require().NoError(err, "can publish version")
+
+	// Create aliases (qualifiers)
+	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
+		"aws", "lambda", "create-alias",
+		"--endpoint-url", s.endpointURL,
+		"--function-name", "hello-function",
+		"--name", "prod",
+		"--function-version", "1")
+	s.Require().NoError(err, "can create prod alias")
+
+	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
+		"aws", "lambda", "create-alias",
+		"--endpoint-url", s.endpointURL,
+		"--function-name", "hello-function",
+		"--name", "dev",
+		"--function-version", "1")
+	s.Require().NoError(err, "can create dev alias")
+}
+
+// createLambdaFunction creates the function from the handler in codePath, replacing any function
+// left over from a previous run.
+func (s *testingSuite) createLambdaFunction(name, codePath string) {
+	k := s.ti.Actions.Kubectl().WithReceiver(io.Discard)
+
 	// Check if function exists and delete it if it does
 	err := k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
 		"aws", "lambda", "get-function",
 		"--endpoint-url", s.endpointURL,
-		"--function-name", "hello-function")
+		"--function-name", name)
 	if err == nil {
 		// Function exists, delete it
 		err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
 			"aws", "lambda", "delete-function",
 			"--endpoint-url", s.endpointURL,
-			"--function-name", "hello-function")
-		s.Require().NoError(err, "can delete existing function")
+			"--function-name", name)
+		s.Require().NoError(err, "can delete existing function "+name)
 
 		// Wait a bit to ensure the function is fully deleted
 		err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--", "sleep", "5")
 		s.Require().NoError(err, "can wait for function deletion")
 	}
 
-	functionCode, err := os.ReadFile(lambdaFunctionPath)
+	functionCode, err := os.ReadFile(codePath)
 	s.Require().NoError(err, "can read function code")
 
 	// Create the function code directly in the pod using base64 to preserve formatting
+	fileName := filepath.Base(codePath)
 	encodedCode := base64.StdEncoding.EncodeToString(functionCode)
 	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
-		"sh", "-c", fmt.Sprintf("echo %s | base64 -d > /tmp/hello-function.js", encodedCode))
+		"sh", "-c", fmt.Sprintf("echo %s | base64 -d > /tmp/%s", encodedCode, fileName))
 	s.Require().NoError(err, "can create function code in pod")
 
 	// Create the zip file in the pod
-	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--", "zip", "-j", "/tmp/function.zip", "/tmp/hello-function.js")
+	zipFile := fmt.Sprintf("/tmp/%s.zip", name)
+	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--", "zip", "-j", zipFile, "/tmp/"+fileName)
 	s.Require().NoError(err, "can create zip file")
 
-	// Create the Lambda functions with different qualifiers
 	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
 		"aws", "lambda", "create-function",
 		"--endpoint-url", s.endpointURL,
-		"--function-name", "hello-function",
+		"--function-name", name,
 		"--runtime", "nodejs18.x",
-		"--handler", "hello-function.handler",
+		"--handler", strings.TrimSuffix(fileName, filepath.Ext(fileName))+".handler",
 		"--role", "arn:aws:iam::000000000000:role/lambda-role",
-		"--zip-file", "fileb:///tmp/function.zip")
-	s.Require().NoError(err, "can create base function")
-
-	// Create function versions
-	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
-		"aws", "lambda", "publish-version",
-		"--endpoint-url", s.endpointURL,
-		"--function-name", "hello-function")
-	s.Require().NoError(err, "can publish version")
-
-	// Create aliases (qualifiers)
-	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
-		"aws", "lambda", "create-alias",
-		"--endpoint-url", s.endpointURL,
-		"--function-name", "hello-function",
-		"--name", "prod",
-		"--function-version", "1")
-	s.Require().NoError(err, "can create prod alias")
-
-	err = k.RunCommand(s.ctx, "exec", "-n", "lambda-test", "aws-cli", "--",
-		"aws", "lambda", "create-alias",
-		"--endpoint-url", s.endpointURL,
-		"--function-name", "hello-function",
-		"--name", "dev",
-		"--function-version", "1")
-	s.Require().NoError(err, "can create dev alias")
+		"--zip-file", "fileb://"+zipFile)
+	s.Require().NoError(err, "can create function "+name)
 }
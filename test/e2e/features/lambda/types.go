@@ -18,12 +18,16 @@ const (
 )
 
 var (
-	setupManifest           = filepath.Join(fsutils.MustGetThisDir(), "testdata", "setup.yaml")
-	awsCliPodManifest       = filepath.Join(fsutils.MustGetThisDir(), "testdata", "aws-cli.yaml")
-	lambdaBackendManifest   = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-backend.yaml")
-	lambdaAsyncManifest     = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-async.yaml")
-	lambdaQualifierManifest = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-qualifier.yaml")
-	lambdaFunctionPath      = filepath.Join(fsutils.MustGetThisDir(), "functions", "hello-function.js")
+	setupManifest                 = filepath.Join(fsutils.MustGetThisDir(), "testdata", "setup.yaml")
+	awsCliPodManifest             = filepath.Join(fsutils.MustGetThisDir(), "testdata", "aws-cli.yaml")
+	lambdaBackendManifest         = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-backend.yaml")
+	lambdaAsyncManifest           = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-async.yaml")
+	lambdaQualifierManifest       = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-qualifier.yaml")
+	lambdaQualifierHeaderManifest = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-qualifier-header.yaml")
+	lambdaAsyncResponseManifest   = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-async-response.yaml")
+	lambdaStreamingManifest       = filepath.Join(fsutils.MustGetThisDir(), "testdata", "lambda-streaming.yaml")
+	lambdaFunctionPath            = filepath.Join(fsutils.MustGetThisDir(), "functions", "hello-function.js")
+	lambdaStreamFunctionPath      = filepath.Join(fsutils.MustGetThisDir(), "functions", "stream-function.js")
 
 	gatewayObjectMeta = metav1.ObjectMeta{
 		Name:      gatewayName,
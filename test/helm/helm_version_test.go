@@ -345,6 +345,24 @@ var helmChartTemplateCases = []helmTemplateCase{
   xds:
     tls:
       enabled: true
+`,
+	},
+	{
+		name: "validation-webhook-enabled",
+		valuesYAML: `controller:
+  validationWebhook:
+    enabled: true
+    annotations:
+      cert-manager.io/inject-ca-from: default/kgateway-webhook-cert
+`,
+	},
+	{
+		name: "file-endpoints-volume",
+		valuesYAML: `controller:
+  fileEndpoints:
+    volume:
+      configMap:
+        name: backend-endpoints
 `,
 	},
 	{
@@ -2,6 +2,7 @@ package testutils
 
 import (
 	"github.com/kgateway-dev/kgateway/v2/pkg/utils/envutils"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/manifestutils"
 )
 
 const (
@@ -157,5 +158,5 @@ func Cleanup(t TestingT, f func()) {
 // This can be overridden via the DEFAULT_NAMESPACE environment variable.
 // Defaults to "default" if not set.
 func GetDefaultNamespace() string {
-	return envutils.GetOrDefault(DefaultNamespace, "default", false)
+	return manifestutils.DefaultNamespace()
 }